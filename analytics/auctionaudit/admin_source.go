@@ -0,0 +1,86 @@
+package auctionaudit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	adminFiltersPath = "/auction_audit/filters"
+	maxFilterBody    = 64 << 10
+)
+
+// AdminFilterSource registers filters through the admin port: POST an AuctionFilterRequest in
+// protobuf JSON form to register it, and DELETE with account_id and session_id query parameters to
+// unregister it.
+type AdminFilterSource struct {
+	registry *FilterRegistry
+}
+
+func NewAdminFilterSource(registry *FilterRegistry) *AdminFilterSource {
+	return &AdminFilterSource{registry: registry}
+}
+
+func (s *AdminFilterSource) Close() error {
+	return nil
+}
+
+func (s *AdminFilterSource) adminPath() string {
+	return adminFiltersPath
+}
+
+func (s *AdminFilterSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.register(w, r)
+	case http.MethodDelete:
+		s.unregister(w, r)
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *AdminFilterSource) register(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxFilterBody))
+	if err != nil {
+		http.Error(w, "failed to read filter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := &AuctionFilterRequest{}
+	if err := protojson.Unmarshal(body, filter); err != nil {
+		http.Error(w, "invalid filter: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := applyFilter(s.registry, FilterActionCreate, filter); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrRegistryAtCapacity) {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AdminFilterSource) unregister(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	sessionID, err := strconv.ParseInt(query.Get("session_id"), 10, 32)
+	if err != nil || query.Get("account_id") == "" {
+		http.Error(w, "account_id and a numeric session_id are required", http.StatusBadRequest)
+		return
+	}
+
+	applyFilter(s.registry, FilterActionRemove, &AuctionFilterRequest{
+		AccountId: query.Get("account_id"),
+		SessionId: int32(sessionID),
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
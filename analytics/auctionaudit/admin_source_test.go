@@ -0,0 +1,45 @@
+package auctionaudit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminFilterSource(t *testing.T) {
+	registry := newTestRegistry(1)
+	source := NewAdminFilterSource(registry)
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		source.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := serve(http.MethodPost, adminFiltersPath, `{"sessionId": 1, "accountId": "Account-123", "country": "USA"}`)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, 1, registry.Count())
+
+	recorder = serve(http.MethodPost, adminFiltersPath, `{"sessionId": 2, "accountId": "account-123"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code, "registry at capacity")
+
+	recorder = serve(http.MethodPost, adminFiltersPath, `{"sessionId": 2}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "missing account")
+
+	recorder = serve(http.MethodPost, adminFiltersPath, `{"session": 2`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "invalid JSON")
+
+	recorder = serve(http.MethodDelete, adminFiltersPath+"?account_id=account-123", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "missing session")
+
+	recorder = serve(http.MethodDelete, adminFiltersPath+"?account_id=Account-123&session_id=1", "")
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, 0, registry.Count())
+
+	recorder = serve(http.MethodGet, adminFiltersPath, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, "POST, DELETE", recorder.Header().Get("Allow"))
+}
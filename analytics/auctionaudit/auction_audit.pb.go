@@ -138,6 +138,12 @@ type AuctionEvent struct {
 	TimestampMs   int64                  `protobuf:"varint,6,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
 	Status        int32                  `protobuf:"varint,7,opt,name=status,proto3" json:"status,omitempty"`
 	Errors        []*AuctionError        `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+	RequestId     string                 `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Imps          []*AuctionImp          `protobuf:"bytes,10,rep,name=imps,proto3" json:"imps,omitempty"`
+	SeatBids      []*AuctionSeatBid      `protobuf:"bytes,11,rep,name=seat_bids,json=seatBids,proto3" json:"seat_bids,omitempty"`
+	SeatNonBids   []*AuctionSeatNonBid   `protobuf:"bytes,12,rep,name=seat_non_bids,json=seatNonBids,proto3" json:"seat_non_bids,omitempty"`
+	HookOutcomes  []*AuctionHookOutcome  `protobuf:"bytes,13,rep,name=hook_outcomes,json=hookOutcomes,proto3" json:"hook_outcomes,omitempty"`
+	Currency      string                 `protobuf:"bytes,14,opt,name=currency,proto3" json:"currency,omitempty"`
 	BidRequest    string                 `protobuf:"bytes,30,opt,name=bid_request,json=bidRequest,proto3" json:"bid_request,omitempty"`
 	BidResponse   string                 `protobuf:"bytes,31,opt,name=bid_response,json=bidResponse,proto3" json:"bid_response,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -230,6 +236,48 @@ func (x *AuctionEvent) GetErrors() []*AuctionError {
 	return nil
 }
 
+func (x *AuctionEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuctionEvent) GetImps() []*AuctionImp {
+	if x != nil {
+		return x.Imps
+	}
+	return nil
+}
+
+func (x *AuctionEvent) GetSeatBids() []*AuctionSeatBid {
+	if x != nil {
+		return x.SeatBids
+	}
+	return nil
+}
+
+func (x *AuctionEvent) GetSeatNonBids() []*AuctionSeatNonBid {
+	if x != nil {
+		return x.SeatNonBids
+	}
+	return nil
+}
+
+func (x *AuctionEvent) GetHookOutcomes() []*AuctionHookOutcome {
+	if x != nil {
+		return x.HookOutcomes
+	}
+	return nil
+}
+
+func (x *AuctionEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 func (x *AuctionEvent) GetBidRequest() string {
 	if x != nil {
 		return x.BidRequest
@@ -253,6 +301,14 @@ type AuctionFilterRequest struct {
 	AppBundle     string                 `protobuf:"bytes,5,opt,name=app_bundle,json=appBundle,proto3" json:"app_bundle,omitempty"`
 	MediaTypes    []MediaType            `protobuf:"varint,6,rep,packed,name=media_types,json=mediaTypes,proto3,enum=quicksilver.MediaType" json:"media_types,omitempty"`
 	ExpiresAtMs   int64                  `protobuf:"varint,7,opt,name=expires_at_ms,json=expiresAtMs,proto3" json:"expires_at_ms,omitempty"`
+	Bidder        string                 `protobuf:"bytes,8,opt,name=bidder,proto3" json:"bidder,omitempty"`
+	DealId        string                 `protobuf:"bytes,9,opt,name=deal_id,json=dealId,proto3" json:"deal_id,omitempty"`
+	Country       string                 `protobuf:"bytes,10,opt,name=country,proto3" json:"country,omitempty"`
+	DeviceType    int32                  `protobuf:"varint,11,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	MinPrice      float64                `protobuf:"fixed64,12,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	NoBids        bool                   `protobuf:"varint,13,opt,name=no_bids,json=noBids,proto3" json:"no_bids,omitempty"`
+	HasErrors     bool                   `protobuf:"varint,14,opt,name=has_errors,json=hasErrors,proto3" json:"has_errors,omitempty"`
+	TagId         string                 `protobuf:"bytes,15,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -336,6 +392,502 @@ func (x *AuctionFilterRequest) GetExpiresAtMs() int64 {
 	return 0
 }
 
+func (x *AuctionFilterRequest) GetBidder() string {
+	if x != nil {
+		return x.Bidder
+	}
+	return ""
+}
+
+func (x *AuctionFilterRequest) GetDealId() string {
+	if x != nil {
+		return x.DealId
+	}
+	return ""
+}
+
+func (x *AuctionFilterRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *AuctionFilterRequest) GetDeviceType() int32 {
+	if x != nil {
+		return x.DeviceType
+	}
+	return 0
+}
+
+func (x *AuctionFilterRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *AuctionFilterRequest) GetNoBids() bool {
+	if x != nil {
+		return x.NoBids
+	}
+	return false
+}
+
+func (x *AuctionFilterRequest) GetHasErrors() bool {
+	if x != nil {
+		return x.HasErrors
+	}
+	return false
+}
+
+func (x *AuctionFilterRequest) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+type AuctionImp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TagId         string                 `protobuf:"bytes,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	MediaTypes    []MediaType            `protobuf:"varint,3,rep,packed,name=media_types,json=mediaTypes,proto3,enum=quicksilver.MediaType" json:"media_types,omitempty"`
+	BidFloor      float64                `protobuf:"fixed64,4,opt,name=bid_floor,json=bidFloor,proto3" json:"bid_floor,omitempty"`
+	BidFloorCur   string                 `protobuf:"bytes,5,opt,name=bid_floor_cur,json=bidFloorCur,proto3" json:"bid_floor_cur,omitempty"`
+	DealIds       []string               `protobuf:"bytes,6,rep,name=deal_ids,json=dealIds,proto3" json:"deal_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionImp) Reset() {
+	*x = AuctionImp{}
+	mi := &file_AuctionAudit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionImp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionImp) ProtoMessage() {}
+
+func (x *AuctionImp) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionImp.ProtoReflect.Descriptor instead.
+func (*AuctionImp) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuctionImp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuctionImp) GetTagId() string {
+	if x != nil {
+		return x.TagId
+	}
+	return ""
+}
+
+func (x *AuctionImp) GetMediaTypes() []MediaType {
+	if x != nil {
+		return x.MediaTypes
+	}
+	return nil
+}
+
+func (x *AuctionImp) GetBidFloor() float64 {
+	if x != nil {
+		return x.BidFloor
+	}
+	return 0
+}
+
+func (x *AuctionImp) GetBidFloorCur() string {
+	if x != nil {
+		return x.BidFloorCur
+	}
+	return ""
+}
+
+func (x *AuctionImp) GetDealIds() []string {
+	if x != nil {
+		return x.DealIds
+	}
+	return nil
+}
+
+type AuctionBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ImpId         string                 `protobuf:"bytes,2,opt,name=imp_id,json=impId,proto3" json:"imp_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Adomain       []string               `protobuf:"bytes,4,rep,name=adomain,proto3" json:"adomain,omitempty"`
+	Crid          string                 `protobuf:"bytes,5,opt,name=crid,proto3" json:"crid,omitempty"`
+	DealId        string                 `protobuf:"bytes,6,opt,name=deal_id,json=dealId,proto3" json:"deal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionBid) Reset() {
+	*x = AuctionBid{}
+	mi := &file_AuctionAudit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionBid) ProtoMessage() {}
+
+func (x *AuctionBid) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionBid.ProtoReflect.Descriptor instead.
+func (*AuctionBid) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuctionBid) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuctionBid) GetImpId() string {
+	if x != nil {
+		return x.ImpId
+	}
+	return ""
+}
+
+func (x *AuctionBid) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AuctionBid) GetAdomain() []string {
+	if x != nil {
+		return x.Adomain
+	}
+	return nil
+}
+
+func (x *AuctionBid) GetCrid() string {
+	if x != nil {
+		return x.Crid
+	}
+	return ""
+}
+
+func (x *AuctionBid) GetDealId() string {
+	if x != nil {
+		return x.DealId
+	}
+	return ""
+}
+
+type AuctionSeatBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          string                 `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	Bids          []*AuctionBid          `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionSeatBid) Reset() {
+	*x = AuctionSeatBid{}
+	mi := &file_AuctionAudit_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionSeatBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionSeatBid) ProtoMessage() {}
+
+func (x *AuctionSeatBid) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionSeatBid.ProtoReflect.Descriptor instead.
+func (*AuctionSeatBid) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuctionSeatBid) GetSeat() string {
+	if x != nil {
+		return x.Seat
+	}
+	return ""
+}
+
+func (x *AuctionSeatBid) GetBids() []*AuctionBid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+type AuctionNonBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImpId         string                 `protobuf:"bytes,1,opt,name=imp_id,json=impId,proto3" json:"imp_id,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionNonBid) Reset() {
+	*x = AuctionNonBid{}
+	mi := &file_AuctionAudit_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionNonBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionNonBid) ProtoMessage() {}
+
+func (x *AuctionNonBid) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionNonBid.ProtoReflect.Descriptor instead.
+func (*AuctionNonBid) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuctionNonBid) GetImpId() string {
+	if x != nil {
+		return x.ImpId
+	}
+	return ""
+}
+
+func (x *AuctionNonBid) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+type AuctionSeatNonBid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          string                 `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	NonBids       []*AuctionNonBid       `protobuf:"bytes,2,rep,name=non_bids,json=nonBids,proto3" json:"non_bids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionSeatNonBid) Reset() {
+	*x = AuctionSeatNonBid{}
+	mi := &file_AuctionAudit_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionSeatNonBid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionSeatNonBid) ProtoMessage() {}
+
+func (x *AuctionSeatNonBid) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionSeatNonBid.ProtoReflect.Descriptor instead.
+func (*AuctionSeatNonBid) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AuctionSeatNonBid) GetSeat() string {
+	if x != nil {
+		return x.Seat
+	}
+	return ""
+}
+
+func (x *AuctionSeatNonBid) GetNonBids() []*AuctionNonBid {
+	if x != nil {
+		return x.NonBids
+	}
+	return nil
+}
+
+type AuctionHookOutcome struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Stage           string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Entity          string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	ModuleCode      string                 `protobuf:"bytes,3,opt,name=module_code,json=moduleCode,proto3" json:"module_code,omitempty"`
+	HookImplCode    string                 `protobuf:"bytes,4,opt,name=hook_impl_code,json=hookImplCode,proto3" json:"hook_impl_code,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Action          string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	Message         string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	ExecutionTimeMs int64                  `protobuf:"varint,8,opt,name=execution_time_ms,json=executionTimeMs,proto3" json:"execution_time_ms,omitempty"`
+	Errors          []string               `protobuf:"bytes,9,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings        []string               `protobuf:"bytes,10,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AuctionHookOutcome) Reset() {
+	*x = AuctionHookOutcome{}
+	mi := &file_AuctionAudit_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionHookOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionHookOutcome) ProtoMessage() {}
+
+func (x *AuctionHookOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_AuctionAudit_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionHookOutcome.ProtoReflect.Descriptor instead.
+func (*AuctionHookOutcome) Descriptor() ([]byte, []int) {
+	return file_AuctionAudit_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AuctionHookOutcome) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetModuleCode() string {
+	if x != nil {
+		return x.ModuleCode
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetHookImplCode() string {
+	if x != nil {
+		return x.HookImplCode
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AuctionHookOutcome) GetExecutionTimeMs() int64 {
+	if x != nil {
+		return x.ExecutionTimeMs
+	}
+	return 0
+}
+
+func (x *AuctionHookOutcome) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *AuctionHookOutcome) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 var File_AuctionAudit_proto protoreflect.FileDescriptor
 
 const file_AuctionAudit_proto_rawDesc = "" +
@@ -343,7 +895,7 @@ const file_AuctionAudit_proto_rawDesc = "" +
 	"\x12AuctionAudit.proto\x12\vquicksilver\"<\n" +
 	"\fAuctionError\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
-	"\x04code\x18\x02 \x01(\x05R\x04code\"\xf1\x02\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\"\x9d\x05\n" +
 	"\fAuctionEvent\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
@@ -355,10 +907,18 @@ const file_AuctionAudit_proto_rawDesc = "" +
 	"\venvironment\x18\x05 \x01(\tR\venvironment\x12!\n" +
 	"\ftimestamp_ms\x18\x06 \x01(\x03R\vtimestampMs\x12\x16\n" +
 	"\x06status\x18\a \x01(\x05R\x06status\x121\n" +
-	"\x06errors\x18\b \x03(\v2\x19.quicksilver.AuctionErrorR\x06errors\x12\x1f\n" +
+	"\x06errors\x18\b \x03(\v2\x19.quicksilver.AuctionErrorR\x06errors\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\t \x01(\tR\trequestId\x12+\n" +
+	"\x04imps\x18\n" +
+	" \x03(\v2\x17.quicksilver.AuctionImpR\x04imps\x128\n" +
+	"\tseat_bids\x18\v \x03(\v2\x1b.quicksilver.AuctionSeatBidR\bseatBids\x12B\n" +
+	"\rseat_non_bids\x18\f \x03(\v2\x1e.quicksilver.AuctionSeatNonBidR\vseatNonBids\x12D\n" +
+	"\rhook_outcomes\x18\r \x03(\v2\x1f.quicksilver.AuctionHookOutcomeR\fhookOutcomes\x12\x1a\n" +
+	"\bcurrency\x18\x0e \x01(\tR\bcurrency\x12\x1f\n" +
 	"\vbid_request\x18\x1e \x01(\tR\n" +
 	"bidRequest\x12!\n" +
-	"\fbid_response\x18\x1f \x01(\tR\vbidResponse\"\x8b\x02\n" +
+	"\fbid_response\x18\x1f \x01(\tR\vbidResponse\"\xe3\x03\n" +
 	"\x14AuctionFilterRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\x05R\tsessionId\x12!\n" +
@@ -370,7 +930,58 @@ const file_AuctionAudit_proto_rawDesc = "" +
 	"app_bundle\x18\x05 \x01(\tR\tappBundle\x127\n" +
 	"\vmedia_types\x18\x06 \x03(\x0e2\x16.quicksilver.MediaTypeR\n" +
 	"mediaTypes\x12\"\n" +
-	"\rexpires_at_ms\x18\a \x01(\x03R\vexpiresAtMs*\x81\x01\n" +
+	"\rexpires_at_ms\x18\a \x01(\x03R\vexpiresAtMs\x12\x16\n" +
+	"\x06bidder\x18\b \x01(\tR\x06bidder\x12\x17\n" +
+	"\adeal_id\x18\t \x01(\tR\x06dealId\x12\x18\n" +
+	"\acountry\x18\n" +
+	" \x01(\tR\acountry\x12\x1f\n" +
+	"\vdevice_type\x18\v \x01(\x05R\n" +
+	"deviceType\x12\x1b\n" +
+	"\tmin_price\x18\f \x01(\x01R\bminPrice\x12\x17\n" +
+	"\ano_bids\x18\r \x01(\bR\x06noBids\x12\x1d\n" +
+	"\n" +
+	"has_errors\x18\x0e \x01(\bR\thasErrors\x12\x15\n" +
+	"\x06tag_id\x18\x0f \x01(\tR\x05tagId\"\xc8\x01\n" +
+	"\n" +
+	"AuctionImp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06tag_id\x18\x02 \x01(\tR\x05tagId\x127\n" +
+	"\vmedia_types\x18\x03 \x03(\x0e2\x16.quicksilver.MediaTypeR\n" +
+	"mediaTypes\x12\x1b\n" +
+	"\tbid_floor\x18\x04 \x01(\x01R\bbidFloor\x12\"\n" +
+	"\rbid_floor_cur\x18\x05 \x01(\tR\vbidFloorCur\x12\x19\n" +
+	"\bdeal_ids\x18\x06 \x03(\tR\adealIds\"\x90\x01\n" +
+	"\n" +
+	"AuctionBid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06imp_id\x18\x02 \x01(\tR\x05impId\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x18\n" +
+	"\aadomain\x18\x04 \x03(\tR\aadomain\x12\x12\n" +
+	"\x04crid\x18\x05 \x01(\tR\x04crid\x12\x17\n" +
+	"\adeal_id\x18\x06 \x01(\tR\x06dealId\"Q\n" +
+	"\x0eAuctionSeatBid\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\tR\x04seat\x12+\n" +
+	"\x04bids\x18\x02 \x03(\v2\x17.quicksilver.AuctionBidR\x04bids\"G\n" +
+	"\rAuctionNonBid\x12\x15\n" +
+	"\x06imp_id\x18\x01 \x01(\tR\x05impId\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\"^\n" +
+	"\x11AuctionSeatNonBid\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\tR\x04seat\x125\n" +
+	"\bnon_bids\x18\x02 \x03(\v2\x1a.quicksilver.AuctionNonBidR\anonBids\"\xb3\x02\n" +
+	"\x12AuctionHookOutcome\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\x12\x1f\n" +
+	"\vmodule_code\x18\x03 \x01(\tR\n" +
+	"moduleCode\x12$\n" +
+	"\x0ehook_impl_code\x18\x04 \x01(\tR\fhookImplCode\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12*\n" +
+	"\x11execution_time_ms\x18\b \x01(\x03R\x0fexecutionTimeMs\x12\x16\n" +
+	"\x06errors\x18\t \x03(\tR\x06errors\x12\x1a\n" +
+	"\bwarnings\x18\n" +
+	" \x03(\tR\bwarnings*\x81\x01\n" +
 	"\tMediaType\x12\x1a\n" +
 	"\x16MEDIA_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11MEDIA_TYPE_BANNER\x10\x01\x12\x14\n" +
@@ -391,22 +1002,35 @@ func file_AuctionAudit_proto_rawDescGZIP() []byte {
 }
 
 var file_AuctionAudit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_AuctionAudit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_AuctionAudit_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_AuctionAudit_proto_goTypes = []any{
 	(MediaType)(0),               // 0: quicksilver.MediaType
 	(*AuctionError)(nil),         // 1: quicksilver.AuctionError
 	(*AuctionEvent)(nil),         // 2: quicksilver.AuctionEvent
 	(*AuctionFilterRequest)(nil), // 3: quicksilver.AuctionFilterRequest
+	(*AuctionImp)(nil),           // 4: quicksilver.AuctionImp
+	(*AuctionBid)(nil),           // 5: quicksilver.AuctionBid
+	(*AuctionSeatBid)(nil),       // 6: quicksilver.AuctionSeatBid
+	(*AuctionNonBid)(nil),        // 7: quicksilver.AuctionNonBid
+	(*AuctionSeatNonBid)(nil),    // 8: quicksilver.AuctionSeatNonBid
+	(*AuctionHookOutcome)(nil),   // 9: quicksilver.AuctionHookOutcome
 }
 var file_AuctionAudit_proto_depIdxs = []int32{
-	0, // 0: quicksilver.AuctionEvent.media_types:type_name -> quicksilver.MediaType
-	1, // 1: quicksilver.AuctionEvent.errors:type_name -> quicksilver.AuctionError
-	0, // 2: quicksilver.AuctionFilterRequest.media_types:type_name -> quicksilver.MediaType
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: quicksilver.AuctionEvent.media_types:type_name -> quicksilver.MediaType
+	1,  // 1: quicksilver.AuctionEvent.errors:type_name -> quicksilver.AuctionError
+	4,  // 2: quicksilver.AuctionEvent.imps:type_name -> quicksilver.AuctionImp
+	6,  // 3: quicksilver.AuctionEvent.seat_bids:type_name -> quicksilver.AuctionSeatBid
+	8,  // 4: quicksilver.AuctionEvent.seat_non_bids:type_name -> quicksilver.AuctionSeatNonBid
+	9,  // 5: quicksilver.AuctionEvent.hook_outcomes:type_name -> quicksilver.AuctionHookOutcome
+	0,  // 6: quicksilver.AuctionFilterRequest.media_types:type_name -> quicksilver.MediaType
+	0,  // 7: quicksilver.AuctionImp.media_types:type_name -> quicksilver.MediaType
+	5,  // 8: quicksilver.AuctionSeatBid.bids:type_name -> quicksilver.AuctionBid
+	7,  // 9: quicksilver.AuctionSeatNonBid.non_bids:type_name -> quicksilver.AuctionNonBid
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_AuctionAudit_proto_init() }
@@ -420,7 +1044,7 @@ func file_AuctionAudit_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_AuctionAudit_proto_rawDesc), len(file_AuctionAudit_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
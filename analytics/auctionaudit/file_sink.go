@@ -0,0 +1,129 @@
+package auctionaudit
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/prebid/prebid-server/v3/config"
+)
+
+// FileMatchSink appends matched events to a local file, one JSON record per line. When a write
+// would take the file past its maximum size, the file is rotated to <path>.1, older files are
+// shifted up to <path>.<max_backups> and the oldest is removed.
+type FileMatchSink struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewFileMatchSink(cfg config.AuctionAuditFileConfig) (*FileMatchSink, error) {
+	if cfg.MaxSizeMB <= 0 {
+		return nil, fmt.Errorf("file.max_size_mb must be positive")
+	}
+	if cfg.MaxBackups < 0 {
+		return nil, fmt.Errorf("file.max_backups must not be negative")
+	}
+
+	s := &FileMatchSink{
+		path:       cfg.MatchesPath,
+		maxSize:    int64(cfg.MaxSizeMB) << 20,
+		maxBackups: cfg.MaxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileMatchSink) SendMatchedEvent(event *AuctionEvent, filters []*AuctionFilterRequest) error {
+	if event == nil || len(filters) == 0 {
+		return nil
+	}
+
+	record, err := encodeMatchedRecord(event, filters)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+	record = append(record, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("match file %s is closed", s.path)
+	}
+
+	if s.size > 0 && s.size+int64(len(record)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(record)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write match file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileMatchSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileMatchSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open match file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open match file: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileMatchSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to rotate match file: %w", err)
+	}
+	s.file = nil
+
+	if s.maxBackups == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate match file: %w", err)
+		}
+		return s.open()
+	}
+
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate match file: %w", err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("failed to rotate match file: %w", err)
+	}
+	return s.open()
+}
+
+func (s *FileMatchSink) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", s.path, n)
+}
@@ -0,0 +1,90 @@
+package auctionaudit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMatchSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.jsonl")
+	sink, err := NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: path, MaxSizeMB: 1, MaxBackups: 2})
+	require.NoError(t, err)
+
+	filters := []*AuctionFilterRequest{{SessionId: 1}}
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-123"}, filters))
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-456"}, filters))
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-789"}, nil), "no filters, nothing written")
+	require.NoError(t, sink.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"account-123"`)
+	assert.Contains(t, string(lines[1]), `"account-456"`)
+
+	assert.Error(t, sink.SendMatchedEvent(&AuctionEvent{}, filters), "closed sink")
+}
+
+func TestFileMatchSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.jsonl")
+	sink, err := NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: path, MaxSizeMB: 1, MaxBackups: 2})
+	require.NoError(t, err)
+	defer sink.Close()
+	// rotate after every record
+	sink.maxSize = 1
+
+	filters := []*AuctionFilterRequest{{SessionId: 1}}
+	for _, account := range []string{"first", "second", "third", "fourth"} {
+		require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: account}, filters))
+	}
+
+	expected := map[string]string{
+		path:        "fourth",
+		path + ".1": "third",
+		path + ".2": "second",
+	}
+	for file, account := range expected {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err, file)
+		assert.Contains(t, string(data), `"`+account+`"`, file)
+	}
+	assert.NoFileExists(t, path+".3", "oldest file is removed")
+}
+
+func TestFileMatchSink_RotationWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.jsonl")
+	sink, err := NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: path, MaxSizeMB: 1})
+	require.NoError(t, err)
+	defer sink.Close()
+	sink.maxSize = 1
+
+	filters := []*AuctionFilterRequest{{SessionId: 1}}
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "first"}, filters))
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "second"}, filters))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"first"`)
+	assert.Contains(t, string(data), `"second"`)
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestNewFileMatchSink_InvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.jsonl")
+
+	_, err := NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: path})
+	assert.ErrorContains(t, err, "max_size_mb")
+
+	_, err = NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: path, MaxSizeMB: 1, MaxBackups: -1})
+	assert.ErrorContains(t, err, "max_backups")
+
+	_, err = NewFileMatchSink(config.AuctionAuditFileConfig{MatchesPath: filepath.Join(path, "missing", "matches.jsonl"), MaxSizeMB: 1})
+	assert.ErrorContains(t, err, "failed to open match file")
+}
@@ -0,0 +1,150 @@
+package auctionaudit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type filterKey struct {
+	accountID string
+	sessionID int32
+}
+
+// FileFilterSource registers the filters listed in a file, one AuctionFilterRequest in protobuf
+// JSON form per line. The file is polled for changes: filters are registered again each time it
+// changes, and filters removed from it are unregistered.
+type FileFilterSource struct {
+	path          string
+	registry      *FilterRegistry
+	metricsEngine metrics.MetricsEngine
+	cancel        context.CancelFunc
+	done          chan struct{}
+
+	modTime time.Time
+	size    int64
+	loaded  map[filterKey]struct{}
+}
+
+func NewFileFilterSource(ctx context.Context, cfg config.AuctionAuditFileConfig, registry *FilterRegistry, metricsEngine metrics.MetricsEngine) (*FileFilterSource, error) {
+	pollInterval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file.poll_interval: %w", err)
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("file.poll_interval must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	fs := &FileFilterSource{
+		path:          cfg.FiltersPath,
+		registry:      registry,
+		metricsEngine: metricsEngine,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+		loaded:        make(map[filterKey]struct{}),
+	}
+
+	fs.poll()
+	go fs.pollLoop(ctx, pollInterval)
+
+	return fs, nil
+}
+
+func (fs *FileFilterSource) Close() error {
+	fs.cancel()
+	<-fs.done
+	return nil
+}
+
+func (fs *FileFilterSource) pollLoop(ctx context.Context, interval time.Duration) {
+	defer close(fs.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fs.poll()
+		}
+	}
+}
+
+// poll reloads the file when its modification time or size changed. A missing file holds no filters.
+func (fs *FileFilterSource) poll() {
+	info, err := os.Stat(fs.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Errorf("[auctionaudit] Failed to stat filter file %s: %v", fs.path, err)
+			fs.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorConsume)
+			return
+		}
+		fs.modTime, fs.size = time.Time{}, 0
+		fs.reload(nil)
+		return
+	}
+
+	if info.ModTime().Equal(fs.modTime) && info.Size() == fs.size {
+		return
+	}
+
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		logger.Errorf("[auctionaudit] Failed to read filter file %s: %v", fs.path, err)
+		fs.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorConsume)
+		return
+	}
+	fs.modTime = info.ModTime()
+	fs.size = info.Size()
+
+	fs.reload(fs.parse(data))
+}
+
+func (fs *FileFilterSource) parse(data []byte) []*AuctionFilterRequest {
+	var filters []*AuctionFilterRequest
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		filter := &AuctionFilterRequest{}
+		if err := protojson.Unmarshal(text, filter); err != nil {
+			logger.Errorf("[auctionaudit] Failed to parse filter on line %d of %s: %v", line, fs.path, err)
+			fs.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorConsume)
+			continue
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+func (fs *FileFilterSource) reload(filters []*AuctionFilterRequest) {
+	current := make(map[filterKey]struct{}, len(filters))
+	for _, filter := range filters {
+		if applyFilter(fs.registry, FilterActionCreate, filter) == nil {
+			current[filterKey{accountID: filter.AccountId, sessionID: filter.SessionId}] = struct{}{}
+		}
+	}
+
+	for key := range fs.loaded {
+		if _, ok := current[key]; !ok {
+			applyFilter(fs.registry, FilterActionRemove, &AuctionFilterRequest{AccountId: key.accountID, SessionId: key.sessionID})
+		}
+	}
+	fs.loaded = current
+}
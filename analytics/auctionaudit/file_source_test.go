@@ -0,0 +1,96 @@
+package auctionaudit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFileFilterSource(t *testing.T, path string) (*FileFilterSource, *FilterRegistry) {
+	t.Helper()
+
+	registry := newTestRegistry(10)
+	source, err := NewFileFilterSource(context.Background(), config.AuctionAuditFileConfig{
+		FiltersPath:  path,
+		PollInterval: "1h",
+	}, registry, &metricsConfig.NilMetricsEngine{})
+	require.NoError(t, err)
+	t.Cleanup(func() { source.Close() })
+
+	return source, registry
+}
+
+func writeFilterFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestFileFilterSource_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.jsonl")
+	start := time.Now()
+	writeFilterFile(t, path, `{"sessionId": 1, "accountId": "Account-123", "domain": "example.com"}
+
+{"sessionId": 2, "accountId": "account-123", "bidder": "appnexus"}
+not a filter
+{"sessionId": 3}
+`, start)
+
+	source, registry := newTestFileFilterSource(t, path)
+	assert.Equal(t, 2, registry.Count(), "invalid lines are skipped")
+
+	matches, _ := registry.GetMatches("account-123", "example.com", "", 0, nil)
+	assert.Len(t, matches, 1)
+
+	// session 1 is removed, session 4 is added
+	writeFilterFile(t, path, `{"sessionId": 2, "accountId": "account-123", "bidder": "appnexus"}
+{"sessionId": 4, "accountId": "account-456"}
+`, start.Add(time.Second))
+	source.poll()
+	assert.Equal(t, 2, registry.Count())
+
+	matches, _ = registry.GetMatches("account-123", "example.com", "", 0, nil)
+	assert.Len(t, matches, 0)
+	matches, _ = registry.GetMatches("account-456", "", "", 0, nil)
+	assert.Len(t, matches, 1)
+
+	// a removed file holds no filters
+	require.NoError(t, os.Remove(path))
+	source.poll()
+	assert.Equal(t, 0, registry.Count())
+}
+
+func TestFileFilterSource_UnchangedFileIsNotReloaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.jsonl")
+	writeFilterFile(t, path, `{"sessionId": 1, "accountId": "account-123"}`+"\n", time.Now())
+
+	source, registry := newTestFileFilterSource(t, path)
+	require.Equal(t, 1, registry.Count())
+
+	// filters registered elsewhere are left alone while the file is unchanged
+	registry.Unregister(1, "account-123")
+	source.poll()
+	assert.Equal(t, 0, registry.Count())
+}
+
+func TestFileFilterSource_MissingFile(t *testing.T) {
+	_, registry := newTestFileFilterSource(t, filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.Equal(t, 0, registry.Count())
+}
+
+func TestFileFilterSource_InvalidPollInterval(t *testing.T) {
+	for _, interval := range []string{"soon", "0s"} {
+		_, err := NewFileFilterSource(context.Background(), config.AuctionAuditFileConfig{
+			FiltersPath:  "filters.jsonl",
+			PollInterval: interval,
+		}, newTestRegistry(10), &metricsConfig.NilMetricsEngine{})
+		assert.Error(t, err, interval)
+	}
+}
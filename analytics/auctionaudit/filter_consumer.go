@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -145,23 +144,10 @@ func (h *filterConsumerHandler) processMessage(msg *sarama.ConsumerMessage) {
 		return
 	}
 
-	filter.AccountId = strings.ToLower(filter.AccountId)
-
 	action := FilterActionCreate
 	if len(msg.Key) > 0 {
 		action = msg.Key[0]
 	}
 
-	switch action {
-	case FilterActionRemove:
-		h.registry.Unregister(filter.SessionId, filter.AccountId)
-		logger.Infof("[auctionaudit] Unregistered filter: session=%d account=%s", filter.SessionId, filter.AccountId)
-	default:
-		// Default to create
-		if err := h.registry.Register(filter); err != nil {
-			logger.Warnf("[auctionaudit] Failed to register filter: session=%d account=%s: %v", filter.SessionId, filter.AccountId, err)
-		} else {
-			logger.Infof("[auctionaudit] Registered filter: session=%d account=%s", filter.SessionId, filter.AccountId)
-		}
-	}
+	applyFilter(h.registry, action, filter)
 }
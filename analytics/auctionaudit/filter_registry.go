@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/metrics"
 	"golang.org/x/time/rate"
@@ -89,10 +90,13 @@ func (s MediaTypeSet) ToSlice() []MediaType {
 type storedFilter struct {
 	*AuctionFilterRequest
 	mediaTypeSet MediaTypeSet
-	rateLimiter  *rate.Limiter
+	// hasAuctionPredicates is set when any predicate beyond domain, app bundle and media types is,
+	// so filters without them never look at the auction
+	hasAuctionPredicates bool
+	rateLimiter          *rate.Limiter
 }
 
-func (f *storedFilter) matches(domain, appBundle string, eventMediaTypes MediaTypeSet) bool {
+func (f *storedFilter) matches(domain, appBundle string, eventMediaTypes MediaTypeSet, auction *analytics.AuctionObject) bool {
 	if f.Domain != "" && !strings.EqualFold(f.Domain, domain) {
 		return false
 	}
@@ -106,9 +110,161 @@ func (f *storedFilter) matches(domain, appBundle string, eventMediaTypes MediaTy
 		return false
 	}
 
+	if !f.hasAuctionPredicates {
+		return true
+	}
+
+	if auction == nil {
+		return false
+	}
+
+	return f.matchesAuction(auction)
+}
+
+// matchesAuction evaluates the predicates that read the auction itself, cheapest first. Each one is
+// skipped when unset.
+func (f *storedFilter) matchesAuction(auction *analytics.AuctionObject) bool {
+	var req *openrtb2.BidRequest
+	if auction.RequestWrapper != nil {
+		req = auction.RequestWrapper.BidRequest
+	}
+	resp := auction.Response
+
+	if f.HasErrors && len(auction.Errors) == 0 {
+		return false
+	}
+
+	if f.Country != "" && !strings.EqualFold(f.Country, requestCountry(req)) {
+		return false
+	}
+
+	if f.DeviceType != 0 && (req == nil || req.Device == nil || int32(req.Device.DeviceType) != f.DeviceType) {
+		return false
+	}
+
+	if f.NoBids && hasBids(resp) {
+		return false
+	}
+
+	if f.Bidder != "" && !hasSeatBid(resp, f.Bidder) {
+		return false
+	}
+
+	if f.MinPrice > 0 && maxBidPrice(resp) < f.MinPrice {
+		return false
+	}
+
+	if f.TagId != "" && !hasTagID(req, f.TagId) {
+		return false
+	}
+
+	if f.DealId != "" && !hasDeal(req, resp, f.DealId) {
+		return false
+	}
+
 	return true
 }
 
+// hasAuctionPredicates reports whether the filter sets any predicate that reads the auction beyond
+// its domain, app bundle and media types.
+func hasAuctionPredicates(filter *AuctionFilterRequest) bool {
+	return filter.Bidder != "" ||
+		filter.DealId != "" ||
+		filter.Country != "" ||
+		filter.DeviceType != 0 ||
+		filter.MinPrice > 0 ||
+		filter.NoBids ||
+		filter.HasErrors ||
+		filter.TagId != ""
+}
+
+func requestCountry(req *openrtb2.BidRequest) string {
+	if req == nil || req.Device == nil || req.Device.Geo == nil {
+		return ""
+	}
+	return req.Device.Geo.Country
+}
+
+func hasBids(resp *openrtb2.BidResponse) bool {
+	if resp == nil {
+		return false
+	}
+	for i := range resp.SeatBid {
+		if len(resp.SeatBid[i].Bid) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSeatBid reports whether bidder returned at least one bid in the response.
+func hasSeatBid(resp *openrtb2.BidResponse, bidder string) bool {
+	if resp == nil {
+		return false
+	}
+	for i := range resp.SeatBid {
+		if len(resp.SeatBid[i].Bid) > 0 && strings.EqualFold(resp.SeatBid[i].Seat, bidder) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBidPrice returns the highest bid price in the response, which is the price of the winning bid.
+func maxBidPrice(resp *openrtb2.BidResponse) float64 {
+	var price float64
+	if resp == nil {
+		return price
+	}
+	for i := range resp.SeatBid {
+		for j := range resp.SeatBid[i].Bid {
+			if resp.SeatBid[i].Bid[j].Price > price {
+				price = resp.SeatBid[i].Bid[j].Price
+			}
+		}
+	}
+	return price
+}
+
+func hasTagID(req *openrtb2.BidRequest, tagID string) bool {
+	if req == nil {
+		return false
+	}
+	for i := range req.Imp {
+		if strings.EqualFold(req.Imp[i].TagID, tagID) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeal reports whether dealID is offered on any imp of the request or was bid on in the
+// response, so that deals which received no bids can be audited too.
+func hasDeal(req *openrtb2.BidRequest, resp *openrtb2.BidResponse, dealID string) bool {
+	if req != nil {
+		for i := range req.Imp {
+			if req.Imp[i].PMP == nil {
+				continue
+			}
+			for j := range req.Imp[i].PMP.Deals {
+				if strings.EqualFold(req.Imp[i].PMP.Deals[j].ID, dealID) {
+					return true
+				}
+			}
+		}
+	}
+	if resp != nil {
+		for i := range resp.SeatBid {
+			for j := range resp.SeatBid[i].Bid {
+				if strings.EqualFold(resp.SeatBid[i].Bid[j].DealID, dealID) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 type FilterRegistry struct {
 	mu              sync.RWMutex
 	byAccount       map[string]map[int32]*storedFilter // accountId -> sessionId -> filter
@@ -167,6 +323,7 @@ func (r *FilterRegistry) Register(filter *AuctionFilterRequest) error {
 	sf := &storedFilter{
 		AuctionFilterRequest: filter,
 		mediaTypeSet:         ToMediaTypeSet(filter.MediaTypes),
+		hasAuctionPredicates: hasAuctionPredicates(filter),
 	}
 	if r.maxEventsPerSec > 0 {
 		sf.rateLimiter = rate.NewLimiter(rate.Limit(r.maxEventsPerSec), int(r.maxEventsPerSec))
@@ -203,7 +360,9 @@ func (r *FilterRegistry) Unregister(sessionId int32, accountId string) {
 
 // GetMatches returns filters that match AND pass their per-filter rate limiter.
 // dropped is the count of filters that matched but were dropped by rate limiting.
-func (r *FilterRegistry) GetMatches(accountID, domain, appBundle string, eventMediaTypes MediaTypeSet) (allowed []*AuctionFilterRequest, dropped int) {
+// auction is only read by filters with predicates on the auction itself, such as bidder or country,
+// and a nil auction matches none of them.
+func (r *FilterRegistry) GetMatches(accountID, domain, appBundle string, eventMediaTypes MediaTypeSet, auction *analytics.AuctionObject) (allowed []*AuctionFilterRequest, dropped int) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -219,7 +378,7 @@ func (r *FilterRegistry) GetMatches(accountID, domain, appBundle string, eventMe
 			continue
 		}
 
-		if !filter.matches(domain, appBundle, eventMediaTypes) {
+		if !filter.matches(domain, appBundle, eventMediaTypes, auction) {
 			continue
 		}
 
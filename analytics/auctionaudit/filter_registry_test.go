@@ -1,10 +1,15 @@
 package auctionaudit
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/prebid/openrtb/v20/adcom1"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/analytics"
 	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -82,7 +87,7 @@ func TestFilterRegistry_Register(t *testing.T) {
 	assert.NoError(t, registry.Register(filter2))
 	assert.Equal(t, 1, registry.Count())
 
-	matches, _ := registry.GetMatches("account-123", "updated.com", "", 0)
+	matches, _ := registry.GetMatches("account-123", "updated.com", "", 0, nil)
 	assert.Len(t, matches, 1)
 }
 
@@ -139,10 +144,10 @@ func TestFilterRegistry_GetMatches_AccountIdRequired(t *testing.T) {
 		AccountId: "account-123",
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-456", "", "", 0)
+	matches, _ = registry.GetMatches("account-456", "", "", 0, nil)
 	assert.Len(t, matches, 0)
 }
 
@@ -155,13 +160,13 @@ func TestFilterRegistry_GetMatches_DomainFilter(t *testing.T) {
 		Domain:    "example.com",
 	})
 
-	matches, _ := registry.GetMatches("account-123", "example.com", "", 0)
+	matches, _ := registry.GetMatches("account-123", "example.com", "", 0, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "other.com", "", 0)
+	matches, _ = registry.GetMatches("account-123", "other.com", "", 0, nil)
 	assert.Len(t, matches, 0)
 
-	matches, _ = registry.GetMatches("account-123", "", "", 0)
+	matches, _ = registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 0)
 }
 
@@ -174,10 +179,10 @@ func TestFilterRegistry_GetMatches_AppBundleFilter(t *testing.T) {
 		AppBundle: "com.example.app",
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "com.example.app", 0)
+	matches, _ := registry.GetMatches("account-123", "", "com.example.app", 0, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "com.other.app", 0)
+	matches, _ = registry.GetMatches("account-123", "", "com.other.app", 0, nil)
 	assert.Len(t, matches, 0)
 }
 
@@ -190,19 +195,19 @@ func TestFilterRegistry_GetMatches_MediaTypeFilter(t *testing.T) {
 		MediaTypes: []MediaType{MediaType_MEDIA_TYPE_VIDEO, MediaType_MEDIA_TYPE_BANNER},
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "", MediaTypeVideoBit)
+	matches, _ := registry.GetMatches("account-123", "", "", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeBannerBit)
+	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeBannerBit, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeAudioBit)
+	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeAudioBit, nil)
 	assert.Len(t, matches, 0)
 
-	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeAudioBit|MediaTypeVideoBit)
+	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeAudioBit|MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeBannerBit|MediaTypeVideoBit)
+	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeBannerBit|MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 1)
 }
 
@@ -214,13 +219,13 @@ func TestFilterRegistry_GetMatches_NoMediaTypeFilter(t *testing.T) {
 		AccountId: "account-123",
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "", MediaTypeVideoBit)
+	matches, _ := registry.GetMatches("account-123", "", "", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeNativeBit)
+	matches, _ = registry.GetMatches("account-123", "", "", MediaTypeNativeBit, nil)
 	assert.Len(t, matches, 1)
 
-	matches, _ = registry.GetMatches("account-123", "", "", 0)
+	matches, _ = registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 }
 
@@ -241,12 +246,12 @@ func TestFilterRegistry_GetMatches_MultipleFiltersPerAccount(t *testing.T) {
 	assert.Equal(t, 2, registry.Count())
 
 	// Event matches first filter only
-	matches, _ := registry.GetMatches("account-123", "example.com", "", 0)
+	matches, _ := registry.GetMatches("account-123", "example.com", "", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(1), matches[0].SessionId)
 
 	// Event matches second filter only
-	matches, _ = registry.GetMatches("account-123", "", "com.example.app", 0)
+	matches, _ = registry.GetMatches("account-123", "", "com.example.app", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(2), matches[0].SessionId)
 }
@@ -264,16 +269,16 @@ func TestFilterRegistry_GetMatches_DifferentAccounts(t *testing.T) {
 	})
 
 	// Only returns filters for the requested account
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(1), matches[0].SessionId)
 
-	matches, _ = registry.GetMatches("account-456", "", "", 0)
+	matches, _ = registry.GetMatches("account-456", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(2), matches[0].SessionId)
 
 	// No filters for this account
-	matches, _ = registry.GetMatches("account-789", "", "", 0)
+	matches, _ = registry.GetMatches("account-789", "", "", 0, nil)
 	assert.Len(t, matches, 0)
 }
 
@@ -294,7 +299,7 @@ func TestFilterRegistry_GetMatches_ExpiredFilter(t *testing.T) {
 		ExpiresAtMs: time.Now().Add(10 * time.Minute).UnixMilli(),
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(2), matches[0].SessionId)
 }
@@ -308,7 +313,7 @@ func TestFilterRegistry_GetMatches_NoExpiry(t *testing.T) {
 		ExpiresAtMs: 0,
 	})
 
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 }
 
@@ -334,7 +339,7 @@ func TestFilterRegistry_CleanupExpired(t *testing.T) {
 	registry.cleanupExpired()
 
 	assert.Equal(t, 1, registry.Count())
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 1)
 	assert.Equal(t, int32(2), matches[0].SessionId)
 }
@@ -355,7 +360,7 @@ func TestFilterRegistry_CleanupExpired_RemovesEmptyAccountMap(t *testing.T) {
 
 	assert.Equal(t, 0, registry.Count())
 
-	matches, _ := registry.GetMatches("account-123", "", "", 0)
+	matches, _ := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, matches, 0)
 }
 
@@ -372,26 +377,96 @@ func TestFilterRegistry_CombinedFilters(t *testing.T) {
 	})
 
 	// All criteria match
-	matches, _ := registry.GetMatches("account-123", "example.com", "com.example.app", MediaTypeVideoBit)
+	matches, _ := registry.GetMatches("account-123", "example.com", "com.example.app", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 1)
 
 	// Wrong account
-	matches, _ = registry.GetMatches("account-456", "example.com", "com.example.app", MediaTypeVideoBit)
+	matches, _ = registry.GetMatches("account-456", "example.com", "com.example.app", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 0)
 
 	// Wrong domain
-	matches, _ = registry.GetMatches("account-123", "other.com", "com.example.app", MediaTypeVideoBit)
+	matches, _ = registry.GetMatches("account-123", "other.com", "com.example.app", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 0)
 
 	// Wrong app bundle
-	matches, _ = registry.GetMatches("account-123", "example.com", "com.other.app", MediaTypeVideoBit)
+	matches, _ = registry.GetMatches("account-123", "example.com", "com.other.app", MediaTypeVideoBit, nil)
 	assert.Len(t, matches, 0)
 
 	// Wrong media type
-	matches, _ = registry.GetMatches("account-123", "example.com", "com.example.app", MediaTypeBannerBit)
+	matches, _ = registry.GetMatches("account-123", "example.com", "com.example.app", MediaTypeBannerBit, nil)
 	assert.Len(t, matches, 0)
 }
 
+func TestFilterRegistry_GetMatches_AuctionPredicates(t *testing.T) {
+	auction := &analytics.AuctionObject{
+		RequestWrapper: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{
+					{TagID: "homepage-top"},
+					{TagID: "sidebar", PMP: &openrtb2.PMP{Deals: []openrtb2.Deal{{ID: "offered-deal"}}}},
+				},
+				Device: &openrtb2.Device{DeviceType: adcom1.DeviceMobile, Geo: &openrtb2.Geo{Country: "USA"}},
+			},
+		},
+		Response: &openrtb2.BidResponse{
+			SeatBid: []openrtb2.SeatBid{
+				{Seat: "appnexus", Bid: []openrtb2.Bid{{Price: 1.5, DealID: "won-deal"}}},
+				{Seat: "rubicon", Bid: []openrtb2.Bid{{Price: 2.25}}},
+				{Seat: "openx"},
+			},
+		},
+	}
+	noBids := &analytics.AuctionObject{
+		RequestWrapper: auction.RequestWrapper,
+		Response:       &openrtb2.BidResponse{SeatBid: []openrtb2.SeatBid{{Seat: "openx"}}},
+		Errors:         []error{errors.New("timeout")},
+	}
+
+	tests := []struct {
+		name     string
+		filter   *AuctionFilterRequest
+		auction  *analytics.AuctionObject
+		expected bool
+	}{
+		{"no predicates, no auction", &AuctionFilterRequest{}, nil, true},
+		{"predicates, no auction", &AuctionFilterRequest{Bidder: "appnexus"}, nil, false},
+		{"bidder in response", &AuctionFilterRequest{Bidder: "AppNexus"}, auction, true},
+		{"bidder without bids", &AuctionFilterRequest{Bidder: "openx"}, auction, false},
+		{"bidder not in response", &AuctionFilterRequest{Bidder: "pubmatic"}, auction, false},
+		{"deal offered in request", &AuctionFilterRequest{DealId: "offered-deal"}, auction, true},
+		{"deal bid in response", &AuctionFilterRequest{DealId: "won-deal"}, auction, true},
+		{"unknown deal", &AuctionFilterRequest{DealId: "other-deal"}, auction, false},
+		{"country", &AuctionFilterRequest{Country: "usa"}, auction, true},
+		{"other country", &AuctionFilterRequest{Country: "CAN"}, auction, false},
+		{"device type", &AuctionFilterRequest{DeviceType: int32(adcom1.DeviceMobile)}, auction, true},
+		{"other device type", &AuctionFilterRequest{DeviceType: int32(adcom1.DeviceTV)}, auction, false},
+		{"min price below winning price", &AuctionFilterRequest{MinPrice: 2}, auction, true},
+		{"min price equal to winning price", &AuctionFilterRequest{MinPrice: 2.25}, auction, true},
+		{"min price above winning price", &AuctionFilterRequest{MinPrice: 3}, auction, false},
+		{"min price without bids", &AuctionFilterRequest{MinPrice: 0.01}, noBids, false},
+		{"no bids", &AuctionFilterRequest{NoBids: true}, noBids, true},
+		{"no bids with bids", &AuctionFilterRequest{NoBids: true}, auction, false},
+		{"has errors", &AuctionFilterRequest{HasErrors: true}, noBids, true},
+		{"has errors without errors", &AuctionFilterRequest{HasErrors: true}, auction, false},
+		{"tagid", &AuctionFilterRequest{TagId: "Sidebar"}, auction, true},
+		{"other tagid", &AuctionFilterRequest{TagId: "footer"}, auction, false},
+		{"all predicates", &AuctionFilterRequest{Bidder: "rubicon", DealId: "won-deal", Country: "USA", DeviceType: int32(adcom1.DeviceMobile), MinPrice: 1, TagId: "homepage-top"}, auction, true},
+		{"one predicate fails", &AuctionFilterRequest{Bidder: "rubicon", Country: "CAN"}, auction, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(10)
+			tt.filter.SessionId = 1
+			tt.filter.AccountId = "account-123"
+			assert.NoError(t, registry.Register(tt.filter))
+
+			matches, _ := registry.GetMatches("account-123", "", "", 0, tt.auction)
+			assert.Equal(t, tt.expected, len(matches) == 1)
+		})
+	}
+}
+
 func TestFilterRegistry_MaxTTL_CapsExpiration(t *testing.T) {
 	maxTTL := 1 * time.Hour
 	registry := NewFilterRegistry(10, maxTTL, 0, &metricsConfig.NilMetricsEngine{})
@@ -452,12 +527,12 @@ func TestFilterRegistry_GetMatches_RateLimitDropsExcess(t *testing.T) {
 	})
 
 	// First call: burst of 1 allows it
-	allowed, dropped := registry.GetMatches("account-123", "", "", 0)
+	allowed, dropped := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, allowed, 1)
 	assert.Equal(t, 0, dropped)
 
 	// Second call: token exhausted
-	allowed, dropped = registry.GetMatches("account-123", "", "", 0)
+	allowed, dropped = registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, allowed, 0)
 	assert.Equal(t, 1, dropped)
 }
@@ -477,12 +552,12 @@ func TestFilterRegistry_GetMatches_RateLimitIndependentPerFilter(t *testing.T) {
 	})
 
 	// First call: both filters pass
-	allowed, dropped := registry.GetMatches("account-123", "", "", 0)
+	allowed, dropped := registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, allowed, 2)
 	assert.Equal(t, 0, dropped)
 
 	// Second call: both rate-limited
-	allowed, dropped = registry.GetMatches("account-123", "", "", 0)
+	allowed, dropped = registry.GetMatches("account-123", "", "", 0, nil)
 	assert.Len(t, allowed, 0)
 	assert.Equal(t, 2, dropped)
 }
@@ -497,7 +572,7 @@ func TestFilterRegistry_GetMatches_NoRateLimitWhenZero(t *testing.T) {
 	})
 
 	for i := 0; i < 20; i++ {
-		allowed, dropped := registry.GetMatches("account-123", "", "", 0)
+		allowed, dropped := registry.GetMatches("account-123", "", "", 0, nil)
 		assert.Len(t, allowed, 1)
 		assert.Equal(t, 0, dropped)
 	}
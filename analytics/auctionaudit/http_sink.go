@@ -0,0 +1,198 @@
+package auctionaudit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/metrics"
+)
+
+// maxPendingBatches is how many batches may wait for the sender while it is busy. Batches beyond
+// that are dropped, so a slow endpoint can't hold on to an unbounded amount of memory.
+const maxPendingBatches = 8
+
+// HTTPMatchSink batches matched events and POSTs them to an endpoint as newline delimited JSON
+// records. A batch is sent when it reaches batch_size records or when flush_interval elapses,
+// whichever is first. Batches are sent one at a time by a single sender. A batch that fails to
+// send, or that finds the sender's queue full, is dropped.
+type HTTPMatchSink struct {
+	endpoint      string
+	client        *http.Client
+	batchSize     int
+	timeout       time.Duration
+	metricsEngine metrics.MetricsEngine
+
+	mu      sync.Mutex
+	batch   bytes.Buffer
+	records int
+	closed  bool
+
+	pending chan []byte
+	sent    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func NewHTTPMatchSink(cfg config.AuctionAuditHTTPConfig, client *http.Client, metricsEngine metrics.MetricsEngine) (*HTTPMatchSink, error) {
+	flushInterval, err := time.ParseDuration(cfg.FlushInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http.flush_interval: %w", err)
+	}
+	if flushInterval <= 0 {
+		return nil, fmt.Errorf("http.flush_interval must be positive")
+	}
+
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid http.timeout: %w", err)
+	}
+
+	if cfg.BatchSize <= 0 {
+		return nil, fmt.Errorf("http.batch_size must be positive")
+	}
+
+	s := &HTTPMatchSink{
+		endpoint:      cfg.Endpoint,
+		client:        client,
+		batchSize:     cfg.BatchSize,
+		timeout:       timeout,
+		metricsEngine: metricsEngine,
+		pending:       make(chan []byte, maxPendingBatches),
+		sent:          make(chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go s.sendLoop()
+	go s.flushLoop(flushInterval)
+
+	return s, nil
+}
+
+func (s *HTTPMatchSink) SendMatchedEvent(event *AuctionEvent, filters []*AuctionFilterRequest) error {
+	if event == nil || len(filters) == 0 {
+		return nil
+	}
+
+	record, err := encodeMatchedRecord(event, filters)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("http match sink is closed")
+	}
+
+	s.batch.Write(record)
+	s.batch.WriteByte('\n')
+	s.records++
+
+	if s.records >= s.batchSize {
+		s.flushLocked()
+	}
+	return nil
+}
+
+// Close sends the pending batch and waits for batches in flight.
+func (s *HTTPMatchSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+
+	s.mu.Lock()
+	s.flushLocked()
+	s.mu.Unlock()
+
+	close(s.pending)
+	<-s.sent
+	return nil
+}
+
+func (s *HTTPMatchSink) flushLoop(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.flushLocked()
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *HTTPMatchSink) sendLoop() {
+	defer close(s.sent)
+
+	for body := range s.pending {
+		if err := s.post(body); err != nil {
+			logger.Errorf("[auctionaudit] %v", err)
+			s.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorProduce)
+		}
+	}
+}
+
+// flushLocked queues the pending batch for the sender, or drops it when the queue is full. The
+// caller holds s.mu.
+func (s *HTTPMatchSink) flushLocked() {
+	if s.records == 0 {
+		return
+	}
+
+	body := bytes.Clone(s.batch.Bytes())
+	records := s.records
+	s.batch.Reset()
+	s.records = 0
+
+	select {
+	case s.pending <- body:
+	default:
+		logger.Errorf("[auctionaudit] dropped a match batch of %d records, %d batches are already waiting to be sent", records, maxPendingBatches)
+		s.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorDropped)
+	}
+}
+
+func (s *HTTPMatchSink) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create match batch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send match batch: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to send match batch: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package auctionaudit
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/metrics"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]byte
+	status  int
+}
+
+func (b *batchRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r.Header.Get("Content-Type") == "application/x-ndjson" {
+		b.batches = append(b.batches, body)
+	}
+	if b.status != 0 {
+		w.WriteHeader(b.status)
+	}
+}
+
+func (b *batchRecorder) received() [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([][]byte(nil), b.batches...)
+}
+
+func newTestHTTPMatchSink(t *testing.T, endpoint string, batchSize int, flushInterval string, me metrics.MetricsEngine) *HTTPMatchSink {
+	t.Helper()
+	sink, err := NewHTTPMatchSink(config.AuctionAuditHTTPConfig{
+		Endpoint:      endpoint,
+		BatchSize:     batchSize,
+		FlushInterval: flushInterval,
+		Timeout:       "1s",
+	}, http.DefaultClient, me)
+	require.NoError(t, err)
+	return sink
+}
+
+func TestHTTPMatchSink_FlushesFullBatches(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	sink := newTestHTTPMatchSink(t, server.URL, 2, "1h", &metricsConfig.NilMetricsEngine{})
+
+	filters := []*AuctionFilterRequest{{SessionId: 1}}
+	for _, account := range []string{"first", "second", "third"} {
+		require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: account}, filters))
+	}
+	assert.Eventually(t, func() bool { return len(recorder.received()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// the partial batch is sent on close
+	require.NoError(t, sink.Close())
+	batches := recorder.received()
+	require.Len(t, batches, 2)
+
+	first := bytes.Split(bytes.TrimSpace(batches[0]), []byte("\n"))
+	require.Len(t, first, 2)
+	assert.Contains(t, string(first[0]), `"first"`)
+	assert.Contains(t, string(first[1]), `"second"`)
+	assert.Contains(t, string(batches[1]), `"third"`)
+
+	assert.Error(t, sink.SendMatchedEvent(&AuctionEvent{}, filters), "closed sink")
+}
+
+func TestHTTPMatchSink_FlushesOnInterval(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	sink := newTestHTTPMatchSink(t, server.URL, 100, "10ms", &metricsConfig.NilMetricsEngine{})
+	defer sink.Close()
+
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-123"}, []*AuctionFilterRequest{{SessionId: 1}}))
+	assert.Eventually(t, func() bool { return len(recorder.received()) == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPMatchSink_RecordsFailedBatches(t *testing.T) {
+	recorder := &batchRecorder{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	me := &metrics.MetricsEngineMock{}
+	me.On("RecordAuctionAuditError", metrics.AuctionAuditErrorProduce).Return()
+
+	sink := newTestHTTPMatchSink(t, server.URL, 1, "1h", me)
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-123"}, []*AuctionFilterRequest{{SessionId: 1}}))
+	require.NoError(t, sink.Close())
+
+	me.AssertNumberOfCalls(t, "RecordAuctionAuditError", 1)
+}
+
+func TestNewHTTPMatchSink_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.AuctionAuditHTTPConfig
+	}{
+		{"invalid flush interval", config.AuctionAuditHTTPConfig{BatchSize: 1, FlushInterval: "soon", Timeout: "1s"}},
+		{"zero flush interval", config.AuctionAuditHTTPConfig{BatchSize: 1, FlushInterval: "0s", Timeout: "1s"}},
+		{"invalid timeout", config.AuctionAuditHTTPConfig{BatchSize: 1, FlushInterval: "1s", Timeout: "soon"}},
+		{"zero batch size", config.AuctionAuditHTTPConfig{FlushInterval: "1s", Timeout: "1s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHTTPMatchSink(tt.cfg, http.DefaultClient, &metricsConfig.NilMetricsEngine{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHTTPMatchSink_DropsBatchesWhenQueueIsFull(t *testing.T) {
+	release := make(chan struct{})
+	var requests sync.WaitGroup
+	requests.Add(1)
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(requests.Done)
+		<-release
+	}))
+	defer server.Close()
+
+	me := &metrics.MetricsEngineMock{}
+	me.On("RecordAuctionAuditError", metrics.AuctionAuditErrorDropped).Return()
+
+	sink := newTestHTTPMatchSink(t, server.URL, 1, "1h", me)
+	filters := []*AuctionFilterRequest{{SessionId: 1}}
+
+	// the first batch blocks the sender, the next ones fill its queue
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-123"}, filters))
+	requests.Wait()
+	for i := 0; i < maxPendingBatches+2; i++ {
+		require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{AccountId: "account-123"}, filters))
+	}
+	me.AssertNumberOfCalls(t, "RecordAuctionAuditError", 2)
+
+	close(release)
+	require.NoError(t, sink.Close())
+	me.AssertNumberOfCalls(t, "RecordAuctionAuditError", 2)
+}
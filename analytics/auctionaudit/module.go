@@ -3,6 +3,7 @@ package auctionaudit
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -15,15 +16,16 @@ import (
 type AuctionAuditModule struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
-	producer       *Producer
+	sink           MatchSink
 	environment    string
+	includeRawJSON bool
 	filterRegistry *FilterRegistry
-	filterConsumer *FilterConsumer
+	filterSource   FilterSource
 	metricsEngine  metrics.MetricsEngine
 }
 
 func NewModule(cfg config.AuctionAuditAnalytics, metricsEngine metrics.MetricsEngine) (analytics.Module, error) {
-	if err := validateConfig(cfg.Kafka); err != nil {
+	if err := validateTransports(cfg); err != nil {
 		return nil, fmt.Errorf("invalid auction audit config: %w", err)
 	}
 
@@ -41,33 +43,34 @@ func NewModule(cfg config.AuctionAuditAnalytics, metricsEngine metrics.MetricsEn
 
 	filterRegistry := NewFilterRegistry(cfg.MaxFilters, maxFilterTTL, cfg.MaxEventsPerSec, metricsEngine)
 
-	producer, err := NewProducer(cfg.Kafka, metricsEngine)
+	sink, err := newMatchSink(cfg, metricsEngine)
 	if err != nil {
 		cancel()
 		return nil, err
 	}
 
-	filterConsumer, err := NewFilterConsumer(ctx, cfg.Kafka, filterRegistry, metricsEngine)
+	filterSource, err := newFilterSource(ctx, cfg, filterRegistry, metricsEngine)
 	if err != nil {
 		cancel()
-		producer.Close()
+		sink.Close()
 		return nil, err
 	}
 
 	module := &AuctionAuditModule{
 		ctx:            ctx,
 		cancel:         cancel,
-		producer:       producer,
+		sink:           sink,
 		environment:    cfg.Environment,
+		includeRawJSON: cfg.IncludeRawJSON,
 		filterRegistry: filterRegistry,
-		filterConsumer: filterConsumer,
+		filterSource:   filterSource,
 		metricsEngine:  metricsEngine,
 	}
 
 	filterRegistry.Start(ctx, cleanupInterval)
 
-	logger.Infof("[auctionaudit] Auction audit module initialized: matched_topic=%s filter_topic=%s brokers=%v",
-		cfg.Kafka.MatchedTopic, cfg.Kafka.FilterTopic, cfg.Kafka.Brokers)
+	logger.Infof("[auctionaudit] Auction audit module initialized: filter_source=%s match_sink=%s",
+		cfg.FilterSource, cfg.MatchSink)
 
 	return module, nil
 }
@@ -90,7 +93,7 @@ func (m *AuctionAuditModule) LogAuctionObject(ao *analytics.AuctionObject) {
 	}
 
 	mediaTypeSet := MediaTypeSetFromImps(req.Imp)
-	filters, dropped := m.filterRegistry.GetMatches(accountID, domain, appBundle, mediaTypeSet)
+	filters, dropped := m.filterRegistry.GetMatches(accountID, domain, appBundle, mediaTypeSet, ao)
 
 	if dropped > 0 {
 		m.metricsEngine.RecordAuctionAudit(metrics.AuctionAuditEventDropped, accountID, dropped)
@@ -100,9 +103,9 @@ func (m *AuctionAuditModule) LogAuctionObject(ao *analytics.AuctionObject) {
 		return
 	}
 
-	event := buildAuctionEvent(ao, m.environment, accountID, domain, appBundle, mediaTypeSet.ToSlice())
+	event := buildAuctionEvent(ao, m.environment, accountID, domain, appBundle, mediaTypeSet.ToSlice(), m.includeRawJSON)
 
-	if err := m.producer.SendMatchedEvent(event, filters); err != nil {
+	if err := m.sink.SendMatchedEvent(event, filters); err != nil {
 		logger.Errorf("[auctionaudit] %v", err)
 		m.metricsEngine.RecordAuctionAuditError(metrics.AuctionAuditErrorSend)
 		return
@@ -116,19 +119,31 @@ func (m *AuctionAuditModule) Shutdown() {
 
 	m.cancel()
 
-	if m.filterConsumer != nil {
-		if err := m.filterConsumer.Close(); err != nil {
-			logger.Errorf("[auctionaudit] Failed to close filter consumer: %v", err)
+	if m.filterSource != nil {
+		if err := m.filterSource.Close(); err != nil {
+			logger.Errorf("[auctionaudit] Failed to close filter source: %v", err)
 		}
 	}
 
-	if err := m.producer.Close(); err != nil {
-		logger.Errorf("[auctionaudit] Failed to close producer: %v", err)
+	if err := m.sink.Close(); err != nil {
+		logger.Errorf("[auctionaudit] Failed to close match sink: %v", err)
 	}
 
 	logger.Infof("[auctionaudit] Shutdown complete")
 }
 
+// AdminHandlers returns the handlers of the filter source and match sink served on the admin port,
+// by path.
+func (m *AuctionAuditModule) AdminHandlers() map[string]http.Handler {
+	handlers := make(map[string]http.Handler)
+	for _, transport := range []interface{}{m.filterSource, m.sink} {
+		if route, ok := transport.(adminRoute); ok {
+			handlers[route.adminPath()] = route
+		}
+	}
+	return handlers
+}
+
 func validateConfig(cfg config.AuctionAuditKafkaConfig) error {
 	if len(cfg.Brokers) == 0 {
 		return fmt.Errorf("kafka.brokers is required")
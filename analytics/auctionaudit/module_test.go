@@ -96,7 +96,7 @@ func newTestModule(t *testing.T, me *metrics.MetricsEngineMock, maxEventsPerSec
 	return &AuctionAuditModule{
 		ctx:    ctx,
 		cancel: cancel,
-		producer: &Producer{
+		sink: &Producer{
 			producer:      mockProducer,
 			topic:         "test-topic",
 			metricsEngine: me,
@@ -138,7 +138,7 @@ func TestLogAuctionObject_RateLimiterDisabled_AllEventsPass(t *testing.T) {
 	me.On("RecordAuctionAuditActiveFilters", mock.Anything).Return()
 
 	module := newTestModule(t, me, 0)
-	module.producer.producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+	module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
 
 	registerTestFilter(t, module.filterRegistry)
 
@@ -154,7 +154,7 @@ func TestLogAuctionObject_PerFilterRateLimit_DropsExcessEvents(t *testing.T) {
 	me.On("RecordAuctionAuditActiveFilters", mock.Anything).Return()
 
 	module := newTestModule(t, me, 1)
-	module.producer.producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+	module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
 
 	registerTestFilter(t, module.filterRegistry)
 
@@ -224,8 +224,8 @@ func TestLogAuctionObject_PerFilterRateLimit_IndependentPerFilter(t *testing.T)
 	assert.NoError(t, err)
 
 	// First call: both filters should pass (each has burst of 1)
-	module.producer.producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
-	module.producer.producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+	module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+	module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
 	module.LogAuctionObject(newTestAuctionObject())
 
 	matchedCalls := 0
@@ -282,7 +282,7 @@ func TestLogAuctionObject_ZeroConfig_NoRateLimiting(t *testing.T) {
 
 	// Send many events — none should be sampled
 	for i := 0; i < 10; i++ {
-		module.producer.producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+		module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
 		module.LogAuctionObject(newTestAuctionObject())
 	}
 
@@ -302,3 +302,44 @@ func TestLogAuctionObject_ZeroConfig_NoRateLimiting(t *testing.T) {
 	assert.Equal(t, 0, sampledCalls, "no events should be sampled with rate=0")
 	assert.Equal(t, 10, matchedCalls, "all events should be matched")
 }
+
+func TestLogAuctionObject_AuctionPredicates(t *testing.T) {
+	me := &metrics.MetricsEngineMock{}
+	me.On("RecordAuctionAudit", mock.Anything, mock.Anything, mock.Anything).Return()
+	me.On("RecordAuctionAuditActiveFilters", mock.Anything).Return()
+
+	module := newTestModule(t, me, 0)
+
+	err := module.filterRegistry.Register(&AuctionFilterRequest{
+		SessionId:   1,
+		AccountId:   "testaccount",
+		Bidder:      "appnexus",
+		ExpiresAtMs: time.Now().Add(time.Hour).UnixMilli(),
+	})
+	assert.NoError(t, err)
+
+	// No bid from the bidder: not matched
+	module.LogAuctionObject(newTestAuctionObject())
+	me.AssertNotCalled(t, "RecordAuctionAudit", metrics.AuctionAuditEventMatched, mock.Anything, mock.Anything)
+
+	module.sink.(*Producer).producer.(*mocks.AsyncProducer).ExpectInputAndSucceed()
+	ao := newTestAuctionObject()
+	ao.Response = &openrtb2.BidResponse{
+		SeatBid: []openrtb2.SeatBid{{Seat: "appnexus", Bid: []openrtb2.Bid{{ImpID: "1", Price: 1}}}},
+	}
+	module.LogAuctionObject(ao)
+	me.AssertCalled(t, "RecordAuctionAudit", metrics.AuctionAuditEventMatched, "testaccount", 1)
+}
+
+func TestAdminHandlers(t *testing.T) {
+	registry := newTestRegistry(10)
+
+	module := &AuctionAuditModule{filterSource: NewAdminFilterSource(registry), sink: NewSSEMatchSink()}
+	handlers := module.AdminHandlers()
+	assert.Len(t, handlers, 2)
+	assert.Contains(t, handlers, "/auction_audit/filters")
+	assert.Contains(t, handlers, "/auction_audit/events")
+
+	module = newTestModule(t, &metrics.MetricsEngineMock{}, 0)
+	assert.Empty(t, module.AdminHandlers(), "kafka transports are not served on the admin port")
+}
@@ -3,13 +3,18 @@ package auctionaudit
 import (
 	"fmt"
 
+	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"google.golang.org/protobuf/proto"
 )
 
-func buildAuctionEvent(ao *analytics.AuctionObject, environment, accountID, domain, appBundle string, mediaTypes []MediaType) *AuctionEvent {
+// buildAuctionEvent maps the auction into the typed event. The request and response are also
+// attached as JSON text when includeRawJSON is set.
+func buildAuctionEvent(ao *analytics.AuctionObject, environment, accountID, domain, appBundle string, mediaTypes []MediaType, includeRawJSON bool) *AuctionEvent {
 	event := &AuctionEvent{
 		Environment: environment,
 		TimestampMs: ao.StartTime.UnixMilli(),
@@ -21,17 +26,35 @@ func buildAuctionEvent(ao *analytics.AuctionObject, environment, accountID, doma
 	}
 
 	if ao.RequestWrapper != nil && ao.RequestWrapper.BidRequest != nil {
-		if reqJSON, err := jsonutil.Marshal(ao.RequestWrapper.BidRequest); err == nil {
-			event.BidRequest = string(reqJSON)
+		event.RequestId = ao.RequestWrapper.BidRequest.ID
+		event.Imps = buildAuctionImps(ao.RequestWrapper.BidRequest.Imp)
+
+		if includeRawJSON {
+			if reqJSON, err := jsonutil.Marshal(ao.RequestWrapper.BidRequest); err == nil {
+				event.BidRequest = string(reqJSON)
+			}
 		}
 	}
 
 	if ao.Response != nil {
-		if respJSON, err := jsonutil.Marshal(ao.Response); err == nil {
-			event.BidResponse = string(respJSON)
+		event.Currency = ao.Response.Cur
+		event.SeatBids = buildAuctionSeatBids(ao.Response.SeatBid)
+
+		if includeRawJSON {
+			if respJSON, err := jsonutil.Marshal(ao.Response); err == nil {
+				event.BidResponse = string(respJSON)
+			}
 		}
 	}
 
+	if len(ao.SeatNonBid) > 0 {
+		event.SeatNonBids = buildAuctionSeatNonBids(ao.SeatNonBid)
+	}
+
+	if len(ao.HookExecutionOutcome) > 0 {
+		event.HookOutcomes = buildAuctionHookOutcomes(ao.HookExecutionOutcome)
+	}
+
 	if len(ao.Errors) > 0 {
 		event.Errors = buildAuctionErrors(ao.Errors)
 	}
@@ -39,6 +62,99 @@ func buildAuctionEvent(ao *analytics.AuctionObject, environment, accountID, doma
 	return event
 }
 
+func buildAuctionImps(imps []openrtb2.Imp) []*AuctionImp {
+	if len(imps) == 0 {
+		return nil
+	}
+
+	result := make([]*AuctionImp, len(imps))
+	for i := range imps {
+		imp := &imps[i]
+		result[i] = &AuctionImp{
+			Id:          imp.ID,
+			TagId:       imp.TagID,
+			MediaTypes:  MediaTypeSetFromImps(imps[i : i+1]).ToSlice(),
+			BidFloor:    imp.BidFloor,
+			BidFloorCur: imp.BidFloorCur,
+		}
+		if imp.PMP != nil {
+			for _, deal := range imp.PMP.Deals {
+				result[i].DealIds = append(result[i].DealIds, deal.ID)
+			}
+		}
+	}
+	return result
+}
+
+func buildAuctionSeatBids(seatBids []openrtb2.SeatBid) []*AuctionSeatBid {
+	if len(seatBids) == 0 {
+		return nil
+	}
+
+	result := make([]*AuctionSeatBid, len(seatBids))
+	for i := range seatBids {
+		bids := make([]*AuctionBid, len(seatBids[i].Bid))
+		for j := range seatBids[i].Bid {
+			bid := &seatBids[i].Bid[j]
+			bids[j] = &AuctionBid{
+				Id:      bid.ID,
+				ImpId:   bid.ImpID,
+				Price:   bid.Price,
+				Adomain: bid.ADomain,
+				Crid:    bid.CrID,
+				DealId:  bid.DealID,
+			}
+		}
+		result[i] = &AuctionSeatBid{
+			Seat: seatBids[i].Seat,
+			Bids: bids,
+		}
+	}
+	return result
+}
+
+func buildAuctionSeatNonBids(seatNonBids []openrtb_ext.SeatNonBid) []*AuctionSeatNonBid {
+	result := make([]*AuctionSeatNonBid, len(seatNonBids))
+	for i := range seatNonBids {
+		nonBids := make([]*AuctionNonBid, len(seatNonBids[i].NonBid))
+		for j, nonBid := range seatNonBids[i].NonBid {
+			nonBids[j] = &AuctionNonBid{
+				ImpId:      nonBid.ImpId,
+				StatusCode: int32(nonBid.StatusCode),
+			}
+		}
+		result[i] = &AuctionSeatNonBid{
+			Seat:    seatNonBids[i].Seat,
+			NonBids: nonBids,
+		}
+	}
+	return result
+}
+
+// buildAuctionHookOutcomes flattens the stage outcomes into one entry per hook invocation.
+func buildAuctionHookOutcomes(stageOutcomes []hookexecution.StageOutcome) []*AuctionHookOutcome {
+	var result []*AuctionHookOutcome
+	for _, stageOutcome := range stageOutcomes {
+		for _, group := range stageOutcome.Groups {
+			for _, hook := range group.InvocationResults {
+				result = append(result, &AuctionHookOutcome{
+					Stage:           stageOutcome.Stage,
+					Entity:          string(stageOutcome.Entity),
+					ModuleCode:      hook.HookID.ModuleCode,
+					HookImplCode:    hook.HookID.HookImplCode,
+					Status:          string(hook.Status),
+					Action:          string(hook.Action),
+					Message:         hook.Message,
+					ExecutionTimeMs: hook.ExecutionTimeMillis.Milliseconds(),
+					Errors:          hook.Errors,
+					Warnings:        hook.Warnings,
+				})
+			}
+		}
+	}
+	return result
+}
+
 func buildAuctionErrors(errs []error) []*AuctionError {
 	result := make([]*AuctionError, len(errs))
 	for i, e := range errs {
@@ -9,6 +9,7 @@ import (
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,7 +41,7 @@ func TestBuildAuctionEvent(t *testing.T) {
 	}
 
 	mediaTypes := []MediaType{MediaType_MEDIA_TYPE_BANNER, MediaType_MEDIA_TYPE_VIDEO}
-	event := buildAuctionEvent(ao, "production", "test-account-123", "example.com", "", mediaTypes)
+	event := buildAuctionEvent(ao, "production", "test-account-123", "example.com", "", mediaTypes, true)
 
 	assert.Equal(t, "test-account-123", event.AccountId)
 	assert.Equal(t, "example.com", event.Domain)
@@ -76,13 +77,123 @@ func TestBuildAuctionEvent_WithApp(t *testing.T) {
 	}
 
 	mediaTypes := []MediaType{MediaType_MEDIA_TYPE_NATIVE}
-	event := buildAuctionEvent(ao, "staging", "app-account", "", "com.example.app", mediaTypes)
+	event := buildAuctionEvent(ao, "staging", "app-account", "", "com.example.app", mediaTypes, false)
 
 	assert.Equal(t, "app-account", event.AccountId)
 	assert.Equal(t, "", event.Domain)
 	assert.Equal(t, "com.example.app", event.AppBundle)
 	assert.Equal(t, startTime.UnixMilli(), event.TimestampMs)
 	assert.ElementsMatch(t, []MediaType{MediaType_MEDIA_TYPE_NATIVE}, event.MediaTypes)
+	assert.Empty(t, event.BidRequest, "raw JSON is only attached when enabled")
+}
+
+func TestBuildAuctionEvent_TypedPayload(t *testing.T) {
+	ao := &analytics.AuctionObject{
+		Status:    http.StatusOK,
+		StartTime: time.Now(),
+		RequestWrapper: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				ID: "request-id",
+				Imp: []openrtb2.Imp{
+					{
+						ID:          "imp-1",
+						TagID:       "homepage-top",
+						Banner:      &openrtb2.Banner{},
+						Video:       &openrtb2.Video{},
+						BidFloor:    0.5,
+						BidFloorCur: "USD",
+						PMP:         &openrtb2.PMP{Deals: []openrtb2.Deal{{ID: "deal-1"}, {ID: "deal-2"}}},
+					},
+					{ID: "imp-2", Native: &openrtb2.Native{}},
+				},
+			},
+		},
+		Response: &openrtb2.BidResponse{
+			ID:  "response-id",
+			Cur: "USD",
+			SeatBid: []openrtb2.SeatBid{
+				{
+					Seat: "appnexus",
+					Bid: []openrtb2.Bid{
+						{ID: "bid-1", ImpID: "imp-1", Price: 1.25, ADomain: []string{"advertiser.com"}, CrID: "creative-1", DealID: "deal-1"},
+					},
+				},
+			},
+		},
+		SeatNonBid: []openrtb_ext.SeatNonBid{
+			{Seat: "rubicon", NonBid: []openrtb_ext.NonBid{{ImpId: "imp-2", StatusCode: 101}}},
+		},
+		HookExecutionOutcome: []hookexecution.StageOutcome{
+			{
+				Stage:  "bidder_request",
+				Entity: "appnexus",
+				Groups: []hookexecution.GroupOutcome{
+					{
+						InvocationResults: []hookexecution.HookOutcome{
+							{
+								ExecutionTime: hookexecution.ExecutionTime{ExecutionTimeMillis: 12 * time.Millisecond},
+								HookID:        hookexecution.HookID{ModuleCode: "openads.signatures", HookImplCode: "bidder-request"},
+								Status:        hookexecution.StatusSuccess,
+								Action:        hookexecution.ActionUpdate,
+								Message:       "signed",
+								Warnings:      []string{"cache miss"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	event := buildAuctionEvent(ao, "test", "account", "", "", nil, false)
+
+	assert.Equal(t, "request-id", event.RequestId)
+	assert.Equal(t, "USD", event.Currency)
+	assert.Empty(t, event.BidRequest)
+	assert.Empty(t, event.BidResponse)
+
+	assert.Len(t, event.Imps, 2)
+	assert.Equal(t, "imp-1", event.Imps[0].Id)
+	assert.Equal(t, "homepage-top", event.Imps[0].TagId)
+	assert.Equal(t, []MediaType{MediaType_MEDIA_TYPE_BANNER, MediaType_MEDIA_TYPE_VIDEO}, event.Imps[0].MediaTypes)
+	assert.Equal(t, 0.5, event.Imps[0].BidFloor)
+	assert.Equal(t, "USD", event.Imps[0].BidFloorCur)
+	assert.Equal(t, []string{"deal-1", "deal-2"}, event.Imps[0].DealIds)
+	assert.Equal(t, []MediaType{MediaType_MEDIA_TYPE_NATIVE}, event.Imps[1].MediaTypes)
+	assert.Nil(t, event.Imps[1].DealIds)
+
+	assert.Len(t, event.SeatBids, 1)
+	assert.Equal(t, "appnexus", event.SeatBids[0].Seat)
+	assert.Len(t, event.SeatBids[0].Bids, 1)
+	bid := event.SeatBids[0].Bids[0]
+	assert.Equal(t, "bid-1", bid.Id)
+	assert.Equal(t, "imp-1", bid.ImpId)
+	assert.Equal(t, 1.25, bid.Price)
+	assert.Equal(t, []string{"advertiser.com"}, bid.Adomain)
+	assert.Equal(t, "creative-1", bid.Crid)
+	assert.Equal(t, "deal-1", bid.DealId)
+
+	assert.Len(t, event.SeatNonBids, 1)
+	assert.Equal(t, "rubicon", event.SeatNonBids[0].Seat)
+	assert.Len(t, event.SeatNonBids[0].NonBids, 1)
+	assert.Equal(t, "imp-2", event.SeatNonBids[0].NonBids[0].ImpId)
+	assert.Equal(t, int32(101), event.SeatNonBids[0].NonBids[0].StatusCode)
+
+	assert.Len(t, event.HookOutcomes, 1)
+	hook := event.HookOutcomes[0]
+	assert.Equal(t, "bidder_request", hook.Stage)
+	assert.Equal(t, "appnexus", hook.Entity)
+	assert.Equal(t, "openads.signatures", hook.ModuleCode)
+	assert.Equal(t, "bidder-request", hook.HookImplCode)
+	assert.Equal(t, "success", hook.Status)
+	assert.Equal(t, "update", hook.Action)
+	assert.Equal(t, "signed", hook.Message)
+	assert.Equal(t, int64(12), hook.ExecutionTimeMs)
+	assert.Equal(t, []string{"cache miss"}, hook.Warnings)
+
+	withRawJSON := buildAuctionEvent(ao, "test", "account", "", "", nil, true)
+	assert.Contains(t, withRawJSON.BidRequest, "request-id")
+	assert.Contains(t, withRawJSON.BidResponse, "response-id")
 }
 
 func TestBuildAuctionEvent_WithErrors(t *testing.T) {
@@ -102,7 +213,7 @@ func TestBuildAuctionEvent_WithErrors(t *testing.T) {
 		},
 	}
 
-	event := buildAuctionEvent(ao, "test", "", "", "", nil)
+	event := buildAuctionEvent(ao, "test", "", "", "", nil, false)
 
 	assert.Equal(t, int32(http.StatusBadRequest), event.Status)
 	assert.Len(t, event.Errors, 2)
@@ -0,0 +1,141 @@
+package auctionaudit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	adminEventsPath      = "/auction_audit/events"
+	sseSubscriberBacklog = 16
+)
+
+// SSEMatchSink streams matched events as server-sent events on the admin port. A client subscribes
+// to one filter with GET /auction_audit/events?account_id=...&session_id=... and receives the
+// events matched by that filter from then on. Events are dropped for a client that falls behind.
+type SSEMatchSink struct {
+	mu          sync.Mutex
+	subscribers map[filterKey]map[chan []byte]struct{}
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+func NewSSEMatchSink() *SSEMatchSink {
+	return &SSEMatchSink{
+		subscribers: make(map[filterKey]map[chan []byte]struct{}),
+		closed:      make(chan struct{}),
+	}
+}
+
+func (s *SSEMatchSink) SendMatchedEvent(event *AuctionEvent, filters []*AuctionFilterRequest) error {
+	if event == nil || len(filters) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var record []byte
+	for _, filter := range filters {
+		subscribers := s.subscribers[filterKey{accountID: filter.AccountId, sessionID: filter.SessionId}]
+		if len(subscribers) == 0 {
+			continue
+		}
+
+		// serialize only when someone is listening
+		if record == nil {
+			var err error
+			if record, err = encodeMatchedRecord(event, filters); err != nil {
+				return fmt.Errorf("failed to serialize event: %w", err)
+			}
+		}
+
+		for ch := range subscribers {
+			select {
+			case ch <- record:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Close ends all streams.
+func (s *SSEMatchSink) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *SSEMatchSink) adminPath() string {
+	return adminEventsPath
+}
+
+func (s *SSEMatchSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	sessionID, err := strconv.ParseInt(query.Get("session_id"), 10, 32)
+	if err != nil || query.Get("account_id") == "" {
+		http.Error(w, "account_id and a numeric session_id are required", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	key := filterKey{accountID: strings.ToLower(query.Get("account_id")), sessionID: int32(sessionID)}
+	ch := s.subscribe(key)
+	defer s.unsubscribe(key, ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closed:
+			return
+		case record := <-ch:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", record); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *SSEMatchSink) subscribe(key filterKey) chan []byte {
+	ch := make(chan []byte, sseSubscriberBacklog)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers[key] == nil {
+		s.subscribers[key] = make(map[chan []byte]struct{})
+	}
+	s.subscribers[key][ch] = struct{}{}
+	return ch
+}
+
+func (s *SSEMatchSink) unsubscribe(key filterKey, ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscribers[key], ch)
+	if len(s.subscribers[key]) == 0 {
+		delete(s.subscribers, key)
+	}
+}
@@ -0,0 +1,69 @@
+package auctionaudit
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEMatchSink(t *testing.T) {
+	sink := NewSSEMatchSink()
+	server := httptest.NewServer(sink)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + adminEventsPath + "?account_id=Account-123&session_id=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// wait for the subscription before sending
+	require.Eventually(t, func() bool {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return len(sink.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	other := &AuctionFilterRequest{AccountId: "account-123", SessionId: 2}
+	subscribed := &AuctionFilterRequest{AccountId: "account-123", SessionId: 1}
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{Domain: "other.com"}, []*AuctionFilterRequest{other}))
+	require.NoError(t, sink.SendMatchedEvent(&AuctionEvent{Domain: "example.com"}, []*AuctionFilterRequest{other, subscribed}))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, "data: "))
+	assert.Contains(t, line, `"example.com"`)
+	assert.Contains(t, line, `"session_ids":[2,1]`)
+
+	// closing the sink ends the stream
+	require.NoError(t, sink.Close())
+	require.Eventually(t, func() bool {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return len(sink.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSSEMatchSink_InvalidRequests(t *testing.T) {
+	sink := NewSSEMatchSink()
+
+	recorder := httptest.NewRecorder()
+	sink.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, adminEventsPath+"?account_id=account-123", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	sink.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, adminEventsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestSSEMatchSink_NoSubscribers(t *testing.T) {
+	sink := NewSSEMatchSink()
+	assert.NoError(t, sink.SendMatchedEvent(&AuctionEvent{}, []*AuctionFilterRequest{{AccountId: "account-123", SessionId: 1}}))
+}
@@ -0,0 +1,142 @@
+package auctionaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Transport names accepted by filter_source and match_sink
+const (
+	TransportKafka = "kafka"
+	TransportFile  = "file"
+	TransportAdmin = "admin"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+// FilterSource feeds filter registrations and removals into a FilterRegistry until closed.
+type FilterSource interface {
+	Close() error
+}
+
+// MatchSink delivers an event to the sessions of the filters that matched it.
+type MatchSink interface {
+	SendMatchedEvent(event *AuctionEvent, filters []*AuctionFilterRequest) error
+	Close() error
+}
+
+// adminRoute is implemented by transports served on the admin port.
+type adminRoute interface {
+	http.Handler
+	adminPath() string
+}
+
+func newFilterSource(ctx context.Context, cfg config.AuctionAuditAnalytics, registry *FilterRegistry, metricsEngine metrics.MetricsEngine) (FilterSource, error) {
+	switch cfg.FilterSource {
+	case TransportKafka:
+		return NewFilterConsumer(ctx, cfg.Kafka, registry, metricsEngine)
+	case TransportFile:
+		return NewFileFilterSource(ctx, cfg.File, registry, metricsEngine)
+	case TransportAdmin:
+		return NewAdminFilterSource(registry), nil
+	default:
+		return nil, fmt.Errorf("unknown filter_source: %s", cfg.FilterSource)
+	}
+}
+
+func newMatchSink(cfg config.AuctionAuditAnalytics, metricsEngine metrics.MetricsEngine) (MatchSink, error) {
+	switch cfg.MatchSink {
+	case TransportKafka:
+		return NewProducer(cfg.Kafka, metricsEngine)
+	case TransportFile:
+		return NewFileMatchSink(cfg.File)
+	case TransportHTTP:
+		return NewHTTPMatchSink(cfg.HTTP, http.DefaultClient, metricsEngine)
+	case TransportSSE:
+		return NewSSEMatchSink(), nil
+	default:
+		return nil, fmt.Errorf("unknown match_sink: %s", cfg.MatchSink)
+	}
+}
+
+func validateTransports(cfg config.AuctionAuditAnalytics) error {
+	switch cfg.FilterSource {
+	case TransportKafka, TransportAdmin:
+	case TransportFile:
+		if cfg.File.FiltersPath == "" {
+			return fmt.Errorf("file.filters_path is required")
+		}
+	default:
+		return fmt.Errorf("filter_source must be one of kafka, file, admin: %s", cfg.FilterSource)
+	}
+
+	switch cfg.MatchSink {
+	case TransportKafka, TransportSSE:
+	case TransportFile:
+		if cfg.File.MatchesPath == "" {
+			return fmt.Errorf("file.matches_path is required")
+		}
+	case TransportHTTP:
+		if cfg.HTTP.Endpoint == "" {
+			return fmt.Errorf("http.endpoint is required")
+		}
+	default:
+		return fmt.Errorf("match_sink must be one of kafka, file, http, sse: %s", cfg.MatchSink)
+	}
+
+	if cfg.FilterSource == TransportKafka || cfg.MatchSink == TransportKafka {
+		return validateConfig(cfg.Kafka)
+	}
+	return nil
+}
+
+// applyFilter registers or removes filter, as the filter topic and every other source do.
+func applyFilter(registry *FilterRegistry, action byte, filter *AuctionFilterRequest) error {
+	filter.AccountId = strings.ToLower(filter.AccountId)
+
+	switch action {
+	case FilterActionRemove:
+		registry.Unregister(filter.SessionId, filter.AccountId)
+		logger.Infof("[auctionaudit] Unregistered filter: session=%d account=%s", filter.SessionId, filter.AccountId)
+	default:
+		// Default to create
+		if err := registry.Register(filter); err != nil {
+			logger.Warnf("[auctionaudit] Failed to register filter: session=%d account=%s: %v", filter.SessionId, filter.AccountId, err)
+			return err
+		}
+		logger.Infof("[auctionaudit] Registered filter: session=%d account=%s", filter.SessionId, filter.AccountId)
+	}
+	return nil
+}
+
+// matchedRecord is how sinks other than Kafka write an event: once, with the sessions whose
+// filters matched it, and the event in its protobuf JSON form.
+type matchedRecord struct {
+	SessionIDs []int32         `json:"session_ids"`
+	Event      json.RawMessage `json:"event"`
+}
+
+func encodeMatchedRecord(event *AuctionEvent, filters []*AuctionFilterRequest) ([]byte, error) {
+	eventJSON, err := protojson.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	record := matchedRecord{
+		SessionIDs: make([]int32, len(filters)),
+		Event:      eventJSON,
+	}
+	for i, filter := range filters {
+		record.SessionIDs[i] = filter.SessionId
+	}
+	return jsonutil.Marshal(record)
+}
@@ -0,0 +1,101 @@
+package auctionaudit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransports(t *testing.T) {
+	kafka := config.AuctionAuditKafkaConfig{
+		Brokers:      []string{"mybroker"},
+		FilterTopic:  "filter-topic",
+		MatchedTopic: "matched-topic",
+	}
+
+	tests := []struct {
+		name   string
+		cfg    config.AuctionAuditAnalytics
+		errMsg string
+	}{
+		{
+			name: "kafka",
+			cfg:  config.AuctionAuditAnalytics{FilterSource: "kafka", MatchSink: "kafka", Kafka: kafka},
+		},
+		{
+			name:   "kafka sink without brokers",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "admin", MatchSink: "kafka"},
+			errMsg: "kafka.brokers is required",
+		},
+		{
+			name: "no kafka",
+			cfg: config.AuctionAuditAnalytics{
+				FilterSource: "file",
+				MatchSink:    "http",
+				File:         config.AuctionAuditFileConfig{FiltersPath: "filters.jsonl"},
+				HTTP:         config.AuctionAuditHTTPConfig{Endpoint: "http://localhost/matches"},
+			},
+		},
+		{
+			name: "admin and sse",
+			cfg:  config.AuctionAuditAnalytics{FilterSource: "admin", MatchSink: "sse"},
+		},
+		{
+			name:   "file source without path",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "file", MatchSink: "sse"},
+			errMsg: "file.filters_path is required",
+		},
+		{
+			name:   "file sink without path",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "admin", MatchSink: "file"},
+			errMsg: "file.matches_path is required",
+		},
+		{
+			name:   "http sink without endpoint",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "admin", MatchSink: "http"},
+			errMsg: "http.endpoint is required",
+		},
+		{
+			name:   "unknown filter source",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "pubsub", MatchSink: "sse"},
+			errMsg: "filter_source must be one of",
+		},
+		{
+			name:   "unknown match sink",
+			cfg:    config.AuctionAuditAnalytics{FilterSource: "admin", MatchSink: "pubsub"},
+			errMsg: "match_sink must be one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTransports(tt.cfg)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestEncodeMatchedRecord(t *testing.T) {
+	event := &AuctionEvent{AccountId: "account-123", Domain: "example.com", TimestampMs: 1705315800000}
+	filters := []*AuctionFilterRequest{{SessionId: 1}, {SessionId: 7}}
+
+	record, err := encodeMatchedRecord(event, filters)
+	require.NoError(t, err)
+
+	var decoded struct {
+		SessionIDs []int32                `json:"session_ids"`
+		Event      map[string]interface{} `json:"event"`
+	}
+	require.NoError(t, json.Unmarshal(record, &decoded))
+	assert.Equal(t, []int32{1, 7}, decoded.SessionIDs)
+	assert.Equal(t, "account-123", decoded.Event["accountId"])
+	assert.Equal(t, "example.com", decoded.Event["domain"])
+	assert.NotContains(t, string(record), "\n", "records are written one per line")
+}
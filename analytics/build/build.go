@@ -2,6 +2,8 @@ package build
 
 import (
 	"encoding/json"
+	"net/http"
+
 	"github.com/benbjohnson/clock"
 	"github.com/prebid/prebid-server/v3/analytics"
 	"github.com/prebid/prebid-server/v3/analytics/agma"
@@ -85,6 +87,26 @@ func New(analytics *config.Analytics, metricsEngine metrics.MetricsEngine) analy
 	return modules
 }
 
+// AdminHandlers returns the handlers that the analytics modules of runner serve on the admin port,
+// by path.
+func AdminHandlers(runner analytics.Runner) map[string]http.Handler {
+	handlers := make(map[string]http.Handler)
+	modules, ok := runner.(enabledAnalytics)
+	if !ok {
+		return handlers
+	}
+	for _, module := range modules {
+		if adminModule, ok := module.(interface {
+			AdminHandlers() map[string]http.Handler
+		}); ok {
+			for path, handler := range adminModule.AdminHandlers() {
+				handlers[path] = handler
+			}
+		}
+	}
+	return handlers
+}
+
 // Collection of all the correctly configured analytics modules - implements the PBSAnalyticsModule interface
 type enabledAnalytics map[string]analytics.Module
 
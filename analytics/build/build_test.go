@@ -82,12 +82,34 @@ func (m *sampleModule) LogNotificationEventObject(ne *analytics.NotificationEven
 
 func (m *sampleModule) Shutdown() { *m.count++ }
 
+type adminSampleModule struct {
+	sampleModule
+}
+
+func (m *adminSampleModule) AdminHandlers() map[string]http.Handler {
+	return map[string]http.Handler{"/sample": http.NotFoundHandler()}
+}
+
 func initAnalytics(count *int) analytics.Runner {
 	modules := make(enabledAnalytics, 0)
 	modules["sampleModule"] = &sampleModule{count}
 	return &modules
 }
 
+func TestAdminHandlers(t *testing.T) {
+	var count int
+	runner := enabledAnalytics{
+		"sampleModule":      &sampleModule{&count},
+		"adminSampleModule": &adminSampleModule{sampleModule{&count}},
+	}
+
+	handlers := AdminHandlers(runner)
+	assert.Len(t, handlers, 1)
+	assert.Contains(t, handlers, "/sample")
+
+	assert.Empty(t, AdminHandlers(nil))
+}
+
 func TestNewPBSAnalytics(t *testing.T) {
 	pbsAnalytics := New(&config.Analytics{}, &metricsConfig.NilMetricsEngine{})
 	instance := pbsAnalytics.(enabledAnalytics)
@@ -31,6 +31,7 @@ type AuctionObject struct {
 	StartTime            time.Time
 	HookExecutionOutcome []hookexecution.StageOutcome
 	SeatNonBid           []openrtb_ext.SeatNonBid
+	BidAdjustments       []openrtb_ext.AppliedBidAdjustment
 	RequestWrapper       *openrtb_ext.RequestWrapper
 }
 
@@ -44,6 +45,7 @@ type AmpObject struct {
 	StartTime            time.Time
 	HookExecutionOutcome []hookexecution.StageOutcome
 	SeatNonBid           []openrtb_ext.SeatNonBid
+	BidAdjustments       []openrtb_ext.AppliedBidAdjustment
 	RequestWrapper       *openrtb_ext.RequestWrapper
 }
 
@@ -56,6 +58,7 @@ type VideoObject struct {
 	VideoResponse  *openrtb_ext.BidResponseVideo
 	StartTime      time.Time
 	SeatNonBid     []openrtb_ext.SeatNonBid
+	BidAdjustments []openrtb_ext.AppliedBidAdjustment
 	RequestWrapper *openrtb_ext.RequestWrapper
 }
 
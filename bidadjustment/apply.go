@@ -2,7 +2,12 @@ package bidadjustment
 
 import (
 	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/adapters"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
@@ -15,27 +20,116 @@ const (
 	Delimiter                = "|"
 )
 
-const maxNumOfCombos = 8
+// Rule keys are made of the following dimensions, in priority order. Keys drop trailing wildcard dimensions
+// beyond the first three, so media type, bidder and deal ID only rules keep their "mediaType|bidder|dealID" form
+const (
+	dimMediaType = iota
+	dimBidder
+	dimDealID
+	dimCountry
+	dimDeviceType
+	dimDomain
+	dimBundle
+	numOfDims
+)
+
+const minNumOfKeyDims = dimDealID + 1
+const maxNumOfCombos = 1 << numOfDims
 const pricePrecision float64 = 10000 // Rounds to 4 Decimal Places
 const minBid = 0.1
 
+// comboMasks lists every combination of dimensions, as bit masks with the media type as the most significant bit,
+// ordered by priority from highest to lowest: combinations matching more dimensions come first, and among those
+// with the same number of dimensions, the ones matching the higher priority dimensions come first
+var comboMasks = buildComboMasks()
+
+func buildComboMasks() [maxNumOfCombos]int {
+	var masks [maxNumOfCombos]int
+	for i := range masks {
+		masks[i] = maxNumOfCombos - 1 - i
+	}
+	sort.SliceStable(masks[:], func(i, j int) bool {
+		return bits.OnesCount(uint(masks[i])) > bits.OnesCount(uint(masks[j]))
+	})
+	return masks
+}
+
+// RequestDimensions holds the request values that bid adjustment rules can be keyed on, in addition to the media type, bidder and deal ID
+type RequestDimensions struct {
+	Country    string
+	DeviceType string
+	Domain     string
+	Bundle     string
+}
+
+// NewRequestDimensions extracts the device country, device type, site domain and app bundle from the request
+// Only the dimensions that some rule is keyed on are filled, so the others don't add combinations to look up for every bid
+func NewRequestDimensions(req *openrtb2.BidRequest, rules map[string][]openrtb_ext.Adjustment) RequestDimensions {
+	var dims RequestDimensions
+	if req == nil {
+		return dims
+	}
+	keyed := keyedDims(rules)
+	if req.Device != nil {
+		if req.Device.Geo != nil && keyed[dimCountry] {
+			dims.Country = strings.ToUpper(req.Device.Geo.Country)
+		}
+		if req.Device.DeviceType != 0 && keyed[dimDeviceType] {
+			dims.DeviceType = strconv.Itoa(int(req.Device.DeviceType))
+		}
+	}
+	if req.Site != nil && keyed[dimDomain] {
+		dims.Domain = req.Site.Domain
+	}
+	if req.App != nil && keyed[dimBundle] {
+		dims.Bundle = req.App.Bundle
+	}
+	return dims
+}
+
+// keyedDims reports, for each dimension, whether any of the rules is keyed on a value other than the wildcard
+func keyedDims(rules map[string][]openrtb_ext.Adjustment) [numOfDims]bool {
+	var keyed [numOfDims]bool
+	for rule := range rules {
+		for i, value := range strings.SplitN(rule, Delimiter, numOfDims) {
+			if value != WildCard {
+				keyed[i] = true
+			}
+		}
+	}
+	return keyed
+}
+
 // Apply gets the highest priority adjustment slice given a map of rules, and applies those adjustments to a bid's price
-func Apply(rules map[string][]openrtb_ext.Adjustment, bidInfo *adapters.TypedBid, bidderName openrtb_ext.BidderName, currency string, reqInfo *adapters.ExtraRequestInfo, bidType string) (float64, string) {
-	var adjustments []openrtb_ext.Adjustment
-	if len(rules) > 0 {
-		adjustments = get(rules, bidType, string(bidderName), bidInfo.Bid.DealID)
-	} else {
-		return bidInfo.Bid.Price, currency
+// When a rule matched, it also returns the details of the applied adjustments for auditing
+func Apply(rules map[string][]openrtb_ext.Adjustment, bidInfo *adapters.TypedBid, bidderName openrtb_ext.BidderName, currency string, reqInfo *adapters.ExtraRequestInfo, bidType string, dims RequestDimensions) (float64, string, *openrtb_ext.AppliedBidAdjustment) {
+	if len(rules) == 0 {
+		return bidInfo.Bid.Price, currency, nil
 	}
+	rule, adjustments := get(rules, bidType, string(bidderName), bidInfo.Bid.DealID, dims)
 	adjustedPrice, adjustedCurrency := apply(adjustments, bidInfo.Bid.Price, currency, reqInfo)
 
 	if bidInfo.Bid.DealID != "" && adjustedPrice < 0 {
-		return 0, currency
+		adjustedPrice, adjustedCurrency = 0, currency
+	} else if bidInfo.Bid.DealID == "" && adjustedPrice <= 0 {
+		adjustedPrice, adjustedCurrency = minBid, currency
 	}
-	if bidInfo.Bid.DealID == "" && adjustedPrice <= 0 {
-		return minBid, currency
+
+	var applied *openrtb_ext.AppliedBidAdjustment
+	if len(adjustments) > 0 {
+		applied = &openrtb_ext.AppliedBidAdjustment{
+			Bidder:           bidderName,
+			ImpID:            bidInfo.Bid.ImpID,
+			BidID:            bidInfo.Bid.ID,
+			Rule:             rule,
+			Adjustments:      adjustments,
+			OriginalPrice:    bidInfo.Bid.Price,
+			OriginalCurrency: currency,
+			AdjustedPrice:    adjustedPrice,
+			AdjustedCurrency: adjustedCurrency,
+		}
 	}
-	return adjustedPrice, adjustedCurrency
+	return adjustedPrice, adjustedCurrency, applied
 }
 
 func apply(adjustments []openrtb_ext.Adjustment, bidPrice float64, currency string, reqInfo *adapters.ExtraRequestInfo) (float64, string) {
@@ -64,31 +158,52 @@ func apply(adjustments []openrtb_ext.Adjustment, bidPrice float64, currency stri
 	return roundedBidPrice, currency
 }
 
-// get() should return the highest priority slice of adjustments from the map that we can match with the given bid info
+// get() should return the highest priority slice of adjustments from the map that we can match with the given bid info, along with the matched rule
 // given the bid info, we create the same format of combinations that's present in the key of the ruleToAdjustments map
-// the slice is ordered by priority from highest to lowest, as soon as we find a match, we return that slice
-func get(rules map[string][]openrtb_ext.Adjustment, bidType, bidderName, dealID string) []openrtb_ext.Adjustment {
-	priorityRules := [maxNumOfCombos]string{}
-	if dealID != "" {
-		priorityRules[0] = bidType + Delimiter + bidderName + Delimiter + dealID
-		priorityRules[1] = bidType + Delimiter + bidderName + Delimiter + WildCard
-		priorityRules[2] = bidType + Delimiter + WildCard + Delimiter + dealID
-		priorityRules[3] = WildCard + Delimiter + bidderName + Delimiter + dealID
-		priorityRules[4] = bidType + Delimiter + WildCard + Delimiter + WildCard
-		priorityRules[5] = WildCard + Delimiter + bidderName + Delimiter + WildCard
-		priorityRules[6] = WildCard + Delimiter + WildCard + Delimiter + dealID
-		priorityRules[7] = WildCard + Delimiter + WildCard + Delimiter + WildCard
-	} else {
-		priorityRules[0] = bidType + Delimiter + bidderName + Delimiter + WildCard
-		priorityRules[1] = bidType + Delimiter + WildCard + Delimiter + WildCard
-		priorityRules[2] = WildCard + Delimiter + bidderName + Delimiter + WildCard
-		priorityRules[3] = WildCard + Delimiter + WildCard + Delimiter + WildCard
+// the combinations are checked by priority from highest to lowest, as soon as we find a match, we return that slice
+// dimensions without a value on the bid or the request can only be matched by a wildcard, so their combinations are skipped
+func get(rules map[string][]openrtb_ext.Adjustment, bidType, bidderName, dealID string, dims RequestDimensions) (string, []openrtb_ext.Adjustment) {
+	values := [numOfDims]string{
+		dimMediaType:  bidType,
+		dimBidder:     bidderName,
+		dimDealID:     dealID,
+		dimCountry:    dims.Country,
+		dimDeviceType: dims.DeviceType,
+		dimDomain:     dims.Domain,
+		dimBundle:     dims.Bundle,
+	}
+	var emptyDims int
+	for i, value := range values {
+		if value == "" {
+			emptyDims |= 1 << (numOfDims - 1 - i)
+		}
 	}
 
-	for _, rule := range priorityRules {
-		if _, ok := rules[rule]; ok {
-			return rules[rule]
+	var combo [numOfDims]string
+	for _, mask := range comboMasks {
+		if mask&emptyDims != 0 {
+			continue
+		}
+		for i := range combo {
+			if mask&(1<<(numOfDims-1-i)) != 0 {
+				combo[i] = values[i]
+			} else {
+				combo[i] = WildCard
+			}
 		}
+		rule := buildKey(combo[:])
+		if adjustments, ok := rules[rule]; ok {
+			return rule, adjustments
+		}
+	}
+	return "", nil
+}
+
+// buildKey joins the given dimension values into a rule key, dropping trailing wildcards beyond the first three dimensions
+func buildKey(values []string) string {
+	end := len(values)
+	for end > minNumOfKeyDims && values[end-1] == WildCard {
+		end--
 	}
-	return nil
+	return strings.Join(values[:end], Delimiter)
 }
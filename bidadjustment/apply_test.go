@@ -212,7 +212,7 @@ func TestGetAndApply(t *testing.T) {
 				test.setMock(&mockConversions.Mock)
 				reqInfo = adapters.NewExtraRequestInfo(mockConversions)
 			}
-			bidPrice, currencyAfterAdjustment := Apply(test.givenRuleToAdjustments, test.givenBidInfo, test.givenBidderName, bidCur, &reqInfo, test.givenBidType)
+			bidPrice, currencyAfterAdjustment, _ := Apply(test.givenRuleToAdjustments, test.givenBidInfo, test.givenBidderName, bidCur, &reqInfo, test.givenBidType, RequestDimensions{})
 			assert.Equal(t, test.expectedBidPrice, bidPrice)
 			assert.Equal(t, test.expectedCurrency, currencyAfterAdjustment)
 		})
@@ -525,8 +525,185 @@ func TestGet(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			adjArray := get(test.givenRuleToAdjustments, string(test.givenBidType), string(test.givenBidderName), test.givenDealId)
+			_, adjArray := get(test.givenRuleToAdjustments, string(test.givenBidType), string(test.givenBidderName), test.givenDealId, RequestDimensions{})
 			assert.Equal(t, test.expected, adjArray)
 		})
 	}
 }
+
+func TestGetWithRequestDimensions(t *testing.T) {
+	rules := map[string][]openrtb_ext.Adjustment{
+		"banner|bidderA|*":                 {{Type: AdjustmentTypeMultiplier, Value: 1.1}},
+		"*|*|*|USA":                        {{Type: AdjustmentTypeMultiplier, Value: 1.2}},
+		"*|bidderA|*|USA":                  {{Type: AdjustmentTypeMultiplier, Value: 1.3}},
+		"*|*|*|*|4":                        {{Type: AdjustmentTypeMultiplier, Value: 1.4}},
+		"*|*|*|*|*|example.com":            {{Type: AdjustmentTypeMultiplier, Value: 1.5}},
+		"*|*|dealId|*|*|*|com.example.app": {{Type: AdjustmentTypeMultiplier, Value: 1.6}},
+		"banner|*|*|*|*|*|com.example.app": {{Type: AdjustmentTypeMultiplier, Value: 1.7}},
+		"*|*|*|*|4|example.com":            {{Type: AdjustmentTypeMultiplier, Value: 1.8}},
+	}
+
+	testCases := []struct {
+		name          string
+		givenBidder   string
+		givenDealID   string
+		givenDims     RequestDimensions
+		expectedRule  string
+		expectedValue float64
+	}{
+		{
+			name:          "NoRequestDimensions",
+			givenBidder:   "bidderA",
+			expectedRule:  "banner|bidderA|*",
+			expectedValue: 1.1,
+		},
+		{
+			name:          "MoreDimensionsTakePrecedence",
+			givenBidder:   "bidderB",
+			givenDims:     RequestDimensions{Country: "USA", DeviceType: "4", Domain: "example.com"},
+			expectedRule:  "*|*|*|*|4|example.com",
+			expectedValue: 1.8,
+		},
+		{
+			name:          "HigherPriorityDimensionTakesPrecedence",
+			givenBidder:   "bidderA",
+			givenDims:     RequestDimensions{Country: "USA", DeviceType: "4", Domain: "example.com"},
+			expectedRule:  "banner|bidderA|*",
+			expectedValue: 1.1,
+		},
+		{
+			name:          "CountryOverDeviceType",
+			givenBidder:   "bidderB",
+			givenDims:     RequestDimensions{Country: "USA", DeviceType: "4"},
+			expectedRule:  "*|*|*|USA",
+			expectedValue: 1.2,
+		},
+		{
+			name:          "Domain",
+			givenBidder:   "bidderB",
+			givenDims:     RequestDimensions{Country: "CAN", Domain: "example.com"},
+			expectedRule:  "*|*|*|*|*|example.com",
+			expectedValue: 1.5,
+		},
+		{
+			name:          "MediaTypeOverDealID",
+			givenBidder:   "bidderB",
+			givenDealID:   "dealId",
+			givenDims:     RequestDimensions{Bundle: "com.example.app"},
+			expectedRule:  "banner|*|*|*|*|*|com.example.app",
+			expectedValue: 1.7,
+		},
+		{
+			name:         "NoMatch",
+			givenBidder:  "bidderB",
+			givenDims:    RequestDimensions{Country: "CAN", DeviceType: "2"},
+			expectedRule: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			rule, adjustments := get(rules, string(openrtb_ext.BidTypeBanner), test.givenBidder, test.givenDealID, test.givenDims)
+			assert.Equal(t, test.expectedRule, rule)
+			if test.expectedRule == "" {
+				assert.Nil(t, adjustments)
+			} else {
+				assert.Equal(t, []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: test.expectedValue}}, adjustments)
+			}
+		})
+	}
+}
+
+func TestApplyReportsAdjustment(t *testing.T) {
+	rules := map[string][]openrtb_ext.Adjustment{
+		"banner|bidderA|*|*|2": {{Type: AdjustmentTypeMultiplier, Value: 0.5}},
+	}
+	bidInfo := &adapters.TypedBid{Bid: &openrtb2.Bid{ID: "bid1", ImpID: "imp1", Price: 2.0}}
+	reqInfo := adapters.ExtraRequestInfo{}
+
+	price, currency, applied := Apply(rules, bidInfo, "bidderA", "USD", &reqInfo, string(openrtb_ext.BidTypeBanner), RequestDimensions{DeviceType: "2"})
+	assert.Equal(t, 1.0, price)
+	assert.Equal(t, "USD", currency)
+	assert.Equal(t, &openrtb_ext.AppliedBidAdjustment{
+		Bidder:           "bidderA",
+		ImpID:            "imp1",
+		BidID:            "bid1",
+		Rule:             "banner|bidderA|*|*|2",
+		Adjustments:      []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 0.5}},
+		OriginalPrice:    2.0,
+		OriginalCurrency: "USD",
+		AdjustedPrice:    1.0,
+		AdjustedCurrency: "USD",
+	}, applied)
+
+	price, _, applied = Apply(rules, bidInfo, "bidderA", "USD", &reqInfo, string(openrtb_ext.BidTypeBanner), RequestDimensions{DeviceType: "4"})
+	assert.Equal(t, 2.0, price)
+	assert.Nil(t, applied)
+}
+
+func TestNewRequestDimensions(t *testing.T) {
+	allDimsRules := map[string][]openrtb_ext.Adjustment{
+		"banner|*|*|*|2":                        {{Type: AdjustmentTypeMultiplier, Value: 1.1}},
+		"*|*|*|USA|*|*|com.example.app":         {{Type: AdjustmentTypeMultiplier, Value: 1.2}},
+		"*|*|*|*|*|example.com":                 {{Type: AdjustmentTypeMultiplier, Value: 1.3}},
+		"video-instream|bidderA|dealId|*|*|*|*": {{Type: AdjustmentTypeMultiplier, Value: 1.4}},
+	}
+	testCases := []struct {
+		name       string
+		givenReq   *openrtb2.BidRequest
+		givenRules map[string][]openrtb_ext.Adjustment
+		expected   RequestDimensions
+	}{
+		{
+			name:       "NilRequest",
+			givenReq:   nil,
+			givenRules: allDimsRules,
+			expected:   RequestDimensions{},
+		},
+		{
+			name: "Site",
+			givenReq: &openrtb2.BidRequest{
+				Site:   &openrtb2.Site{Domain: "example.com"},
+				Device: &openrtb2.Device{DeviceType: 2, Geo: &openrtb2.Geo{Country: "usa"}},
+			},
+			givenRules: allDimsRules,
+			expected:   RequestDimensions{Country: "USA", DeviceType: "2", Domain: "example.com"},
+		},
+		{
+			name: "App",
+			givenReq: &openrtb2.BidRequest{
+				App:    &openrtb2.App{Bundle: "com.example.app"},
+				Device: &openrtb2.Device{},
+			},
+			givenRules: allDimsRules,
+			expected:   RequestDimensions{Bundle: "com.example.app"},
+		},
+		{
+			name: "OnlyKeyedDimensions",
+			givenReq: &openrtb2.BidRequest{
+				Site:   &openrtb2.Site{Domain: "example.com"},
+				Device: &openrtb2.Device{DeviceType: 2, Geo: &openrtb2.Geo{Country: "usa"}},
+			},
+			givenRules: map[string][]openrtb_ext.Adjustment{
+				"banner|bidderA|*": {{Type: AdjustmentTypeMultiplier, Value: 1.1}},
+				"*|*|*|USA":        {{Type: AdjustmentTypeMultiplier, Value: 1.2}},
+				"*|*|*|*|*|*|*":    {{Type: AdjustmentTypeMultiplier, Value: 1.3}},
+			},
+			expected: RequestDimensions{Country: "USA"},
+		},
+		{
+			name: "NoRules",
+			givenReq: &openrtb2.BidRequest{
+				Site:   &openrtb2.Site{Domain: "example.com"},
+				Device: &openrtb2.Device{DeviceType: 2, Geo: &openrtb2.Geo{Country: "usa"}},
+			},
+			expected: RequestDimensions{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewRequestDimensions(test.givenReq, test.givenRules))
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package bidadjustment
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 )
@@ -11,8 +15,13 @@ const (
 )
 
 // BuildRules() will populate the rules map with a rule that's a combination of the mediaType, bidderName, and dealId for a particular adjustment
+// Adjustment rules keyed on additional dimensions are added when they are active at the current time, without overriding earlier rules with the same key
 // The result will be a map that'll map a given rule with its adjustment
 func BuildRules(bidAdjustments *openrtb_ext.ExtRequestPrebidBidAdjustments) map[string][]openrtb_ext.Adjustment {
+	return buildRules(bidAdjustments, time.Now())
+}
+
+func buildRules(bidAdjustments *openrtb_ext.ExtRequestPrebidBidAdjustments, now time.Time) map[string][]openrtb_ext.Adjustment {
 	if bidAdjustments == nil {
 		return nil
 	}
@@ -25,9 +34,54 @@ func BuildRules(bidAdjustments *openrtb_ext.ExtRequestPrebidBidAdjustments) map[
 	buildRulesForMediaType(VideoOutstream, bidAdjustments.MediaType.VideoOutstream, rules)
 	buildRulesForMediaType(WildCard, bidAdjustments.MediaType.WildCard, rules)
 
+	for _, adjustmentRule := range bidAdjustments.Rules {
+		if !isActive(adjustmentRule.Hours, now) {
+			continue
+		}
+		rule := buildRuleKey(adjustmentRule)
+		if _, ok := rules[rule]; !ok {
+			rules[rule] = adjustmentRule.Adjustments
+		}
+	}
+
 	return rules
 }
 
+func buildRuleKey(rule openrtb_ext.AdjustmentRule) string {
+	values := [numOfDims]string{
+		dimMediaType:  orWildCard(rule.MediaType),
+		dimBidder:     orWildCard(string(rule.Bidder)),
+		dimDealID:     orWildCard(rule.DealID),
+		dimCountry:    orWildCard(strings.ToUpper(rule.Country)),
+		dimDeviceType: WildCard,
+		dimDomain:     orWildCard(rule.Domain),
+		dimBundle:     orWildCard(rule.Bundle),
+	}
+	if rule.DeviceType != 0 {
+		values[dimDeviceType] = strconv.Itoa(rule.DeviceType)
+	}
+	return buildKey(values[:])
+}
+
+func orWildCard(value string) string {
+	if value == "" {
+		return WildCard
+	}
+	return value
+}
+
+// isActive checks whether the given UTC hour is within the time window, a rule without a time window is always active
+func isActive(hours *openrtb_ext.AdjustmentHours, now time.Time) bool {
+	if hours == nil {
+		return true
+	}
+	hour := now.UTC().Hour()
+	if hours.Start <= hours.End {
+		return hour >= hours.Start && hour < hours.End
+	}
+	return hour >= hours.Start || hour < hours.End
+}
+
 func buildRulesForMediaType(mediaType string, rulesByBidder map[openrtb_ext.BidderName]openrtb_ext.AdjustmentsByDealID, rules map[string][]openrtb_ext.Adjustment) {
 	for bidderName := range rulesByBidder {
 		for dealID, adjustments := range rulesByBidder[bidderName] {
@@ -74,6 +128,8 @@ func merge(req *openrtb_ext.RequestWrapper, acct *openrtb_ext.ExtRequestPrebidBi
 	extPrebid.BidAdjustments.MediaType.VideoInstream = mergeForMediaType(extPrebid.BidAdjustments.MediaType.VideoInstream, acct.MediaType.VideoInstream)
 	extPrebid.BidAdjustments.MediaType.VideoOutstream = mergeForMediaType(extPrebid.BidAdjustments.MediaType.VideoOutstream, acct.MediaType.VideoOutstream)
 	extPrebid.BidAdjustments.MediaType.WildCard = mergeForMediaType(extPrebid.BidAdjustments.MediaType.WildCard, acct.MediaType.WildCard)
+	// rules on the request are listed first so they take precedence over account rules with the same dimensions
+	extPrebid.BidAdjustments.Rules = append(extPrebid.BidAdjustments.Rules, acct.Rules...)
 
 	return extPrebid.BidAdjustments, nil
 }
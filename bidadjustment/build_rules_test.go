@@ -1,7 +1,10 @@
 package bidadjustment
 
 import (
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/config"
@@ -101,6 +104,29 @@ func TestBuildRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "AdjustmentRules",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				MediaType: openrtb_ext.MediaType{
+					Banner: map[openrtb_ext.BidderName]openrtb_ext.AdjustmentsByDealID{
+						"bidderA": {
+							"*": []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}},
+						},
+					},
+				},
+				Rules: []openrtb_ext.AdjustmentRule{
+					{MediaType: "banner", Bidder: "bidderA", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 2.0}}},
+					{Country: "usa", DeviceType: 4, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 0.9}}},
+					{Bidder: "bidderB", Bundle: "com.example.app", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeCPM, Value: 0.1, Currency: "USD"}}},
+					{Bidder: "bidderB", Bundle: "com.example.app", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 3.0}}},
+				},
+			},
+			expectedRules: map[string][]openrtb_ext.Adjustment{
+				"banner|bidderA|*":                  {{Type: AdjustmentTypeMultiplier, Value: 1.1}},
+				"*|*|*|USA|4":                       {{Type: AdjustmentTypeMultiplier, Value: 0.9}},
+				"*|bidderB|*|*|*|*|com.example.app": {{Type: AdjustmentTypeCPM, Value: 0.1, Currency: "USD"}},
+			},
+		},
 		{
 			name:                "NilAdjustments",
 			givenBidAdjustments: nil,
@@ -116,6 +142,50 @@ func TestBuildRules(t *testing.T) {
 	}
 }
 
+func TestBuildRulesTimeWindow(t *testing.T) {
+	bidAdjustments := &openrtb_ext.ExtRequestPrebidBidAdjustments{
+		Rules: []openrtb_ext.AdjustmentRule{
+			{Domain: "day.com", Hours: &openrtb_ext.AdjustmentHours{Start: 8, End: 20}, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+			{Domain: "night.com", Hours: &openrtb_ext.AdjustmentHours{Start: 20, End: 8}, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 0.9}}},
+			{Domain: "always.com", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.5}}},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		givenTime     time.Time
+		expectedRules []string
+	}{
+		{
+			name:          "WindowStart",
+			givenTime:     time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			expectedRules: []string{"*|*|*|*|*|day.com", "*|*|*|*|*|always.com"},
+		},
+		{
+			name:          "WindowEnd",
+			givenTime:     time.Date(2024, 1, 1, 19, 59, 0, 0, time.UTC),
+			expectedRules: []string{"*|*|*|*|*|day.com", "*|*|*|*|*|always.com"},
+		},
+		{
+			name:          "WrapsAroundMidnight",
+			givenTime:     time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			expectedRules: []string{"*|*|*|*|*|night.com", "*|*|*|*|*|always.com"},
+		},
+		{
+			name:          "ConvertedToUTC",
+			givenTime:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("UTC-10", -10*60*60)),
+			expectedRules: []string{"*|*|*|*|*|night.com", "*|*|*|*|*|always.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			rules := buildRules(bidAdjustments, test.givenTime)
+			assert.ElementsMatch(t, test.expectedRules, slices.Collect(maps.Keys(rules)))
+		})
+	}
+}
+
 func TestMergeAndValidate(t *testing.T) {
 	testCases := []struct {
 		name                   string
@@ -514,6 +584,25 @@ func TestMerge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "AdjustmentRulesRequestFirst",
+			givenRequestWrapper: &openrtb_ext.RequestWrapper{
+				BidRequest: &openrtb2.BidRequest{Ext: []byte(`{"prebid":{"bidadjustments":{"rules":[{"country":"USA","adjustments":[{"adjtype":"multiplier","value":1.1}]}]}}}`)},
+			},
+			acctBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Country: "USA", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.5}}},
+					{DeviceType: 4, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 0.9}}},
+				},
+			},
+			expectedBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Country: "USA", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+					{Country: "USA", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.5}}},
+					{DeviceType: 4, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 0.9}}},
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
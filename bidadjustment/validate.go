@@ -29,6 +29,36 @@ func Validate(bidAdjustments *openrtb_ext.ExtRequestPrebidBidAdjustments) bool {
 	if !validateForMediaType(bidAdjustments.MediaType.WildCard) {
 		return false
 	}
+	for _, rule := range bidAdjustments.Rules {
+		if !validateRule(rule) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateRule(rule openrtb_ext.AdjustmentRule) bool {
+	switch rule.MediaType {
+	case "", WildCard, string(openrtb_ext.BidTypeBanner), string(openrtb_ext.BidTypeAudio), string(openrtb_ext.BidTypeNative), VideoInstream, VideoOutstream:
+	default:
+		return false
+	}
+	if rule.DeviceType < 0 {
+		return false
+	}
+	if rule.Hours != nil {
+		if rule.Hours.Start < 0 || rule.Hours.Start > 23 || rule.Hours.End < 0 || rule.Hours.End > 24 || rule.Hours.Start == rule.Hours.End {
+			return false
+		}
+	}
+	if len(rule.Adjustments) == 0 {
+		return false
+	}
+	for _, adjustment := range rule.Adjustments {
+		if !validateAdjustment(adjustment) {
+			return false
+		}
+	}
 	return true
 }
 
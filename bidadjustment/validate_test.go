@@ -137,6 +137,61 @@ func TestValidate(t *testing.T) {
 			givenBidAdjustments: nil,
 			expected:            true,
 		},
+		{
+			name: "AdjustmentRulesValid",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{MediaType: VideoInstream, Country: "USA", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+					{Bidder: "bidderA", DeviceType: 4, Hours: &openrtb_ext.AdjustmentHours{Start: 22, End: 6}, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeCPM, Value: 0.5, Currency: "USD"}}},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "AdjustmentRuleInvalidMediaType",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{MediaType: "video", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "AdjustmentRuleWithoutAdjustments",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Domain: "example.com"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "AdjustmentRuleInvalidAdjustment",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Bundle: "com.example.app", Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeStatic, Value: 1.1}}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "AdjustmentRuleHoursOutOfRange",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Hours: &openrtb_ext.AdjustmentHours{Start: 8, End: 25}, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "AdjustmentRuleHoursEmptyWindow",
+			givenBidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+				Rules: []openrtb_ext.AdjustmentRule{
+					{Hours: &openrtb_ext.AdjustmentHours{Start: 8, End: 8}, Adjustments: []openrtb_ext.Adjustment{{Type: AdjustmentTypeMultiplier, Value: 1.1}}},
+				},
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range testCases {
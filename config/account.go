@@ -71,14 +71,15 @@ type AccountCCPA struct {
 }
 
 type AccountPriceFloors struct {
-	Enabled                bool              `mapstructure:"enabled" json:"enabled"`
-	EnforceFloorsRate      int               `mapstructure:"enforce_floors_rate" json:"enforce_floors_rate"`
-	AdjustForBidAdjustment bool              `mapstructure:"adjust_for_bid_adjustment" json:"adjust_for_bid_adjustment"`
-	EnforceDealFloors      bool              `mapstructure:"enforce_deal_floors" json:"enforce_deal_floors"`
-	UseDynamicData         bool              `mapstructure:"use_dynamic_data" json:"use_dynamic_data"`
-	MaxRule                int               `mapstructure:"max_rules" json:"max_rules"`
-	MaxSchemaDims          int               `mapstructure:"max_schema_dims" json:"max_schema_dims"`
-	Fetcher                AccountFloorFetch `mapstructure:"fetch" json:"fetch"`
+	Enabled                bool                 `mapstructure:"enabled" json:"enabled"`
+	EnforceFloorsRate      int                  `mapstructure:"enforce_floors_rate" json:"enforce_floors_rate"`
+	AdjustForBidAdjustment bool                 `mapstructure:"adjust_for_bid_adjustment" json:"adjust_for_bid_adjustment"`
+	EnforceDealFloors      bool                 `mapstructure:"enforce_deal_floors" json:"enforce_deal_floors"`
+	UseDynamicData         bool                 `mapstructure:"use_dynamic_data" json:"use_dynamic_data"`
+	MaxRule                int                  `mapstructure:"max_rules" json:"max_rules"`
+	MaxSchemaDims          int                  `mapstructure:"max_schema_dims" json:"max_schema_dims"`
+	Fetcher                AccountFloorFetch    `mapstructure:"fetch" json:"fetch"`
+	Learning               AccountFloorLearning `mapstructure:"learning" json:"learning"`
 }
 
 // AccountFloorFetch defines the configuration for dynamic floors fetching.
@@ -94,6 +95,28 @@ type AccountFloorFetch struct {
 	AccountID     string `mapstructure:"accountID" json:"accountID"`
 }
 
+// AccountFloorLearning defines the configuration for floors learned from the clearing prices of the account auctions.
+// The learned floors are used as dynamic data, unless in shadow mode where they are only recorded.
+type AccountFloorLearning struct {
+	Enabled          bool     `mapstructure:"enabled" json:"enabled"`
+	ShadowMode       bool     `mapstructure:"shadow_mode" json:"shadow_mode"`
+	Schema           []string `mapstructure:"schema" json:"schema"`
+	Percentile       int      `mapstructure:"percentile" json:"percentile"`
+	MinSamples       int      `mapstructure:"min_samples" json:"min_samples"`
+	MinFloor         float64  `mapstructure:"min_floor" json:"min_floor"`
+	MaxFloor         float64  `mapstructure:"max_floor" json:"max_floor"`
+	MaxChangePercent int      `mapstructure:"max_change_percent" json:"max_change_percent"`
+}
+
+// learningSchemaFields are the floors schema dimensions the clearing prices are learned by
+var learningSchemaFields = map[string]struct{}{
+	"mediaType":  {},
+	"size":       {},
+	"domain":     {},
+	"country":    {},
+	"deviceType": {},
+}
+
 func (pf *AccountPriceFloors) validate(errs []error) []error {
 	if pf.EnforceFloorsRate < 0 || pf.EnforceFloorsRate > 100 {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.enforce_floors_rate should be between 0 and 100`))
@@ -135,6 +158,46 @@ func (pf *AccountPriceFloors) validate(errs []error) []error {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.fetch.max_schema_dims should not be less than 0 and greater than 20`))
 	}
 
+	if pf.Learning.Enabled {
+		errs = pf.Learning.validate(pf.MaxSchemaDims, errs)
+	}
+
+	return errs
+}
+
+func (fl *AccountFloorLearning) validate(maxSchemaDims int, errs []error) []error {
+	if len(fl.Schema) == 0 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.schema should have at least one field`))
+	}
+
+	for _, field := range fl.Schema {
+		if _, ok := learningSchemaFields[field]; !ok {
+			errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.schema field %s is not supported, supported fields are mediaType, size, domain, country and deviceType`, field))
+		}
+	}
+
+	if maxSchemaDims > 0 && len(fl.Schema) > maxSchemaDims {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.schema should not have more fields than account_defaults.price_floors.max_schema_dims`))
+	}
+
+	if fl.Percentile < 1 || fl.Percentile > 100 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.percentile should be between 1 and 100`))
+	}
+
+	if fl.MinSamples < 1 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.min_samples should be greater than 0`))
+	}
+
+	if fl.MinFloor < 0 || fl.MaxFloor < 0 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.min_floor and max_floor should be greater than or equal to 0`))
+	} else if fl.MaxFloor > 0 && fl.MinFloor > fl.MaxFloor {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.min_floor should be less than or equal to max_floor`))
+	}
+
+	if fl.MaxChangePercent < 0 || fl.MaxChangePercent > 100 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.learning.max_change_percent should be between 0 and 100`))
+	}
+
 	return errs
 }
 
@@ -956,6 +956,93 @@ func TestAccountPriceFloorsValidate(t *testing.T) {
 			},
 			want: []error{errors.New("account_defaults.price_floors.fetch.max_schema_dims should not be less than 0 and greater than 20")},
 		},
+		{
+			description: "valid learning configuration",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     2,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Learning: AccountFloorLearning{
+					Enabled:          true,
+					Schema:           []string{"mediaType", "size"},
+					Percentile:       50,
+					MinSamples:       100,
+					MinFloor:         0.01,
+					MaxFloor:         10,
+					MaxChangePercent: 20,
+				},
+			},
+		},
+		{
+			description: "Invalid disabled learning configuration is ignored",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     10,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Learning: AccountFloorLearning{Percentile: 200},
+			},
+		},
+		{
+			description: "Invalid learning schema",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     1,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Learning: AccountFloorLearning{
+					Enabled:    true,
+					Schema:     []string{"mediaType", "gptSlot"},
+					Percentile: 50,
+					MinSamples: 100,
+				},
+			},
+			want: []error{
+				errors.New("account_defaults.price_floors.learning.schema field gptSlot is not supported, supported fields are mediaType, size, domain, country and deviceType"),
+				errors.New("account_defaults.price_floors.learning.schema should not have more fields than account_defaults.price_floors.max_schema_dims"),
+			},
+		},
+		{
+			description: "Invalid learning guardrails",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     10,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Learning: AccountFloorLearning{
+					Enabled:          true,
+					Schema:           []string{"mediaType"},
+					Percentile:       0,
+					MinSamples:       0,
+					MinFloor:         5,
+					MaxFloor:         1,
+					MaxChangePercent: 120,
+				},
+			},
+			want: []error{
+				errors.New("account_defaults.price_floors.learning.percentile should be between 1 and 100"),
+				errors.New("account_defaults.price_floors.learning.min_samples should be greater than 0"),
+				errors.New("account_defaults.price_floors.learning.min_floor should be less than or equal to max_floor"),
+				errors.New("account_defaults.price_floors.learning.max_change_percent should be between 0 and 100"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
@@ -29,6 +29,7 @@ type Configuration struct {
 	CacheClient      HTTPClient  `mapstructure:"http_client_cache"`
 	Admin            Admin       `mapstructure:"admin"`
 	AdminPort        int         `mapstructure:"admin_port"`
+	Attestation      Attestation `mapstructure:"attestation"`
 	Compression      Compression `mapstructure:"compression"`
 	// GarbageCollectorThreshold allocates virtual memory (in bytes) which is not used by PBS but
 	// serves as a hack to trigger the garbage collector only when the heap reaches at least this size.
@@ -55,6 +56,8 @@ type Configuration struct {
 	// Note that StoredVideo refers to stored video requests, and has nothing to do with caching video creatives.
 	StoredVideo     StoredRequests `mapstructure:"stored_video_req"`
 	StoredResponses StoredRequests `mapstructure:"stored_responses"`
+	// StoredFloors holds the price floors data of the accounts, see floors.NewStoredFloorFetcher
+	StoredFloors StoredRequests `mapstructure:"stored_floors"`
 	// StoredRequestsTimeout defines the number of milliseconds before a timeout occurs with stored requests fetch
 	StoredRequestsTimeout int `mapstructure:"stored_requests_timeout_ms"`
 
@@ -111,10 +114,21 @@ type Configuration struct {
 
 type Admin struct {
 	Enabled bool `mapstructure:"enabled"`
+	// ConfigToken is the bearer token required by the admin /config endpoint, which serves the
+	// redacted effective configuration. The endpoint is disabled while the token is empty.
+	ConfigToken string `mapstructure:"config_token"`
+}
+
+type Attestation struct {
+	// InstanceKeyFile is a PEM file with the PKCS#8 Ed25519 private key that signs the /attestation
+	// nonce statements, provisioned for the instance so that its public key can be published ahead
+	// of time. A key is generated at startup when empty.
+	InstanceKeyFile string `mapstructure:"instance_key_file"`
 }
 type PriceFloors struct {
-	Enabled bool              `mapstructure:"enabled"`
-	Fetcher PriceFloorFetcher `mapstructure:"fetcher"`
+	Enabled  bool               `mapstructure:"enabled"`
+	Fetcher  PriceFloorFetcher  `mapstructure:"fetcher"`
+	Learning PriceFloorLearning `mapstructure:"learning"`
 }
 
 type PriceFloorFetcher struct {
@@ -125,6 +139,35 @@ type PriceFloorFetcher struct {
 	MaxRetries int        `mapstructure:"max_retries"`
 }
 
+// PriceFloorLearning configures the floors learned from auction clearing prices for the accounts
+// which enable account_defaults.price_floors.learning. The sizes bound the memory of the statistics.
+type PriceFloorLearning struct {
+	Enabled          bool `mapstructure:"enabled"`
+	RefreshPeriodSec int  `mapstructure:"refresh_period_sec"`
+	SampleSize       int  `mapstructure:"sample_size"`
+	MaxRules         int  `mapstructure:"max_rules"`
+	MaxAccounts      int  `mapstructure:"max_accounts"`
+}
+
+func (cfg *PriceFloorLearning) validate(errs []error) []error {
+	if !cfg.Enabled {
+		return errs
+	}
+	if cfg.RefreshPeriodSec <= 0 {
+		errs = append(errs, errors.New("price_floors.learning.refresh_period_sec must be positive"))
+	}
+	if cfg.SampleSize <= 0 {
+		errs = append(errs, errors.New("price_floors.learning.sample_size must be positive"))
+	}
+	if cfg.MaxRules <= 0 {
+		errs = append(errs, errors.New("price_floors.learning.max_rules must be positive"))
+	}
+	if cfg.MaxAccounts <= 0 {
+		errs = append(errs, errors.New("price_floors.learning.max_accounts must be positive"))
+	}
+	return errs
+}
+
 const MIN_COOKIE_SIZE_BYTES = 500
 
 type HTTPClient struct {
@@ -167,6 +210,7 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.Accounts.validate(errs)
 	errs = cfg.CategoryMapping.validate(errs)
 	errs = cfg.StoredVideo.validate(errs)
+	errs = cfg.StoredFloors.validate(errs)
 	errs = cfg.Metrics.validate(errs)
 	if cfg.MaxRequestSize < 0 {
 		errs = append(errs, fmt.Errorf("cfg.max_request_size must be >= 0. Got %d", cfg.MaxRequestSize))
@@ -176,6 +220,7 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.Debug.validate(errs)
 	errs = cfg.ExtCacheURL.validate(errs)
 	errs = cfg.AccountDefaults.PriceFloors.validate(errs)
+	errs = cfg.PriceFloors.Learning.validate(errs)
 	if cfg.AccountDefaults.Disabled {
 		logger.Warnf(`With account_defaults.disabled=true, host-defined accounts must exist and have "disabled":false. All other requests will be rejected.`)
 	}
@@ -493,6 +538,28 @@ type CurrencyConverter struct {
 	FetchTimeoutMilliseconds int    `mapstructure:"fetch_timeout_ms"`
 	FetchIntervalSeconds     int    `mapstructure:"fetch_interval_seconds"`
 	StaleRatesSeconds        int    `mapstructure:"stale_rates_seconds"`
+	// Sources lists the rates sources in priority order. When empty, the rates are fetched from FetchURL
+	Sources []CurrencyRatesSource `mapstructure:"sources"`
+	// MaxRateChangePercent rejects new rates if any conversion moved by more than this percentage since
+	// the last rates accepted from the same source, until the source confirms the move in consecutive fetches. 0 disables the check
+	MaxRateChangePercent float64 `mapstructure:"max_rate_change_percent"`
+}
+
+const (
+	CurrencyRatesSourceURL    = "url"
+	CurrencyRatesSourceFile   = "file"
+	CurrencyRatesSourceStatic = "static"
+)
+
+// CurrencyRatesSource configures one of the currency rates sources
+type CurrencyRatesSource struct {
+	// Type is one of "url", "file" or "static"
+	Type string `mapstructure:"type"`
+	URL  string `mapstructure:"url"`
+	Path string `mapstructure:"path"`
+	// Rates holds the conversions of a static source
+	Rates             map[string]map[string]float64 `mapstructure:"rates"`
+	StaleRatesSeconds int                           `mapstructure:"stale_rates_seconds"`
 }
 
 func (cfg *CurrencyConverter) validate(errs []error) []error {
@@ -502,6 +569,30 @@ func (cfg *CurrencyConverter) validate(errs []error) []error {
 	if cfg.FetchTimeoutMilliseconds < 0 {
 		errs = append(errs, fmt.Errorf("currency_converter.fetch_timeout_ms must be 0 or greater. Got %d", cfg.FetchTimeoutMilliseconds))
 	}
+	if cfg.MaxRateChangePercent < 0 {
+		errs = append(errs, fmt.Errorf("currency_converter.max_rate_change_percent must be 0 or greater. Got %f", cfg.MaxRateChangePercent))
+	}
+	for i, source := range cfg.Sources {
+		switch source.Type {
+		case CurrencyRatesSourceURL:
+			if source.URL == "" {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].url must be set for a url source", i))
+			}
+		case CurrencyRatesSourceFile:
+			if source.Path == "" {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].path must be set for a file source", i))
+			}
+		case CurrencyRatesSourceStatic:
+			if len(source.Rates) == 0 {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].rates must be set for a static source", i))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].type must be one of: %s, %s, %s. Got %s", i, CurrencyRatesSourceURL, CurrencyRatesSourceFile, CurrencyRatesSourceStatic, source.Type))
+		}
+		if source.StaleRatesSeconds < 0 {
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].stale_rates_seconds must be 0 or greater. Got %d", i, source.StaleRatesSeconds))
+		}
+	}
 	return errs
 }
 
@@ -573,7 +664,29 @@ type AuctionAuditAnalytics struct {
 	MaxFilterTTL    string                  `mapstructure:"max_filter_ttl"`
 	CleanupInterval string                  `mapstructure:"cleanup_interval"`
 	MaxEventsPerSec float64                 `mapstructure:"max_events_per_sec"`
+	IncludeRawJSON  bool                    `mapstructure:"include_raw_json"`
+	FilterSource    string                  `mapstructure:"filter_source"`
+	MatchSink       string                  `mapstructure:"match_sink"`
 	Kafka           AuctionAuditKafkaConfig `mapstructure:"kafka"`
+	File            AuctionAuditFileConfig  `mapstructure:"file"`
+	HTTP            AuctionAuditHTTPConfig  `mapstructure:"http"`
+}
+
+// AuctionAuditFileConfig configures the file filter source and the file match sink.
+type AuctionAuditFileConfig struct {
+	FiltersPath  string `mapstructure:"filters_path"`
+	PollInterval string `mapstructure:"poll_interval"`
+	MatchesPath  string `mapstructure:"matches_path"`
+	MaxSizeMB    int    `mapstructure:"max_size_mb"`
+	MaxBackups   int    `mapstructure:"max_backups"`
+}
+
+// AuctionAuditHTTPConfig configures the HTTP POST match sink.
+type AuctionAuditHTTPConfig struct {
+	Endpoint      string `mapstructure:"endpoint"`
+	BatchSize     int    `mapstructure:"batch_size"`
+	FlushInterval string `mapstructure:"flush_interval"`
+	Timeout       string `mapstructure:"timeout"`
 }
 
 type AuctionAuditKafkaConfig struct {
@@ -1012,6 +1125,8 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("unix_socket_name", "prebid-server.sock") // path of the socket's file which must be listened.
 	v.SetDefault("admin_port", 6060)
 	v.SetDefault("admin.enabled", true) // boolean to determine if admin listener will be started.
+	v.SetDefault("admin.config_token", "")
+	v.SetDefault("attestation.instance_key_file", "")
 	v.SetDefault("garbage_collector_threshold", 0)
 	v.SetDefault("status_response", "")
 	v.SetDefault("datacenter", "")
@@ -1189,6 +1304,34 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("stored_responses.http_events.endpoint", "")
 	v.SetDefault("stored_responses.http_events.refresh_rate_seconds", 0)
 	v.SetDefault("stored_responses.http_events.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.connection.driver", "")
+	v.SetDefault("stored_floors.database.connection.dbname", "")
+	v.SetDefault("stored_floors.database.connection.host", "")
+	v.SetDefault("stored_floors.database.connection.port", 0)
+	v.SetDefault("stored_floors.database.connection.user", "")
+	v.SetDefault("stored_floors.database.connection.password", "")
+	v.SetDefault("stored_floors.database.connection.query_string", "")
+	v.SetDefault("stored_floors.database.connection.tls.root_cert", "")
+	v.SetDefault("stored_floors.database.connection.tls.client_cert", "")
+	v.SetDefault("stored_floors.database.connection.tls.client_key", "")
+	v.SetDefault("stored_floors.database.fetcher.query", "")
+	v.SetDefault("stored_floors.database.initialize_caches.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.initialize_caches.query", "")
+	v.SetDefault("stored_floors.database.poll_for_updates.refresh_rate_seconds", 0)
+	v.SetDefault("stored_floors.database.poll_for_updates.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.poll_for_updates.query", "")
+	v.SetDefault("stored_floors.filesystem.enabled", false)
+	v.SetDefault("stored_floors.filesystem.directorypath", "")
+	v.SetDefault("stored_floors.http.endpoint", "")
+	v.SetDefault("stored_floors.http.use_rfc3986_compliant_request_builder", false)
+	v.SetDefault("stored_floors.in_memory_cache.type", "none")
+	v.SetDefault("stored_floors.in_memory_cache.ttl_seconds", 0)
+	v.SetDefault("stored_floors.in_memory_cache.size_bytes", 0)
+	v.SetDefault("stored_floors.cache_events.enabled", false)
+	v.SetDefault("stored_floors.cache_events.endpoint", "")
+	v.SetDefault("stored_floors.http_events.endpoint", "")
+	v.SetDefault("stored_floors.http_events.refresh_rate_seconds", 0)
+	v.SetDefault("stored_floors.http_events.timeout_ms", 0)
 
 	v.SetDefault("vtrack.timeout_ms", 2000)
 	v.SetDefault("vtrack.allow_unknown_bidder", true)
@@ -1247,6 +1390,9 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("analytics.auction_audit.max_filter_ttl", "1h")
 	v.SetDefault("analytics.auction_audit.cleanup_interval", "10m")
 	v.SetDefault("analytics.auction_audit.max_events_per_sec", 5)
+	v.SetDefault("analytics.auction_audit.include_raw_json", true)
+	v.SetDefault("analytics.auction_audit.filter_source", "kafka")
+	v.SetDefault("analytics.auction_audit.match_sink", "kafka")
 	v.SetDefault("analytics.auction_audit.kafka.brokers", []string{})
 	v.SetDefault("analytics.auction_audit.kafka.matched_topic", "matched-auction-events")
 	v.SetDefault("analytics.auction_audit.kafka.filter_topic", "session-filters-request")
@@ -1256,6 +1402,15 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("analytics.auction_audit.kafka.sasl.username", "")
 	v.SetDefault("analytics.auction_audit.kafka.sasl.password", "")
 	v.SetDefault("analytics.auction_audit.kafka.sasl.insecure_skip_verify", false)
+	v.SetDefault("analytics.auction_audit.file.filters_path", "")
+	v.SetDefault("analytics.auction_audit.file.poll_interval", "5s")
+	v.SetDefault("analytics.auction_audit.file.matches_path", "")
+	v.SetDefault("analytics.auction_audit.file.max_size_mb", 100)
+	v.SetDefault("analytics.auction_audit.file.max_backups", 3)
+	v.SetDefault("analytics.auction_audit.http.endpoint", "")
+	v.SetDefault("analytics.auction_audit.http.batch_size", 100)
+	v.SetDefault("analytics.auction_audit.http.flush_interval", "1s")
+	v.SetDefault("analytics.auction_audit.http.timeout", "5s")
 	v.SetDefault("amp_timeout_adjustment_ms", 0)
 	v.BindEnv("gdpr.default_value")
 	v.SetDefault("gdpr.enabled", true)
@@ -1295,6 +1450,7 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("currency_converter.fetch_timeout_ms", 60000)      // 60 seconds
 	v.SetDefault("currency_converter.fetch_interval_seconds", 1800) // fetch currency rates every 30 minutes
 	v.SetDefault("currency_converter.stale_rates_seconds", 0)
+	v.SetDefault("currency_converter.max_rate_change_percent", 0)
 	v.SetDefault("default_request.type", "")
 	v.SetDefault("default_request.file.name", "")
 	v.SetDefault("default_request.alias_info", false)
@@ -1317,6 +1473,14 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("account_defaults.price_floors.fetch.max_age_sec", 86400)
 	v.SetDefault("account_defaults.price_floors.fetch.period_sec", 3600)
 	v.SetDefault("account_defaults.price_floors.fetch.max_schema_dims", 0)
+	v.SetDefault("account_defaults.price_floors.learning.enabled", false)
+	v.SetDefault("account_defaults.price_floors.learning.shadow_mode", false)
+	v.SetDefault("account_defaults.price_floors.learning.schema", []string{"mediaType", "size"})
+	v.SetDefault("account_defaults.price_floors.learning.percentile", 50)
+	v.SetDefault("account_defaults.price_floors.learning.min_samples", 100)
+	v.SetDefault("account_defaults.price_floors.learning.min_floor", 0.01)
+	v.SetDefault("account_defaults.price_floors.learning.max_floor", 0)
+	v.SetDefault("account_defaults.price_floors.learning.max_change_percent", 20)
 	v.SetDefault("account_defaults.privacy.privacysandbox.topicsdomain", "")
 	v.SetDefault("account_defaults.privacy.privacysandbox.cookiedeprecation.enabled", false)
 	v.SetDefault("account_defaults.privacy.privacysandbox.cookiedeprecation.ttl_sec", 604800)
@@ -1340,6 +1504,11 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("price_floors.fetcher.http_client.dialer.timeout_seconds", 30)
 	v.SetDefault("price_floors.fetcher.http_client.dialer.keep_alive_seconds", 15)
 	v.SetDefault("price_floors.fetcher.max_retries", 10)
+	v.SetDefault("price_floors.learning.enabled", false)
+	v.SetDefault("price_floors.learning.refresh_period_sec", 300)
+	v.SetDefault("price_floors.learning.sample_size", 1000)
+	v.SetDefault("price_floors.learning.max_rules", 500)
+	v.SetDefault("price_floors.learning.max_accounts", 1000)
 
 	v.SetDefault("account_defaults.events_enabled", false)
 	v.SetDefault("compression.response.enable_gzip", false)
@@ -1431,6 +1600,7 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("experiment.adscert.remote.signing_timeout_ms", 5)
 
 	v.SetDefault("hooks.enabled", false)
+	v.SetDefault("hooks.fail_on_invalid_execution_plans", false)
 
 	for bidderName := range bidderInfos {
 		setBidderDefaults(v, strings.ToLower(bidderName))
@@ -152,6 +152,8 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "host_cookie.max_cookie_size_bytes", 0, cfg.HostCookie.MaxCookieSizeBytes)
 	cmpInts(t, "currency_converter.fetch_interval_seconds", 1800, cfg.CurrencyConverter.FetchIntervalSeconds)
 	cmpStrings(t, "currency_converter.fetch_url", "https://cdn.jsdelivr.net/gh/prebid/currency-file@1/latest.json", cfg.CurrencyConverter.FetchURL)
+	assert.Equal(t, 0.0, cfg.CurrencyConverter.MaxRateChangePercent, "currency_converter.max_rate_change_percent")
+	assert.Empty(t, cfg.CurrencyConverter.Sources, "currency_converter.sources")
 	cmpBools(t, "account_required", false, cfg.AccountRequired)
 	cmpInts(t, "metrics.influxdb.collection_rate_seconds", 20, cfg.Metrics.Influxdb.MetricSendInterval)
 	cmpBools(t, "account_adapter_details", false, cfg.Metrics.Disabled.AccountAdapterDetails)
@@ -202,6 +204,11 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "price_floors.fetcher.http_client.max_idle_connections_per_host", 2, cfg.PriceFloors.Fetcher.HttpClient.MaxIdleConnsPerHost)
 	cmpInts(t, "price_floors.fetcher.http_client.idle_connection_timeout_seconds", 60, cfg.PriceFloors.Fetcher.HttpClient.IdleConnTimeout)
 	cmpInts(t, "price_floors.fetcher.max_retries", 10, cfg.PriceFloors.Fetcher.MaxRetries)
+	cmpBools(t, "price_floors.learning.enabled", false, cfg.PriceFloors.Learning.Enabled)
+	cmpInts(t, "price_floors.learning.refresh_period_sec", 300, cfg.PriceFloors.Learning.RefreshPeriodSec)
+	cmpInts(t, "price_floors.learning.sample_size", 1000, cfg.PriceFloors.Learning.SampleSize)
+	cmpInts(t, "price_floors.learning.max_rules", 500, cfg.PriceFloors.Learning.MaxRules)
+	cmpInts(t, "price_floors.learning.max_accounts", 1000, cfg.PriceFloors.Learning.MaxAccounts)
 
 	// Assert compression related defaults
 	cmpBools(t, "compression.request.enable_gzip", false, cfg.Compression.Request.GZIP)
@@ -222,6 +229,12 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "account_defaults.price_floors.fetch.period_sec", 3600, cfg.AccountDefaults.PriceFloors.Fetcher.Period)
 	cmpInts(t, "account_defaults.price_floors.fetch.max_age_sec", 86400, cfg.AccountDefaults.PriceFloors.Fetcher.MaxAge)
 	cmpInts(t, "account_defaults.price_floors.fetch.max_schema_dims", 0, cfg.AccountDefaults.PriceFloors.Fetcher.MaxSchemaDims)
+	cmpBools(t, "account_defaults.price_floors.learning.enabled", false, cfg.AccountDefaults.PriceFloors.Learning.Enabled)
+	cmpBools(t, "account_defaults.price_floors.learning.shadow_mode", false, cfg.AccountDefaults.PriceFloors.Learning.ShadowMode)
+	assert.Equal(t, []string{"mediaType", "size"}, cfg.AccountDefaults.PriceFloors.Learning.Schema)
+	cmpInts(t, "account_defaults.price_floors.learning.percentile", 50, cfg.AccountDefaults.PriceFloors.Learning.Percentile)
+	cmpInts(t, "account_defaults.price_floors.learning.min_samples", 100, cfg.AccountDefaults.PriceFloors.Learning.MinSamples)
+	cmpInts(t, "account_defaults.price_floors.learning.max_change_percent", 20, cfg.AccountDefaults.PriceFloors.Learning.MaxChangePercent)
 	cmpStrings(t, "account_defaults.privacy.topicsdomain", "", cfg.AccountDefaults.Privacy.PrivacySandbox.TopicsDomain)
 	cmpBools(t, "account_defaults.privacy.privacysandbox.cookiedeprecation.enabled", false, cfg.AccountDefaults.Privacy.PrivacySandbox.CookieDeprecation.Enabled)
 	cmpInts(t, "account_defaults.privacy.privacysandbox.cookiedeprecation.ttl_sec", 604800, cfg.AccountDefaults.Privacy.PrivacySandbox.CookieDeprecation.TTLSec)
@@ -229,6 +242,7 @@ func TestDefaults(t *testing.T) {
 	cmpBools(t, "account_defaults.events.enabled", false, cfg.AccountDefaults.Events.Enabled)
 
 	cmpBools(t, "hooks.enabled", false, cfg.Hooks.Enabled)
+	cmpBools(t, "hooks.fail_on_invalid_execution_plans", false, cfg.Hooks.FailOnInvalidExecutionPlans)
 	cmpStrings(t, "validations.banner_creative_max_size", "skip", cfg.Validations.BannerCreativeMaxSize)
 	cmpStrings(t, "validations.secure_markup", "skip", cfg.Validations.SecureMarkup)
 	cmpInts(t, "validations.max_creative_width", 0, int(cfg.Validations.MaxCreativeWidth))
@@ -254,6 +268,7 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "analytics.agma.buffers.count", 100, cfg.Analytics.Agma.Buffers.EventCount)
 	cmpStrings(t, "analytics.agma.buffers.timeout", "15m", cfg.Analytics.Agma.Buffers.Timeout)
 	cmpInts(t, "analytics.agma.accounts", 0, len(cfg.Analytics.Agma.Accounts))
+	cmpBools(t, "analytics.auction_audit.include_raw_json", true, cfg.Analytics.AuctionAudit.IncludeRawJSON)
 	expectedTCF2 := TCF2{
 		Enabled: true,
 		Purpose1: TCF2Purpose{
@@ -472,6 +487,13 @@ http_client_cache:
 currency_converter:
   fetch_url: https://currency.prebid.org
   fetch_interval_seconds: 1800
+  max_rate_change_percent: 5
+  sources:
+    - type: url
+      url: https://currency.prebid.org
+      stale_rates_seconds: 3600
+    - type: file
+      path: /etc/pbs/rates.json
 recaptcha_secret: asdfasdfasdfasdf
 metrics:
   influxdb:
@@ -908,6 +930,11 @@ func TestFullConfig(t *testing.T) {
 
 	cmpStrings(t, "currency_converter.fetch_url", "https://currency.prebid.org", cfg.CurrencyConverter.FetchURL)
 	cmpInts(t, "currency_converter.fetch_interval_seconds", 1800, cfg.CurrencyConverter.FetchIntervalSeconds)
+	assert.Equal(t, 5.0, cfg.CurrencyConverter.MaxRateChangePercent, "currency_converter.max_rate_change_percent")
+	assert.Equal(t, []CurrencyRatesSource{
+		{Type: CurrencyRatesSourceURL, URL: "https://currency.prebid.org", StaleRatesSeconds: 3600},
+		{Type: CurrencyRatesSourceFile, Path: "/etc/pbs/rates.json"},
+	}, cfg.CurrencyConverter.Sources, "currency_converter.sources")
 	cmpStrings(t, "recaptcha_secret", "asdfasdfasdfasdf", cfg.RecaptchaSecret)
 	cmpStrings(t, "metrics.influxdb.host", "upstream:8232", cfg.Metrics.Influxdb.Host)
 	cmpStrings(t, "metrics.influxdb.database", "metricsdb", cfg.Metrics.Influxdb.Database)
@@ -992,6 +1019,9 @@ func TestValidateConfig(t *testing.T) {
 			Files:         FileFetcherConfig{Enabled: true},
 			InMemoryCache: InMemoryCache{Type: "none"},
 		},
+		StoredFloors: StoredRequests{
+			InMemoryCache: InMemoryCache{Type: "none"},
+		},
 		AccountDefaults: Account{
 			PriceFloors: AccountPriceFloors{
 				Fetcher: AccountFloorFetch{
@@ -1361,6 +1391,55 @@ func TestOverflowedCurrencyConverterFetchInterval(t *testing.T) {
 	assert.NotNil(t, err, "cfg.currency_converter.fetch_interval_seconds prevent values over %d, but it doesn't", 0xffff)
 }
 
+func TestCurrencyConverterValidate(t *testing.T) {
+	testCases := []struct {
+		description  string
+		giveConfig   CurrencyConverter
+		expectedErrs []error
+	}{
+		{
+			description: "valid-sources",
+			giveConfig: CurrencyConverter{
+				MaxRateChangePercent: 10,
+				Sources: []CurrencyRatesSource{
+					{Type: CurrencyRatesSourceURL, URL: "https://currency.prebid.org", StaleRatesSeconds: 60},
+					{Type: CurrencyRatesSourceFile, Path: "/etc/pbs/rates.json"},
+					{Type: CurrencyRatesSourceStatic, Rates: map[string]map[string]float64{"USD": {"EUR": 0.9}}},
+				},
+			},
+			expectedErrs: nil,
+		},
+		{
+			description:  "negative-max-rate-change-percent",
+			giveConfig:   CurrencyConverter{MaxRateChangePercent: -1},
+			expectedErrs: []error{errors.New("currency_converter.max_rate_change_percent must be 0 or greater. Got -1.000000")},
+		},
+		{
+			description: "invalid-sources",
+			giveConfig: CurrencyConverter{
+				Sources: []CurrencyRatesSource{
+					{Type: CurrencyRatesSourceURL},
+					{Type: CurrencyRatesSourceFile, StaleRatesSeconds: -1},
+					{Type: CurrencyRatesSourceStatic},
+					{Type: "ftp"},
+				},
+			},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[0].url must be set for a url source"),
+				errors.New("currency_converter.sources[1].path must be set for a file source"),
+				errors.New("currency_converter.sources[1].stale_rates_seconds must be 0 or greater. Got -1"),
+				errors.New("currency_converter.sources[2].rates must be set for a static source"),
+				errors.New("currency_converter.sources[3].type must be one of: url, file, static. Got ftp"),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		errs := test.giveConfig.validate(nil)
+		assert.ElementsMatch(t, test.expectedErrs, errs, test.description)
+	}
+}
+
 func TestLimitTimeout(t *testing.T) {
 	doTimeoutTest(t, 10, 15, 10, 0)
 	doTimeoutTest(t, 10, 0, 10, 0)
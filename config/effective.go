@@ -0,0 +1,121 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const redactedValue = "<REDACTED>"
+
+// EffectiveDocument returns the resolved configuration as canonical JSON, so that two instances
+// with the same effective configuration produce the same bytes. Objects are keyed by the
+// mapstructure names used in the config files, members are sorted, unexported fields are left out
+// and empty maps and slices are written as empty objects and arrays. Values of fields and map keys
+// on the same blocklist as the startup log, and passwords embedded in URLs, are replaced with
+// "<REDACTED>".
+func (cfg *Configuration) EffectiveDocument() (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(effectiveValue(reflect.ValueOf(*cfg))); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Digest returns the hex encoded SHA-256 of the EffectiveDocument.
+func (cfg *Configuration) Digest() (string, error) {
+	document, err := cfg.EffectiveDocument()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(document)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func effectiveValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		fields := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := effectiveFieldName(field)
+			if allowedName(name) {
+				fields[name] = effectiveValue(v.Field(i))
+			} else {
+				fields[name] = redactedValue
+			}
+		}
+		return fields
+	case reflect.Map:
+		entries := make(map[string]interface{}, v.Len())
+		for _, k := range v.MapKeys() {
+			key := fmt.Sprintf("%v", k.Interface())
+			if allowedName(key) {
+				entries[key] = effectiveValue(v.MapIndex(k))
+			} else {
+				entries[key] = redactedValue
+			}
+		}
+		return entries
+	case reflect.Slice, reflect.Array:
+		elems := make([]interface{}, v.Len())
+		for i := range elems {
+			elems[i] = effectiveValue(v.Index(i))
+		}
+		return elems
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return effectiveValue(v.Elem())
+	case reflect.String:
+		return redactURLPassword(v.String())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		// Functions and channels are not configuration
+		return nil
+	}
+}
+
+// effectiveFieldName returns the mapstructure name of a field, or its Go name when it has none.
+func effectiveFieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("mapstructure"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func redactURLPassword(s string) string {
+	if !strings.Contains(s, "@") || !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return s
+	}
+	u.User = url.UserPassword(u.User.Username(), redactedValue)
+	return u.String()
+}
@@ -3,6 +3,9 @@ package config
 type Hooks struct {
 	Enabled bool    `mapstructure:"enabled"`
 	Modules Modules `mapstructure:"modules"`
+	// FailOnInvalidExecutionPlans makes startup fail when the configured execution plans are invalid,
+	// instead of logging a warning for each problem
+	FailOnInvalidExecutionPlans bool `mapstructure:"fail_on_invalid_execution_plans"`
 	// HostExecutionPlan defined by the host company and is executed always
 	HostExecutionPlan HookExecutionPlan `mapstructure:"host_execution_plan"`
 	// DefaultAccountExecutionPlan can be replaced by the account-specific hook execution plan
@@ -31,5 +34,24 @@ type HookExecutionGroup struct {
 		ModuleCode string `mapstructure:"module_code" json:"module_code"`
 		// HookImplCode is an arbitrary value, used to identify hook when sending metrics, debug information, etc.
 		HookImplCode string `mapstructure:"hook_impl_code" json:"hook_impl_code"`
+		// RunAfter holds the module codes of the hooks in the same group that must complete before this hook starts.
+		// The hook can read the module contexts they returned. Waiting counts towards the group timeout.
+		RunAfter   []string `mapstructure:"run_after" json:"run_after,omitempty"`
+		HookToggle `mapstructure:",squash"`
 	} `mapstructure:"hook_sequence" json:"hook_sequence"`
 }
+
+// HookToggle controls the requests a hook of the execution plan is called for.
+// Publishers are matched against the account ID and domains against the site domain or the app bundle
+// of the request, a domain also matching its subdomains. Deny lists take precedence over allow lists.
+type HookToggle struct {
+	// Enabled set to false turns the hook off without removing it from the plan.
+	Enabled *bool `mapstructure:"enabled" json:"enabled,omitempty"`
+	// SamplePercent is the percentage of requests, from 0 to 100, the hook is called for.
+	// The hook is called for all requests when it is not set.
+	SamplePercent   *float64 `mapstructure:"sample_percent" json:"sample_percent,omitempty"`
+	AllowPublishers []string `mapstructure:"allow_publishers" json:"allow_publishers,omitempty"`
+	DenyPublishers  []string `mapstructure:"deny_publishers" json:"deny_publishers,omitempty"`
+	AllowDomains    []string `mapstructure:"allow_domains" json:"allow_domains,omitempty"`
+	DenyDomains     []string `mapstructure:"deny_domains" json:"deny_domains,omitempty"`
+}
@@ -18,6 +18,7 @@ const (
 	AMPRequestDataType DataType = "AMP Request"
 	AccountDataType    DataType = "Account"
 	ResponseDataType   DataType = "Response"
+	FloorsDataType     DataType = "Floors"
 )
 
 // Section returns the config section this type is defined in
@@ -29,6 +30,7 @@ func (dataType DataType) Section() string {
 		AMPRequestDataType: "stored_amp_req",
 		AccountDataType:    "accounts",
 		ResponseDataType:   "stored_responses",
+		FloorsDataType:     "stored_floors",
 	}[dataType]
 }
 
@@ -135,6 +137,7 @@ func resolvedStoredRequestsConfig(cfg *Configuration) {
 	cfg.CategoryMapping.dataType = CategoryDataType
 	cfg.Accounts.dataType = AccountDataType
 	cfg.StoredResponses.dataType = ResponseDataType
+	cfg.StoredFloors.dataType = FloorsDataType
 }
 
 func (cfg *StoredRequests) validate(errs []error) []error {
@@ -150,6 +153,10 @@ func (cfg *StoredRequests) validate(errs []error) []error {
 	}
 
 	if cfg.InMemoryCache.Type == "none" {
+		// stored floors are looked up for every auction of the accounts fetching floors
+		if cfg.DataType() == FloorsDataType && cfg.hasBackend() {
+			errs = append(errs, fmt.Errorf("%s: in_memory_cache must be enabled when floors are stored", cfg.Section()))
+		}
 		if cfg.CacheEvents.Enabled {
 			errs = append(errs, fmt.Errorf("%s: cache_events must be disabled if in_memory_cache=none", cfg.Section()))
 		}
@@ -169,6 +176,11 @@ func (cfg *StoredRequests) validate(errs []error) []error {
 	return errs
 }
 
+// hasBackend reports whether a backend is configured to fetch the data from
+func (cfg *StoredRequests) hasBackend() bool {
+	return cfg.Files.Enabled || cfg.Database.ConnectionInfo.Database != "" || cfg.HTTP.Endpoint != ""
+}
+
 // DatabaseConfig configures the Stored Request ecosystem to use Database. This must include a Fetcher,
 // and may optionally include some EventProducers to populate and refresh the caches.
 type DatabaseConfig struct {
@@ -325,6 +337,11 @@ type InMemoryCache struct {
 	RespCacheSize int `mapstructure:"resp_cache_size_bytes"`
 }
 
+// singleCache reports whether the data type is saved in a single cache, sized by in_memory_cache.size_bytes
+func (dataType DataType) singleCache() bool {
+	return dataType == AccountDataType || dataType == FloorsDataType
+}
+
 func (cfg *InMemoryCache) validate(dataType DataType, errs []error) []error {
 	section := dataType.Section()
 	switch cfg.Type {
@@ -334,7 +351,7 @@ func (cfg *InMemoryCache) validate(dataType DataType, errs []error) []error {
 		if cfg.TTL != 0 {
 			errs = append(errs, fmt.Errorf("%s: in_memory_cache.ttl_seconds is not supported for unbounded caches. Got %d", section, cfg.TTL))
 		}
-		if dataType == AccountDataType {
+		if dataType.singleCache() {
 			// single cache
 			if cfg.Size != 0 {
 				errs = append(errs, fmt.Errorf("%s: in_memory_cache.size_bytes is not supported for unbounded caches. Got %d", section, cfg.Size))
@@ -352,7 +369,7 @@ func (cfg *InMemoryCache) validate(dataType DataType, errs []error) []error {
 			}
 		}
 	case "lru":
-		if dataType == AccountDataType {
+		if dataType.singleCache() {
 			// single cache
 			if cfg.Size <= 0 {
 				errs = append(errs, fmt.Errorf("%s: in_memory_cache.size_bytes must be >= 0 when in_memory_cache.type=lru. Got %d", section, cfg.Size))
@@ -101,6 +101,30 @@ func TestInMemoryCacheValidationSingleCache(t *testing.T) {
 		Type:          "lru",
 		RespCacheSize: 1000,
 	}).validate(AccountDataType, nil))
+	assertNoErrs(t, (&InMemoryCache{
+		Type: "lru",
+		Size: 1000,
+	}).validate(FloorsDataType, nil))
+	assertErrsExist(t, (&InMemoryCache{
+		Type:             "lru",
+		RequestCacheSize: 1000,
+	}).validate(FloorsDataType, nil))
+}
+
+func TestStoredFloorsCacheValidation(t *testing.T) {
+	storedFloors := func(files bool, cacheType string) *StoredRequests {
+		cfg := &StoredRequests{
+			Files:         FileFetcherConfig{Enabled: files},
+			InMemoryCache: InMemoryCache{Type: cacheType, Size: 1000},
+		}
+		cfg.SetDataType(FloorsDataType)
+		return cfg
+	}
+
+	assertNoErrs(t, (&StoredRequests{InMemoryCache: InMemoryCache{Type: "none"}}).validate(nil))
+	assertNoErrs(t, storedFloors(false, "none").validate(nil))
+	assertNoErrs(t, storedFloors(true, "lru").validate(nil))
+	assertErrsExist(t, storedFloors(true, "none").validate(nil))
 }
 
 func TestDatabaseConfigValidation(t *testing.T) {
@@ -14,6 +14,10 @@ type logMsg func(string, ...interface{})
 var mapregex = regexp.MustCompile(`mapstructure:"([^"]+)"`)
 var blocklistregexp = []*regexp.Regexp{
 	regexp.MustCompile("password"),
+	regexp.MustCompile("secret"),
+	regexp.MustCompile("token"),
+	regexp.MustCompile("credential"),
+	regexp.MustCompile("api_?key"),
 }
 
 // LogGeneral will log nearly any sort of value, but requires the name of the root object to be in the
@@ -65,3 +65,16 @@ func TestBasic(t *testing.T) {
 		t.Errorf("Did not log properly.\ndesired:%s\nfound:%s\nsource: %v", expected, result, testCfg)
 	}
 }
+
+func TestAllowedName(t *testing.T) {
+	for _, name := range []string{"host", "endpoint", "private_key_file", "key_id"} {
+		if !allowedName(name) {
+			t.Errorf("%s should be allowed", name)
+		}
+	}
+	for _, name := range []string{"password", "recaptcha_secret", "override_token", "credentials", "api_key", "apikey"} {
+		if allowedName(name) {
+			t.Errorf("%s should be redacted", name)
+		}
+	}
+}
@@ -36,3 +36,19 @@ func (ci converterInfo) Rates() *map[string]map[string]float64 {
 func (ci converterInfo) AdditionalInfo() interface{} {
 	return ci.additionalInfo
 }
+
+// RatesSourcesInfo holds the rate converter's sources, the one currently providing the rates
+// and the outcome of the last rate changes check, reported as the converter's additional info
+type RatesSourcesInfo struct {
+	Sources        []string         `json:"sources"`
+	ActiveSource   string           `json:"activeSource,omitempty"`
+	LastValidation *RatesValidation `json:"lastValidation,omitempty"`
+}
+
+// RatesValidation holds the outcome of checking new rates against the last rates accepted from the same source
+type RatesValidation struct {
+	Source  string    `json:"source"`
+	Time    time.Time `json:"time"`
+	Valid   bool      `json:"valid"`
+	Message string    `json:"message,omitempty"`
+}
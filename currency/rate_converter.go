@@ -1,108 +1,165 @@
 package currency
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"io"
+	"math"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/prebid/prebid-server/v3/errortypes"
 	"github.com/prebid/prebid-server/v3/logger"
-	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/prebid/prebid-server/v3/util/timeutil"
 )
 
+// rateChangeConfirmations is the number of consecutive fetches a source has to return consistent rates in
+// before a change larger than the allowed percentage is accepted as a genuine move rather than a bad fetch
+const rateChangeConfirmations = 3
+
 // RateConverter holds the currencies conversion rates dictionary
+// Rates are fetched from an ordered list of sources: a source is only used when the sources before it fail
+// and the rates previously fetched from them are stale
 type RateConverter struct {
-	httpClient          httpClient
-	httpTimeout         time.Duration
-	staleRatesThreshold time.Duration
-	syncSourceURL       string
-	rates               atomic.Value // Should only hold Rates struct
-	lastUpdated         atomic.Value // Should only hold time.Time
-	constantRates       Conversions
-	time                timeutil.Time
+	sources              []RatesSource
+	sourceRates          []*Rates            // last accepted rates of each source, only accessed while holding updateMutex
+	rateChanges          []pendingRateChange // rejected rates of each source, only accessed while holding updateMutex
+	maxRateChangePercent float64
+	updateMutex          sync.Mutex
+	rates                atomic.Value // Should only hold Rates struct
+	lastUpdated          atomic.Value // Should only hold time.Time
+	activeSource         atomic.Int64 // Index of the source the rates were fetched from, -1 if none
+	lastValidation       atomic.Value // Should only hold *RatesValidation
+	constantRates        Conversions
+	time                 timeutil.Time
 }
 
-// NewRateConverter returns a new RateConverter
+// NewRateConverter returns a new RateConverter fetching the rates from a single URL
 func NewRateConverter(
 	httpClient httpClient,
 	httpTimeout time.Duration,
 	syncSourceURL string,
 	staleRatesThreshold time.Duration,
 ) *RateConverter {
-	return &RateConverter{
-		httpClient:          httpClient,
-		httpTimeout:         httpTimeout,
-		staleRatesThreshold: staleRatesThreshold,
-		syncSourceURL:       syncSourceURL,
-		rates:               atomic.Value{},
-		lastUpdated:         atomic.Value{},
-		constantRates:       NewConstantRates(),
-		time:                &timeutil.RealTime{},
-	}
+	return NewRateConverterWithSources([]RatesSource{NewHTTPRatesSource(httpClient, httpTimeout, syncSourceURL, staleRatesThreshold)}, 0)
 }
 
-// fetch allows to retrieve the currencies rates from the syncSourceURL provided
-func (rc *RateConverter) fetch() (*Rates, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), rc.httpTimeout)
-	defer cancel()
-
-	request, err := http.NewRequestWithContext(ctx, "GET", rc.syncSourceURL, nil)
-	if err != nil {
-		return nil, err
+// NewRateConverterWithSources returns a new RateConverter trying the sources in order. New rates are rejected if any
+// conversion moved by more than maxRateChangePercent since the last rates accepted from the same source, unless the
+// source returned consistent rates in the last rateChangeConfirmations fetches. 0 disables the check
+func NewRateConverterWithSources(sources []RatesSource, maxRateChangePercent float64) *RateConverter {
+	rc := &RateConverter{
+		sources:              sources,
+		sourceRates:          make([]*Rates, len(sources)),
+		rateChanges:          make([]pendingRateChange, len(sources)),
+		maxRateChangePercent: maxRateChangePercent,
+		rates:                atomic.Value{},
+		lastUpdated:          atomic.Value{},
+		lastValidation:       atomic.Value{},
+		constantRates:        NewConstantRates(),
+		time:                 &timeutil.RealTime{},
 	}
+	rc.activeSource.Store(-1)
+	return rc
+}
 
-	response, err := rc.httpClient.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		// read the entire response body to ensure full connection reuse if there's an
-		// invalid status code
-		if _, err := io.Copy(io.Discard, response.Body); err != nil {
-			logger.Errorf("error draining conversion rates response body: %v", err)
+// Update updates the internal currencies rates from the first source providing valid rates, keeping the current rates
+// when their source fails until they are stale
+func (rc *RateConverter) update() error {
+	rc.updateMutex.Lock()
+	defer rc.updateMutex.Unlock()
+
+	var errs []error
+	for i, source := range rc.sources {
+		rates, err := source.Fetch()
+		if err == nil {
+			err = rc.validate(i, rates)
 		}
-		response.Body.Close()
-	}()
+		if err == nil {
+			rc.sourceRates[i] = rates
+			rc.rates.Store(rates)
+			rc.lastUpdated.Store(rc.time.Now())
+			rc.activeSource.Store(int64(i))
+			return nil
+		}
+		errs = append(errs, err)
 
-	if response.StatusCode >= 400 {
-		message := fmt.Sprintf("the currency rates request failed with status code %d", response.StatusCode)
-		return nil, &errortypes.BadServerResponse{Message: message}
+		if rc.activeSource.Load() == int64(i) && !rc.checkStaleRates() {
+			logger.Errorf("Error updating conversion rates from %s: %v", source.Name(), err)
+			return errors.Join(errs...)
+		}
+		if i < len(rc.sources)-1 {
+			logger.Errorf("Error updating conversion rates from %s, trying the next source: %v", source.Name(), err)
+		} else {
+			logger.Errorf("Error updating conversion rates from %s, falling back to constant rates: %v", source.Name(), err)
+		}
 	}
 
-	bytesJSON, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("the currency rates request failed: %v", err)
+	rc.clearRates()
+	return errors.Join(errs...)
+}
+
+// validate checks that no conversion moved by more than the allowed percentage since the last rates accepted from the source,
+// a larger move is accepted once the source confirmed it in enough consecutive fetches
+func (rc *RateConverter) validate(sourceIndex int, rates *Rates) error {
+	if rc.maxRateChangePercent <= 0 {
+		return nil
 	}
 
-	updatedRates := &Rates{}
-	err = jsonutil.UnmarshalValid(bytesJSON, updatedRates)
+	err := checkRateChanges(rc.sourceRates[sourceIndex], rates, rc.maxRateChangePercent)
+	if err != nil && rc.rateChanges[sourceIndex].confirm(rates, rc.maxRateChangePercent) {
+		logger.Infof("Accepting conversion rates from %s after %d consistent fetches: %v", rc.sources[sourceIndex].Name(), rateChangeConfirmations, err)
+		err = nil
+	}
+	if err == nil {
+		rc.rateChanges[sourceIndex] = pendingRateChange{}
+	}
+	validation := &RatesValidation{
+		Source: rc.sources[sourceIndex].Name(),
+		Time:   rc.time.Now(),
+		Valid:  err == nil,
+	}
 	if err != nil {
-		return nil, fmt.Errorf("the currency rates request failed to parse json: %v", err)
+		validation.Message = err.Error()
 	}
+	rc.lastValidation.Store(validation)
+	return err
+}
 
-	return updatedRates, err
+// pendingRateChange tracks the rates a source returned since its rates were last accepted
+type pendingRateChange struct {
+	rates *Rates
+	count int
 }
 
-// Update updates the internal currencies rates from remote sources
-func (rc *RateConverter) update() error {
-	rates, err := rc.fetch()
-	if err == nil {
-		rc.rates.Store(rates)
-		rc.lastUpdated.Store(rc.time.Now())
+// confirm records rejected rates and returns whether the source returned consistent rates in enough consecutive fetches
+func (p *pendingRateChange) confirm(rates *Rates, maxChangePercent float64) bool {
+	if p.rates != nil && checkRateChanges(p.rates, rates, maxChangePercent) == nil {
+		p.count++
 	} else {
-		if rc.checkStaleRates() {
-			rc.clearRates()
-			logger.Errorf("Error updating conversion rates, falling back to constant rates: %v", err)
-		} else {
-			logger.Errorf("Error updating conversion rates: %v", err)
-		}
+		p.count = 1
 	}
+	p.rates = rates
+	return p.count >= rateChangeConfirmations
+}
 
-	return err
+// checkRateChanges returns an error if a conversion present in both rates moved by more than maxChangePercent
+func checkRateChanges(previous, updated *Rates, maxChangePercent float64) error {
+	if previous == nil || updated == nil {
+		return nil
+	}
+	for from, conversions := range updated.Conversions {
+		for to, rate := range conversions {
+			previousRate, ok := previous.Conversions[from][to]
+			if !ok || previousRate == 0 {
+				continue
+			}
+			if change := math.Abs(rate-previousRate) / previousRate * 100; change > maxChangePercent {
+				return fmt.Errorf("the %s to %s rate changed by %.2f%%, more than the allowed %.2f%%", from, to, change, maxChangePercent)
+			}
+		}
+	}
+	return nil
 }
 
 func (rc *RateConverter) Run() error {
@@ -131,18 +188,24 @@ func (rc *RateConverter) Rates() Conversions {
 func (rc *RateConverter) clearRates() {
 	// atomic.Value field rates must be of type *Rates so we cast nil to that type
 	rc.rates.Store((*Rates)(nil))
+	rc.activeSource.Store(-1)
 }
 
-// checkStaleRates checks if loaded third party conversion rates are stale
+// checkStaleRates checks if loaded third party conversion rates are stale, according to the threshold of their source
 func (rc *RateConverter) checkStaleRates() bool {
-	if rc.staleRatesThreshold <= 0 {
+	activeSource := rc.activeSource.Load()
+	if activeSource < 0 {
+		return false
+	}
+	staleRatesThreshold := rc.sources[activeSource].StaleRatesThreshold()
+	if staleRatesThreshold <= 0 {
 		return false
 	}
 
 	currentTime := rc.time.Now().UTC()
 	if lastUpdated := rc.lastUpdated.Load(); lastUpdated != nil {
 		delta := currentTime.Sub(lastUpdated.(time.Time).UTC())
-		if delta.Seconds() > rc.staleRatesThreshold.Seconds() {
+		if delta.Seconds() > staleRatesThreshold.Seconds() {
 			return true
 		}
 	}
@@ -152,10 +215,31 @@ func (rc *RateConverter) checkStaleRates() bool {
 // GetInfo returns setup information about the converter
 func (rc *RateConverter) GetInfo() ConverterInfo {
 	var rates *map[string]map[string]float64 = rc.Rates().GetRates()
+
+	sourcesInfo := RatesSourcesInfo{
+		Sources: make([]string, 0, len(rc.sources)),
+	}
+	for _, source := range rc.sources {
+		sourcesInfo.Sources = append(sourcesInfo.Sources, source.Name())
+	}
+	if activeSource := rc.activeSource.Load(); activeSource >= 0 {
+		sourcesInfo.ActiveSource = rc.sources[activeSource].Name()
+	}
+	if validation, ok := rc.lastValidation.Load().(*RatesValidation); ok {
+		sourcesInfo.LastValidation = validation
+	}
+
+	// the primary source is reported when no source is active, as it is the one the converter is trying to use
+	source := sourcesInfo.ActiveSource
+	if source == "" && len(sourcesInfo.Sources) > 0 {
+		source = sourcesInfo.Sources[0]
+	}
+
 	return converterInfo{
-		source:      rc.syncSourceURL,
-		lastUpdated: rc.LastUpdated(),
-		rates:       rates,
+		source:         source,
+		lastUpdated:    rc.LastUpdated(),
+		rates:          rates,
+		additionalInfo: sourcesInfo,
 	}
 }
 
@@ -1,6 +1,7 @@
 package currency
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -381,3 +382,201 @@ func (m *mockHttpClient) Do(req *http.Request) (*http.Response, error) {
 		Body:       io.NopCloser(strings.NewReader(m.responseBody)),
 	}, nil
 }
+
+// mockRatesSource is a rates source returning the configured rates or error, and counting its fetches
+type mockRatesSource struct {
+	name                string
+	rates               *Rates
+	err                 error
+	staleRatesThreshold time.Duration
+	fetchCount          int
+}
+
+func (m *mockRatesSource) Name() string {
+	return m.name
+}
+
+func (m *mockRatesSource) Fetch() (*Rates, error) {
+	m.fetchCount++
+	return m.rates, m.err
+}
+
+func (m *mockRatesSource) StaleRatesThreshold() time.Duration {
+	return m.staleRatesThreshold
+}
+
+func TestRatesSourcesFallback(t *testing.T) {
+	primaryRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77}})
+	fallbackRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.78}})
+	primary := &mockRatesSource{name: "primary", rates: primaryRates, staleRatesThreshold: 30 * time.Second}
+	fallback := &mockRatesSource{name: "fallback", rates: fallbackRates}
+
+	initialFakeTime := time.Date(2018, time.September, 12, 30, 0, 0, 0, time.UTC)
+	fakeTime := &FakeTime{time: initialFakeTime}
+	currencyConverter := NewRateConverterWithSources([]RatesSource{primary, fallback}, 0)
+	currencyConverter.time = fakeTime
+
+	// Primary source provides the rates
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, primaryRates, currencyConverter.Rates())
+	assert.Equal(t, "primary", currencyConverter.GetInfo().Source())
+	assert.Equal(t, 0, fallback.fetchCount, "Fallback source should not be fetched")
+
+	// Primary source fails but its rates aren't stale so they are kept
+	primary.err = errors.New("primary failure")
+	fakeTime.time = initialFakeTime.Add(29 * time.Second)
+	assert.NotNil(t, currencyConverter.Run())
+	assert.Equal(t, primaryRates, currencyConverter.Rates())
+	assert.Equal(t, initialFakeTime, currencyConverter.LastUpdated())
+	assert.Equal(t, 0, fallback.fetchCount, "Fallback source should not be fetched")
+
+	// Primary rates are stale, the fallback source provides the rates
+	fakeTime.time = initialFakeTime.Add(31 * time.Second)
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, fallbackRates, currencyConverter.Rates())
+	assert.Equal(t, fakeTime.time, currencyConverter.LastUpdated())
+	assert.Equal(t, "fallback", currencyConverter.GetInfo().Source())
+
+	// Fallback rates are never stale, they are kept when both sources fail
+	fallback.err = errors.New("fallback failure")
+	fakeTime.time = initialFakeTime.Add(24 * time.Hour)
+	assert.NotNil(t, currencyConverter.Run())
+	assert.Equal(t, fallbackRates, currencyConverter.Rates())
+
+	// Primary source recovers and provides the rates again
+	primary.err = nil
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, primaryRates, currencyConverter.Rates())
+	assert.Equal(t, RatesSourcesInfo{Sources: []string{"primary", "fallback"}, ActiveSource: "primary"}, currencyConverter.GetInfo().AdditionalInfo())
+}
+
+func TestRatesSourcesAllFailing(t *testing.T) {
+	primary := &mockRatesSource{name: "primary", err: errors.New("primary failure")}
+	fallback := &mockRatesSource{name: "fallback", err: errors.New("fallback failure")}
+
+	currencyConverter := NewRateConverterWithSources([]RatesSource{primary, fallback}, 0)
+	err := currencyConverter.Run()
+
+	assert.ErrorContains(t, err, "primary failure")
+	assert.ErrorContains(t, err, "fallback failure")
+	assert.Equal(t, &ConstantRates{}, currencyConverter.Rates(), "Rates should return constant rates")
+	assert.Equal(t, RatesSourcesInfo{Sources: []string{"primary", "fallback"}}, currencyConverter.GetInfo().AdditionalInfo())
+	assert.Equal(t, "primary", currencyConverter.GetInfo().Source())
+}
+
+func TestRateChangesValidation(t *testing.T) {
+	initialRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.8, "EUR": 0.9}})
+	movedRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.8, "EUR": 1.0}})
+	slightlyMovedRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.84, "EUR": 0.9}})
+	source := &mockRatesSource{name: "primary", rates: initialRates}
+
+	fakeTime := &FakeTime{time: time.Date(2018, time.September, 12, 30, 0, 0, 0, time.UTC)}
+	currencyConverter := NewRateConverterWithSources([]RatesSource{source}, 10)
+	currencyConverter.time = fakeTime
+
+	// First rates have nothing to be compared with
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, initialRates, currencyConverter.Rates())
+
+	// USD to EUR moved by 11.11%, the new rates are rejected and the current ones kept
+	source.rates = movedRates
+	err := currencyConverter.Run()
+	assert.EqualError(t, err, "the USD to EUR rate changed by 11.11%, more than the allowed 10.00%")
+	assert.Equal(t, initialRates, currencyConverter.Rates())
+	assert.Equal(t, &RatesValidation{
+		Source:  "primary",
+		Time:    fakeTime.time,
+		Valid:   false,
+		Message: "the USD to EUR rate changed by 11.11%, more than the allowed 10.00%",
+	}, currencyConverter.GetInfo().AdditionalInfo().(RatesSourcesInfo).LastValidation)
+
+	// USD to GBP moved by 5%, the new rates are accepted
+	source.rates = slightlyMovedRates
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, slightlyMovedRates, currencyConverter.Rates())
+	assert.Equal(t, &RatesValidation{Source: "primary", Time: fakeTime.time, Valid: true}, currencyConverter.GetInfo().AdditionalInfo().(RatesSourcesInfo).LastValidation)
+}
+
+func TestRateChangesConfirmation(t *testing.T) {
+	initialRates := NewRates(map[string]map[string]float64{"USD": {"EUR": 0.9}})
+	movedRates := NewRates(map[string]map[string]float64{"USD": {"EUR": 1.0}})
+	glitchRates := NewRates(map[string]map[string]float64{"USD": {"EUR": 2.0}})
+	source := &mockRatesSource{name: "primary", rates: initialRates}
+
+	currencyConverter := NewRateConverterWithSources([]RatesSource{source}, 10)
+	currencyConverter.time = &FakeTime{time: time.Date(2018, time.September, 12, 30, 0, 0, 0, time.UTC)}
+	assert.Nil(t, currencyConverter.Run())
+
+	// A move seen in a single fetch is rejected
+	source.rates = movedRates
+	assert.NotNil(t, currencyConverter.Run())
+	source.rates = glitchRates
+	assert.NotNil(t, currencyConverter.Run())
+	assert.Equal(t, initialRates, currencyConverter.Rates())
+
+	// The same move seen in consecutive fetches is accepted as the new baseline
+	source.rates = movedRates
+	for i := 1; i < rateChangeConfirmations; i++ {
+		assert.NotNil(t, currencyConverter.Run())
+		assert.Equal(t, initialRates, currencyConverter.Rates())
+	}
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, movedRates, currencyConverter.Rates())
+	assert.True(t, currencyConverter.GetInfo().AdditionalInfo().(RatesSourcesInfo).LastValidation.Valid)
+
+	// Later rates are compared with the new baseline
+	source.rates = NewRates(map[string]map[string]float64{"USD": {"EUR": 1.05}})
+	assert.Nil(t, currencyConverter.Run())
+	assert.Equal(t, source.rates, currencyConverter.Rates())
+}
+
+func TestCheckRateChanges(t *testing.T) {
+	previous := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.8}})
+
+	tests := []struct {
+		description string
+		givePrev    *Rates
+		giveUpdated *Rates
+		wantErr     bool
+	}{
+		{
+			description: "No previous rates",
+			givePrev:    nil,
+			giveUpdated: NewRates(map[string]map[string]float64{"USD": {"GBP": 8}}),
+			wantErr:     false,
+		},
+		{
+			description: "Change within the limit",
+			givePrev:    previous,
+			giveUpdated: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.73}}),
+			wantErr:     false,
+		},
+		{
+			description: "Decrease over the limit",
+			givePrev:    previous,
+			giveUpdated: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.71}}),
+			wantErr:     true,
+		},
+		{
+			description: "Increase over the limit",
+			givePrev:    previous,
+			giveUpdated: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.89}}),
+			wantErr:     true,
+		},
+		{
+			description: "New conversions are not checked",
+			givePrev:    previous,
+			giveUpdated: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.8, "EUR": 5}, "EUR": {"USD": 0.2}}),
+			wantErr:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		err := checkRateChanges(tt.givePrev, tt.giveUpdated, 10)
+		if tt.wantErr {
+			assert.NotNil(t, err, tt.description)
+		} else {
+			assert.Nil(t, err, tt.description)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/errortypes"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const staticRatesSourceName = "static"
+
+// RatesSource provides currencies conversion rates to the RateConverter
+type RatesSource interface {
+	// Name identifies the source, e.g. its URL or file path
+	Name() string
+	// Fetch retrieves the current rates from the source
+	Fetch() (*Rates, error)
+	// StaleRatesThreshold is the age after which rates fetched from the source are considered stale, 0 means never
+	StaleRatesThreshold() time.Duration
+}
+
+// httpRatesSource fetches rates from a remote URL
+type httpRatesSource struct {
+	httpClient          httpClient
+	httpTimeout         time.Duration
+	syncSourceURL       string
+	staleRatesThreshold time.Duration
+}
+
+// NewHTTPRatesSource returns a source fetching the currencies rates from the syncSourceURL provided
+func NewHTTPRatesSource(httpClient httpClient, httpTimeout time.Duration, syncSourceURL string, staleRatesThreshold time.Duration) RatesSource {
+	return &httpRatesSource{
+		httpClient:          httpClient,
+		httpTimeout:         httpTimeout,
+		syncSourceURL:       syncSourceURL,
+		staleRatesThreshold: staleRatesThreshold,
+	}
+}
+
+func (s *httpRatesSource) Name() string {
+	return s.syncSourceURL
+}
+
+func (s *httpRatesSource) StaleRatesThreshold() time.Duration {
+	return s.staleRatesThreshold
+}
+
+func (s *httpRatesSource) Fetch() (*Rates, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.httpTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, "GET", s.syncSourceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// read the entire response body to ensure full connection reuse if there's an
+		// invalid status code
+		if _, err := io.Copy(io.Discard, response.Body); err != nil {
+			logger.Errorf("error draining conversion rates response body: %v", err)
+		}
+		response.Body.Close()
+	}()
+
+	if response.StatusCode >= 400 {
+		message := fmt.Sprintf("the currency rates request failed with status code %d", response.StatusCode)
+		return nil, &errortypes.BadServerResponse{Message: message}
+	}
+
+	bytesJSON, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("the currency rates request failed: %v", err)
+	}
+
+	updatedRates := &Rates{}
+	err = jsonutil.UnmarshalValid(bytesJSON, updatedRates)
+	if err != nil {
+		return nil, fmt.Errorf("the currency rates request failed to parse json: %v", err)
+	}
+
+	return updatedRates, err
+}
+
+// fileRatesSource reads rates from a local file, in the same format as the remote currency file
+type fileRatesSource struct {
+	path                string
+	staleRatesThreshold time.Duration
+}
+
+// NewFileRatesSource returns a source reading the currencies rates from the file at the path provided
+func NewFileRatesSource(path string, staleRatesThreshold time.Duration) RatesSource {
+	return &fileRatesSource{
+		path:                path,
+		staleRatesThreshold: staleRatesThreshold,
+	}
+}
+
+func (s *fileRatesSource) Name() string {
+	return s.path
+}
+
+func (s *fileRatesSource) StaleRatesThreshold() time.Duration {
+	return s.staleRatesThreshold
+}
+
+func (s *fileRatesSource) Fetch() (*Rates, error) {
+	bytesJSON, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("the currency rates file could not be read: %v", err)
+	}
+
+	updatedRates := &Rates{}
+	if err := jsonutil.UnmarshalValid(bytesJSON, updatedRates); err != nil {
+		return nil, fmt.Errorf("the currency rates file failed to parse json: %v", err)
+	}
+	return updatedRates, nil
+}
+
+// staticRatesSource always provides the rates it was configured with, they are never stale
+type staticRatesSource struct {
+	rates *Rates
+}
+
+// NewStaticRatesSource returns a source providing the conversions from the config
+// Currency codes are upper cased as config keys may have been lower cased when loaded
+func NewStaticRatesSource(conversions map[string]map[string]float64) RatesSource {
+	normalized := make(map[string]map[string]float64, len(conversions))
+	for from, rates := range conversions {
+		normalizedRates := make(map[string]float64, len(rates))
+		for to, rate := range rates {
+			normalizedRates[strings.ToUpper(to)] = rate
+		}
+		normalized[strings.ToUpper(from)] = normalizedRates
+	}
+	return &staticRatesSource{
+		rates: NewRates(normalized),
+	}
+}
+
+func (s *staticRatesSource) Name() string {
+	return staticRatesSourceName
+}
+
+func (s *staticRatesSource) StaleRatesThreshold() time.Duration {
+	return 0
+}
+
+func (s *staticRatesSource) Fetch() (*Rates, error) {
+	return s.rates, nil
+}
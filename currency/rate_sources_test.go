@@ -0,0 +1,67 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRatesSource(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "rates.json")
+	invalidPath := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(validPath, getMockRates(), 0644))
+	assert.NoError(t, os.WriteFile(invalidPath, []byte(`{"conversions": Invalid-JSON}`), 0644))
+
+	tests := []struct {
+		description     string
+		givePath        string
+		wantErr         bool
+		wantConversions map[string]map[string]float64
+	}{
+		{
+			description:     "Valid rates file",
+			givePath:        validPath,
+			wantConversions: map[string]map[string]float64{"USD": {"GBP": 0.77208}, "GBP": {"USD": 1.2952}},
+		},
+		{
+			description: "Missing rates file",
+			givePath:    filepath.Join(dir, "missing.json"),
+			wantErr:     true,
+		},
+		{
+			description: "Invalid json rates file",
+			givePath:    invalidPath,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		source := NewFileRatesSource(tt.givePath, time.Hour)
+		rates, err := source.Fetch()
+
+		assert.Equal(t, tt.givePath, source.Name(), tt.description)
+		assert.Equal(t, time.Hour, source.StaleRatesThreshold(), tt.description)
+		if tt.wantErr {
+			assert.NotNil(t, err, tt.description)
+			assert.Nil(t, rates, tt.description)
+		} else {
+			assert.Nil(t, err, tt.description)
+			assert.Equal(t, tt.wantConversions, rates.Conversions, tt.description)
+		}
+	}
+}
+
+func TestStaticRatesSource(t *testing.T) {
+	source := NewStaticRatesSource(map[string]map[string]float64{"usd": {"eur": 0.9}})
+
+	rates, err := source.Fetch()
+
+	assert.Nil(t, err)
+	assert.Equal(t, NewRates(map[string]map[string]float64{"USD": {"EUR": 0.9}}), rates)
+	assert.Equal(t, "static", source.Name())
+	assert.Equal(t, time.Duration(0), source.StaleRatesThreshold())
+}
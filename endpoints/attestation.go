@@ -1,9 +1,18 @@
 package endpoints
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"time"
 
 	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
@@ -12,20 +21,187 @@ import (
 
 const attestationEndpointValueNotSet = "not-set"
 
-// NewAttestationEndpoint returns build signature information for attestation purposes
-func NewAttestationEndpoint() http.HandlerFunc {
-	response, err := prepareAttestationEndpointResponse()
+// Sources of the instance key, reported so clients know what a statement signature proves
+const (
+	instanceKeyProvisioned = "provisioned"
+	instanceKeyEphemeral   = "ephemeral"
+)
+
+// attestationNonce limits client nonces to base64, base64url or hex text of a reasonable length
+var attestationNonce = regexp.MustCompile(`^[A-Za-z0-9+/=_-]{16,128}$`)
+
+// AttestationRuntime describes the running instance covered by nonce challenge responses.
+type AttestationRuntime struct {
+	Bidders      []string
+	Modules      []string
+	ConfigDigest string
+}
+
+type attestationResponse struct {
+	BuildSignature    string `json:"build_signature"`
+	Version           string `json:"version"`
+	Revision          string `json:"revision"`
+	BuildTimestamp    string `json:"build_timestamp"`
+	SignaturePayload  string `json:"signature_payload"`
+	PayloadFormat     string `json:"payload_format"`
+	InstancePublicKey string `json:"instance_public_key"`
+	InstanceKeySource string `json:"instance_key_source"`
+	// Statement is the exact JSON text of an instanceStatement, set in reply to a nonce
+	Statement          string `json:"statement,omitempty"`
+	StatementSignature string `json:"statement_signature,omitempty"`
+}
+
+// instanceStatement is what the instance key signs in reply to a nonce. The build fields are what
+// the instance reports about itself; nothing ties the instance key to the build, so they are only as
+// trustworthy as the key. A provisioned key can be checked against its published public key, while a
+// generated one only proves that the same process answered every nonce signed with it.
+type instanceStatement struct {
+	Nonce          string   `json:"nonce"`
+	Timestamp      string   `json:"timestamp"`
+	PublicKey      string   `json:"public_key"`
+	KeySource      string   `json:"key_source"`
+	Revision       string   `json:"revision"`
+	BuildTimestamp string   `json:"build_timestamp"`
+	Bidders        []string `json:"bidders"`
+	Modules        []string `json:"modules"`
+	ConfigDigest   string   `json:"config_digest"`
+}
+
+type attestationEndpoint struct {
+	build      attestationResponse
+	runtime    AttestationRuntime
+	key        ed25519.PrivateKey
+	staticBody json.RawMessage
+	now        func() time.Time
+}
+
+// NewAttestationEndpoint returns build signature information for attestation purposes. When called
+// with a nonce query parameter it also returns a statement about the running instance signed by the
+// instance key, read from instanceKeyFile or generated at startup when it is empty.
+func NewAttestationEndpoint(runtime AttestationRuntime, instanceKeyFile string) http.HandlerFunc {
+	key, keySource, err := loadInstanceKey(instanceKeyFile)
+	if err != nil {
+		logger.Fatalf("error creating /attestation endpoint instance key: %v", err)
+	}
+
+	endpoint, err := newAttestationEndpoint(runtime, key, keySource)
 	if err != nil {
 		logger.Fatalf("error creating /attestation endpoint response: %v", err)
 	}
 
-	return func(w http.ResponseWriter, _ *http.Request) {
+	return endpoint.handle
+}
+
+// loadInstanceKey reads the PKCS#8 Ed25519 private key in path, or generates one when path is empty.
+func loadInstanceKey(path string) (ed25519.PrivateKey, string, error) {
+	if path == "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, instanceKeyEphemeral, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, "", fmt.Errorf("%s has no PEM PRIVATE KEY block", path)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid private key in %s: %v", path, err)
+	}
+
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, "", fmt.Errorf("private key in %s is %T, not Ed25519", path, parsed)
+	}
+	return key, instanceKeyProvisioned, nil
+}
+
+func newAttestationEndpoint(runtime AttestationRuntime, key ed25519.PrivateKey, keySource string) (*attestationEndpoint, error) {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	runtime.Bidders = sortedCopy(runtime.Bidders)
+	runtime.Modules = sortedCopy(runtime.Modules)
+
+	endpoint := &attestationEndpoint{
+		build:   prepareBuildAttestation(),
+		runtime: runtime,
+		key:     key,
+		now:     time.Now,
+	}
+	endpoint.build.InstancePublicKey = base64.StdEncoding.EncodeToString(der)
+	endpoint.build.InstanceKeySource = keySource
+
+	endpoint.staticBody, err = jsonutil.Marshal(endpoint.build)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (e *attestationEndpoint) handle(w http.ResponseWriter, r *http.Request) {
+	nonce := ""
+	if r != nil {
+		nonce = r.URL.Query().Get("nonce")
+	}
+
+	if nonce == "" {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write(response)
+		w.Write(e.staticBody)
+		return
+	}
+
+	if !attestationNonce.MatchString(nonce) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("nonce must be 16 to 128 base64, base64url or hex characters"))
+		return
 	}
+
+	response, err := e.challengeResponse(nonce)
+	if err != nil {
+		logger.Errorf("error creating /attestation challenge response: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(response)
+}
+
+// challengeResponse signs a statement for nonce. The statement is returned as the exact JSON text
+// that was signed, so clients verify the signature before decoding it.
+func (e *attestationEndpoint) challengeResponse(nonce string) (json.RawMessage, error) {
+	statement, err := jsonutil.Marshal(instanceStatement{
+		Nonce:          nonce,
+		Timestamp:      e.now().UTC().Format(time.RFC3339),
+		PublicKey:      e.build.InstancePublicKey,
+		KeySource:      e.build.InstanceKeySource,
+		Revision:       e.build.Revision,
+		BuildTimestamp: e.build.BuildTimestamp,
+		Bidders:        e.runtime.Bidders,
+		Modules:        e.runtime.Modules,
+		ConfigDigest:   e.runtime.ConfigDigest,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := e.build
+	response.Statement = string(statement)
+	response.StatementSignature = base64.StdEncoding.EncodeToString(ed25519.Sign(e.key, statement))
+	return jsonutil.Marshal(response)
 }
 
-func prepareAttestationEndpointResponse() (json.RawMessage, error) {
+func prepareBuildAttestation() attestationResponse {
 	buildSignature := version.BuildSignature
 	if buildSignature == "" {
 		buildSignature = attestationEndpointValueNotSet
@@ -53,17 +229,19 @@ func prepareAttestationEndpointResponse() (json.RawMessage, error) {
 		signaturePayload = fmt.Sprintf("%s:%s:openads-server-build", revision, buildTimestamp)
 	}
 
-	return jsonutil.Marshal(struct {
-		BuildSignature   string `json:"build_signature"`
-		Version          string `json:"version"`
-		Revision         string `json:"revision"`
-		SignaturePayload string `json:"signature_payload"`
-		PayloadFormat    string `json:"payload_format"`
-	}{
+	return attestationResponse{
 		BuildSignature:   buildSignature,
 		Version:          versionStr,
 		Revision:         revision,
+		BuildTimestamp:   buildTimestamp,
 		SignaturePayload: signaturePayload,
 		PayloadFormat:    "<commit-hash>:<timestamp>:openads-server-build",
-	})
+	}
+}
+
+func sortedCopy(values []string) []string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.Strings(sorted)
+	return sorted
 }
@@ -0,0 +1,152 @@
+package endpoints
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAttestationEndpoint(t *testing.T) *attestationEndpoint {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	endpoint, err := newAttestationEndpoint(AttestationRuntime{
+		Bidders:      []string{"rubicon", "appnexus"},
+		Modules:      []string{"openads.signatures"},
+		ConfigDigest: "config-digest",
+	}, key, instanceKeyEphemeral)
+	require.NoError(t, err)
+	endpoint.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return endpoint
+}
+
+func TestAttestationWithoutNonce(t *testing.T) {
+	endpoint := newTestAttestationEndpoint(t)
+
+	w := httptest.NewRecorder()
+	endpoint.handle(w, httptest.NewRequest(http.MethodGet, "/attestation", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response attestationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, attestationEndpointValueNotSet, response.BuildSignature)
+	assert.Equal(t, "<commit-hash>:<timestamp>:openads-server-build", response.PayloadFormat)
+	assert.NotEmpty(t, response.InstancePublicKey)
+	assert.Equal(t, instanceKeyEphemeral, response.InstanceKeySource)
+	assert.Empty(t, response.Statement)
+	assert.Empty(t, response.StatementSignature)
+}
+
+func TestAttestationBuildPayload(t *testing.T) {
+	defer func(sig, rev, ts string) {
+		version.BuildSignature, version.Rev, version.BuildTimestamp = sig, rev, ts
+	}(version.BuildSignature, version.Rev, version.BuildTimestamp)
+	version.BuildSignature, version.Rev, version.BuildTimestamp = "c2lnbmF0dXJl", "abc123", "2025-01-01T00:00:00Z"
+
+	response := prepareBuildAttestation()
+	assert.Equal(t, "abc123:2025-01-01T00:00:00Z:openads-server-build", response.SignaturePayload)
+	assert.Equal(t, "2025-01-01T00:00:00Z", response.BuildTimestamp)
+}
+
+func TestAttestationWithNonce(t *testing.T) {
+	endpoint := newTestAttestationEndpoint(t)
+
+	nonce := "bm9uY2Utbm9uY2Utbm9uY2U="
+	w := httptest.NewRecorder()
+	endpoint.handle(w, httptest.NewRequest(http.MethodGet, "/attestation?nonce="+nonce, nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	var response attestationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+	der, err := base64.StdEncoding.DecodeString(response.InstancePublicKey)
+	require.NoError(t, err)
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	require.NoError(t, err)
+
+	signature, err := base64.StdEncoding.DecodeString(response.StatementSignature)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey.(ed25519.PublicKey), []byte(response.Statement), signature))
+
+	var statement instanceStatement
+	require.NoError(t, json.Unmarshal([]byte(response.Statement), &statement))
+	assert.Equal(t, instanceStatement{
+		Nonce:          nonce,
+		Timestamp:      "2025-01-02T03:04:05Z",
+		PublicKey:      response.InstancePublicKey,
+		KeySource:      instanceKeyEphemeral,
+		Revision:       versionEndpointValueNotSet,
+		BuildTimestamp: attestationEndpointValueNotSet,
+		Bidders:        []string{"appnexus", "rubicon"},
+		Modules:        []string{"openads.signatures"},
+		ConfigDigest:   "config-digest",
+	}, statement)
+}
+
+func TestAttestationInvalidNonce(t *testing.T) {
+	endpoint := newTestAttestationEndpoint(t)
+
+	for _, nonce := range []string{"short", "has%20space%20characters%20in%20it", strings.Repeat("a", 129)} {
+		w := httptest.NewRecorder()
+		endpoint.handle(w, httptest.NewRequest(http.MethodGet, "/attestation?nonce="+nonce, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, nonce)
+	}
+}
+
+func TestLoadInstanceKey(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+
+	key, source, err := loadInstanceKey("")
+	require.NoError(t, err)
+	assert.Equal(t, instanceKeyEphemeral, source)
+	assert.Len(t, key, ed25519.PrivateKeySize)
+
+	key, source, err = loadInstanceKey(writeFile("instance.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER})))
+	require.NoError(t, err)
+	assert.Equal(t, instanceKeyProvisioned, source)
+	assert.Equal(t, edKey, key)
+
+	_, _, err = loadInstanceKey(writeFile("ec.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER})))
+	assert.ErrorContains(t, err, "not Ed25519")
+
+	_, _, err = loadInstanceKey(writeFile("empty.pem", []byte("not a key")))
+	assert.ErrorContains(t, err, "no PEM PRIVATE KEY block")
+
+	_, _, err = loadInstanceKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
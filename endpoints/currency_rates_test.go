@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -219,3 +220,29 @@ func newRateConverterMockWithNilInfo() rateConverterMock {
 		shouldReturnNilInfo: true,
 	}
 }
+
+func TestCurrencyRatesEndpointRatesSources(t *testing.T) {
+	converter := currency.NewRateConverterWithSources([]currency.RatesSource{
+		currency.NewFileRatesSource("/does/not/exist.json", time.Hour),
+		currency.NewStaticRatesSource(map[string]map[string]float64{"USD": {"EUR": 0.9}}),
+	}, 10)
+	converter.Run()
+
+	handler := NewCurrencyRatesEndpoint(converter, time.Minute)
+	w := httptest.NewRecorder()
+	handler(w, nil)
+
+	var info struct {
+		Source         string                    `json:"source"`
+		AdditionalInfo currency.RatesSourcesInfo `json:"additionalInfo"`
+	}
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, jsonutil.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, "static", info.Source)
+	assert.Equal(t, []string{"/does/not/exist.json", "static"}, info.AdditionalInfo.Sources)
+	assert.Equal(t, "static", info.AdditionalInfo.ActiveSource)
+	if assert.NotNil(t, info.AdditionalInfo.LastValidation) {
+		assert.Equal(t, "static", info.AdditionalInfo.LastValidation.Source)
+		assert.True(t, info.AdditionalInfo.LastValidation.Valid)
+	}
+}
@@ -0,0 +1,38 @@
+package endpoints
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// NewEffectiveConfigEndpoint serves the redacted effective configuration to callers presenting token
+// as a bearer token. The body is the exact document whose SHA-256 the /attestation endpoint reports
+// as config_digest, which is also returned in the X-Config-Digest header.
+func NewEffectiveConfigEndpoint(document json.RawMessage, token string) http.HandlerFunc {
+	sum := sha256.Sum256(document)
+	digest := hex.EncodeToString(sum[:])
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !validBearerToken(r, token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Config-Digest", digest)
+		w.Write(document)
+	}
+}
+
+func validBearerToken(r *http.Request, token string) bool {
+	scheme, presented, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
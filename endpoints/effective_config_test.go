@@ -0,0 +1,62 @@
+package endpoints
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveConfigEndpoint(t *testing.T) {
+	document := []byte(`{"host":"localhost","port":8000}`)
+	sum := sha256.Sum256(document)
+	handler := NewEffectiveConfigEndpoint(document, "admin-token")
+
+	testCases := []struct {
+		description   string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			description:  "No authorization",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description:   "Wrong token",
+			authorization: "Bearer other-token",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			description:   "Wrong scheme",
+			authorization: "Basic admin-token",
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			description:   "Valid token",
+			authorization: "Bearer admin-token",
+			expectedCode:  http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/config", nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		w := httptest.NewRecorder()
+
+		handler(w, req)
+
+		assert.Equal(t, test.expectedCode, w.Code, test.description)
+		if test.expectedCode == http.StatusOK {
+			assert.Equal(t, string(document), w.Body.String(), test.description)
+			assert.Equal(t, hex.EncodeToString(sum[:]), w.Header().Get("X-Config-Digest"), test.description)
+		} else {
+			assert.Empty(t, w.Body.String(), test.description)
+			assert.Equal(t, `Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"), test.description)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/prebid/prebid-server/v3/floors"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// floorsLearningInfo lists the floors learning state of each account.
+type floorsLearningInfo struct {
+	Accounts map[string]floors.LearningStatus `json:"accounts"`
+}
+
+// NewFloorsLearningEndpoint returns the floors learned from the clearing prices of the account query parameter,
+// or of all accounts when it is missing, with the shadow mode statistics of the accounts learning in shadow mode.
+func NewFloorsLearningEndpoint(learner *floors.Learner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		account := r.URL.Query().Get("account")
+		status := learner.Status(account)
+		if len(account) > 0 && len(status) == 0 {
+			http.Error(w, "no floors learning for account "+account, http.StatusNotFound)
+			return
+		}
+
+		jsonOutput, err := jsonutil.Marshal(floorsLearningInfo{Accounts: status})
+		if err != nil {
+			logger.Errorf("/floors/learning Critical error when trying to marshal floorsLearningInfo: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonOutput)
+	}
+}
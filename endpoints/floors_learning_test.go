@@ -0,0 +1,78 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/floors"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloorsLearningEndpoint(t *testing.T) {
+	testCases := []struct {
+		description    string
+		method         string
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			description:    "all-accounts",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"accounts":{"acc":{"shadow_mode":false,"samples":1,"keys":1}}}`,
+		},
+		{
+			description:    "one-account",
+			method:         http.MethodGet,
+			query:          "?account=acc",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"accounts":{"acc":{"shadow_mode":false,"samples":1,"keys":1}}}`,
+		},
+		{
+			description:    "unknown-account",
+			method:         http.MethodGet,
+			query:          "?account=unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "no floors learning for account unknown\n",
+		},
+		{
+			description:    "post-not-allowed",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	learner := floors.NewLearner(config.PriceFloorLearning{Enabled: true, RefreshPeriodSec: 300, SampleSize: 10, MaxRules: 10, MaxAccounts: 10}, nil)
+	defer learner.Stop()
+
+	account := config.Account{
+		ID: "acc",
+		PriceFloors: config.AccountPriceFloors{
+			Learning: config.AccountFloorLearning{Enabled: true, Schema: []string{"mediaType"}, Percentile: 50, MinSamples: 1},
+		},
+	}
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Imp: []openrtb2.Imp{{ID: "imp-1", Banner: &openrtb2.Banner{}}}}}
+	learner.ObserveAuction(request, account, map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"pubmatic": {
+			Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid", ImpID: "imp-1", Price: 1}}},
+			Currency: "USD",
+		},
+	}, currency.NewRates(nil))
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewFloorsLearningEndpoint(learner)(w, httptest.NewRequest(test.method, "/floors/learning"+test.query, nil))
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			assert.Equal(t, test.expectedBody, w.Body.String())
+		})
+	}
+}
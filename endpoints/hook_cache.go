@@ -0,0 +1,43 @@
+package endpoints
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// hookCachePurgeInfo lists the number of entries purged from the caches of each module.
+type hookCachePurgeInfo struct {
+	Purged map[string]int `json:"purged"`
+}
+
+// NewHookCachePurgeEndpoint purges the caches of the module query parameter, or of all modules when it is missing.
+// It only accepts POST requests and answers with the number of entries purged per module.
+func NewHookCachePurgeEndpoint(caches *hookcache.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		module := r.URL.Query().Get("module")
+		if len(module) > 0 && !slices.Contains(caches.Modules(), module) {
+			http.Error(w, "no cache for module "+module, http.StatusNotFound)
+			return
+		}
+
+		jsonOutput, err := jsonutil.Marshal(hookCachePurgeInfo{Purged: caches.Purge(module)})
+		if err != nil {
+			logger.Errorf("/hooks/cache/purge Critical error when trying to marshal hookCachePurgeInfo: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonOutput)
+	}
+}
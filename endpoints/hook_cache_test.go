@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookCachePurgeEndpoint(t *testing.T) {
+	testCases := []struct {
+		description    string
+		method         string
+		query          string
+		expectedStatus int
+		expectedBody   string
+		expectedLens   map[string]int
+	}{
+		{
+			description:    "purge-all-modules",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"purged":{"vendor.first":2,"vendor.second":1}}`,
+			expectedLens:   map[string]int{"vendor.first": 0, "vendor.second": 0},
+		},
+		{
+			description:    "purge-one-module",
+			method:         http.MethodPost,
+			query:          "?module=vendor.first",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"purged":{"vendor.first":2}}`,
+			expectedLens:   map[string]int{"vendor.first": 0, "vendor.second": 1},
+		},
+		{
+			description:    "unknown-module",
+			method:         http.MethodPost,
+			query:          "?module=vendor.unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "no cache for module vendor.unknown\n",
+			expectedLens:   map[string]int{"vendor.first": 2, "vendor.second": 1},
+		},
+		{
+			description:    "get-not-allowed",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedLens:   map[string]int{"vendor.first": 2, "vendor.second": 1},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			caches, err := hookcache.NewRegistry(nil)
+			require.NoError(t, err)
+
+			first := caches.NewCache("vendor.first", 1024, 0)
+			first.Set("a", []byte("1"))
+			first.Set("b", []byte("2"))
+			second := caches.NewCache("vendor.second", 1024, 0)
+			second.Set("a", []byte("1"))
+
+			w := httptest.NewRecorder()
+			NewHookCachePurgeEndpoint(caches)(w, httptest.NewRequest(test.method, "/hooks/cache/purge"+test.query, nil))
+
+			assert.Equal(t, test.expectedStatus, w.Code)
+			assert.Equal(t, test.expectedBody, w.Body.String())
+			assert.Equal(t, test.expectedLens, map[string]int{"vendor.first": first.Len(), "vendor.second": second.Len()})
+		})
+	}
+}
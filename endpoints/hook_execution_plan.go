@@ -0,0 +1,117 @@
+package endpoints
+
+import (
+	"net/http"
+	"slices"
+
+	accountService "github.com/prebid/prebid-server/v3/account"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/stored_requests"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// hookExecutionPlanInfo is the hook execution plan resolved for an account and endpoint.
+type hookExecutionPlanInfo struct {
+	Account  string          `json:"account,omitempty"`
+	Endpoint string          `json:"endpoint"`
+	Stages   []hookStageInfo `json:"stages"`
+	// Errors lists the problems found in the account-level plan, whose hooks are skipped when the plan is built
+	Errors []string `json:"errors,omitempty"`
+}
+
+type hookStageInfo struct {
+	Stage  hooks.Stage     `json:"stage"`
+	Groups []hookGroupInfo `json:"groups"`
+}
+
+type hookGroupInfo struct {
+	TimeoutMs       int64      `json:"timeout_ms"`
+	RejectOnTimeout bool       `json:"reject_on_timeout"`
+	Hooks           []hookInfo `json:"hooks"`
+}
+
+type hookInfo struct {
+	ModuleCode   string   `json:"module_code"`
+	HookImplCode string   `json:"hook_impl_code"`
+	RunAfter     []string `json:"run_after,omitempty"`
+	config.HookToggle
+}
+
+// NewHookExecutionPlanEndpoint returns the hooks that run for the endpoint and optional account query parameters,
+// grouped by stage in execution order: the host-level plan followed by the account-level plan, or by the default
+// account plan when the account has none. Problems found in the account-level plan are listed as errors.
+func NewHookExecutionPlanEndpoint(cfg *config.Configuration, repo hooks.HookRepository, accounts stored_requests.AccountFetcher, endpoints []string, me metrics.MetricsEngine) http.HandlerFunc {
+	planBuilder := hooks.NewExecutionPlanBuilder(cfg.Hooks, repo)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		endpoint := query.Get("endpoint")
+		if !slices.Contains(endpoints, endpoint) {
+			http.Error(w, "endpoint must be one of the hook endpoints", http.StatusBadRequest)
+			return
+		}
+
+		info := hookExecutionPlanInfo{
+			Account:  query.Get("account"),
+			Endpoint: endpoint,
+		}
+
+		var account *config.Account
+		if len(info.Account) > 0 {
+			var errs []error
+			account, errs = accountService.GetAccount(r.Context(), cfg, accounts, info.Account, me)
+			if account == nil {
+				http.Error(w, "failed to load account: "+errs[0].Error(), http.StatusBadRequest)
+				return
+			}
+			if account.Hooks.ExecutionPlan.Endpoints != nil {
+				for _, err := range hooks.ValidateExecutionPlan(account.Hooks.ExecutionPlan, repo, endpoints) {
+					info.Errors = append(info.Errors, err.Error())
+				}
+			}
+		}
+
+		info.Stages = []hookStageInfo{
+			newHookStageInfo(hooks.StageEntrypoint, planBuilder.PlanForEntrypointStage(endpoint)),
+			newHookStageInfo(hooks.StageRawAuctionRequest, planBuilder.PlanForRawAuctionStage(endpoint, account)),
+			newHookStageInfo(hooks.StageProcessedAuctionRequest, planBuilder.PlanForProcessedAuctionStage(endpoint, account)),
+			newHookStageInfo(hooks.StageBidderRequest, planBuilder.PlanForBidderRequestStage(endpoint, account)),
+			newHookStageInfo(hooks.StageRawBidderResponse, planBuilder.PlanForRawBidderResponseStage(endpoint, account)),
+			newHookStageInfo(hooks.StageAllProcessedBidResponses, planBuilder.PlanForAllProcessedBidResponsesStage(endpoint, account)),
+			newHookStageInfo(hooks.StageAuctionResponse, planBuilder.PlanForAuctionResponseStage(endpoint, account)),
+			newHookStageInfo(hooks.StageExitpoint, planBuilder.PlanForExitpointStage(endpoint, account)),
+		}
+
+		jsonOutput, err := jsonutil.Marshal(info)
+		if err != nil {
+			logger.Errorf("/hooks/execution_plan Critical error when trying to marshal hookExecutionPlanInfo: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonOutput)
+	}
+}
+
+func newHookStageInfo[T any](stage hooks.Stage, plan hooks.Plan[T]) hookStageInfo {
+	stageInfo := hookStageInfo{
+		Stage:  stage,
+		Groups: make([]hookGroupInfo, 0, len(plan)),
+	}
+	for _, group := range plan {
+		groupInfo := hookGroupInfo{
+			TimeoutMs:       group.Timeout.Milliseconds(),
+			RejectOnTimeout: group.RejectOnTimeout,
+			Hooks:           make([]hookInfo, 0, len(group.Hooks)),
+		}
+		for _, hook := range group.Hooks {
+			groupInfo.Hooks = append(groupInfo.Hooks, hookInfo{ModuleCode: hook.Module, HookImplCode: hook.Code, RunAfter: hook.RunAfter, HookToggle: hook.Toggle})
+		}
+		stageInfo.Groups = append(stageInfo.Groups, groupInfo)
+	}
+	return stageInfo
+}
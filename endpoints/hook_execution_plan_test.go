@@ -0,0 +1,142 @@
+package endpoints
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	metricsConf "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookExecutionPlanEndpoint(t *testing.T) {
+	repo, err := hooks.NewHookRepository(map[string]interface{}{
+		"vendor.module": fakeExecutionPlanHook{},
+	})
+	require.NoError(t, err)
+
+	cfg := &config.Configuration{Hooks: config.Hooks{Enabled: true}}
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(`{"endpoints": {"/openrtb2/auction": {"stages": {
+		"entrypoint": {"groups": [{"timeout": 5, "hook_sequence": [{"module_code": "vendor.module", "hook_impl_code": "host"}]}]}
+	}}}}`), &cfg.Hooks.HostExecutionPlan))
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(`{"endpoints": {"/openrtb2/auction": {"stages": {
+		"raw_auction_request": {"groups": [{"timeout": 10, "hook_sequence": [{"module_code": "vendor.module", "hook_impl_code": "default"}]}]}
+	}}}}`), &cfg.Hooks.DefaultAccountExecutionPlan))
+
+	accounts := FakeAccountsFetcher{AccountData: map[string]json.RawMessage{
+		"with-plan": json.RawMessage(`{"hooks": {"execution_plan": {"endpoints": {"/openrtb2/auction": {"stages": {
+			"raw_auction_request": {"groups": [{"timeout": 20, "reject_on_timeout": true, "hook_sequence": [
+				{"module_code": "vendor.module", "hook_impl_code": "account", "sample_percent": 10},
+				{"module_code": "vendor.modlue", "hook_impl_code": "typo"}
+			]}]}
+		}}}}}}`),
+		"without-plan": json.RawMessage(`{}`),
+		"disabled":     json.RawMessage(`{"disabled": true}`),
+	}}
+
+	samplePercent := 10.0
+	handler := NewHookExecutionPlanEndpoint(cfg, repo, accounts, []string{"/openrtb2/auction", "/openrtb2/amp"}, &metricsConf.NilMetricsEngine{})
+
+	hostEntrypoint := hookStageInfo{Stage: hooks.StageEntrypoint, Groups: []hookGroupInfo{
+		{TimeoutMs: 5, Hooks: []hookInfo{{ModuleCode: "vendor.module", HookImplCode: "host"}}},
+	}}
+	withRawAuction := func(groups ...hookGroupInfo) []hookStageInfo {
+		stages := []hookStageInfo{hostEntrypoint, {Stage: hooks.StageRawAuctionRequest, Groups: groups}}
+		for _, stage := range hooks.Stages[2:] {
+			stages = append(stages, hookStageInfo{Stage: stage, Groups: []hookGroupInfo{}})
+		}
+		return stages
+	}
+
+	testCases := []struct {
+		description  string
+		query        string
+		expectedCode int
+		expectedPlan hookExecutionPlanInfo
+	}{
+		{
+			description:  "No account uses the default account plan",
+			query:        "?endpoint=/openrtb2/auction",
+			expectedCode: http.StatusOK,
+			expectedPlan: hookExecutionPlanInfo{
+				Endpoint: "/openrtb2/auction",
+				Stages: withRawAuction(hookGroupInfo{TimeoutMs: 10, Hooks: []hookInfo{
+					{ModuleCode: "vendor.module", HookImplCode: "default"},
+				}}),
+			},
+		},
+		{
+			description:  "Account without plan uses the default account plan",
+			query:        "?endpoint=/openrtb2/auction&account=without-plan",
+			expectedCode: http.StatusOK,
+			expectedPlan: hookExecutionPlanInfo{
+				Account:  "without-plan",
+				Endpoint: "/openrtb2/auction",
+				Stages: withRawAuction(hookGroupInfo{TimeoutMs: 10, Hooks: []hookInfo{
+					{ModuleCode: "vendor.module", HookImplCode: "default"},
+				}}),
+			},
+		},
+		{
+			description:  "Account plan replaces the default account plan and is validated",
+			query:        "?endpoint=/openrtb2/auction&account=with-plan",
+			expectedCode: http.StatusOK,
+			expectedPlan: hookExecutionPlanInfo{
+				Account:  "with-plan",
+				Endpoint: "/openrtb2/auction",
+				Stages: withRawAuction(hookGroupInfo{TimeoutMs: 20, RejectOnTimeout: true, Hooks: []hookInfo{
+					{ModuleCode: "vendor.module", HookImplCode: "account", HookToggle: config.HookToggle{SamplePercent: &samplePercent}},
+				}}),
+				Errors: []string{
+					`endpoint "/openrtb2/auction": stage "raw_auction_request": group 0: hook 1 (typo): module "vendor.modlue" is not registered or not enabled`,
+				},
+			},
+		},
+		{
+			description:  "Missing endpoint",
+			query:        "?account=with-plan",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Unknown endpoint",
+			query:        "?endpoint=/openrtb2/video",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Disabled account",
+			query:        "?endpoint=/openrtb2/auction&account=disabled",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range testCases {
+		w := httptest.NewRecorder()
+
+		handler(w, httptest.NewRequest(http.MethodGet, "/hooks/execution_plan"+test.query, nil))
+
+		assert.Equal(t, test.expectedCode, w.Code, test.description)
+		if test.expectedCode == http.StatusOK {
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"), test.description)
+			var plan hookExecutionPlanInfo
+			require.NoError(t, jsonutil.UnmarshalValid(w.Body.Bytes(), &plan), test.description)
+			assert.Equal(t, test.expectedPlan, plan, test.description)
+		}
+	}
+}
+
+type fakeExecutionPlanHook struct{}
+
+func (fakeExecutionPlanHook) HandleEntrypointHook(ctx context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	return hookstage.HookResult[hookstage.EntrypointPayload]{}, nil
+}
+
+func (fakeExecutionPlanHook) HandleRawAuctionHook(ctx context.Context, _ hookstage.ModuleInvocationContext, _ hookstage.RawAuctionRequestPayload) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	return hookstage.HookResult[hookstage.RawAuctionRequestPayload]{}, nil
+}
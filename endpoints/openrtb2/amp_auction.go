@@ -185,6 +185,9 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 		ctx, cancel = context.WithDeadline(ctx, start.Add(time.Duration(defaultAmpRequestTimeoutMillis)*time.Millisecond))
 	}
 	defer cancel()
+	if deadline, ok := ctx.Deadline(); ok {
+		hookExecutor.SetDeadline(deadline)
+	}
 
 	// Read UserSyncs/Cookie from Request
 	usersyncs := usersync.ReadCookie(r, usersync.Base64Decoder{}, &deps.cfg.HostCookie)
@@ -288,6 +291,7 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 		response = auctionResponse.BidResponse
 	}
 	ao.SeatNonBid = auctionResponse.GetSeatNonBid()
+	ao.BidAdjustments = auctionResponse.GetBidAdjustments()
 	ao.AuctionResponse = response
 	rejectErr, isRejectErr := hookexecution.CastRejectErr(err)
 	if err != nil && !isRejectErr {
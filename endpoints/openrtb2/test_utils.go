@@ -1564,6 +1564,10 @@ func (m mockPlanBuilder) PlanForExitpointStage(_ string, _ *config.Account) hook
 	return m.exitpointPlan
 }
 
+func (m mockPlanBuilder) ValidateAccountPlan(_ *config.Account, _ []string) []error {
+	return nil
+}
+
 func makePlan[H any](hook H) hooks.Plan[H] {
 	return hooks.Plan[H]{
 		{
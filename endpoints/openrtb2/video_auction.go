@@ -355,6 +355,7 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 	}
 	vo.Response = response
 	vo.SeatNonBid = auctionResponse.GetSeatNonBid()
+	vo.BidAdjustments = auctionResponse.GetBidAdjustments()
 	if err != nil {
 		errL := []error{err}
 		handleError(&labels, w, errL, &vo, &debugLog)
@@ -18,3 +18,11 @@ func (ar *AuctionResponse) GetSeatNonBid() []openrtb_ext.SeatNonBid {
 	}
 	return nil
 }
+
+// GetBidAdjustments returns array of applied bid adjustments if present. nil otherwise
+func (ar *AuctionResponse) GetBidAdjustments() []openrtb_ext.AppliedBidAdjustment {
+	if ar != nil && ar.ExtBidResponse != nil && ar.ExtBidResponse.Prebid != nil {
+		return ar.ExtBidResponse.Prebid.BidAdjustments
+	}
+	return nil
+}
@@ -84,6 +84,7 @@ type bidRequestOptions struct {
 type extraBidderRespInfo struct {
 	respProcessingStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	bidAdjustments          []openrtb_ext.AppliedBidAdjustment
 }
 
 type extraAuctionResponseInfo struct {
@@ -91,6 +92,7 @@ type extraAuctionResponseInfo struct {
 	bidsFound               bool
 	bidderResponseStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	bidAdjustments          []openrtb_ext.AppliedBidAdjustment
 }
 
 const ImpIdReqBody = "Stored bid response for impression id: "
@@ -410,7 +412,11 @@ func (bidder *BidderAdapter) requestBid(ctx context.Context, bidderRequest Bidde
 							bidResponse.Bids[i].Bid.Price = bidResponse.Bids[i].Bid.Price * adjustmentFactor * conversionRate
 
 							bidType := getBidTypeForAdjustments(bidResponse.Bids[i].BidType, bidResponse.Bids[i].Bid.ImpID, bidderRequest.BidRequest.Imp)
-							bidResponse.Bids[i].Bid.Price, currencyAfterAdjustments = bidadjustment.Apply(ruleToAdjustments, bidResponse.Bids[i], bidderRequest.BidderName, seatBidMap[bidderRequest.BidderName].Currency, reqInfo, bidType)
+							var appliedAdjustment *openrtb_ext.AppliedBidAdjustment
+							bidResponse.Bids[i].Bid.Price, currencyAfterAdjustments, appliedAdjustment = bidadjustment.Apply(ruleToAdjustments, bidResponse.Bids[i], bidderRequest.BidderName, seatBidMap[bidderRequest.BidderName].Currency, reqInfo, bidType, bidderRequest.BidAdjustmentDims)
+							if appliedAdjustment != nil {
+								extraRespInfo.bidAdjustments = append(extraRespInfo.bidAdjustments, *appliedAdjustment)
+							}
 						}
 
 						if _, ok := seatBidMap[bidderName]; !ok {
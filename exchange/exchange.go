@@ -107,6 +107,7 @@ type bidResponseWrapper struct {
 	adapter                 openrtb_ext.BidderName
 	bidderResponseStartTime time.Time
 	seatNonBidBuilder       SeatNonBidBuilder
+	bidAdjustments          []openrtb_ext.AppliedBidAdjustment
 }
 
 type BidIDGenerator interface {
@@ -233,6 +234,8 @@ type BidderRequest struct {
 	BidderStoredResponses map[string]json.RawMessage
 	IsRequestAlias        bool
 	ImpReplaceImpId       map[string]bool
+	// BidAdjustmentDims are taken from the auction request, since the bidder request may have been scrubbed for privacy
+	BidAdjustmentDims bidadjustment.RequestDimensions
 }
 
 func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog *DebugLog) (*AuctionResponse, error) {
@@ -240,11 +243,27 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		return nil, nil
 	}
 
+	// Floors are resolved before the processed auction stage, so its hooks see the floors the
+	// bidders will get and can change them, for example with the rules engine setBidFloor.
+	var floorErrs []error
+	if e.priceFloorEnabled {
+		floorErrs = e.enrichWithPriceFloors(r)
+	}
+
+	tmax := r.BidRequestWrapper.TMax
 	err := r.HookExecutor.ExecuteProcessedAuctionStage(r.BidRequestWrapper)
 	if err != nil {
 		return nil, err
 	}
 
+	// Hooks may lower tmax, for example with the rules engine setTmax, so the auction deadline is
+	// shortened to match the tmax the bidders will get.
+	if r.BidRequestWrapper.TMax > 0 && (tmax <= 0 || r.BidRequestWrapper.TMax < tmax) && !r.StartTime.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, r.StartTime.Add(time.Duration(r.BidRequestWrapper.TMax)*time.Millisecond))
+		defer cancel()
+	}
+
 	requestExt, err := r.BidRequestWrapper.GetRequestExt()
 	if err != nil {
 		return nil, err
@@ -288,11 +307,6 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	// Get currency rates conversions for the auction
 	conversions := currency.GetAuctionCurrencyRates(e.currencyConverter, requestExtPrebid.CurrencyConversions)
 
-	var floorErrs []error
-	if e.priceFloorEnabled {
-		floorErrs = floors.EnrichWithPriceFloors(r.BidRequestWrapper, r.Account, conversions, e.priceFloorFetcher)
-	}
-
 	responseDebugAllow, accountDebugAllow, debugLog := getDebugInfo(r.BidRequestWrapper.Test, requestExtPrebid, r.Account.DebugAllow, debugLog)
 
 	// save incoming request with stored requests (if applicable) to return in debug logs
@@ -366,6 +380,10 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		errs = append(errs, err)
 	}
 	bidAdjustmentRules := bidadjustment.BuildRules(mergedBidAdj)
+	bidAdjustmentDims := bidadjustment.NewRequestDimensions(r.BidRequestWrapper.BidRequest, bidAdjustmentRules)
+	for i := range bidderRequests {
+		bidderRequests[i].BidAdjustmentDims = bidAdjustmentDims
+	}
 
 	e.me.RecordRequestPrivacy(privacyLabels)
 
@@ -386,6 +404,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		// List of bidders we have requests for.
 		liveAdapters      []openrtb_ext.BidderName
 		seatNonBidBuilder SeatNonBidBuilder = SeatNonBidBuilder{}
+		bidAdjustments    []openrtb_ext.AppliedBidAdjustment
 	)
 
 	if len(r.StoredAuctionResponses) > 0 {
@@ -417,6 +436,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		if extraRespInfo.seatNonBidBuilder != nil {
 			seatNonBidBuilder = extraRespInfo.seatNonBidBuilder
 		}
+		bidAdjustments = extraRespInfo.bidAdjustments
 	}
 
 	var (
@@ -430,6 +450,12 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			var rejectedBids []*entities.PbsOrtbSeatBid
 			var enforceErrs []error
 
+			// floors are learned from all the bids, those below the current floors included, so that
+			// the learned floors can go down as well as up
+			if observer, ok := e.priceFloorFetcher.(floors.AuctionObserver); ok {
+				observer.ObserveAuction(r.BidRequestWrapper, r.Account, adapterBids, conversions)
+			}
+
 			adapterBids, enforceErrs, rejectedBids = floors.Enforce(r.BidRequestWrapper, adapterBids, r.Account, conversions)
 			errs = append(errs, enforceErrs...)
 			for _, rejectedBid := range rejectedBids {
@@ -442,6 +468,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 				}
 				seatNonBidBuilder.rejectBid(rejectedBid.Bids[0], int(rejectionReason), rejectedBid.Seat)
 			}
+			e.recordBidsBelowFloor(r.PubID, adapterBids, rejectedBids, conversions)
 		}
 
 		var bidCategory map[string]string
@@ -563,11 +590,16 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		}
 	}
 
+	// applied bid adjustments are only returned in the response in debug mode, but are always available for analytics
+	if responseDebugAllow {
+		bidResponseExt = setBidAdjustments(bidResponseExt, bidAdjustments)
+	}
 	bidResponse.Ext, err = encodeBidResponseExt(bidResponseExt)
 	if err != nil {
 		return nil, err
 	}
 	bidResponseExt = setSeatNonBid(bidResponseExt, seatNonBidBuilder)
+	bidResponseExt = setBidAdjustments(bidResponseExt, bidAdjustments)
 
 	return &AuctionResponse{
 		BidResponse:    bidResponse,
@@ -890,6 +922,7 @@ func (e *exchange) getAllBids(
 			elapsed := time.Since(start)
 			brw.adapterSeatBids = seatBids
 			brw.seatNonBidBuilder = extraBidderRespInfo.seatNonBidBuilder
+			brw.bidAdjustments = extraBidderRespInfo.bidAdjustments
 			// Structure to record extra tracking data generated during bidding
 			ae := new(seatResponseExtra)
 			ae.ResponseTimeMillis = int(elapsed / time.Millisecond)
@@ -946,6 +979,9 @@ func (e *exchange) getAllBids(
 		// collect adapter non bids
 		extraRespInfo.seatNonBidBuilder.append(brw.seatNonBidBuilder)
 
+		// collect applied bid adjustments
+		extraRespInfo.bidAdjustments = append(extraRespInfo.bidAdjustments, brw.bidAdjustments...)
+
 	}
 
 	return adapterBids, adapterExtra, extraRespInfo
@@ -1412,6 +1448,52 @@ func (e *exchange) makeSeatBid(adapterBid *entities.PbsOrtbSeatBid, adapter open
 	return seatBid
 }
 
+// enrichWithPriceFloors sets the imp floors of the auction request from the resolved price floors.
+func (e *exchange) enrichWithPriceFloors(r *AuctionRequest) []error {
+	requestExt, err := r.BidRequestWrapper.GetRequestExt()
+	if err != nil {
+		return []error{err}
+	}
+
+	var requestRates *openrtb_ext.ExtRequestCurrency
+	if prebid := requestExt.GetPrebid(); prebid != nil {
+		requestRates = prebid.CurrencyConversions
+	}
+	conversions := currency.GetAuctionCurrencyRates(e.currencyConverter, requestRates)
+
+	return floors.EnrichWithPriceFloors(r.BidRequestWrapper, r.Account, conversions, e.priceFloorFetcher)
+}
+
+// recordBidsBelowFloor records the bids rejected by floors enforcement and the bids it would have rejected,
+// with their price in USD
+func (e *exchange) recordBidsBelowFloor(pubID string, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, rejectedBids []*entities.PbsOrtbSeatBid, conversions currency.Conversions) {
+	for _, rejectedBid := range rejectedBids {
+		for _, bid := range rejectedBid.Bids {
+			e.me.RecordBidBelowFloor(openrtb_ext.BidderName(rejectedBid.Seat), pubID, metrics.FloorsOutcomeRejected, priceInUSD(bid, rejectedBid.Currency, conversions))
+		}
+	}
+
+	for bidderName, seatBid := range seatBids {
+		for _, bid := range seatBid.Bids {
+			if bid.BidFloors != nil && bid.BidFloors.WouldReject {
+				e.me.RecordBidBelowFloor(bidderName, pubID, metrics.FloorsOutcomeWouldReject, priceInUSD(bid, seatBid.Currency, conversions))
+			}
+		}
+	}
+}
+
+// priceInUSD converts the bid price to USD, or returns 0 when the rate is unknown
+func priceInUSD(bid *entities.PbsOrtbBid, bidCurrency string, conversions currency.Conversions) float64 {
+	if bidCurrency == "" || bidCurrency == "USD" {
+		return bid.Bid.Price
+	}
+	rate, err := conversions.GetRate(bidCurrency, "USD")
+	if err != nil {
+		return 0
+	}
+	return rate * bid.Bid.Price
+}
+
 func (e *exchange) makeBid(bids []*entities.PbsOrtbBid, auc *auction, returnCreativeBids bool, returnCreativeVast bool, impExtInfoMap map[string]ImpExtInfo, bidRequest *openrtb_ext.RequestWrapper, bidResponseExt *openrtb_ext.ExtBidResponse, adapter openrtb_ext.BidderName, pubID string, seatNonBidBuilder *SeatNonBidBuilder) ([]openrtb2.Bid, []error) {
 	result := make([]openrtb2.Bid, 0, len(bids))
 	errs := make([]error, 0, 1)
@@ -1744,3 +1826,19 @@ func setSeatNonBid(bidResponseExt *openrtb_ext.ExtBidResponse, seatNonBidBuilder
 	bidResponseExt.Prebid.SeatNonBid = seatNonBidBuilder.Slice()
 	return bidResponseExt
 }
+
+// setBidAdjustments adds the applied bid adjustments within bidResponse.Ext.Prebid.BidAdjustments
+func setBidAdjustments(bidResponseExt *openrtb_ext.ExtBidResponse, bidAdjustments []openrtb_ext.AppliedBidAdjustment) *openrtb_ext.ExtBidResponse {
+	if len(bidAdjustments) == 0 {
+		return bidResponseExt
+	}
+	if bidResponseExt == nil {
+		bidResponseExt = &openrtb_ext.ExtBidResponse{}
+	}
+	if bidResponseExt.Prebid == nil {
+		bidResponseExt.Prebid = &openrtb_ext.ExtResponsePrebid{}
+	}
+
+	bidResponseExt.Prebid.BidAdjustments = bidAdjustments
+	return bidResponseExt
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/buger/jsonparser"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/bidadjustment"
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/currency"
 	"github.com/prebid/prebid-server/v3/errortypes"
@@ -1000,6 +1001,295 @@ func TestFloorsSignalling(t *testing.T) {
 
 }
 
+// floorSettingHookExecutor sets the first imp floor in the processed auction stage
+type floorSettingHookExecutor struct {
+	hookexecution.EmptyHookExecutor
+	bidFloor float64
+}
+
+func (e floorSettingHookExecutor) ExecuteProcessedAuctionStage(req *openrtb_ext.RequestWrapper) error {
+	req.Imp[0].BidFloor = e.bidFloor
+	return nil
+}
+
+func TestFloorsResolvedBeforeProcessedAuctionStage(t *testing.T) {
+	e := exchange{
+		cache: &wellBehavedCache{},
+		me:    &metricsConf.NilMetricsEngine{},
+		gdprPermsBuilder: fakePermissionsBuilder{
+			permissions: &permissionsMock{
+				allowAllBidders: true,
+			},
+		}.Builder,
+		currencyConverter: currency.NewRateConverter(&http.Client{}, 0, "", 0),
+		categoriesFetcher: nilCategoryFetcher{},
+		bidIDGenerator:    &fakeBidIDGenerator{GenerateBidID: false, ReturnError: false},
+		priceFloorEnabled: true,
+		priceFloorFetcher: &mockPriceFloorFetcher{},
+	}
+	e.requestSplitter = requestSplitter{
+		me:               e.me,
+		gdprPermsBuilder: e.gdprPermsBuilder,
+	}
+
+	req := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+		ID: "some-request-id",
+		Imp: []openrtb2.Imp{{
+			ID:     "some-impression-id",
+			Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+			Ext:    json.RawMessage(`{"prebid":{}}`),
+		}},
+		Site: &openrtb2.Site{
+			Page:   "prebid.org",
+			Domain: "www.website.com",
+		},
+		Cur: []string{"USD"},
+		Ext: json.RawMessage(`{"prebid":{"floors":{"data":{"currency":"USD","modelgroups":[{"values":{"banner|300x250|www.website.com":10},"schema":{"fields":["mediaType","size","domain"]}}]},"enabled":true}}}`),
+	}}
+
+	auctionRequest := &AuctionRequest{
+		BidRequestWrapper: req,
+		Account:           config.Account{PriceFloors: config.AccountPriceFloors{Enabled: true, MaxRule: 100, MaxSchemaDims: 5}},
+		UserSyncs:         &emptyUsersync{},
+		HookExecutor:      floorSettingHookExecutor{bidFloor: 42},
+		TCF2Config:        gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+	}
+	_, err := e.HoldAuction(context.Background(), auctionRequest, &DebugLog{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42.0, auctionRequest.BidRequestWrapper.Imp[0].BidFloor, "the processed auction hook floor should not be overwritten")
+}
+
+// observingPriceFloorFetcher records the bids it's shown for floors learning
+type observingPriceFloorFetcher struct {
+	mockPriceFloorFetcher
+	observedBids []*entities.PbsOrtbBid
+}
+
+func (mpf *observingPriceFloorFetcher) ObserveAuction(request *openrtb_ext.RequestWrapper, account config.Account, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions) {
+	for _, seatBid := range seatBids {
+		mpf.observedBids = append(mpf.observedBids, seatBid.Bids...)
+	}
+}
+
+func TestFloorsLearningObservesBidsBelowFloor(t *testing.T) {
+	fetcher := &observingPriceFloorFetcher{}
+	lowBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "low-bid", ImpID: "some-impression-id", Price: 1}, BidType: openrtb_ext.BidTypeBanner}
+	highBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "high-bid", ImpID: "some-impression-id", Price: 20}, BidType: openrtb_ext.BidTypeBanner}
+	e := exchange{
+		cache: &wellBehavedCache{},
+		me:    &metricsConf.NilMetricsEngine{},
+		gdprPermsBuilder: fakePermissionsBuilder{
+			permissions: &permissionsMock{
+				allowAllBidders: true,
+			},
+		}.Builder,
+		currencyConverter: currency.NewRateConverter(&http.Client{}, 0, "", 0),
+		categoriesFetcher: nilCategoryFetcher{},
+		bidIDGenerator:    &fakeBidIDGenerator{GenerateBidID: false, ReturnError: false},
+		priceFloorEnabled: true,
+		priceFloorFetcher: fetcher,
+		adapterMap: map[openrtb_ext.BidderName]AdaptedBidder{
+			openrtb_ext.BidderAppnexus: &mockAdaptedBidder{
+				bidResponse: []*entities.PbsOrtbSeatBid{{Bids: []*entities.PbsOrtbBid{lowBid, highBid}, Currency: "USD"}},
+			},
+		},
+	}
+	e.requestSplitter = requestSplitter{
+		me:               e.me,
+		gdprPermsBuilder: e.gdprPermsBuilder,
+	}
+
+	auctionRequest := &AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+			ID: "some-request-id",
+			Imp: []openrtb2.Imp{{
+				ID:     "some-impression-id",
+				Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+				Ext:    json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1}}}}`),
+			}},
+			Site: &openrtb2.Site{Page: "prebid.org", Domain: "www.website.com"},
+			Cur:  []string{"USD"},
+			Ext:  json.RawMessage(`{"prebid":{"floors":{"data":{"currency":"USD","modelgroups":[{"values":{"banner|300x250|www.website.com":10},"schema":{"fields":["mediaType","size","domain"]}}]},"enabled":true,"enforcement":{"enforcepbs":true,"enforcerate":100}}}}`),
+		}},
+		Account:      config.Account{PriceFloors: config.AccountPriceFloors{Enabled: true, EnforceFloorsRate: 100, MaxRule: 100, MaxSchemaDims: 5}},
+		UserSyncs:    &emptyUsersync{},
+		HookExecutor: &hookexecution.EmptyHookExecutor{},
+		TCF2Config:   gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+	}
+	_, err := e.HoldAuction(context.Background(), auctionRequest, &DebugLog{})
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*entities.PbsOrtbBid{lowBid, highBid}, fetcher.observedBids, "bids below the floor should be observed too")
+}
+
+// tmaxSettingHookExecutor sets the request tmax in the processed auction stage
+type tmaxSettingHookExecutor struct {
+	hookexecution.EmptyHookExecutor
+	tmax int64
+}
+
+func (e tmaxSettingHookExecutor) ExecuteProcessedAuctionStage(req *openrtb_ext.RequestWrapper) error {
+	req.TMax = e.tmax
+	return nil
+}
+
+// deadlineCapturingBidder records the deadline of the context it's called with
+type deadlineCapturingBidder struct {
+	deadline time.Time
+}
+
+func (b *deadlineCapturingBidder) requestBid(ctx context.Context, bidderRequest BidderRequest, conversions currency.Conversions, reqInfo *adapters.ExtraRequestInfo, adsCertSigner adscert.Signer, bidRequestOptions bidRequestOptions, alternateBidderCodes openrtb_ext.ExtAlternateBidderCodes, executor hookexecution.StageExecutor, ruleToAdjustments openrtb_ext.AdjustmentsByDealID) ([]*entities.PbsOrtbSeatBid, extraBidderRespInfo, []error) {
+	b.deadline, _ = ctx.Deadline()
+	return []*entities.PbsOrtbSeatBid{{}}, extraBidderRespInfo{}, nil
+}
+
+func (b *deadlineCapturingBidder) logHealthCheck(success bool) {}
+
+func (b *deadlineCapturingBidder) shouldRequest() bool {
+	return true
+}
+
+func TestProcessedAuctionStageTmaxShortensAuction(t *testing.T) {
+	startTime := time.Now()
+
+	testCases := []struct {
+		desc             string
+		requestTmax      int64
+		hookTmax         int64
+		expectedDeadline time.Time
+	}{
+		{
+			desc:             "hook lowers tmax",
+			requestTmax:      1000,
+			hookTmax:         100,
+			expectedDeadline: startTime.Add(100 * time.Millisecond),
+		},
+		{
+			desc:             "hook sets tmax on a request without one",
+			hookTmax:         100,
+			expectedDeadline: startTime.Add(100 * time.Millisecond),
+		},
+		{
+			desc:        "hook leaves tmax as is",
+			requestTmax: 1000,
+			hookTmax:    1000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			bidder := &deadlineCapturingBidder{}
+			e := exchange{
+				cache: &wellBehavedCache{},
+				me:    &metricsConf.NilMetricsEngine{},
+				gdprPermsBuilder: fakePermissionsBuilder{
+					permissions: &permissionsMock{
+						allowAllBidders: true,
+					},
+				}.Builder,
+				currencyConverter: currency.NewRateConverter(&http.Client{}, 0, "", 0),
+				categoriesFetcher: nilCategoryFetcher{},
+				bidIDGenerator:    &fakeBidIDGenerator{GenerateBidID: false, ReturnError: false},
+				adapterMap: map[openrtb_ext.BidderName]AdaptedBidder{
+					openrtb_ext.BidderAppnexus: bidder,
+				},
+			}
+			e.requestSplitter = requestSplitter{
+				me:               e.me,
+				gdprPermsBuilder: e.gdprPermsBuilder,
+			}
+
+			auctionRequest := &AuctionRequest{
+				BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+					ID:   "some-request-id",
+					TMax: test.requestTmax,
+					Imp: []openrtb2.Imp{{
+						ID:     "some-impression-id",
+						Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+						Ext:    json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1}}}}`),
+					}},
+					Site: &openrtb2.Site{Page: "prebid.org"},
+				}},
+				Account:      config.Account{},
+				UserSyncs:    &emptyUsersync{},
+				HookExecutor: tmaxSettingHookExecutor{tmax: test.hookTmax},
+				TCF2Config:   gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+				StartTime:    startTime,
+			}
+			_, err := e.HoldAuction(context.Background(), auctionRequest, &DebugLog{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedDeadline, bidder.deadline)
+		})
+	}
+}
+
+// bidAdjustmentDimsCapturingBidder records the bid adjustment dimensions of the bidder request it's called with
+type bidAdjustmentDimsCapturingBidder struct {
+	dims bidadjustment.RequestDimensions
+}
+
+func (b *bidAdjustmentDimsCapturingBidder) requestBid(ctx context.Context, bidderRequest BidderRequest, conversions currency.Conversions, reqInfo *adapters.ExtraRequestInfo, adsCertSigner adscert.Signer, bidRequestOptions bidRequestOptions, alternateBidderCodes openrtb_ext.ExtAlternateBidderCodes, executor hookexecution.StageExecutor, ruleToAdjustments openrtb_ext.AdjustmentsByDealID) ([]*entities.PbsOrtbSeatBid, extraBidderRespInfo, []error) {
+	b.dims = bidderRequest.BidAdjustmentDims
+	return []*entities.PbsOrtbSeatBid{{}}, extraBidderRespInfo{}, nil
+}
+
+func (b *bidAdjustmentDimsCapturingBidder) logHealthCheck(success bool) {}
+
+func (b *bidAdjustmentDimsCapturingBidder) shouldRequest() bool {
+	return true
+}
+
+func TestBidAdjustmentDimsFromAuctionRequest(t *testing.T) {
+	bidder := &bidAdjustmentDimsCapturingBidder{}
+	e := exchange{
+		cache: &wellBehavedCache{},
+		me:    &metricsConf.NilMetricsEngine{},
+		gdprPermsBuilder: fakePermissionsBuilder{
+			permissions: &permissionsMock{
+				allowAllBidders: true,
+			},
+		}.Builder,
+		currencyConverter: currency.NewRateConverter(&http.Client{}, 0, "", 0),
+		categoriesFetcher: nilCategoryFetcher{},
+		bidIDGenerator:    &fakeBidIDGenerator{GenerateBidID: false, ReturnError: false},
+		adapterMap: map[openrtb_ext.BidderName]AdaptedBidder{
+			openrtb_ext.BidderAppnexus: bidder,
+		},
+	}
+	e.requestSplitter = requestSplitter{
+		me:               e.me,
+		gdprPermsBuilder: e.gdprPermsBuilder,
+	}
+
+	auctionRequest := &AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+			ID: "some-request-id",
+			Imp: []openrtb2.Imp{{
+				ID:     "some-impression-id",
+				Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+				Ext:    json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1}}}}`),
+			}},
+			Site:   &openrtb2.Site{Page: "prebid.org", Domain: "example.com"},
+			Device: &openrtb2.Device{DeviceType: 2, Geo: &openrtb2.Geo{Country: "usa"}},
+		}},
+		Account: config.Account{BidAdjustments: &openrtb_ext.ExtRequestPrebidBidAdjustments{
+			Rules: []openrtb_ext.AdjustmentRule{{
+				Country:     "USA",
+				Adjustments: []openrtb_ext.Adjustment{{Type: bidadjustment.AdjustmentTypeMultiplier, Value: 1.1}},
+			}},
+		}},
+		UserSyncs:    &emptyUsersync{},
+		HookExecutor: &hookexecution.EmptyHookExecutor{},
+		TCF2Config:   gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+	}
+	_, err := e.HoldAuction(context.Background(), auctionRequest, &DebugLog{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, bidadjustment.RequestDimensions{Country: "USA"}, bidder.dims, "only the dimensions the rules are keyed on should be set")
+}
+
 func TestReturnCreativeEndToEnd(t *testing.T) {
 	sampleAd := "<?xml version=\"1.0\" encoding=\"UTF-8\"?><VAST ...></VAST>"
 
@@ -6447,6 +6737,41 @@ func TestSetSeatNonBid(t *testing.T) {
 	}
 }
 
+func TestSetBidAdjustments(t *testing.T) {
+	applied := []openrtb_ext.AppliedBidAdjustment{{Bidder: "appnexus", BidID: "bid1", Rule: "banner|appnexus|*", OriginalPrice: 2, AdjustedPrice: 1}}
+
+	tests := []struct {
+		name           string
+		bidResponseExt *openrtb_ext.ExtBidResponse
+		bidAdjustments []openrtb_ext.AppliedBidAdjustment
+		want           *openrtb_ext.ExtBidResponse
+	}{
+		{
+			name:           "no-bidAdjustments",
+			bidResponseExt: nil,
+			bidAdjustments: nil,
+			want:           nil,
+		},
+		{
+			name:           "nil-bidResponseExt",
+			bidResponseExt: nil,
+			bidAdjustments: applied,
+			want:           &openrtb_ext.ExtBidResponse{Prebid: &openrtb_ext.ExtResponsePrebid{BidAdjustments: applied}},
+		},
+		{
+			name:           "existing-prebid",
+			bidResponseExt: &openrtb_ext.ExtBidResponse{Prebid: &openrtb_ext.ExtResponsePrebid{AuctionTimestamp: 1}},
+			bidAdjustments: applied,
+			want:           &openrtb_ext.ExtBidResponse{Prebid: &openrtb_ext.ExtResponsePrebid{AuctionTimestamp: 1, BidAdjustments: applied}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, setBidAdjustments(tt.bidResponseExt, tt.bidAdjustments))
+		})
+	}
+}
+
 func TestBuildMultiBidMap(t *testing.T) {
 	type testCase struct {
 		desc     string
@@ -6997,3 +7322,33 @@ func TestAmpEnv(t *testing.T) {
 		assert.Equalf(t, test.expectedEnvInResponse, responseExt.Prebid.Targeting["oa_env"], "Response mismatch")
 	}
 }
+
+func TestRecordBidsBelowFloor(t *testing.T) {
+	metricsMock := &metrics.MetricsEngineMock{}
+	metricsMock.On("RecordBidBelowFloor", openrtb_ext.BidderName("appnexus"), "pub-id", metrics.FloorsOutcomeRejected, 2.0).Once()
+	metricsMock.On("RecordBidBelowFloor", openrtb_ext.BidderName("pubmatic"), "pub-id", metrics.FloorsOutcomeWouldReject, 1.5).Once()
+	e := &exchange{me: metricsMock}
+
+	seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"pubmatic": {
+			Bids: []*entities.PbsOrtbBid{
+				{Bid: &openrtb2.Bid{ID: "bid-1", Price: 3}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorValue: 4, WouldReject: true}},
+				{Bid: &openrtb2.Bid{ID: "bid-2", Price: 5}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorValue: 4}},
+				{Bid: &openrtb2.Bid{ID: "bid-3", Price: 5}},
+			},
+			Seat:     "pubmatic",
+			Currency: "EUR",
+		},
+	}
+	rejectedBids := []*entities.PbsOrtbSeatBid{
+		{
+			Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid-4", Price: 2}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorValue: 4}}},
+			Seat:     "appnexus",
+			Currency: "USD",
+		},
+	}
+	conversions := currency.NewRates(map[string]map[string]float64{"EUR": {"USD": 0.5}})
+
+	e.recordBidsBelowFloor("pub-id", seatBids, rejectedBids, conversions)
+	metricsMock.AssertExpectations(t)
+}
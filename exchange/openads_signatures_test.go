@@ -0,0 +1,151 @@
+package exchange
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/firstpartydata"
+	"github.com/prebid/prebid-server/v3/gdpr"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/metrics"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/modules/openads/signatures"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAdsSignaturesAuctionModeAfterSplit checks that signatures obtained by
+// the openads.signatures module at the processed auction stage are reused for
+// the bidder requests produced by the real request split.
+func TestOpenAdsSignaturesAuctionModeAfterSplit(t *testing.T) {
+	// Ed25519 signatures are deterministic, so a reused auction-stage signature
+	// must be identical to one computed over the bidder request
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "signing-key.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	module, err := signatures.Builder(json.RawMessage(fmt.Sprintf(
+		`{"transport":"inprocess","mode":"auction","inprocess":{"private_key_file":%q,"source":"example.com"}}`, keyPath,
+	)), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+	auctionHook := module.(hookstage.ProcessedAuctionRequest)
+	bidderHook := module.(hookstage.BidderRequest)
+
+	testCases := []struct {
+		name           string
+		firstPartyData map[openrtb_ext.BidderName]*firstpartydata.ResolvedFirstPartyData
+		expectedReused map[string]bool
+	}{
+		{
+			name:           "split-only changes reuse the auction signatures",
+			expectedReused: map[string]bool{"appnexus": true, "rubicon": true},
+		},
+		{
+			name: "bidder specific first party data is signed per bidder",
+			firstPartyData: map[openrtb_ext.BidderName]*firstpartydata.ResolvedFirstPartyData{
+				"appnexus": {Site: &openrtb2.Site{Page: "https://example.com/?a=1&b=2", Keywords: "appnexus-only"}},
+			},
+			expectedReused: map[string]bool{"appnexus": false, "rubicon": true},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			req := &openrtb2.BidRequest{
+				ID:   "some-request-id",
+				Site: &openrtb2.Site{Page: "https://example.com/?a=1&b=2"},
+				Imp: []openrtb2.Imp{
+					{
+						ID:     "imp-1",
+						Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+						Ext:    json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":1},"rubicon":{"accountId":1}}}}`),
+					},
+					{
+						ID:     "imp-2",
+						Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 728, H: 90}}},
+						Ext:    json.RawMessage(`{"prebid":{"bidder":{"appnexus":{"placementId":2}}}}`),
+					},
+				},
+				Ext: json.RawMessage(`{"prebid":{"debug":true}}`),
+			}
+
+			auctionResult, err := auctionHook.HandleProcessedAuctionHook(
+				context.Background(),
+				hookstage.ModuleInvocationContext{},
+				hookstage.ProcessedAuctionRequestPayload{Request: &openrtb_ext.RequestWrapper{BidRequest: req}},
+			)
+			require.NoError(t, err)
+			require.Empty(t, auctionResult.Warnings)
+
+			reqSplitter := &requestSplitter{
+				bidderToSyncerKey: map[string]string{},
+				me:                &metrics.MetricsEngineMock{},
+				gdprPermsBuilder:  fakePermissionsBuilder{permissions: &permissionsMock{allowAllBidders: true}}.Builder,
+				bidderInfo:        config.BidderInfos{},
+			}
+			auctionReq := AuctionRequest{
+				BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: req},
+				UserSyncs:         &emptyUsersync{},
+				TCF2Config:        gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+				FirstPartyData:    test.firstPartyData,
+			}
+			bidderRequests, _, errs := reqSplitter.cleanOpenRTBRequests(context.Background(), auctionReq, nil, map[string]float64{})
+			require.Empty(t, errs)
+			require.Len(t, bidderRequests, len(test.expectedReused))
+
+			for _, bidderRequest := range bidderRequests {
+				bidder := string(bidderRequest.BidderName)
+
+				cached, reused := openAdsEnvelope(t, bidderHook, bidder, bidderRequest.BidRequest, auctionResult.ModuleContext)
+				fresh, _ := openAdsEnvelope(t, bidderHook, bidder, bidderRequest.BidRequest, nil)
+				assert.Equal(t, test.expectedReused[bidder], reused, bidder)
+				assert.Equal(t, fresh, cached, "%s: both modes sign the same view", bidder)
+			}
+		})
+	}
+}
+
+// openAdsEnvelope runs the bidder request hook on a copy of request and returns
+// the envelope it adds to ext.openads, and whether it reused the auction one.
+func openAdsEnvelope(t *testing.T, hook hookstage.BidderRequest, bidder string, request *openrtb2.BidRequest, moduleCtx hookstage.ModuleContext) (string, bool) {
+	t.Helper()
+
+	requestCopy := *request
+	payload := hookstage.BidderRequestPayload{
+		Request: &openrtb_ext.RequestWrapper{BidRequest: &requestCopy},
+		Bidder:  bidder,
+	}
+
+	result, err := hook.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{ModuleContext: moduleCtx}, payload)
+	require.NoError(t, err)
+	for _, mutation := range result.ChangeSet.Mutations() {
+		payload, err = mutation.Apply(payload)
+		require.NoError(t, err)
+	}
+
+	reqExt, err := payload.Request.GetRequestExt()
+	require.NoError(t, err)
+
+	var openadsExt signatures.OpenAdsExt
+	require.NoError(t, json.Unmarshal(reqExt.GetExt()[signatures.OpenAdsExtKey], &openadsExt))
+	require.Len(t, openadsExt.IntSigs, 1)
+
+	require.Len(t, result.AnalyticsTags.Activities, 1)
+	require.Len(t, result.AnalyticsTags.Activities[0].Results, 1)
+	reused, _ := result.AnalyticsTags.Activities[0].Results[0].Values["auction_signature"].(bool)
+	return openadsExt.IntSigs[0].Envelope, reused
+}
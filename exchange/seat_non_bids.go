@@ -29,6 +29,7 @@ func (b SeatNonBidBuilder) rejectBid(bid *entities.PbsOrtbBid, nonBidReason int,
 				MType:          bid.Bid.MType,
 				OriginalBidCPM: bid.OriginalBidCPM,
 				OriginalBidCur: bid.OriginalBidCur,
+				Floors:         bid.BidFloors,
 			}},
 		},
 	}
@@ -39,10 +39,11 @@ func Enforce(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb
 		}
 	}
 	updateBidExt(bidRequestWrapper, seatBids)
+	enforceDealFloors := account.PriceFloors.EnforceDealFloors && getEnforceDealsFlag(requestExt)
 	if enforceFloors {
-		enforceDealFloors := account.PriceFloors.EnforceDealFloors && getEnforceDealsFlag(requestExt)
 		seatBids, rejectionErrs, rejectedBids = enforceFloorToBids(bidRequestWrapper, seatBids, conversions, enforceDealFloors)
 	}
+	markBidsWouldReject(bidRequestWrapper, seatBids, conversions, enforceDealFloors)
 	return seatBids, rejectionErrs, rejectedBids
 }
 
@@ -154,6 +155,37 @@ func enforceFloorToBids(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids
 	return seatBids, errs, rejectedBids
 }
 
+// markBidsWouldReject flags the bids below floor kept because the enforce rate skipped enforcement or enforcepbs is false,
+// so that the bids floors enforcement would have rejected are reported whether or not it ran
+func markBidsWouldReject(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions, enforceDealFloors bool) {
+	impMap := make(map[string]*openrtb_ext.ImpWrapper, bidRequestWrapper.LenImp())
+	for _, imp := range bidRequestWrapper.GetImp() {
+		impMap[imp.ID] = imp
+	}
+
+	for _, seatBid := range seatBids {
+		for _, bid := range seatBid.Bids {
+			reqImp, ok := impMap[bid.Bid.ImpID]
+			if !ok || bid.BidFloors == nil {
+				continue
+			}
+
+			if hasDealID(bid) && !enforceDealFloors {
+				continue
+			}
+
+			rate, err := getCurrencyConversionRate(seatBid.Currency, reqImp.BidFloorCur, conversions)
+			if err != nil {
+				continue
+			}
+
+			if (rate*bid.Bid.Price + floorPrecision) < reqImp.BidFloor {
+				bid.BidFloors.WouldReject = true
+			}
+		}
+	}
+}
+
 // isEnforcementEnabled check for floors enforcement enabled in request
 func isEnforcementEnabled(requestExt *openrtb_ext.RequestExt) bool {
 	if floorsExt := getFloorsExt(requestExt); floorsExt != nil {
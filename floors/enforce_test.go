@@ -566,7 +566,7 @@ func TestEnforce(t *testing.T) {
 			expEligibleBids: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
 				"pubmatic": {
 					Bids: []*entities.PbsOrtbBid{
-						{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 1.2, ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorValue: 5.01, FloorCurrency: "USD"}},
+						{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 1.2, ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorValue: 5.01, FloorCurrency: "USD", WouldReject: true}},
 					},
 					Seat:     "pubmatic",
 					Currency: "USD",
@@ -732,6 +732,78 @@ func TestEnforce(t *testing.T) {
 	}
 }
 
+func TestMarkBidsWouldReject(t *testing.T) {
+	bidRequestWrapper := &openrtb_ext.RequestWrapper{
+		BidRequest: &openrtb2.BidRequest{
+			Imp: []openrtb2.Imp{
+				{ID: "imp-usd", BidFloor: 5, BidFloorCur: "USD"},
+				{ID: "imp-eur", BidFloor: 5, BidFloorCur: "EUR"},
+			},
+		},
+	}
+	conversions := currency.NewRates(map[string]map[string]float64{"USD": {"EUR": 0.5}})
+
+	tests := []struct {
+		name              string
+		bid               *entities.PbsOrtbBid
+		currency          string
+		enforceDealFloors bool
+		expWouldReject    bool
+	}{
+		{
+			name:           "bid-below-floor",
+			bid:            &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 4}},
+			currency:       "USD",
+			expWouldReject: true,
+		},
+		{
+			name:     "bid-above-floor",
+			bid:      &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 6}},
+			currency: "USD",
+		},
+		{
+			name:           "bid-below-floor-after-conversion",
+			bid:            &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-eur", Price: 8}},
+			currency:       "USD",
+			expWouldReject: true,
+		},
+		{
+			name:     "deal-bid-below-floor-without-deal-enforcement",
+			bid:      &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 4, DealID: "deal"}},
+			currency: "USD",
+		},
+		{
+			name:              "deal-bid-below-floor-with-deal-enforcement",
+			bid:               &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 4, DealID: "deal"}},
+			currency:          "USD",
+			enforceDealFloors: true,
+			expWouldReject:    true,
+		},
+		{
+			name:     "unknown-conversion",
+			bid:      &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 4}},
+			currency: "JPY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bid.BidFloors = &openrtb_ext.ExtBidPrebidFloors{FloorValue: 5}
+			seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+				"pubmatic": {Bids: []*entities.PbsOrtbBid{tt.bid}, Seat: "pubmatic", Currency: tt.currency},
+			}
+
+			markBidsWouldReject(bidRequestWrapper, seatBids, conversions, tt.enforceDealFloors)
+			assert.Equal(t, tt.expWouldReject, tt.bid.BidFloors.WouldReject)
+		})
+	}
+
+	bidWithoutFloors := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ImpID: "imp-usd", Price: 4}}
+	markBidsWouldReject(bidRequestWrapper, map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"pubmatic": {Bids: []*entities.PbsOrtbBid{bidWithoutFloors}, Seat: "pubmatic", Currency: "USD"},
+	}, conversions, false)
+	assert.Nil(t, bidWithoutFloors.BidFloors, "Bids without floors should not be annotated")
+}
+
 func TestUpdateBidExtWithFloors(t *testing.T) {
 	type args struct {
 		reqImp        *openrtb_ext.ImpWrapper
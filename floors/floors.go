@@ -202,6 +202,10 @@ func createFloorsFrom(floors *openrtb_ext.PriceFloorRules, account config.Accoun
 				*finalFloors.Data = *floors.Data
 				finalFloors.PriceFloorLocation = floorLocation
 				finalFloors.FetchStatus = fetchStatus
+				if floorLocation != openrtb_ext.FetchLocation {
+					// version is only set by the floors store, never taken from the request
+					finalFloors.FetchVersion = ""
+				}
 				if len(validModelGroups) > 1 {
 					validModelGroups = selectFloorModelGroup(validModelGroups, rand.Intn)
 				}
@@ -0,0 +1,464 @@
+package floors
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/timeutil"
+)
+
+const learningFloorProvider = "prebid-server-learning"
+
+// AuctionObserver is implemented by the floor fetchers which learn from the bids cleared by the auctions
+type AuctionObserver interface {
+	ObserveAuction(request *openrtb_ext.RequestWrapper, account config.Account, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions)
+}
+
+// Learner keeps per account rolling statistics of the clearing prices, keyed by the floors schema
+// of the account learning config, and periodically generates price floor rules from them.
+// Accounts without learned floors, or learning in shadow mode, get the floors of the fallback fetcher.
+type Learner struct {
+	fallback FloorFetcher
+	config   config.PriceFloorLearning
+	time     timeutil.Time
+	done     chan struct{}
+
+	mutex    sync.RWMutex
+	accounts map[string]*accountLearning
+}
+
+// accountLearning holds the clearing prices observed for an account and the floors learned from them
+type accountLearning struct {
+	mutex       sync.Mutex
+	config      config.AccountFloorLearning
+	maxRules    int
+	samples     map[string]*sampleRing
+	all         *sampleRing
+	rules       *openrtb_ext.PriceFloorRules
+	generation  int
+	generatedAt time.Time
+	shadow      ShadowStats
+}
+
+// ShadowStats records the floors a shadow mode account would have had against the clearing prices
+type ShadowStats struct {
+	Impressions   int64   `json:"impressions"`
+	BelowFloor    int64   `json:"below_floor"`
+	FloorTotal    float64 `json:"floor_total"`
+	ClearingTotal float64 `json:"clearing_total"`
+}
+
+// LearningStatus is the state of the floors learning of an account
+type LearningStatus struct {
+	ShadowMode  bool                         `json:"shadow_mode"`
+	Samples     int                          `json:"samples"`
+	Keys        int                          `json:"keys"`
+	GeneratedAt *time.Time                   `json:"generated_at,omitempty"`
+	Floors      *openrtb_ext.PriceFloorRules `json:"floors,omitempty"`
+	Shadow      *ShadowStats                 `json:"shadow,omitempty"`
+}
+
+// NewLearner creates a Learner wrapping the fallback fetcher, which may be nil,
+// and starts regenerating the learned floors every refresh period.
+func NewLearner(cfg config.PriceFloorLearning, fallback FloorFetcher) *Learner {
+	learner := newLearner(cfg, fallback, &timeutil.RealTime{})
+	go learner.run(time.Duration(cfg.RefreshPeriodSec) * time.Second)
+	return learner
+}
+
+func newLearner(cfg config.PriceFloorLearning, fallback FloorFetcher, clock timeutil.Time) *Learner {
+	return &Learner{
+		fallback: fallback,
+		config:   cfg,
+		time:     clock,
+		done:     make(chan struct{}),
+		accounts: make(map[string]*accountLearning),
+	}
+}
+
+// Fetch returns the floors learned for the account, unless it is in shadow mode or has not enough samples yet
+func (l *Learner) Fetch(configs config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	if configs.Learning.Enabled && !configs.Learning.ShadowMode {
+		if rules := l.learnedRules(configs.Fetcher.AccountID); rules != nil {
+			return rules, openrtb_ext.FetchSuccess
+		}
+	}
+	if l.fallback == nil {
+		return nil, openrtb_ext.FetchNone
+	}
+	return l.fallback.Fetch(configs)
+}
+
+// Stop terminates the floors regeneration and the fallback fetcher
+func (l *Learner) Stop() {
+	close(l.done)
+	if l.fallback != nil {
+		l.fallback.Stop()
+	}
+}
+
+// ObserveAuction records the highest non deal bid of each impression as its clearing price
+func (l *Learner) ObserveAuction(request *openrtb_ext.RequestWrapper, account config.Account, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions) {
+	if !account.PriceFloors.Learning.Enabled || len(account.ID) == 0 || request == nil {
+		return
+	}
+
+	prices := clearingPrices(seatBids, conversions)
+	if len(prices) == 0 {
+		return
+	}
+
+	stats := l.accountStats(account.ID)
+	if stats == nil {
+		return
+	}
+
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	stats.configure(account.PriceFloors, l.config.SampleSize)
+	schema := openrtb_ext.PriceFloorSchema{Fields: stats.config.Schema, Delimiter: defaultDelimiter}
+	for _, imp := range request.GetImp() {
+		price, ok := prices[imp.ID]
+		if !ok {
+			continue
+		}
+		keyValues := createRuleKey(schema, request, imp)
+		if stats.config.ShadowMode {
+			stats.recordShadow(keyValues, price)
+		}
+		stats.observe(learningRuleKey(keyValues), price, l.config.SampleSize, l.config.MaxRules)
+	}
+}
+
+// Status returns the learning state of the account, or of all the accounts when accountID is empty
+func (l *Learner) Status(accountID string) map[string]LearningStatus {
+	l.mutex.RLock()
+	accounts := make(map[string]*accountLearning, len(l.accounts))
+	for id, stats := range l.accounts {
+		if len(accountID) == 0 || id == accountID {
+			accounts[id] = stats
+		}
+	}
+	l.mutex.RUnlock()
+
+	status := make(map[string]LearningStatus, len(accounts))
+	for id, stats := range accounts {
+		status[id] = stats.status()
+	}
+	return status
+}
+
+func (l *Learner) run(refreshPeriod time.Duration) {
+	ticker := time.NewTicker(refreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.refresh()
+		case <-l.done:
+			logger.Infof("Price floors learning terminated")
+			return
+		}
+	}
+}
+
+// refresh regenerates the learned floors of all the accounts
+func (l *Learner) refresh() {
+	l.mutex.RLock()
+	accounts := make([]*accountLearning, 0, len(l.accounts))
+	for _, stats := range l.accounts {
+		accounts = append(accounts, stats)
+	}
+	l.mutex.RUnlock()
+
+	now := l.time.Now()
+	for _, stats := range accounts {
+		stats.mutex.Lock()
+		stats.generate(now)
+		stats.mutex.Unlock()
+	}
+}
+
+func (l *Learner) learnedRules(accountID string) *openrtb_ext.PriceFloorRules {
+	l.mutex.RLock()
+	stats, ok := l.accounts[accountID]
+	l.mutex.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+	return stats.rules.DeepCopy()
+}
+
+// accountStats returns the statistics of the account, or nil when the max number of accounts is reached
+func (l *Learner) accountStats(accountID string) *accountLearning {
+	l.mutex.RLock()
+	stats, ok := l.accounts[accountID]
+	l.mutex.RUnlock()
+	if ok {
+		return stats
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if stats, ok := l.accounts[accountID]; ok {
+		return stats
+	}
+	if len(l.accounts) >= l.config.MaxAccounts {
+		return nil
+	}
+	stats = &accountLearning{samples: make(map[string]*sampleRing)}
+	l.accounts[accountID] = stats
+	return stats
+}
+
+// clearingPrices returns the highest non deal bid price of each impression in the default floors currency
+func clearingPrices(seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions) map[string]float64 {
+	prices := make(map[string]float64)
+	for _, seatBid := range seatBids {
+		if seatBid == nil || len(seatBid.Bids) == 0 {
+			continue
+		}
+		bidCurrency := seatBid.Currency
+		if len(bidCurrency) == 0 {
+			bidCurrency = defaultCurrency
+		}
+		rate, err := getCurrencyConversionRate(bidCurrency, defaultCurrency, conversions)
+		if err != nil {
+			continue
+		}
+		for _, bid := range seatBid.Bids {
+			if bid == nil || bid.Bid == nil || hasDealID(bid) || bid.Bid.Price <= 0 {
+				continue
+			}
+			if price := bid.Bid.Price * rate; price > prices[bid.Bid.ImpID] {
+				prices[bid.Bid.ImpID] = price
+			}
+		}
+	}
+	return prices
+}
+
+// learningRuleKey joins the lower cased schema values the same way the floors rule keys are matched
+func learningRuleKey(keyValues []string) string {
+	lowered := make([]string, len(keyValues))
+	for i, value := range keyValues {
+		lowered[i] = strings.ToLower(value)
+	}
+	return strings.Join(lowered, defaultDelimiter)
+}
+
+// configure applies the latest learning config of the account, resetting the samples when the schema changes
+func (s *accountLearning) configure(floors config.AccountPriceFloors, sampleSize int) {
+	learning := floors.Learning
+	if len(learning.Schema) == 0 {
+		learning.Schema = []string{MediaType}
+	}
+	learning.Percentile = min(max(learning.Percentile, 1), 100)
+	learning.MinSamples = max(learning.MinSamples, 1)
+
+	if !slices.Equal(s.config.Schema, learning.Schema) {
+		s.samples = make(map[string]*sampleRing)
+		s.all = nil
+		s.rules = nil
+	}
+	if s.all == nil {
+		s.all = newSampleRing(sampleSize)
+	}
+	s.config = learning
+	s.maxRules = floors.MaxRule
+}
+
+func (s *accountLearning) observe(key string, price float64, sampleSize, maxKeys int) {
+	s.all.add(price)
+
+	ring, ok := s.samples[key]
+	if !ok {
+		if len(s.samples) >= maxKeys {
+			return
+		}
+		ring = newSampleRing(sampleSize)
+		s.samples[key] = ring
+	}
+	ring.add(price)
+}
+
+// recordShadow compares the clearing price with the floor the learned rules would have set
+func (s *accountLearning) recordShadow(keyValues []string, price float64) {
+	if s.rules == nil {
+		return
+	}
+	modelGroup := s.rules.Data.ModelGroups[0]
+	floor := modelGroup.Default
+	if rule, ok := findRule(modelGroup.Values, modelGroup.Schema.Delimiter, keyValues); ok {
+		floor = modelGroup.Values[rule]
+	}
+	if floor == 0 {
+		return
+	}
+
+	s.shadow.Impressions++
+	s.shadow.FloorTotal += floor
+	s.shadow.ClearingTotal += price
+	if price+floorPrecision < floor {
+		s.shadow.BelowFloor++
+	}
+}
+
+// generate learns the floor of each key with enough samples as the configured percentile of its clearing
+// prices, bounded by the min and max floors and by the max change from the previously learned floor.
+func (s *accountLearning) generate(now time.Time) {
+	if s.all == nil || s.all.len() < s.config.MinSamples {
+		return
+	}
+
+	var previous *openrtb_ext.PriceFloorModelGroup
+	if s.rules != nil {
+		previous = &s.rules.Data.ModelGroups[0]
+	}
+
+	keys := make([]string, 0, len(s.samples))
+	for key, ring := range s.samples {
+		if ring.len() >= s.config.MinSamples {
+			keys = append(keys, key)
+		}
+	}
+	// keep the keys with the most samples when the account allows fewer rules, leaving one for the catch-all rule
+	if maxKeys := s.maxRules - 1; s.maxRules > 0 && len(keys) > maxKeys {
+		sort.Slice(keys, func(i, j int) bool {
+			if s.samples[keys[i]].len() != s.samples[keys[j]].len() {
+				return s.samples[keys[i]].len() > s.samples[keys[j]].len()
+			}
+			return keys[i] < keys[j]
+		})
+		keys = keys[:maxKeys]
+	}
+
+	values := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		var previousFloor float64
+		if previous != nil {
+			previousFloor = previous.Values[key]
+		}
+		values[key] = s.guard(s.samples[key].percentile(s.config.Percentile), previousFloor)
+	}
+
+	var previousDefault float64
+	if previous != nil {
+		previousDefault = previous.Default
+	}
+	defaultFloor := s.guard(s.all.percentile(s.config.Percentile), previousDefault)
+	// rules are only matched when there are values, so the default is also set as the catch-all rule
+	catchAllKey := strings.TrimSuffix(strings.Repeat(catchAll+defaultDelimiter, len(s.config.Schema)), defaultDelimiter)
+	if _, ok := values[catchAllKey]; !ok {
+		values[catchAllKey] = defaultFloor
+	}
+
+	s.generation++
+	s.generatedAt = now
+	version := strconv.Itoa(s.generation)
+	s.rules = &openrtb_ext.PriceFloorRules{
+		FetchVersion: version,
+		Data: &openrtb_ext.PriceFloorData{
+			Currency:       defaultCurrency,
+			ModelTimestamp: int(now.Unix()),
+			FloorProvider:  learningFloorProvider,
+			ModelGroups: []openrtb_ext.PriceFloorModelGroup{{
+				Currency:     defaultCurrency,
+				ModelVersion: "learning-" + version,
+				Schema: openrtb_ext.PriceFloorSchema{
+					Fields:    slices.Clone(s.config.Schema),
+					Delimiter: defaultDelimiter,
+				},
+				Values:  values,
+				Default: defaultFloor,
+			}},
+		},
+	}
+}
+
+// guard applies the max change rate from the previous floor, when there is one, and the min and max floors
+func (s *accountLearning) guard(floor, previous float64) float64 {
+	if previous > 0 && s.config.MaxChangePercent > 0 {
+		maxChange := previous * float64(s.config.MaxChangePercent) / 100
+		floor = math.Min(math.Max(floor, previous-maxChange), previous+maxChange)
+	}
+	if floor < s.config.MinFloor {
+		floor = s.config.MinFloor
+	}
+	if s.config.MaxFloor > 0 && floor > s.config.MaxFloor {
+		floor = s.config.MaxFloor
+	}
+	return roundToFourDecimals(floor)
+}
+
+func (s *accountLearning) status() LearningStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status := LearningStatus{
+		ShadowMode: s.config.ShadowMode,
+		Keys:       len(s.samples),
+		Floors:     s.rules.DeepCopy(),
+	}
+	if s.all != nil {
+		status.Samples = s.all.len()
+	}
+	if !s.generatedAt.IsZero() {
+		generatedAt := s.generatedAt
+		status.GeneratedAt = &generatedAt
+	}
+	if s.config.ShadowMode {
+		shadow := s.shadow
+		status.Shadow = &shadow
+	}
+	return status
+}
+
+// sampleRing keeps the last clearing prices up to its capacity
+type sampleRing struct {
+	values []float64
+	next   int
+}
+
+func newSampleRing(capacity int) *sampleRing {
+	return &sampleRing{values: make([]float64, 0, max(capacity, 1))}
+}
+
+func (r *sampleRing) add(value float64) {
+	if len(r.values) < cap(r.values) {
+		r.values = append(r.values, value)
+		return
+	}
+	r.values[r.next] = value
+	r.next = (r.next + 1) % len(r.values)
+}
+
+func (r *sampleRing) len() int {
+	return len(r.values)
+}
+
+// percentile returns the nearest rank percentile of the samples
+func (r *sampleRing) percentile(p int) float64 {
+	if len(r.values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(r.values)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
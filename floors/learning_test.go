@@ -0,0 +1,238 @@
+package floors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTime struct {
+	now time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	return f.now
+}
+
+func newTestLearner(maxAccounts int) *Learner {
+	return newLearner(config.PriceFloorLearning{
+		RefreshPeriodSec: 60,
+		SampleSize:       10,
+		MaxRules:         10,
+		MaxAccounts:      maxAccounts,
+	}, nil, &fakeTime{now: time.Unix(1700000000, 0)})
+}
+
+func learningAccount(id string, learning config.AccountFloorLearning) config.Account {
+	return config.Account{
+		ID: id,
+		PriceFloors: config.AccountPriceFloors{
+			Enabled:        true,
+			UseDynamicData: true,
+			MaxRule:        100,
+			Fetcher:        config.AccountFloorFetch{AccountID: id},
+			Learning:       learning,
+		},
+	}
+}
+
+func defaultLearning() config.AccountFloorLearning {
+	return config.AccountFloorLearning{
+		Enabled:    true,
+		Schema:     []string{"mediaType"},
+		Percentile: 50,
+		MinSamples: 3,
+	}
+}
+
+func observe(learner *Learner, account config.Account, imp openrtb2.Imp, prices ...float64) {
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Imp: []openrtb2.Imp{imp}}}
+	for _, price := range prices {
+		seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+			"pubmatic": {
+				Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid", ImpID: imp.ID, Price: price}}},
+				Currency: "USD",
+			},
+		}
+		learner.ObserveAuction(request, account, seatBids, currency.NewRates(nil))
+	}
+}
+
+var (
+	bannerImp = openrtb2.Imp{ID: "imp-1", Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}}}
+	videoImp  = openrtb2.Imp{ID: "imp-1", Video: &openrtb2.Video{W: ptrutil.ToPtr[int64](640), H: ptrutil.ToPtr[int64](480)}}
+)
+
+func TestSampleRingPercentile(t *testing.T) {
+	ring := newSampleRing(4)
+	for _, value := range []float64{6, 1, 2, 3, 4, 5} {
+		ring.add(value)
+	}
+
+	assert.Equal(t, 4, ring.len(), "Ring should keep its capacity")
+	assert.Equal(t, 2.0, ring.percentile(1))
+	assert.Equal(t, 3.0, ring.percentile(50))
+	assert.Equal(t, 5.0, ring.percentile(100))
+	assert.Equal(t, 0.0, newSampleRing(4).percentile(50))
+}
+
+func TestLearnerGeneratesFloors(t *testing.T) {
+	learner := newTestLearner(10)
+	account := learningAccount("acc", defaultLearning())
+
+	observe(learner, account, bannerImp, 1, 2, 3)
+	observe(learner, account, videoImp, 4, 5)
+
+	floors, status := learner.Fetch(account.PriceFloors)
+	assert.Nil(t, floors, "Floors should not be learned before a refresh")
+	assert.Equal(t, openrtb_ext.FetchNone, status)
+
+	learner.refresh()
+
+	floors, status = learner.Fetch(account.PriceFloors)
+	require.NotNil(t, floors)
+	assert.Equal(t, openrtb_ext.FetchSuccess, status)
+	assert.Equal(t, "1", floors.FetchVersion)
+	assert.Equal(t, learningFloorProvider, floors.Data.FloorProvider)
+	assert.Equal(t, 1700000000, floors.Data.ModelTimestamp)
+
+	modelGroup := floors.Data.ModelGroups[0]
+	assert.Equal(t, []string{"mediaType"}, modelGroup.Schema.Fields)
+	assert.Equal(t, map[string]float64{"banner": 2, "*": 3}, modelGroup.Values, "Video has fewer samples than min_samples")
+	assert.Equal(t, 3.0, modelGroup.Default)
+
+	// the returned floors are a copy
+	floors.Data.ModelGroups[0].Values["banner"] = 100
+	floors, _ = learner.Fetch(account.PriceFloors)
+	assert.Equal(t, 2.0, floors.Data.ModelGroups[0].Values["banner"])
+}
+
+func TestLearnerGuardrails(t *testing.T) {
+	learning := defaultLearning()
+	learning.MinFloor = 0.5
+	learning.MaxFloor = 4
+	learning.MaxChangePercent = 10
+
+	learner := newTestLearner(10)
+	account := learningAccount("acc", learning)
+
+	observe(learner, account, bannerImp, 0.1, 0.1, 0.1)
+	learner.refresh()
+	floors, _ := learner.Fetch(account.PriceFloors)
+	assert.Equal(t, 0.5, floors.Data.ModelGroups[0].Values["banner"], "Floor should be raised to min_floor")
+
+	observe(learner, account, bannerImp, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10)
+	learner.refresh()
+	floors, _ = learner.Fetch(account.PriceFloors)
+	assert.Equal(t, 0.55, floors.Data.ModelGroups[0].Values["banner"], "Floor should not change by more than max_change_percent")
+	assert.Equal(t, "2", floors.FetchVersion)
+
+	learning.MaxChangePercent = 0
+	account = learningAccount("acc", learning)
+	observe(learner, account, bannerImp, 10)
+	learner.refresh()
+	floors, _ = learner.Fetch(account.PriceFloors)
+	assert.Equal(t, 4.0, floors.Data.ModelGroups[0].Values["banner"], "Floor should be capped to max_floor")
+}
+
+func TestLearnerShadowMode(t *testing.T) {
+	learning := defaultLearning()
+	learning.ShadowMode = true
+
+	fallback := &mockFallbackFetcher{}
+	learner := newTestLearner(10)
+	learner.fallback = fallback
+	account := learningAccount("acc", learning)
+
+	observe(learner, account, bannerImp, 2, 2, 2)
+	learner.refresh()
+
+	floors, status := learner.Fetch(account.PriceFloors)
+	assert.Nil(t, floors, "Shadow mode floors should not be used")
+	assert.Equal(t, openrtb_ext.FetchInprogress, status)
+	assert.Equal(t, 1, fallback.calls)
+
+	observe(learner, account, bannerImp, 1, 3)
+
+	accountStatus := learner.Status("acc")["acc"]
+	assert.True(t, accountStatus.ShadowMode)
+	assert.Equal(t, 2.0, accountStatus.Floors.Data.ModelGroups[0].Values["banner"])
+	assert.Equal(t, &ShadowStats{Impressions: 2, BelowFloor: 1, FloorTotal: 4, ClearingTotal: 4}, accountStatus.Shadow)
+}
+
+func TestLearnerObserveAuction(t *testing.T) {
+	learner := newTestLearner(1)
+	account := learningAccount("acc", defaultLearning())
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Imp: []openrtb2.Imp{bannerImp}}}
+	conversions := currency.NewRates(map[string]map[string]float64{"EUR": {"USD": 2}})
+
+	learner.ObserveAuction(request, account, map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"pubmatic": {
+			Bids: []*entities.PbsOrtbBid{
+				{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", Price: 1}},
+				{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", Price: 10, DealID: "deal"}},
+			},
+			Currency: "EUR",
+		},
+		"appnexus": {
+			Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid-3", ImpID: "imp-1", Price: 1.5}}},
+			Currency: "USD",
+		},
+	}, conversions)
+
+	stats := learner.accounts["acc"]
+	require.NotNil(t, stats)
+	assert.Equal(t, []float64{2}, stats.samples["banner"].values, "Clearing price should be the highest non deal bid in USD")
+
+	observe(learner, learningAccount("other", defaultLearning()), bannerImp, 1)
+	assert.Len(t, learner.Status(""), 1, "Accounts beyond max_accounts should not be learned")
+
+	disabled := learningAccount("disabled", config.AccountFloorLearning{})
+	observe(learner, disabled, bannerImp, 1)
+	assert.NotContains(t, learner.Status(""), "disabled")
+}
+
+func TestLearnerSchemaChangeResetsSamples(t *testing.T) {
+	learner := newTestLearner(10)
+	observe(learner, learningAccount("acc", defaultLearning()), bannerImp, 1, 2, 3)
+	learner.refresh()
+
+	learning := defaultLearning()
+	learning.Schema = []string{"mediaType", "size"}
+	account := learningAccount("acc", learning)
+	observe(learner, account, bannerImp, 5)
+
+	stats := learner.accounts["acc"]
+	assert.Len(t, stats.samples, 1)
+	assert.Equal(t, []float64{5}, stats.samples["banner|300x250"].values)
+	assert.Nil(t, stats.rules, "Floors of the previous schema should be dropped")
+}
+
+func TestLearnerMaxRules(t *testing.T) {
+	learner := newTestLearner(10)
+	account := learningAccount("acc", defaultLearning())
+	account.PriceFloors.MaxRule = 2
+
+	observe(learner, account, bannerImp, 1, 1, 1, 1)
+	observe(learner, account, videoImp, 3, 3, 3)
+	learner.refresh()
+
+	floors, _ := learner.Fetch(account.PriceFloors)
+	assert.Equal(t, map[string]float64{"banner": 1, "*": 1}, floors.Data.ModelGroups[0].Values, "Keys with the most samples should be kept within max_rules")
+}
+
+func TestLearnerStop(t *testing.T) {
+	fallback := &mockFallbackFetcher{}
+	learner := NewLearner(config.PriceFloorLearning{RefreshPeriodSec: 1, SampleSize: 1, MaxRules: 1, MaxAccounts: 1}, fallback)
+	learner.Stop()
+
+	assert.True(t, fallback.stopped)
+}
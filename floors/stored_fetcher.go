@@ -0,0 +1,191 @@
+package floors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/logger"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/stored_requests"
+	"github.com/prebid/prebid-server/v3/util/timeutil"
+)
+
+// storedFloors is the contract of an account floors entry in the stored data backends
+type storedFloors struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// storedFloorsRefreshInterval is how often the floors of an account are read again from the store,
+// whose cache is kept up to date by the stored data events
+var storedFloorsRefreshInterval = 30 * time.Second
+
+// storedFloorsEntry is the result of the last read of the floors of an account from the store
+type storedFloorsEntry struct {
+	raw      json.RawMessage
+	rules    *openrtb_ext.PriceFloorRules
+	status   string
+	notFound bool
+	readTime time.Time
+}
+
+// StoredFloorFetcher loads account floors from the stored data backends configured under
+// stored_floors. Accounts without an entry in the store fall back to the URL fetcher.
+//
+// Like the URL fetcher, it never waits for the store during an auction: the floors of an account
+// are read in the background, and the parsed rules, or the absence of stored floors, are kept
+// until they are read again.
+type StoredFloorFetcher struct {
+	store      stored_requests.FloorsFetcher
+	fallback   FloorFetcher
+	time       timeutil.Time
+	mu         sync.Mutex
+	entries    map[string]storedFloorsEntry
+	refreshing map[string]bool
+	refreshes  sync.WaitGroup
+}
+
+// NewStoredFloorFetcher wraps the URL fetcher with the floors store. The fallback may be nil.
+func NewStoredFloorFetcher(store stored_requests.FloorsFetcher, fallback FloorFetcher) *StoredFloorFetcher {
+	return newStoredFloorFetcher(store, fallback, &timeutil.RealTime{})
+}
+
+func newStoredFloorFetcher(store stored_requests.FloorsFetcher, fallback FloorFetcher, time timeutil.Time) *StoredFloorFetcher {
+	return &StoredFloorFetcher{
+		store:      store,
+		fallback:   fallback,
+		time:       time,
+		entries:    make(map[string]storedFloorsEntry),
+		refreshing: make(map[string]bool),
+	}
+}
+
+func (f *StoredFloorFetcher) Fetch(configs config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	if !configs.UseDynamicData || !configs.Fetcher.Enabled || len(configs.Fetcher.AccountID) == 0 {
+		return f.fetchFallback(configs)
+	}
+
+	entry, found := f.lookup(configs)
+	if !found {
+		return nil, openrtb_ext.FetchInprogress
+	}
+	if entry.notFound {
+		return f.fetchFallback(configs)
+	}
+	return entry.rules, entry.status
+}
+
+// lookup returns the last read of the floors of the account, and reads them again in the background
+// when there is none yet or it is older than the refresh interval
+func (f *StoredFloorFetcher) lookup(configs config.AccountPriceFloors) (storedFloorsEntry, bool) {
+	accountID := configs.Fetcher.AccountID
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, found := f.entries[accountID]
+	if (!found || f.time.Now().Sub(entry.readTime) >= storedFloorsRefreshInterval) && !f.refreshing[accountID] {
+		f.refreshing[accountID] = true
+		f.refreshes.Add(1)
+		go f.refresh(configs, entry)
+	}
+	return entry, found
+}
+
+func (f *StoredFloorFetcher) refresh(configs config.AccountPriceFloors, previous storedFloorsEntry) {
+	defer f.refreshes.Done()
+
+	entry := f.read(configs, previous)
+	entry.readTime = f.time.Now()
+
+	f.mu.Lock()
+	f.entries[configs.Fetcher.AccountID] = entry
+	delete(f.refreshing, configs.Fetcher.AccountID)
+	f.mu.Unlock()
+}
+
+// read reads the floors of the account from the store. The rules are only parsed again when the stored
+// entry changed, and the previous rules are kept when the store can't be read.
+func (f *StoredFloorFetcher) read(configs config.AccountPriceFloors, previous storedFloorsEntry) storedFloorsEntry {
+	ctx := context.Background()
+	if configs.Fetcher.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(configs.Fetcher.Timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	floorsJSON, errs := f.store.FetchFloors(ctx, configs.Fetcher.AccountID)
+	if len(errs) > 0 {
+		var notFound stored_requests.NotFoundError
+		if errors.As(errs[0], &notFound) {
+			return storedFloorsEntry{notFound: true}
+		}
+		logger.Errorf("Error while fetching stored floors for account %s, reason: %v", configs.Fetcher.AccountID, errs)
+		if previous.status == openrtb_ext.FetchSuccess {
+			return previous
+		}
+		if errors.Is(errs[0], context.DeadlineExceeded) {
+			return storedFloorsEntry{status: openrtb_ext.FetchTimeout}
+		}
+		return storedFloorsEntry{status: openrtb_ext.FetchError}
+	}
+
+	if previous.status == openrtb_ext.FetchSuccess && bytes.Equal(previous.raw, floorsJSON) {
+		return previous
+	}
+
+	priceFloors, err := parseStoredFloors(configs.Fetcher, floorsJSON)
+	if err != nil {
+		logger.Errorf("Invalid stored floors for account %s, reason: %s", configs.Fetcher.AccountID, err.Error())
+		return storedFloorsEntry{raw: floorsJSON, status: openrtb_ext.FetchError}
+	}
+	return storedFloorsEntry{raw: floorsJSON, rules: priceFloors, status: openrtb_ext.FetchSuccess}
+}
+
+// Stop waits for the floors being read from the store and terminates the fallback URL fetcher
+func (f *StoredFloorFetcher) Stop() {
+	f.refreshes.Wait()
+	if f.fallback != nil {
+		f.fallback.Stop()
+	}
+}
+
+func (f *StoredFloorFetcher) fetchFallback(configs config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	if f.fallback == nil {
+		return nil, openrtb_ext.FetchNone
+	}
+	return f.fallback.Fetch(configs)
+}
+
+// parseStoredFloors validates a stored floors entry with the same limits as floors fetched from a URL
+func parseStoredFloors(config config.AccountFloorFetch, floorsJSON json.RawMessage) (*openrtb_ext.PriceFloorRules, error) {
+	if config.MaxFileSizeKB > 0 && len(floorsJSON) > config.MaxFileSizeKB*1024 {
+		return nil, errors.New("floor file size is greater than MaxFileSize")
+	}
+
+	var entry storedFloors
+	if err := json.Unmarshal(floorsJSON, &entry); err != nil {
+		return nil, errors.New("invalid stored floors json: " + err.Error())
+	}
+	if len(entry.Version) == 0 {
+		return nil, errors.New("missing version in stored floors")
+	}
+
+	var priceFloors openrtb_ext.PriceFloorRules
+	if len(entry.Data) > 0 {
+		if err := json.Unmarshal(entry.Data, &priceFloors.Data); err != nil {
+			return nil, errors.New("invalid price floor json: " + err.Error())
+		}
+	}
+	if err := validateRules(config, &priceFloors); err != nil {
+		return nil, err
+	}
+	priceFloors.FetchVersion = entry.Version
+
+	return &priceFloors, nil
+}
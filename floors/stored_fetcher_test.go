@@ -0,0 +1,259 @@
+package floors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/stored_requests"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFloorsStore struct {
+	floors map[string]json.RawMessage
+	err    error
+	calls  int
+}
+
+func (m *mockFloorsStore) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	m.calls++
+	if m.err != nil {
+		return nil, []error{m.err}
+	}
+	if data, ok := m.floors[accountID]; ok {
+		return data, nil
+	}
+	return nil, []error{stored_requests.NotFoundError{ID: accountID, DataType: "Floors"}}
+}
+
+type mockFallbackFetcher struct {
+	calls   int
+	stopped bool
+}
+
+func (m *mockFallbackFetcher) Fetch(configs config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	m.calls++
+	return nil, openrtb_ext.FetchInprogress
+}
+
+func (m *mockFallbackFetcher) Stop() {
+	m.stopped = true
+}
+
+func TestStoredFloorFetcherFetch(t *testing.T) {
+	floorsData := `{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType"]},"values":{"banner":1.5}}]}`
+
+	testCases := []struct {
+		name                  string
+		store                 *mockFloorsStore
+		account               string
+		useDynamicData        bool
+		expectedStatus        string
+		expectedVersion       string
+		expectedFallbackCalls int
+	}{
+		{
+			name:            "stored-floors-found",
+			store:           &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"version":"v2","data":` + floorsData + `}`)}},
+			account:         "acc",
+			useDynamicData:  true,
+			expectedStatus:  openrtb_ext.FetchSuccess,
+			expectedVersion: "v2",
+		},
+		{
+			name:                  "stored-floors-not-found-uses-fallback",
+			store:                 &mockFloorsStore{},
+			account:               "acc",
+			useDynamicData:        true,
+			expectedStatus:        openrtb_ext.FetchInprogress,
+			expectedFallbackCalls: 1,
+		},
+		{
+			name:                  "dynamic-data-disabled-uses-fallback",
+			store:                 &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"version":"v2","data":` + floorsData + `}`)}},
+			account:               "acc",
+			expectedStatus:        openrtb_ext.FetchInprogress,
+			expectedFallbackCalls: 1,
+		},
+		{
+			name:           "missing-version",
+			store:          &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"data":` + floorsData + `}`)}},
+			account:        "acc",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+		{
+			name:           "invalid-rules",
+			store:          &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"version":"v1","data":{"currency":"USD"}}`)}},
+			account:        "acc",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+		{
+			name:           "store-error",
+			store:          &mockFloorsStore{err: errors.New("connection refused")},
+			account:        "acc",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+		{
+			name:           "store-timeout",
+			store:          &mockFloorsStore{err: context.DeadlineExceeded},
+			account:        "acc",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fallback := &mockFallbackFetcher{}
+			fetcher := NewStoredFloorFetcher(tc.store, fallback)
+
+			floors, status := fetchRead(fetcher, fallback, config.AccountPriceFloors{
+				UseDynamicData: tc.useDynamicData,
+				Fetcher: config.AccountFloorFetch{
+					Enabled:       true,
+					Timeout:       100,
+					MaxFileSizeKB: 10,
+					MaxRules:      10,
+					AccountID:     tc.account,
+				},
+			})
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Equal(t, tc.expectedFallbackCalls, fallback.calls)
+			if tc.expectedStatus == openrtb_ext.FetchSuccess {
+				assert.Equal(t, tc.expectedVersion, floors.FetchVersion)
+				assert.Equal(t, 1.5, floors.Data.ModelGroups[0].Values["banner"])
+			} else {
+				assert.Nil(t, floors)
+			}
+		})
+	}
+}
+
+// fetchRead fetches the floors of the account once they were read from the store, not counting the
+// calls made to the fallback while they were being read
+func fetchRead(fetcher *StoredFloorFetcher, fallback *mockFallbackFetcher, configs config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	fetcher.Fetch(configs)
+	fetcher.refreshes.Wait()
+	if fallback != nil {
+		fallback.calls = 0
+	}
+	return fetcher.Fetch(configs)
+}
+
+func TestStoredFloorFetcherFileSizeLimit(t *testing.T) {
+	store := &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"version":"v1","data":{"currency":"USD","modelgroups":[{"values":{"*":1}}]}}`)}}
+
+	floors, status := fetchRead(NewStoredFloorFetcher(store, nil), nil, config.AccountPriceFloors{
+		UseDynamicData: true,
+		Fetcher:        config.AccountFloorFetch{Enabled: true, MaxFileSizeKB: 0, MaxRules: 10, AccountID: "acc"},
+	})
+	assert.Equal(t, openrtb_ext.FetchSuccess, status, "A zero MaxFileSizeKB should not limit the stored floors")
+	assert.Equal(t, "v1", floors.FetchVersion)
+
+	store.floors["acc"] = json.RawMessage(`{"version":"v1","data":{"currency":"USD","modelgroups":[{"values":{"*":1}}],"floorprovider":"` + strings.Repeat("a", 1024) + `"}}`)
+	_, status = fetchRead(NewStoredFloorFetcher(store, nil), nil, config.AccountPriceFloors{
+		UseDynamicData: true,
+		Fetcher:        config.AccountFloorFetch{Enabled: true, MaxFileSizeKB: 1, MaxRules: 10, AccountID: "acc"},
+	})
+	assert.Equal(t, openrtb_ext.FetchError, status)
+}
+
+func TestStoredFloorFetcherReadsInBackground(t *testing.T) {
+	store := &mockFloorsStore{floors: map[string]json.RawMessage{"acc": json.RawMessage(`{"version":"v1","data":{"currency":"USD","modelgroups":[{"values":{"*":1}}]}}`)}}
+	clock := &fakeTime{now: time.Unix(1700000000, 0)}
+	fetcher := newStoredFloorFetcher(store, nil, clock)
+	configs := config.AccountPriceFloors{
+		UseDynamicData: true,
+		Fetcher:        config.AccountFloorFetch{Enabled: true, MaxRules: 10, AccountID: "acc"},
+	}
+
+	floors, status := fetcher.Fetch(configs)
+	assert.Nil(t, floors)
+	assert.Equal(t, openrtb_ext.FetchInprogress, status, "The auction should not wait for the store")
+
+	fetcher.refreshes.Wait()
+	first, status := fetcher.Fetch(configs)
+	assert.Equal(t, openrtb_ext.FetchSuccess, status)
+	assert.Equal(t, 1, store.calls, "Floors should be kept until the refresh interval elapses")
+
+	clock.now = clock.now.Add(storedFloorsRefreshInterval)
+	fetcher.Fetch(configs)
+	fetcher.refreshes.Wait()
+	second, status := fetcher.Fetch(configs)
+	assert.Equal(t, openrtb_ext.FetchSuccess, status)
+	assert.Equal(t, 2, store.calls)
+	assert.Same(t, first, second, "Unchanged stored floors should not be parsed again")
+
+	clock.now = clock.now.Add(storedFloorsRefreshInterval)
+	store.err = errors.New("connection refused")
+	fetcher.Fetch(configs)
+	fetcher.refreshes.Wait()
+	third, status := fetcher.Fetch(configs)
+	assert.Equal(t, openrtb_ext.FetchSuccess, status, "Floors should be kept when the store can't be read")
+	assert.Same(t, first, third)
+}
+
+func TestStoredFloorFetcherKeepsNotFound(t *testing.T) {
+	store := &mockFloorsStore{}
+	fallback := &mockFallbackFetcher{}
+	fetcher := NewStoredFloorFetcher(store, fallback)
+	configs := config.AccountPriceFloors{
+		UseDynamicData: true,
+		Fetcher:        config.AccountFloorFetch{Enabled: true, AccountID: "acc"},
+	}
+
+	fetchRead(fetcher, fallback, configs)
+	fetcher.Fetch(configs)
+
+	assert.Equal(t, 1, store.calls, "Accounts without stored floors should not be looked up on every auction")
+	assert.Equal(t, 2, fallback.calls)
+}
+
+func TestStoredFloorFetcherNilFallback(t *testing.T) {
+	fetcher := NewStoredFloorFetcher(&mockFloorsStore{}, nil)
+
+	floors, status := fetchRead(fetcher, nil, config.AccountPriceFloors{
+		UseDynamicData: true,
+		Fetcher:        config.AccountFloorFetch{Enabled: true, AccountID: "acc"},
+	})
+
+	assert.Nil(t, floors)
+	assert.Equal(t, openrtb_ext.FetchNone, status)
+	fetcher.Stop()
+}
+
+func TestStoredFloorFetcherStop(t *testing.T) {
+	fallback := &mockFallbackFetcher{}
+	NewStoredFloorFetcher(&mockFloorsStore{}, fallback).Stop()
+
+	assert.True(t, fallback.stopped)
+}
+
+func TestCreateFloorsFromKeepsVersionOfFetchedData(t *testing.T) {
+	floors := &openrtb_ext.PriceFloorRules{
+		FetchVersion: "v1",
+		Data: &openrtb_ext.PriceFloorData{
+			Currency: "USD",
+			ModelGroups: []openrtb_ext.PriceFloorModelGroup{{
+				Schema: openrtb_ext.PriceFloorSchema{Fields: []string{"mediaType"}, Delimiter: "|"},
+				Values: map[string]float64{"banner": 1},
+			}},
+		},
+	}
+	account := config.Account{PriceFloors: config.AccountPriceFloors{MaxRule: 10, MaxSchemaDims: 5}}
+
+	fetched, _ := createFloorsFrom(floors, account, openrtb_ext.FetchSuccess, openrtb_ext.FetchLocation)
+	assert.Equal(t, "v1", fetched.FetchVersion)
+
+	fromRequest, _ := createFloorsFrom(floors, account, openrtb_ext.FetchNone, openrtb_ext.RequestLocation)
+	assert.Empty(t, fromRequest.FetchVersion, "Version sent in the request should be dropped")
+}
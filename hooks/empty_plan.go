@@ -40,3 +40,7 @@ func (e EmptyPlanBuilder) PlanForAuctionResponseStage(endpoint string, account *
 func (e EmptyPlanBuilder) PlanForExitpointStage(endpoint string, account *config.Account) Plan[hookstage.Exitpoint] {
 	return nil
 }
+
+func (e EmptyPlanBuilder) ValidateAccountPlan(account *config.Account, endpoints []string) []error {
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package hookcache provides the caches shared by hook modules for the responses of the services they call.
+//
+// A cache is a bounded LRU of byte values with a TTL, keyed by a string the module derives from the
+// request. Caches created through the Registry report their hits and misses per module and can all be
+// purged at once, see the /hooks/cache/purge admin endpoint.
+package hookcache
+
+import (
+	"container/list"
+	"hash/maphash"
+	"sync"
+	"time"
+)
+
+const (
+	// maxShards is the number of independently locked shards of a large cache, so that concurrent
+	// lookups, which all move their entry to the front of an LRU list, rarely wait on each other.
+	maxShards = 16
+	// minShardBytes is the smallest shard size. Smaller caches have fewer shards, down to a single one.
+	minShardBytes = 64 * 1024
+)
+
+// Cache is a least recently used cache bounded by the size of its keys and values.
+// Entries expire once they are older than the TTL of the cache. It is safe for concurrent use.
+//
+// The keys are spread over shards with their own lock and LRU list, each holding an equal part of
+// the cache size, so the least recently used entry is evicted per shard rather than for the cache.
+type Cache struct {
+	shards  []*shard
+	seed    maphash.Seed
+	ttl     time.Duration
+	metrics *moduleMetrics
+	now     func() time.Time
+}
+
+type shard struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	entries  *list.List
+	items    map[string]*list.Element
+}
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// New returns a cache holding up to maxBytes of keys and values, whose entries expire after ttl.
+// Entries never expire when ttl is not positive.
+func New(maxBytes int, ttl time.Duration) *Cache {
+	count := min(max(maxBytes/minShardBytes, 1), maxShards)
+
+	shards := make([]*shard, count)
+	for i := range shards {
+		shards[i] = &shard{
+			maxBytes: maxBytes / count,
+			entries:  list.New(),
+			items:    make(map[string]*list.Element),
+		}
+	}
+
+	return &Cache{
+		shards: shards,
+		seed:   maphash.MakeSeed(),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Get returns the value cached for the key, if it has not expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	value, ok := c.shard(key).get(key, c.now())
+	if !ok {
+		c.metrics.recordMiss()
+		return nil, false
+	}
+
+	c.metrics.recordHit()
+	return value, true
+}
+
+// Set caches the value for the key, evicting the least recently used entries of its shard to make
+// room for it. Values which do not fit in a shard on their own are not cached.
+func (c *Cache) Set(key string, value []byte) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	c.shard(key).set(&entry{key: key, value: value, expires: expires})
+}
+
+// Len returns the number of entries of the cache, including the expired entries which were not evicted yet.
+func (c *Cache) Len() int {
+	total := 0
+	for _, s := range c.shards {
+		s.mu.Lock()
+		total += s.entries.Len()
+		s.mu.Unlock()
+	}
+	return total
+}
+
+// Purge removes all entries of the cache and returns their number.
+func (c *Cache) Purge() int {
+	purged := 0
+	for _, s := range c.shards {
+		purged += s.purge()
+	}
+	return purged
+}
+
+func (c *Cache) shard(key string) *shard {
+	if len(c.shards) == 1 {
+		return c.shards[0]
+	}
+	return c.shards[maphash.String(c.seed, key)%uint64(len(c.shards))]
+}
+
+func (s *shard) get(key string, now time.Time) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if !e.expires.IsZero() && !now.Before(e.expires) {
+		s.remove(element)
+		return nil, false
+	}
+
+	s.entries.MoveToFront(element)
+	return e.value, true
+}
+
+func (s *shard) set(e *entry) {
+	entrySize := len(e.key) + len(e.value)
+	if entrySize > s.maxBytes {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.items[e.key]; ok {
+		s.remove(element)
+	}
+
+	for s.size+entrySize > s.maxBytes {
+		s.remove(s.entries.Back())
+	}
+
+	s.items[e.key] = s.entries.PushFront(e)
+	s.size += entrySize
+}
+
+func (s *shard) purge() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := s.entries.Len()
+	s.entries.Init()
+	s.items = make(map[string]*list.Element)
+	s.size = 0
+	return purged
+}
+
+func (s *shard) remove(element *list.Element) {
+	e := s.entries.Remove(element).(*entry)
+	delete(s.items, e.key)
+	s.size -= len(e.key) + len(e.value)
+}
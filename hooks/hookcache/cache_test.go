@@ -0,0 +1,145 @@
+package hookcache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheGetSet(t *testing.T) {
+	cache := New(1024, 0)
+
+	_, ok := cache.Get("key")
+	assert.False(t, ok, "Unexpected value before set")
+
+	cache.Set("key", []byte("value"))
+	value, ok := cache.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	cache.Set("key", []byte("other"))
+	value, ok = cache.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("other"), value)
+	assert.Equal(t, 1, cache.Len(), "Replaced value should not add an entry")
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	// each entry takes 2 bytes, so the cache holds 3 of them
+	cache := New(6, 0)
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+	cache.Set("c", []byte("3"))
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	cache.Set("d", []byte("4"))
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "Least recently used entry should be evicted")
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok = cache.Get(key)
+		assert.True(t, ok, "Entry %s should be kept", key)
+	}
+}
+
+func TestCacheSkipsOversizedValues(t *testing.T) {
+	cache := New(4, 0)
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("too large"))
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "Value larger than the cache should not be cached")
+	_, ok = cache.Get("a")
+	assert.True(t, ok, "Oversized value should not evict entries")
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cache := New(1024, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", []byte("value"))
+
+	now = now.Add(59 * time.Second)
+	_, ok := cache.Get("key")
+	assert.True(t, ok, "Entry should be kept before its TTL")
+
+	now = now.Add(time.Second)
+	_, ok = cache.Get("key")
+	assert.False(t, ok, "Entry should expire after its TTL")
+	assert.Equal(t, 0, cache.Len(), "Expired entry should be evicted on get")
+}
+
+func TestCachePurge(t *testing.T) {
+	cache := New(6, 0)
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+
+	assert.Equal(t, 2, cache.Purge())
+	assert.Equal(t, 0, cache.Len())
+
+	// the whole size is available again after the purge
+	cache.Set("c", []byte("3"))
+	cache.Set("d", []byte("4"))
+	cache.Set("e", []byte("5"))
+	assert.Equal(t, 3, cache.Len())
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	cache := New(64, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d-%d", i, j%10)
+				cache.Set(key, []byte("value"))
+				cache.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, s := range cache.shards {
+		assert.LessOrEqual(t, s.size, s.maxBytes)
+	}
+}
+
+func TestCacheShards(t *testing.T) {
+	assert.Len(t, New(minShardBytes, 0).shards, 1, "Small cache should have a single shard")
+	assert.Len(t, New(4*minShardBytes, 0).shards, 4)
+	assert.Len(t, New(1024*minShardBytes, 0).shards, maxShards)
+
+	cache := New(4*minShardBytes, 0)
+	for _, s := range cache.shards {
+		assert.Equal(t, minShardBytes, s.maxBytes, "Shards should split the cache size")
+	}
+
+	for i := 0; i < 100; i++ {
+		cache.Set(fmt.Sprintf("key-%d", i), []byte("value"))
+	}
+	assert.Equal(t, 100, cache.Len())
+	for i := 0; i < 100; i++ {
+		value, ok := cache.Get(fmt.Sprintf("key-%d", i))
+		assert.True(t, ok)
+		assert.Equal(t, []byte("value"), value)
+	}
+
+	populated := 0
+	for _, s := range cache.shards {
+		if s.entries.Len() > 0 {
+			populated++
+		}
+	}
+	assert.Greater(t, populated, 1, "Keys should be spread over the shards")
+
+	assert.Equal(t, 100, cache.Purge())
+	assert.Equal(t, 0, cache.Len())
+}
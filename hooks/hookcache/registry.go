@@ -0,0 +1,147 @@
+package hookcache
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	prometheusmetrics "github.com/prebid/prebid-server/v3/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Registry creates the caches of the modules and keeps track of them, so that they can be purged together.
+// A nil Registry creates caches which are neither tracked nor reported in metrics.
+type Registry struct {
+	mu      sync.Mutex
+	caches  map[string][]*Cache
+	metrics *registryMetrics
+}
+
+// NewRegistry returns a registry reporting the hits and misses of its caches with the registerer,
+// which is nil when Prometheus metrics are disabled.
+func NewRegistry(registerer prometheus.Registerer) (*Registry, error) {
+	metrics, err := newRegistryMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Registry{
+		caches:  make(map[string][]*Cache),
+		metrics: metrics,
+	}, nil
+}
+
+// NewCache returns a new cache of the module, see New. The module is the code of the module
+// in the "vendor.module_name" format, used to label its metrics and to purge its caches.
+func (r *Registry) NewCache(module string, maxBytes int, ttl time.Duration) *Cache {
+	cache := New(maxBytes, ttl)
+	if r == nil {
+		return cache
+	}
+
+	cache.metrics = r.metrics.forModule(module)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.caches[module] = append(r.caches[module], cache)
+	return cache
+}
+
+// Modules returns the codes of the modules which have caches, sorted.
+func (r *Registry) Modules() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.caches))
+}
+
+// Purge removes the entries of the caches of the module, or of all modules when module is empty,
+// and returns the number of entries removed per module.
+func (r *Registry) Purge(module string) map[string]int {
+	purged := make(map[string]int)
+	if r == nil {
+		return purged
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, caches := range r.caches {
+		if len(module) > 0 && name != module {
+			continue
+		}
+		for _, cache := range caches {
+			purged[name] += cache.Purge()
+		}
+	}
+	return purged
+}
+
+const metricsPrefix = "modules_cache_"
+
+// registryMetrics holds the collectors of the caches, labeled by module. Its collectors are nil
+// when Prometheus metrics are disabled, and so are the metrics it hands out to the caches.
+type registryMetrics struct {
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+func newRegistryMetrics(registerer prometheus.Registerer) (*registryMetrics, error) {
+	m := &registryMetrics{}
+	if registerer == nil {
+		return m, nil
+	}
+
+	var err error
+	m.hits, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "hits",
+		Help: "Count of module cache lookups which found an entry, labeled by module.",
+	}, []string{"module"}))
+	if err != nil {
+		return nil, err
+	}
+
+	m.misses, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "misses",
+		Help: "Count of module cache lookups which found no entry or an expired one, labeled by module.",
+	}, []string{"module"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *registryMetrics) forModule(module string) *moduleMetrics {
+	if m == nil || m.hits == nil {
+		return nil
+	}
+	return &moduleMetrics{
+		hits:   m.hits.WithLabelValues(module),
+		misses: m.misses.WithLabelValues(module),
+	}
+}
+
+// moduleMetrics holds the counters of the caches of a module. All methods are no-ops on a nil receiver.
+type moduleMetrics struct {
+	hits   prometheus.Counter
+	misses prometheus.Counter
+}
+
+func (m *moduleMetrics) recordHit() {
+	if m == nil {
+		return
+	}
+	m.hits.Inc()
+}
+
+func (m *moduleMetrics) recordMiss() {
+	if m == nil {
+		return
+	}
+	m.misses.Inc()
+}
@@ -0,0 +1,67 @@
+package hookcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	caches, err := NewRegistry(registry)
+	require.NoError(t, err)
+
+	first := caches.NewCache("vendor.first", 1024, time.Minute)
+	second := caches.NewCache("vendor.second", 1024, time.Minute)
+
+	first.Set("key", []byte("value"))
+	first.Get("key")
+	first.Get("key")
+	first.Get("missing")
+	second.Get("missing")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(caches.metrics.hits.WithLabelValues("vendor.first")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(caches.metrics.misses.WithLabelValues("vendor.first")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(caches.metrics.hits.WithLabelValues("vendor.second")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(caches.metrics.misses.WithLabelValues("vendor.second")))
+
+	// a second registry on the same Prometheus registry reuses the registered collectors
+	again, err := NewRegistry(registry)
+	require.NoError(t, err)
+	again.NewCache("vendor.first", 1024, time.Minute).Get("missing")
+	assert.Equal(t, 2.0, testutil.ToFloat64(caches.metrics.misses.WithLabelValues("vendor.first")))
+}
+
+func TestRegistryPurge(t *testing.T) {
+	caches, err := NewRegistry(nil)
+	require.NoError(t, err)
+
+	first := caches.NewCache("vendor.first", 1024, 0)
+	first.Set("a", []byte("1"))
+	other := caches.NewCache("vendor.first", 1024, 0)
+	other.Set("a", []byte("1"))
+	second := caches.NewCache("vendor.second", 1024, 0)
+	second.Set("a", []byte("1"))
+
+	assert.Equal(t, []string{"vendor.first", "vendor.second"}, caches.Modules())
+	assert.Equal(t, map[string]int{"vendor.first": 2}, caches.Purge("vendor.first"))
+	assert.Equal(t, 1, second.Len(), "Caches of other modules should be kept")
+	assert.Equal(t, map[string]int{"vendor.first": 0, "vendor.second": 1}, caches.Purge(""))
+	assert.Equal(t, map[string]int{}, caches.Purge("vendor.unknown"))
+}
+
+func TestNilRegistry(t *testing.T) {
+	var caches *Registry
+
+	cache := caches.NewCache("vendor.module", 1024, 0)
+	cache.Set("key", []byte("value"))
+	_, ok := cache.Get("key")
+
+	assert.True(t, ok, "Cache of a nil registry should work without metrics")
+	assert.Empty(t, caches.Modules())
+	assert.Empty(t, caches.Purge(""))
+}
@@ -2,6 +2,7 @@ package hookexecution
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
@@ -17,6 +18,21 @@ type executionContext struct {
 	account         *config.Account
 	moduleContexts  *moduleContexts
 	activityControl privacy.ActivityControl
+	// deadline bounds the execution of the stage, it is zero when the stage is only bounded by group timeouts
+	deadline time.Time
+	// domain is the site domain or the app bundle of the request, once known
+	domain  string
+	sampler *moduleSampler
+}
+
+// groupDeadline returns the time by which the hooks of a group with the given timeout must complete,
+// so that the groups of a stage never run past the stage deadline however many groups the stage has.
+func (ctx executionContext) groupDeadline(timeout time.Duration) time.Time {
+	deadline := time.Now().Add(timeout)
+	if !ctx.deadline.IsZero() && ctx.deadline.Before(deadline) {
+		return ctx.deadline
+	}
+	return deadline
 }
 
 func (ctx executionContext) getModuleContext(moduleName string) hookstage.ModuleInvocationContext {
@@ -37,6 +37,7 @@ type HookOutcomeTest struct {
 	Action        Action                  `json:"action"`
 	Message       string                  `json:"message"`
 	DebugMessages []string                `json:"debug_messages"`
+	SkipReason    SkipReason              `json:"skip_reason"`
 	Errors        []string                `json:"errors"`
 	Warnings      []string                `json:"warnings"`
 }
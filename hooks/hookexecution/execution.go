@@ -22,8 +22,10 @@ import (
 type hookResponse[T any] struct {
 	Err           error
 	ExecutionTime time.Duration
-	HookID        HookID
-	Result        hookstage.HookResult[T]
+	// WaitTime is the time the hook waited for the hooks it runs after
+	WaitTime time.Duration
+	HookID   HookID
+	Result   hookstage.HookResult[T]
 }
 
 type hookHandler[H any, P any] func(
@@ -70,16 +72,46 @@ func executeGroup[H any, P any](
 	var wg sync.WaitGroup
 	rejected := make(chan struct{})
 	resp := make(chan hookResponse[P], len(group.Hooks))
-
+	modules := make([]string, 0, len(group.Hooks))
 	for _, hook := range group.Hooks {
-		mCtx := executionCtx.getModuleContext(hook.Module)
-		mCtx.HookImplCode = hook.Code
-		newPayload := handleModuleActivities(hook.Code, executionCtx.activityControl, payload, executionCtx.account)
+		modules = append(modules, hook.Module)
+	}
+	schedule := newGroupSchedule(modules)
+
+	// hooks are prepared before the group deadline is set, so that it doesn't count towards their timeout
+	var skipped []HookOutcome
+	moduleCtxs := make([]hookstage.ModuleInvocationContext, len(group.Hooks))
+	payloads := make([]P, len(group.Hooks))
+	running := make([]bool, len(group.Hooks))
+	for i, hook := range group.Hooks {
+		// skipped hooks are done right away, so that the hooks running after them are not kept waiting
+		if reason := executionCtx.skipReason(hook.Module, hook.Toggle); len(reason) > 0 {
+			schedule.markDone(i, nil)
+			skipped = append(skipped, HookOutcome{
+				HookID:     HookID{ModuleCode: hook.Module, HookImplCode: hook.Code},
+				Status:     StatusSkipped,
+				Action:     ActionNone,
+				SkipReason: reason,
+			})
+			continue
+		}
+
+		running[i] = true
+		moduleCtxs[i] = executionCtx.getModuleContext(hook.Module)
+		moduleCtxs[i].HookImplCode = hook.Code
+		payloads[i] = handleModuleActivities(hook.Code, executionCtx.activityControl, payload, executionCtx.account)
+	}
+
+	deadline := executionCtx.groupDeadline(group.Timeout)
+	for i, hook := range group.Hooks {
+		if !running[i] {
+			continue
+		}
 		wg.Add(1)
-		go func(hw hooks.HookWrapper[H], moduleCtx hookstage.ModuleInvocationContext) {
+		go func(i int, hw hooks.HookWrapper[H]) {
 			defer wg.Done()
-			executeHook(moduleCtx, hw, newPayload, hookHandler, group.Timeout, group.RejectOnTimeout, resp, rejected)
-		}(hook, mCtx)
+			executeHook(moduleCtxs[i], hw, payloads[i], hookHandler, deadline, group.RejectOnTimeout, schedule, i, resp, rejected)
+		}(i, hook)
 	}
 
 	go func() {
@@ -89,22 +121,71 @@ func executeGroup[H any, P any](
 
 	hookResponses := collectHookResponses(resp, rejected)
 
-	return handleHookResponses(executionCtx, hookResponses, payload, metricEngine)
+	groupOutcome, payload, groupModuleCtx, rejectErr := handleHookResponses(executionCtx, hookResponses, payload, metricEngine)
+	groupOutcome.InvocationResults = append(groupOutcome.InvocationResults, skipped...)
+	return groupOutcome, payload, groupModuleCtx, rejectErr
 }
 
+// executeHook runs the hook once the hooks it runs after are done, and responds with a timeout
+// if they or the hook itself do not complete before the group deadline
 func executeHook[H any, P any](
 	moduleCtx hookstage.ModuleInvocationContext,
 	hw hooks.HookWrapper[H],
 	payload P,
 	hookHandler hookHandler[H, P],
-	timeout time.Duration,
+	deadline time.Time,
 	rejectOnTimeout bool,
+	schedule *groupSchedule,
+	hookIndex int,
 	resp chan<- hookResponse[P],
 	rejected <-chan struct{},
 ) {
+	var resultModuleCtx hookstage.ModuleContext
+	defer func() {
+		schedule.markDone(hookIndex, resultModuleCtx)
+	}()
+
 	hookRespCh := make(chan hookResponse[P], 1)
 	startTime := time.Now()
 	hookId := HookID{ModuleCode: hw.Module, HookImplCode: hw.Code}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	timeoutResponse := func(waitTime, executionTime time.Duration) hookResponse[P] {
+		result := hookstage.HookResult[P]{}
+		if rejectOnTimeout {
+			result.Reject = true
+		}
+		return hookResponse[P]{
+			Err:           TimeoutError{},
+			ExecutionTime: executionTime,
+			WaitTime:      waitTime,
+			HookID:        hookId,
+			Result:        result,
+		}
+	}
+
+	for _, dependency := range schedule.dependencies(hookIndex, hw.RunAfter) {
+		select {
+		case <-dependency:
+		case <-timer.C:
+			resp <- timeoutResponse(time.Since(startTime), 0)
+			return
+		case <-rejected:
+			return
+		}
+	}
+	waitTime := time.Since(startTime)
+	startTime = time.Now()
+	if len(hw.RunAfter) > 0 {
+		moduleCtx.DependencyContexts = schedule.dependencyContexts(hw.RunAfter)
+	}
+
+	// the hook is not started when no time is left for it
+	if !startTime.Before(deadline) {
+		resp <- timeoutResponse(waitTime, 0)
+		return
+	}
 
 	go func() {
 		defer func() {
@@ -114,7 +195,7 @@ func executeHook[H any, P any](
 			}
 		}()
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
 		defer cancel()
 		result, err := hookHandler(ctx, moduleCtx, hw.Hook, payload)
 		hookRespCh <- hookResponse[P]{
@@ -127,18 +208,11 @@ func executeHook[H any, P any](
 	case res := <-hookRespCh:
 		res.HookID = hookId
 		res.ExecutionTime = time.Since(startTime)
+		res.WaitTime = waitTime
+		resultModuleCtx = res.Result.ModuleContext
 		resp <- res
-	case <-time.After(timeout):
-		result := hookstage.HookResult[P]{}
-		if rejectOnTimeout {
-			result.Reject = true
-		}
-		resp <- hookResponse[P]{
-			Err:           TimeoutError{},
-			ExecutionTime: time.Since(startTime),
-			HookID:        hookId,
-			Result:        result,
-		}
+	case <-timer.C:
+		resp <- timeoutResponse(waitTime, time.Since(startTime))
 	case <-rejected:
 		return
 	}
@@ -169,8 +243,9 @@ func handleHookResponses[P any](
 
 	for _, r := range hookResponses {
 		groupModuleCtx[r.HookID.ModuleCode] = r.Result.ModuleContext
-		if r.ExecutionTime > groupOutcome.ExecutionTimeMillis {
-			groupOutcome.ExecutionTimeMillis = r.ExecutionTime
+		// hooks which run after others complete later than their execution time
+		if r.WaitTime+r.ExecutionTime > groupOutcome.ExecutionTimeMillis {
+			groupOutcome.ExecutionTimeMillis = r.WaitTime + r.ExecutionTime
 		}
 
 		updatedPayload, hookOutcome, rejectErr := handleHookResponse(executionCtx, payload, r, metricEngine)
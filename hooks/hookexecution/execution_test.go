@@ -1,14 +1,19 @@
 package hookexecution
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	metricsConfig "github.com/prebid/prebid-server/v3/metrics/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/privacy"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -233,3 +238,273 @@ func TestHandleModuleActivitiesNoBidderRequestPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteGroupRunAfter(t *testing.T) {
+	executionCtx := executionContext{endpoint: EndpointAuction, stage: hooks.StageEntrypoint.String()}
+	handler := func(ctx context.Context, moduleCtx hookstage.ModuleInvocationContext, hook hookstage.Entrypoint, payload hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+		return hook.HandleEntrypointHook(ctx, moduleCtx, payload)
+	}
+
+	testCases := []struct {
+		description             string
+		group                   hooks.Group[hookstage.Entrypoint]
+		expectedStatuses        map[string]Status
+		expectedModuleContexts  groupModuleContext
+		expectedMinGroupElapsed time.Duration
+	}{
+		{
+			description: "Hook starts once the hooks it runs after complete and reads their module contexts",
+			group: hooks.Group[hookstage.Entrypoint]{
+				Timeout: 500 * time.Millisecond,
+				Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+					{Module: "module-1", Code: "after", Hook: mockRunAfterHook{key: "key-1", val: "val-1"}, RunAfter: []string{"module-2", "module-3"}},
+					{Module: "module-2", Code: "slow", Hook: mockRunAfterHook{delay: 20 * time.Millisecond, key: "key-2", val: "val-2"}},
+					{Module: "module-3", Code: "fast", Hook: mockRunAfterHook{key: "key-3", val: "val-3"}},
+				},
+			},
+			expectedStatuses: map[string]Status{"after": StatusSuccess, "slow": StatusSuccess, "fast": StatusSuccess},
+			expectedModuleContexts: groupModuleContext{
+				"module-1": {"key-1": "val-1", "module-2.key-2": "val-2", "module-3.key-3": "val-3"},
+				"module-2": {"key-2": "val-2"},
+				"module-3": {"key-3": "val-3"},
+			},
+			expectedMinGroupElapsed: 20 * time.Millisecond,
+		},
+		{
+			description: "Hook waiting for a hook that does not complete in time times out with the group",
+			group: hooks.Group[hookstage.Entrypoint]{
+				Timeout: 50 * time.Millisecond,
+				Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+					{Module: "module-1", Code: "after", Hook: mockRunAfterHook{key: "key-1", val: "val-1"}, RunAfter: []string{"module-2"}},
+					{Module: "module-2", Code: "slow", Hook: mockRunAfterHook{delay: 100 * time.Millisecond, key: "key-2", val: "val-2"}},
+				},
+			},
+			expectedStatuses: map[string]Status{"after": StatusTimeout, "slow": StatusTimeout},
+			expectedModuleContexts: groupModuleContext{
+				"module-1": nil,
+				"module-2": nil,
+			},
+			expectedMinGroupElapsed: 50 * time.Millisecond,
+		},
+		{
+			description: "Hooks running after each other time out with the group",
+			group: hooks.Group[hookstage.Entrypoint]{
+				Timeout: 10 * time.Millisecond,
+				Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+					{Module: "module-1", Code: "foo", Hook: mockRunAfterHook{key: "key-1", val: "val-1"}, RunAfter: []string{"module-2"}},
+					{Module: "module-2", Code: "bar", Hook: mockRunAfterHook{key: "key-2", val: "val-2"}, RunAfter: []string{"module-1"}},
+					{Module: "module-3", Code: "baz", Hook: mockRunAfterHook{key: "key-3", val: "val-3"}},
+				},
+			},
+			expectedStatuses: map[string]Status{"foo": StatusTimeout, "bar": StatusTimeout, "baz": StatusSuccess},
+			expectedModuleContexts: groupModuleContext{
+				"module-1": nil,
+				"module-2": nil,
+				"module-3": {"key-3": "val-3"},
+			},
+			expectedMinGroupElapsed: 10 * time.Millisecond,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			start := time.Now()
+			outcome, _, moduleContexts, rejectErr := executeGroup(executionCtx, test.group, hookstage.EntrypointPayload{}, handler, &metricsConfig.NilMetricsEngine{})
+			elapsed := time.Since(start)
+
+			assert.Nil(t, rejectErr)
+			assert.Equal(t, test.expectedModuleContexts, moduleContexts)
+			statuses := make(map[string]Status, len(outcome.InvocationResults))
+			for _, result := range outcome.InvocationResults {
+				statuses[result.HookID.HookImplCode] = result.Status
+			}
+			assert.Equal(t, test.expectedStatuses, statuses)
+			assert.GreaterOrEqual(t, outcome.ExecutionTimeMillis, test.expectedMinGroupElapsed, "Group execution time should include the time spent waiting")
+			assert.LessOrEqual(t, outcome.ExecutionTimeMillis, elapsed)
+		})
+	}
+}
+
+func TestExecuteStageDeadline(t *testing.T) {
+	handler := func(ctx context.Context, moduleCtx hookstage.ModuleInvocationContext, hook hookstage.Entrypoint, payload hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+		return hook.HandleEntrypointHook(ctx, moduleCtx, payload)
+	}
+	plan := hooks.Plan[hookstage.Entrypoint]{
+		{
+			Timeout: 500 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+				{Module: "module-1", Code: "slow", Hook: mockRunAfterHook{delay: 100 * time.Millisecond, key: "key-1", val: "val-1"}},
+				{Module: "module-2", Code: "fast", Hook: mockRunAfterHook{key: "key-2", val: "val-2"}},
+			},
+		},
+		{
+			Timeout: 500 * time.Millisecond,
+			Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+				{Module: "module-3", Code: "late", Hook: mockRunAfterHook{key: "key-3", val: "val-3"}},
+			},
+		},
+	}
+	executionCtx := executionContext{
+		endpoint: EndpointAuction,
+		stage:    hooks.StageEntrypoint.String(),
+		deadline: time.Now().Add(30 * time.Millisecond),
+	}
+
+	start := time.Now()
+	outcome, _, moduleContexts, rejectErr := executeStage(executionCtx, plan, hookstage.EntrypointPayload{}, handler, &metricsConfig.NilMetricsEngine{})
+	elapsed := time.Since(start)
+
+	assert.Nil(t, rejectErr)
+	assert.Less(t, elapsed, 100*time.Millisecond, "Stage should not run past its deadline")
+	require.Len(t, outcome.Groups, 2)
+
+	statuses := make(map[string]Status)
+	for _, group := range outcome.Groups {
+		for _, result := range group.InvocationResults {
+			statuses[result.HookID.HookImplCode] = result.Status
+		}
+	}
+	assert.Equal(t, map[string]Status{"slow": StatusTimeout, "fast": StatusSuccess, "late": StatusTimeout}, statuses)
+	assert.Equal(t, []groupModuleContext{
+		{"module-1": nil, "module-2": {"key-2": "val-2"}},
+		{"module-3": nil},
+	}, moduleContexts.groupCtx, "Hooks of groups starting after the deadline should not run")
+}
+
+func TestExecuteGroupToggles(t *testing.T) {
+	handler := func(ctx context.Context, moduleCtx hookstage.ModuleInvocationContext, hook hookstage.Entrypoint, payload hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+		return hook.HandleEntrypointHook(ctx, moduleCtx, payload)
+	}
+	disabled, enabled := false, true
+	percent := func(p float64) *float64 { return &p }
+
+	testCases := []struct {
+		description        string
+		accountID          string
+		domain             string
+		draw               float64
+		toggle             config.HookToggle
+		expectedStatus     Status
+		expectedSkipReason SkipReason
+	}{
+		{
+			description:    "Hook without toggle is called",
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:    "Enabled hook is called",
+			toggle:         config.HookToggle{Enabled: &enabled},
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:        "Disabled hook is skipped",
+			toggle:             config.HookToggle{Enabled: &disabled},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonDisabled,
+		},
+		{
+			description:    "Hook is called for an allowed publisher",
+			accountID:      "pub-1",
+			toggle:         config.HookToggle{AllowPublishers: []string{"pub-1"}},
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:        "Hook is skipped for a publisher that is not allowed",
+			accountID:          "pub-2",
+			toggle:             config.HookToggle{AllowPublishers: []string{"pub-1"}},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonPublisher,
+		},
+		{
+			description:        "Hook restricted to allowed publishers is skipped when the publisher is not known",
+			toggle:             config.HookToggle{AllowPublishers: []string{"pub-1"}},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonPublisher,
+		},
+		{
+			description:        "Deny list takes precedence over allow list",
+			accountID:          "pub-1",
+			toggle:             config.HookToggle{AllowPublishers: []string{"pub-1"}, DenyPublishers: []string{"pub-1"}},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonPublisher,
+		},
+		{
+			description:    "Hook is called for a subdomain of an allowed domain",
+			domain:         "news.Example.com",
+			toggle:         config.HookToggle{AllowDomains: []string{"example.com"}},
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:        "Hook is skipped for a denied domain",
+			domain:             "example.com",
+			toggle:             config.HookToggle{DenyDomains: []string{"example.com"}},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonDomain,
+		},
+		{
+			description:    "Hook is not skipped for a domain ending like a denied domain",
+			domain:         "notexample.com",
+			toggle:         config.HookToggle{DenyDomains: []string{"example.com"}},
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:    "Sampled in hook is called",
+			draw:           0.249,
+			toggle:         config.HookToggle{SamplePercent: percent(25)},
+			expectedStatus: StatusSuccess,
+		},
+		{
+			description:        "Sampled out hook is skipped",
+			draw:               0.25,
+			toggle:             config.HookToggle{SamplePercent: percent(25)},
+			expectedStatus:     StatusSkipped,
+			expectedSkipReason: SkipReasonSampledOut,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			executionCtx := executionContext{
+				endpoint:  EndpointAuction,
+				stage:     hooks.StageEntrypoint.String(),
+				accountID: test.accountID,
+				domain:    test.domain,
+				sampler:   newModuleSampler(func() float64 { return test.draw }),
+			}
+			group := hooks.Group[hookstage.Entrypoint]{
+				Timeout: 500 * time.Millisecond,
+				Hooks: []hooks.HookWrapper[hookstage.Entrypoint]{
+					{Module: "module-1", Code: "toggled", Hook: mockRunAfterHook{key: "key-1", val: "val-1"}, Toggle: test.toggle},
+					{Module: "module-2", Code: "dependent", Hook: mockRunAfterHook{key: "key-2", val: "val-2"}, RunAfter: []string{"module-1"}},
+				},
+			}
+
+			outcome, _, _, rejectErr := executeGroup(executionCtx, group, hookstage.EntrypointPayload{}, handler, &metricsConfig.NilMetricsEngine{})
+
+			assert.Nil(t, rejectErr)
+			outcomes := make(map[string]HookOutcome, len(outcome.InvocationResults))
+			for _, result := range outcome.InvocationResults {
+				outcomes[result.HookID.HookImplCode] = result
+			}
+			assert.Equal(t, test.expectedStatus, outcomes["toggled"].Status)
+			assert.Equal(t, test.expectedSkipReason, outcomes["toggled"].SkipReason)
+			assert.Equal(t, StatusSuccess, outcomes["dependent"].Status, "Hook running after a skipped hook should not wait for it")
+		})
+	}
+}
+
+func TestModuleSamplerDrawsOncePerModule(t *testing.T) {
+	draws := []float64{0.1, 0.9}
+	sampler := newModuleSampler(func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	})
+
+	assert.True(t, sampler.sampled("module-1", 50))
+	assert.True(t, sampler.sampled("module-1", 20), "Module sampled in at a lower percent should stay sampled in")
+	assert.False(t, sampler.sampled("module-1", 5))
+	assert.False(t, sampler.sampled("module-2", 50))
+	assert.True(t, sampler.sampled("module-2", 100))
+	assert.Empty(t, draws)
+}
@@ -2,8 +2,11 @@ package hookexecution
 
 import (
 	"context"
+	"errors"
+	"math/rand"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/adapters"
@@ -11,6 +14,7 @@ import (
 	"github.com/prebid/prebid-server/v3/exchange/entities"
 	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/metrics"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/privacy"
@@ -21,6 +25,10 @@ const (
 	EndpointAmp     = "/openrtb2/amp"
 )
 
+// accountPlanWarnings holds the problems last logged for the execution plan of each account, so they
+// are logged once rather than for every request of the account.
+var accountPlanWarnings sync.Map
+
 // An entity specifies the type of object that was processed during the execution of the stage.
 type entity string
 
@@ -47,6 +55,7 @@ type HookStageExecutor interface {
 	StageExecutor
 	SetAccount(account *config.Account)
 	SetActivityControl(activityControl privacy.ActivityControl)
+	SetDeadline(deadline time.Time)
 	GetOutcomes() []StageOutcome
 }
 
@@ -59,6 +68,9 @@ type hookExecutor struct {
 	moduleContexts  *moduleContexts
 	metricEngine    metrics.MetricsEngine
 	activityControl privacy.ActivityControl
+	deadline        time.Time
+	domain          string
+	sampler         *moduleSampler
 	// Mutex needed for BidderRequest and RawBidderResponse Stages as they are run in several goroutines
 	sync.Mutex
 }
@@ -70,6 +82,7 @@ func NewHookExecutor(builder hooks.ExecutionPlanBuilder, endpoint string, me met
 		stageOutcomes:  []StageOutcome{},
 		moduleContexts: &moduleContexts{ctxs: make(map[string]hookstage.ModuleContext)},
 		metricEngine:   me,
+		sampler:        newModuleSampler(rand.Float64),
 	}
 }
 
@@ -80,12 +93,37 @@ func (e *hookExecutor) SetAccount(account *config.Account) {
 
 	e.account = account
 	e.accountID = account.ID
+	e.validateAccountPlan()
+}
+
+// validateAccountPlan logs the problems of the execution plan of the account when they change.
+func (e *hookExecutor) validateAccountPlan() {
+	errs := e.planBuilder.ValidateAccountPlan(e.account, []string{EndpointAuction, EndpointAmp})
+	if len(errs) == 0 {
+		accountPlanWarnings.Delete(e.accountID)
+		return
+	}
+
+	warning := errors.Join(errs...).Error()
+	if previous, loaded := accountPlanWarnings.Swap(e.accountID, warning); loaded && previous == warning {
+		return
+	}
+	for _, err := range errs {
+		logger.Warnf("Invalid hook execution plan of account %s: %v", e.accountID, err)
+	}
 }
 
 func (e *hookExecutor) SetActivityControl(activityControl privacy.ActivityControl) {
 	e.activityControl = activityControl
 }
 
+// SetDeadline sets the deadline derived from the request tmax. The hook groups of the stages executed
+// afterwards and before the auction are not allowed to run past it, whatever their own timeouts are.
+// The stages from the bidder requests on are only bounded by their group timeouts.
+func (e *hookExecutor) SetDeadline(deadline time.Time) {
+	e.deadline = deadline
+}
+
 func (e *hookExecutor) GetOutcomes() []StageOutcome {
 	return e.stageOutcomes
 }
@@ -124,6 +162,7 @@ func (e *hookExecutor) ExecuteRawAuctionStage(requestBody []byte) ([]byte, *Reje
 	if len(plan) == 0 {
 		return requestBody, nil
 	}
+	e.domain = rawRequestDomain(requestBody)
 
 	handler := func(
 		ctx context.Context,
@@ -149,6 +188,11 @@ func (e *hookExecutor) ExecuteRawAuctionStage(requestBody []byte) ([]byte, *Reje
 }
 
 func (e *hookExecutor) ExecuteProcessedAuctionStage(request *openrtb_ext.RequestWrapper) error {
+	// the domain of the processed request is used by the toggles of the hooks of this and later stages
+	if request != nil && request.BidRequest != nil {
+		e.domain = requestDomain(request.BidRequest)
+	}
+
 	plan := e.planBuilder.PlanForProcessedAuctionStage(e.endpoint, e.account)
 	if len(plan) == 0 {
 		return nil
@@ -327,6 +371,11 @@ func (e *hookExecutor) ExecuteExitpointStage(response any, w http.ResponseWriter
 }
 
 func (e *hookExecutor) newContext(stage string) executionContext {
+	var deadline time.Time
+	if isPreAuctionStage(stage) {
+		deadline = e.deadline
+	}
+
 	return executionContext{
 		account:         e.account,
 		accountID:       e.accountID,
@@ -334,9 +383,22 @@ func (e *hookExecutor) newContext(stage string) executionContext {
 		moduleContexts:  e.moduleContexts,
 		stage:           stage,
 		activityControl: e.activityControl,
+		deadline:        deadline,
+		domain:          e.domain,
+		sampler:         e.sampler,
 	}
 }
 
+// isPreAuctionStage reports whether the stage runs before the bidders are requested, the auction
+// itself having the remaining tmax
+func isPreAuctionStage(stage string) bool {
+	switch hooks.Stage(stage) {
+	case hooks.StageEntrypoint, hooks.StageRawAuctionRequest, hooks.StageProcessedAuctionRequest:
+		return true
+	}
+	return false
+}
+
 func (e *hookExecutor) saveModuleContexts(ctxs stageModuleContext) {
 	for _, moduleCtxs := range ctxs.groupCtx {
 		for moduleName, moduleCtx := range moduleCtxs {
@@ -357,6 +419,8 @@ func (executor EmptyHookExecutor) SetAccount(_ *config.Account) {}
 
 func (executor EmptyHookExecutor) SetActivityControl(_ privacy.ActivityControl) {}
 
+func (executor EmptyHookExecutor) SetDeadline(_ time.Time) {}
+
 func (executor EmptyHookExecutor) GetOutcomes() []StageOutcome {
 	return []StageOutcome{}
 }
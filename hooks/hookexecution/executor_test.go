@@ -3137,3 +3137,46 @@ func (e TestMultipleHooksUpdatePayloadBuilder) PlanForExitpointStage(_ string, _
 		},
 	}
 }
+
+func TestNewContextDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Second)
+	exec := NewHookExecutor(hooks.EmptyPlanBuilder{}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+	exec.SetDeadline(deadline)
+
+	for _, stage := range hooks.Stages {
+		t.Run(stage.String(), func(t *testing.T) {
+			executionCtx := exec.newContext(stage.String())
+			switch stage {
+			case hooks.StageEntrypoint, hooks.StageRawAuctionRequest, hooks.StageProcessedAuctionRequest:
+				assert.Equal(t, deadline, executionCtx.deadline, "Stages before the auction should be bounded by the deadline")
+			default:
+				assert.True(t, executionCtx.deadline.IsZero(), "Stages from the bidder requests on should only be bounded by group timeouts")
+			}
+		})
+	}
+}
+
+type TestAccountPlanErrorsBuilder struct {
+	hooks.EmptyPlanBuilder
+	errs []error
+}
+
+func (e TestAccountPlanErrorsBuilder) ValidateAccountPlan(_ *config.Account, _ []string) []error {
+	return e.errs
+}
+
+func TestSetAccountValidatesPlan(t *testing.T) {
+	account := &config.Account{ID: "invalid-plan-account"}
+	errs := []error{fmt.Errorf("first problem"), fmt.Errorf("second problem")}
+
+	exec := NewHookExecutor(TestAccountPlanErrorsBuilder{errs: errs}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+	exec.SetAccount(account)
+	warning, ok := accountPlanWarnings.Load(account.ID)
+	assert.True(t, ok, "Problems of the account plan should be recorded")
+	assert.Equal(t, "first problem\nsecond problem", warning)
+
+	exec = NewHookExecutor(TestAccountPlanErrorsBuilder{}, EndpointAuction, &metricsConfig.NilMetricsEngine{})
+	exec.SetAccount(account)
+	_, ok = accountPlanWarnings.Load(account.ID)
+	assert.False(t, ok, "Problems should be forgotten once the account plan is valid")
+}
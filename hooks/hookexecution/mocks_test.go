@@ -438,3 +438,21 @@ func (e mockUpdateResponseAgainHook) HandleExitpointHook(_ context.Context, _ ho
 
 	return hookstage.HookResult[hookstage.ExitpointPayload]{ChangeSet: c}, nil
 }
+
+// mockRunAfterHook returns a module context holding its key and the module contexts of the hooks it runs after,
+// the latter keyed by module code and key
+type mockRunAfterHook struct {
+	delay    time.Duration
+	key, val string
+}
+
+func (e mockRunAfterHook) HandleEntrypointHook(_ context.Context, miCtx hookstage.ModuleInvocationContext, _ hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	time.Sleep(e.delay)
+	moduleCtx := hookstage.ModuleContext{e.key: e.val}
+	for module, dependencyCtx := range miCtx.DependencyContexts {
+		for k, v := range dependencyCtx {
+			moduleCtx[module+"."+k] = v
+		}
+	}
+	return hookstage.HookResult[hookstage.EntrypointPayload]{ModuleContext: moduleCtx}, nil
+}
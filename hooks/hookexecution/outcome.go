@@ -14,6 +14,7 @@ const (
 	StatusTimeout          Status = "timeout"           // hook was not completed in the allotted time
 	StatusFailure          Status = "failure"           // expected module-side failure occurred during hook execution
 	StatusExecutionFailure Status = "execution_failure" // unexpected failure occurred during hook execution
+	StatusSkipped          Status = "skipped"           // hook was not called for the request, see SkipReason
 )
 
 // Action indicates the type of taken behaviour after the successful hook execution.
@@ -25,6 +26,16 @@ const (
 	ActionNone   Action = "no_action" // the hook does not want to take any action
 )
 
+// SkipReason indicates why the toggle of a hook kept it from being called for the request.
+type SkipReason string
+
+const (
+	SkipReasonDisabled   SkipReason = "disabled"    // the hook is disabled
+	SkipReasonPublisher  SkipReason = "publisher"   // the publisher is denied or not allowed
+	SkipReasonDomain     SkipReason = "domain"      // the domain is denied or not allowed
+	SkipReasonSampledOut SkipReason = "sampled_out" // the request is not part of the sample
+)
+
 // Messages in format: {"module": {"hook": ["msg1", "msg2"]}}
 type Messages map[string]map[string][]string
 
@@ -83,6 +94,7 @@ type HookOutcome struct {
 	Action        Action                  `json:"action"`
 	Message       string                  `json:"message"` // arbitrary string value returned from hook execution
 	DebugMessages []string                `json:"debug_messages,omitempty"`
+	SkipReason    SkipReason              `json:"skip_reason,omitempty"`
 	Errors        []string                `json:"-"`
 	Warnings      []string                `json:"-"`
 }
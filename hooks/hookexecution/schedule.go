@@ -0,0 +1,75 @@
+package hookexecution
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+)
+
+// groupSchedule orders the hooks of a group which run after other modules of the same group.
+// Every hook of the group is marked done exactly once, whether it completed, timed out or was cancelled,
+// so that the hooks waiting for it can start or give up.
+type groupSchedule struct {
+	modules  []string
+	done     []chan struct{}
+	mu       sync.Mutex
+	contexts map[string]hookstage.ModuleContext
+}
+
+func newGroupSchedule(modules []string) *groupSchedule {
+	schedule := &groupSchedule{
+		modules:  modules,
+		done:     make([]chan struct{}, len(modules)),
+		contexts: make(map[string]hookstage.ModuleContext),
+	}
+	for i := range schedule.done {
+		schedule.done[i] = make(chan struct{})
+	}
+	return schedule
+}
+
+// dependencies returns the channels closed once the hooks of the runAfter modules are done,
+// excluding the hook at index which cannot wait for itself
+func (s *groupSchedule) dependencies(index int, runAfter []string) []<-chan struct{} {
+	var dependencies []<-chan struct{}
+	for i, module := range s.modules {
+		if i != index && slices.Contains(runAfter, module) {
+			dependencies = append(dependencies, s.done[i])
+		}
+	}
+	return dependencies
+}
+
+// markDone records the module context returned by the hook at index and unblocks the hooks waiting for it
+func (s *groupSchedule) markDone(index int, moduleCtx hookstage.ModuleContext) {
+	if len(moduleCtx) > 0 {
+		s.mu.Lock()
+		module := s.modules[index]
+		if s.contexts[module] == nil {
+			s.contexts[module] = make(hookstage.ModuleContext, len(moduleCtx))
+		}
+		maps.Copy(s.contexts[module], moduleCtx)
+		s.mu.Unlock()
+	}
+	close(s.done[index])
+}
+
+// dependencyContexts returns copies of the module contexts returned by the hooks of the runAfter modules
+func (s *groupSchedule) dependencyContexts(runAfter []string) map[string]hookstage.ModuleContext {
+	if len(runAfter) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	contexts := make(map[string]hookstage.ModuleContext, len(runAfter))
+	for _, module := range runAfter {
+		if moduleCtx, ok := s.contexts[module]; ok {
+			contexts[module] = maps.Clone(moduleCtx)
+		}
+	}
+	return contexts
+}
@@ -3,6 +3,7 @@ package hookexecution
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/stretchr/testify/assert"
@@ -57,13 +58,13 @@ func assertEqualStageOutcomes(t *testing.T, expected StageOutcome, actual StageO
 
 	assert.Equal(t, len(actual.Groups), len(expected.Groups), "Stage outcomes contain different number of groups")
 
-	// calculate expected timings from actual outcome
+	// calculate expected timings from actual outcome, a group lasting at least as long as its slowest hook
+	// as the time hooks wait to start is counted towards the group
+	minGroupTimes := make([]time.Duration, len(actual.Groups))
 	for i, group := range actual.Groups {
 		expected.ExecutionTimeMillis += group.ExecutionTimeMillis
 		for _, hook := range group.InvocationResults {
-			if hook.ExecutionTimeMillis > expected.Groups[i].ExecutionTimeMillis {
-				expected.Groups[i].ExecutionTimeMillis = hook.ExecutionTimeMillis
-			}
+			minGroupTimes[i] = max(minGroupTimes[i], hook.ExecutionTimeMillis)
 		}
 	}
 
@@ -74,7 +75,7 @@ func assertEqualStageOutcomes(t *testing.T, expected StageOutcome, actual StageO
 	for i, expGroup := range expected.Groups {
 		gotGroup := actual.Groups[i]
 		assert.Equal(t, len(expGroup.InvocationResults), len(gotGroup.InvocationResults), "Group outcomes #%d contain different number of invocation results", i)
-		assert.Equal(t, expGroup.ExecutionTimeMillis, gotGroup.ExecutionTimeMillis, "Incorrect group #%d execution time", i)
+		assert.GreaterOrEqual(t, gotGroup.ExecutionTimeMillis, minGroupTimes[i], "Incorrect group #%d execution time", i)
 
 		for _, expHook := range expGroup.InvocationResults {
 			gotHook := findCorrespondingHookResult(expHook.HookID, gotGroup)
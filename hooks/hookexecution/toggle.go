@@ -0,0 +1,111 @@
+package hookexecution
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/buger/jsonparser"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/config"
+)
+
+// skipReason returns why the hook of the module must not be called for the request,
+// or an empty reason when the toggle of the hook lets it be called.
+func (ctx executionContext) skipReason(module string, toggle config.HookToggle) SkipReason {
+	if toggle.Enabled != nil && !*toggle.Enabled {
+		return SkipReasonDisabled
+	}
+	if !isAllowed(ctx.accountID, toggle.AllowPublishers, toggle.DenyPublishers, strings.EqualFold) {
+		return SkipReasonPublisher
+	}
+	if !isAllowed(ctx.domain, toggle.AllowDomains, toggle.DenyDomains, matchesDomain) {
+		return SkipReasonDomain
+	}
+	if toggle.SamplePercent != nil && !ctx.sampler.sampled(module, *toggle.SamplePercent) {
+		return SkipReasonSampledOut
+	}
+	return ""
+}
+
+// isAllowed reports whether the value is not denied and, when there is an allow list, is allowed.
+// An unknown value, like the publisher at the entrypoint stage, is never allowed.
+func isAllowed(value string, allow, deny []string, match func(value, entry string) bool) bool {
+	matchesAny := func(entries []string) bool {
+		for _, entry := range entries {
+			if match(value, entry) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(value) > 0 && matchesAny(deny) {
+		return false
+	}
+	if len(allow) > 0 && (len(value) == 0 || !matchesAny(allow)) {
+		return false
+	}
+	return true
+}
+
+// matchesDomain reports whether the domain is the entry or one of its subdomains
+func matchesDomain(domain, entry string) bool {
+	domain = strings.ToLower(domain)
+	entry = strings.ToLower(entry)
+	return domain == entry || strings.HasSuffix(domain, "."+entry)
+}
+
+// requestDomain returns the site domain or the app bundle of the request
+func requestDomain(request *openrtb2.BidRequest) string {
+	if request.Site != nil && len(request.Site.Domain) > 0 {
+		return request.Site.Domain
+	}
+	if request.App != nil {
+		return request.App.Bundle
+	}
+	return ""
+}
+
+// rawRequestDomain returns the site domain or the app bundle of the request body
+func rawRequestDomain(body []byte) string {
+	if domain, err := jsonparser.GetString(body, "site", "domain"); err == nil && len(domain) > 0 {
+		return domain
+	}
+	if bundle, err := jsonparser.GetString(body, "app", "bundle"); err == nil {
+		return bundle
+	}
+	return ""
+}
+
+// moduleSampler draws a number once per module for the request, so that the hooks of a module
+// which are sampled at several stages are all called for the same requests.
+type moduleSampler struct {
+	mu     sync.Mutex
+	draws  map[string]float64
+	random func() float64
+}
+
+func newModuleSampler(random func() float64) *moduleSampler {
+	return &moduleSampler{
+		draws:  make(map[string]float64),
+		random: random,
+	}
+}
+
+// sampled reports whether the module is called for the request at the sample percent
+func (s *moduleSampler) sampled(module string, percent float64) bool {
+	if s == nil {
+		return rand.Float64()*100 < percent
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draw, ok := s.draws[module]
+	if !ok {
+		draw = s.random() * 100
+		s.draws[module] = draw
+	}
+	return draw < percent
+}
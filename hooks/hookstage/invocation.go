@@ -31,6 +31,9 @@ type ModuleInvocationContext struct {
 	ModuleContext ModuleContext
 	// HookImplCode is the hook_impl_code for a module instance to differentiate between multiple hooks
 	HookImplCode string
+	// DependencyContexts holds copies of the module contexts returned in the current group by the hooks
+	// of the modules listed in run_after, keyed by module code. Modules whose hooks failed or returned no context are absent.
+	DependencyContexts map[string]ModuleContext
 }
 
 // ModuleContext holds arbitrary data passed between module hooks at different stages.
@@ -43,6 +43,9 @@ type ExecutionPlanBuilder interface {
 	PlanForAllProcessedBidResponsesStage(endpoint string, account *config.Account) Plan[hookstage.AllProcessedBidResponses]
 	PlanForAuctionResponseStage(endpoint string, account *config.Account) Plan[hookstage.AuctionResponse]
 	PlanForExitpointStage(endpoint string, account *config.Account) Plan[hookstage.Exitpoint]
+	// ValidateAccountPlan checks the execution plan of the account with ValidateExecutionPlan
+	// when the account has its own plan, as it replaces the default account plan.
+	ValidateAccountPlan(account *config.Account, endpoints []string) []error
 }
 
 // Plan represents a slice of groups of hooks of a specific type grouped in the established order.
@@ -69,6 +72,10 @@ type HookWrapper[T any] struct {
 	Code string
 	// Hook is an instance of the specific hook interface.
 	Hook T
+	// RunAfter holds the codes of the modules whose hooks in the same group must complete before the Hook starts.
+	RunAfter []string
+	// Toggle controls the requests the Hook is called for.
+	Toggle config.HookToggle
 }
 
 // NewExecutionPlanBuilder returns a new instance of the ExecutionPlanBuilder interface.
@@ -170,6 +177,13 @@ func (p PlanBuilder) PlanForExitpointStage(endpoint string, account *config.Acco
 	)
 }
 
+func (p PlanBuilder) ValidateAccountPlan(account *config.Account, endpoints []string) []error {
+	if account == nil || account.Hooks.ExecutionPlan.Endpoints == nil {
+		return nil
+	}
+	return ValidateExecutionPlan(account.Hooks.ExecutionPlan, p.repo, endpoints)
+}
+
 type hookFn[T any] func(moduleName string) (T, bool)
 
 func getMergedPlan[T any](
@@ -211,7 +225,7 @@ func getGroup[T any](getHookFn hookFn[T], cfg config.HookExecutionGroup) Group[T
 
 	for _, hookCfg := range cfg.HookSequence {
 		if h, ok := getHookFn(hookCfg.ModuleCode); ok {
-			group.Hooks = append(group.Hooks, HookWrapper[T]{Module: hookCfg.ModuleCode, Code: hookCfg.HookImplCode, Hook: h})
+			group.Hooks = append(group.Hooks, HookWrapper[T]{Module: hookCfg.ModuleCode, Code: hookCfg.HookImplCode, Hook: h, RunAfter: hookCfg.RunAfter, Toggle: hookCfg.HookToggle})
 		} else {
 			logger.Warnf("Not found hook while building hook execution plan: %s %s", hookCfg.ModuleCode, hookCfg.HookImplCode)
 		}
@@ -0,0 +1,180 @@
+package hooks
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/config"
+)
+
+// Stages lists the available stages in the order they are executed.
+var Stages = []Stage{
+	StageEntrypoint,
+	StageRawAuctionRequest,
+	StageProcessedAuctionRequest,
+	StageBidderRequest,
+	StageRawBidderResponse,
+	StageAllProcessedBidResponses,
+	StageAuctionResponse,
+	StageExitpoint,
+}
+
+// ValidateExecutionPlan checks that the hook execution plan only refers to the given endpoints and to known
+// stages, and that every hook of the plan refers to a module registered in the repo that provides a hook
+// for the stage it is listed in. Such hooks would otherwise be skipped when the plan is built for a request.
+// The run_after dependencies of a group must refer to other modules of the group and must not form a cycle,
+// otherwise the hooks involved wait until the group times out. The sample_percent of a hook must be between 0 and 100.
+func ValidateExecutionPlan(plan config.HookExecutionPlan, repo HookRepository, endpoints []string) []error {
+	var errs []error
+
+	for _, endpoint := range slices.Sorted(maps.Keys(plan.Endpoints)) {
+		if !slices.Contains(endpoints, endpoint) {
+			errs = append(errs, fmt.Errorf("endpoint %q is not one of %s", endpoint, strings.Join(endpoints, ", ")))
+			continue
+		}
+
+		stages := plan.Endpoints[endpoint].Stages
+		for _, stageName := range slices.Sorted(maps.Keys(stages)) {
+			stage := Stage(stageName)
+			if !slices.Contains(Stages, stage) {
+				errs = append(errs, fmt.Errorf("endpoint %q: stage %q is not a known stage", endpoint, stageName))
+				continue
+			}
+
+			for i, group := range stages[stageName].Groups {
+				for j, hookCfg := range group.HookSequence {
+					if err := validateHook(repo, stage, hookCfg.ModuleCode); err != nil {
+						errs = append(errs, fmt.Errorf("endpoint %q: stage %q: group %d: hook %d (%s): %v", endpoint, stageName, i, j, hookCfg.HookImplCode, err))
+					}
+					if err := validateRunAfter(group, hookCfg.ModuleCode, hookCfg.RunAfter); err != nil {
+						errs = append(errs, fmt.Errorf("endpoint %q: stage %q: group %d: hook %d (%s): %v", endpoint, stageName, i, j, hookCfg.HookImplCode, err))
+					}
+					if err := validateToggle(hookCfg.HookToggle); err != nil {
+						errs = append(errs, fmt.Errorf("endpoint %q: stage %q: group %d: hook %d (%s): %v", endpoint, stageName, i, j, hookCfg.HookImplCode, err))
+					}
+				}
+				if cycle := findRunAfterCycle(group); len(cycle) > 0 {
+					errs = append(errs, fmt.Errorf("endpoint %q: stage %q: group %d: run_after forms a cycle: %s", endpoint, stageName, i, strings.Join(cycle, " -> ")))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateHook(repo HookRepository, stage Stage, moduleCode string) error {
+	if len(moduleCode) == 0 {
+		return fmt.Errorf("module_code is required")
+	}
+	if hasHook(repo, stage, moduleCode) {
+		return nil
+	}
+
+	for _, otherStage := range Stages {
+		if hasHook(repo, otherStage, moduleCode) {
+			return fmt.Errorf("module %q does not provide a hook for the %s stage", moduleCode, stage)
+		}
+	}
+	return fmt.Errorf("module %q is not registered or not enabled", moduleCode)
+}
+
+func validateRunAfter(group config.HookExecutionGroup, moduleCode string, runAfter []string) error {
+	modules := make([]string, 0, len(group.HookSequence))
+	for _, hookCfg := range group.HookSequence {
+		modules = append(modules, hookCfg.ModuleCode)
+	}
+
+	for _, dependency := range runAfter {
+		if dependency == moduleCode {
+			return fmt.Errorf("run_after %q refers to the module of the hook", dependency)
+		}
+		if !slices.Contains(modules, dependency) {
+			return fmt.Errorf("run_after %q is not a module of the group", dependency)
+		}
+	}
+	return nil
+}
+
+func validateToggle(toggle config.HookToggle) error {
+	if toggle.SamplePercent != nil && (*toggle.SamplePercent < 0 || *toggle.SamplePercent > 100) {
+		return fmt.Errorf("sample_percent %v is not between 0 and 100", *toggle.SamplePercent)
+	}
+	return nil
+}
+
+// findRunAfterCycle returns the module codes of a run_after cycle of the group, if any
+func findRunAfterCycle(group config.HookExecutionGroup) []string {
+	dependencies := make(map[string][]string)
+	for _, hookCfg := range group.HookSequence {
+		dependencies[hookCfg.ModuleCode] = append(dependencies[hookCfg.ModuleCode], hookCfg.RunAfter...)
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(dependencies))
+	var path []string
+	var visit func(module string) []string
+	visit = func(module string) []string {
+		switch state[module] {
+		case visiting:
+			start := slices.Index(path, module)
+			return append(slices.Clone(path[start:]), module)
+		case visited:
+			return nil
+		}
+
+		state[module] = visiting
+		path = append(path, module)
+		for _, dependency := range dependencies[module] {
+			if dependency == module {
+				continue
+			}
+			if cycle := visit(dependency); len(cycle) > 0 {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[module] = visited
+		return nil
+	}
+
+	for _, module := range slices.Sorted(maps.Keys(dependencies)) {
+		if cycle := visit(module); len(cycle) > 0 {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// hasHook reports whether the module registered in the repo under id provides a hook for the stage
+func hasHook(repo HookRepository, stage Stage, id string) bool {
+	if repo == nil {
+		return false
+	}
+
+	var found bool
+	switch stage {
+	case StageEntrypoint:
+		_, found = repo.GetEntrypointHook(id)
+	case StageRawAuctionRequest:
+		_, found = repo.GetRawAuctionHook(id)
+	case StageProcessedAuctionRequest:
+		_, found = repo.GetProcessedAuctionHook(id)
+	case StageBidderRequest:
+		_, found = repo.GetBidderRequestHook(id)
+	case StageRawBidderResponse:
+		_, found = repo.GetRawBidderResponseHook(id)
+	case StageAllProcessedBidResponses:
+		_, found = repo.GetAllProcessedBidResponsesHook(id)
+	case StageAuctionResponse:
+		_, found = repo.GetAuctionResponseHook(id)
+	case StageExitpoint:
+		_, found = repo.GetExitpointHook(id)
+	}
+	return found
+}
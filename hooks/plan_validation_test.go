@@ -0,0 +1,144 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExecutionPlan(t *testing.T) {
+	repo, err := NewHookRepository(map[string]interface{}{
+		"vendor.entrypoint": fakeEntrypointHook{},
+		"vendor.enricher":   fakeEntrypointHook{},
+		"vendor.bidder":     fakeBidderRequestHook{},
+	})
+	require.NoError(t, err)
+	endpoints := []string{"/openrtb2/auction", "/openrtb2/amp"}
+
+	testCases := map[string]struct {
+		givenPlanData  string
+		expectedErrors []string
+	}{
+		"Empty plan is valid": {
+			givenPlanData: `{}`,
+		},
+		"Hooks implemented by registered modules are valid": {
+			givenPlanData: `{"endpoints": {
+				"/openrtb2/auction": {"stages": {
+					"entrypoint": {"groups": [{"hook_sequence": [{"module_code": "vendor.entrypoint", "hook_impl_code": "foo"}]}]},
+					"bidder_request": {"groups": [{"hook_sequence": [{"module_code": "vendor.bidder", "hook_impl_code": "bar"}]}]}
+				}},
+				"/openrtb2/amp": {"stages": {"entrypoint": {"groups": []}}}
+			}}`,
+		},
+		"Invalid endpoints, stages and hooks are reported": {
+			givenPlanData: `{"endpoints": {
+				"/openrtb2/auctoin": {"stages": {"entrypoint": {"groups": []}}},
+				"/openrtb2/auction": {"stages": {
+					"bidder_requests": {"groups": []},
+					"entrypoint": {"groups": [
+						{"hook_sequence": [{"module_code": "vendor.entrypoint", "hook_impl_code": "foo"}]},
+						{"hook_sequence": [{"module_code": "vendor.entrypiont", "hook_impl_code": "typo"}, {"module_code": "vendor.bidder", "hook_impl_code": "wrong-stage"}]}
+					]},
+					"exitpoint": {"groups": [{"hook_sequence": [{"hook_impl_code": "no-module"}]}]}
+				}}
+			}}`,
+			expectedErrors: []string{
+				`endpoint "/openrtb2/auction": stage "bidder_requests" is not a known stage`,
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 1: hook 0 (typo): module "vendor.entrypiont" is not registered or not enabled`,
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 1: hook 1 (wrong-stage): module "vendor.bidder" does not provide a hook for the entrypoint stage`,
+				`endpoint "/openrtb2/auction": stage "exitpoint": group 0: hook 0 (no-module): module_code is required`,
+				`endpoint "/openrtb2/auctoin" is not one of /openrtb2/auction, /openrtb2/amp`,
+			},
+		},
+		"Run after dependencies within the group are valid": {
+			givenPlanData: `{"endpoints": {"/openrtb2/auction": {"stages": {
+				"entrypoint": {"groups": [{"hook_sequence": [
+					{"module_code": "vendor.entrypoint", "hook_impl_code": "foo", "run_after": ["vendor.enricher"]},
+					{"module_code": "vendor.enricher", "hook_impl_code": "bar"}
+				]}]}
+			}}}}`,
+		},
+		"Toggles with a sample percent between 0 and 100 are valid": {
+			givenPlanData: `{"endpoints": {"/openrtb2/auction": {"stages": {
+				"entrypoint": {"groups": [{"hook_sequence": [
+					{"module_code": "vendor.entrypoint", "hook_impl_code": "foo", "enabled": true, "sample_percent": 0},
+					{"module_code": "vendor.enricher", "hook_impl_code": "bar", "sample_percent": 100, "allow_publishers": ["pub"], "deny_domains": ["example.com"]}
+				]}]}
+			}}}}`,
+		},
+		"Invalid sample percents are reported": {
+			givenPlanData: `{"endpoints": {"/openrtb2/auction": {"stages": {
+				"entrypoint": {"groups": [{"hook_sequence": [
+					{"module_code": "vendor.entrypoint", "hook_impl_code": "foo", "sample_percent": -1},
+					{"module_code": "vendor.enricher", "hook_impl_code": "bar", "sample_percent": 100.5}
+				]}]}
+			}}}}`,
+			expectedErrors: []string{
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 0: hook 0 (foo): sample_percent -1 is not between 0 and 100`,
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 0: hook 1 (bar): sample_percent 100.5 is not between 0 and 100`,
+			},
+		},
+		"Invalid run after dependencies are reported": {
+			givenPlanData: `{"endpoints": {"/openrtb2/auction": {"stages": {
+				"entrypoint": {"groups": [
+					{"hook_sequence": [
+						{"module_code": "vendor.entrypoint", "hook_impl_code": "foo", "run_after": ["vendor.enricher"]},
+						{"module_code": "vendor.enricher", "hook_impl_code": "bar", "run_after": ["vendor.entrypoint"]}
+					]},
+					{"hook_sequence": [
+						{"module_code": "vendor.entrypoint", "hook_impl_code": "self", "run_after": ["vendor.entrypoint"]},
+						{"module_code": "vendor.enricher", "hook_impl_code": "other-group", "run_after": ["vendor.bidder"]}
+					]}
+				]}
+			}}}}`,
+			expectedErrors: []string{
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 0: run_after forms a cycle: vendor.enricher -> vendor.entrypoint -> vendor.enricher`,
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 1: hook 0 (self): run_after "vendor.entrypoint" refers to the module of the hook`,
+				`endpoint "/openrtb2/auction": stage "entrypoint": group 1: hook 1 (other-group): run_after "vendor.bidder" is not a module of the group`,
+			},
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var plan config.HookExecutionPlan
+			require.NoError(t, jsonutil.UnmarshalValid([]byte(test.givenPlanData), &plan))
+
+			errs := ValidateExecutionPlan(plan, repo, endpoints)
+
+			actualErrors := make([]string, 0, len(errs))
+			for _, err := range errs {
+				actualErrors = append(actualErrors, err.Error())
+			}
+			if len(test.expectedErrors) == 0 {
+				assert.Empty(t, actualErrors)
+			} else {
+				assert.Equal(t, test.expectedErrors, actualErrors)
+			}
+		})
+	}
+}
+
+func TestPlanBuilderValidateAccountPlan(t *testing.T) {
+	repo, err := NewHookRepository(map[string]interface{}{"vendor.entrypoint": fakeEntrypointHook{}})
+	require.NoError(t, err)
+	builder := PlanBuilder{repo: repo}
+	endpoints := []string{"/openrtb2/auction"}
+
+	var invalidPlan config.HookExecutionPlan
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(`{"endpoints": {"/openrtb2/auction": {"stages": {
+		"entrypoint": {"groups": [{"hook_sequence": [{"module_code": "vendor.missing", "hook_impl_code": "foo"}]}]}
+	}}}}`), &invalidPlan))
+
+	assert.Empty(t, builder.ValidateAccountPlan(nil, endpoints), "No account")
+	assert.Empty(t, builder.ValidateAccountPlan(&config.Account{}, endpoints), "Account without its own plan")
+
+	account := &config.Account{Hooks: config.AccountHooks{ExecutionPlan: invalidPlan}}
+	errs := builder.ValidateAccountPlan(account, endpoints)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `endpoint "/openrtb2/auction": stage "entrypoint": group 0: hook 0 (foo): module "vendor.missing" is not registered or not enabled`)
+}
@@ -89,56 +89,56 @@ func (r *hookRepository) add(id string, hook interface{}) error {
 	var hasAnyHooks bool
 	var err error
 
-	if h, ok := hook.(hookstage.Entrypoint); ok {
+	if h, ok := hook.(hookstage.Entrypoint); ok && ProvidesStage(hook, StageEntrypoint) {
 		hasAnyHooks = true
 		if r.entrypointHooks, err = addHook(r.entrypointHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.RawAuctionRequest); ok {
+	if h, ok := hook.(hookstage.RawAuctionRequest); ok && ProvidesStage(hook, StageRawAuctionRequest) {
 		hasAnyHooks = true
 		if r.rawAuctionHooks, err = addHook(r.rawAuctionHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.ProcessedAuctionRequest); ok {
+	if h, ok := hook.(hookstage.ProcessedAuctionRequest); ok && ProvidesStage(hook, StageProcessedAuctionRequest) {
 		hasAnyHooks = true
 		if r.processedAuctionHooks, err = addHook(r.processedAuctionHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.BidderRequest); ok {
+	if h, ok := hook.(hookstage.BidderRequest); ok && ProvidesStage(hook, StageBidderRequest) {
 		hasAnyHooks = true
 		if r.bidderRequestHooks, err = addHook(r.bidderRequestHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.RawBidderResponse); ok {
+	if h, ok := hook.(hookstage.RawBidderResponse); ok && ProvidesStage(hook, StageRawBidderResponse) {
 		hasAnyHooks = true
 		if r.rawBidderResponseHooks, err = addHook(r.rawBidderResponseHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.AllProcessedBidResponses); ok {
+	if h, ok := hook.(hookstage.AllProcessedBidResponses); ok && ProvidesStage(hook, StageAllProcessedBidResponses) {
 		hasAnyHooks = true
 		if r.allProcessedBidResponseHooks, err = addHook(r.allProcessedBidResponseHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.AuctionResponse); ok {
+	if h, ok := hook.(hookstage.AuctionResponse); ok && ProvidesStage(hook, StageAuctionResponse) {
 		hasAnyHooks = true
 		if r.auctionResponseHooks, err = addHook(r.auctionResponseHooks, h, id); err != nil {
 			return err
 		}
 	}
 
-	if h, ok := hook.(hookstage.Exitpoint); ok {
+	if h, ok := hook.(hookstage.Exitpoint); ok && ProvidesStage(hook, StageExitpoint) {
 		hasAnyHooks = true
 		if r.exitpointHooks, err = addHook(r.exitpointHooks, h, id); err != nil {
 			return err
@@ -152,6 +152,20 @@ func (r *hookRepository) add(id string, hook interface{}) error {
 	return nil
 }
 
+// StageFilter is implemented by modules which implement more hook interfaces than they provide hooks for,
+// like remote modules that forward the payload of the stages they are configured for to another process.
+type StageFilter interface {
+	ProvidesStage(stage Stage) bool
+}
+
+// ProvidesStage reports whether the module provides a hook for the stage, provided it implements its hook interface.
+func ProvidesStage(module interface{}, stage Stage) bool {
+	if filter, ok := module.(StageFilter); ok {
+		return filter.ProvidesStage(stage)
+	}
+	return true
+}
+
 func getHook[T any](hooks map[string]T, id string) (T, bool) {
 	hook, ok := hooks[id]
 	return hook, ok
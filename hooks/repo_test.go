@@ -3,6 +3,7 @@ package hooks
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
@@ -41,6 +42,28 @@ func TestNewHookRepository(t *testing.T) {
 			providedHook: struct{}{},
 			expectedErr:  fmt.Errorf(`hook "%s" does not implement any supported hook interface`, id),
 		},
+		"Filtered stage hook not found": {
+			isFound:      false,
+			providedHook: filteredHook{stages: []Stage{StageRawAuctionRequest}},
+			expectedHook: nil,
+			expectedErr:  nil,
+			getHookFn: func(repo HookRepository) (interface{}, bool) {
+				return repo.GetEntrypointHook(id)
+			},
+		},
+		"Provided stage hook of filtered hook returns": {
+			isFound:      true,
+			providedHook: filteredHook{stages: []Stage{StageRawAuctionRequest}},
+			expectedHook: filteredHook{stages: []Stage{StageRawAuctionRequest}},
+			expectedErr:  nil,
+			getHookFn: func(repo HookRepository) (interface{}, bool) {
+				return repo.GetRawAuctionHook(id)
+			},
+		},
+		"Fails to add filtered hook that does not provide any implemented hook interface": {
+			providedHook: filteredHook{stages: []Stage{StageExitpoint}},
+			expectedErr:  fmt.Errorf(`hook "%s" does not implement any supported hook interface`, id),
+		},
 	}
 
 	for name, test := range testCases {
@@ -74,3 +97,20 @@ type hook struct{}
 func (h hook) HandleEntrypointHook(ctx context.Context, context hookstage.ModuleInvocationContext, payload hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
 	return hookstage.HookResult[hookstage.EntrypointPayload]{}, nil
 }
+
+// filteredHook implements the entrypoint and raw auction hooks but only provides the hooks of its stages
+type filteredHook struct {
+	stages []Stage
+}
+
+func (h filteredHook) ProvidesStage(stage Stage) bool {
+	return slices.Contains(h.stages, stage)
+}
+
+func (h filteredHook) HandleEntrypointHook(ctx context.Context, context hookstage.ModuleInvocationContext, payload hookstage.EntrypointPayload) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	return hookstage.HookResult[hookstage.EntrypointPayload]{}, nil
+}
+
+func (h filteredHook) HandleRawAuctionHook(ctx context.Context, context hookstage.ModuleInvocationContext, payload hookstage.RawAuctionRequestPayload) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	return hookstage.HookResult[hookstage.RawAuctionRequestPayload]{}, nil
+}
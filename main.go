@@ -66,11 +66,32 @@ func loadConfig(bidderInfos config.BidderInfos) (*config.Configuration, error) {
 	return config.New(v, bidderInfos, openrtb_ext.NormalizeBidderName)
 }
 
+// newCurrencyRatesSources builds the currency rates sources in priority order, defaulting to the fetch url
+func newCurrencyRatesSources(cfg config.CurrencyConverter) []currency.RatesSource {
+	httpTimeout := time.Duration(cfg.FetchTimeoutMilliseconds) * time.Millisecond
+	if len(cfg.Sources) == 0 {
+		staleRatesThreshold := time.Duration(cfg.StaleRatesSeconds) * time.Second
+		return []currency.RatesSource{currency.NewHTTPRatesSource(&http.Client{}, httpTimeout, cfg.FetchURL, staleRatesThreshold)}
+	}
+
+	sources := make([]currency.RatesSource, 0, len(cfg.Sources))
+	for _, source := range cfg.Sources {
+		staleRatesThreshold := time.Duration(source.StaleRatesSeconds) * time.Second
+		switch source.Type {
+		case config.CurrencyRatesSourceURL:
+			sources = append(sources, currency.NewHTTPRatesSource(&http.Client{}, httpTimeout, source.URL, staleRatesThreshold))
+		case config.CurrencyRatesSourceFile:
+			sources = append(sources, currency.NewFileRatesSource(source.Path, staleRatesThreshold))
+		case config.CurrencyRatesSourceStatic:
+			sources = append(sources, currency.NewStaticRatesSource(source.Rates))
+		}
+	}
+	return sources
+}
+
 func serve(cfg *config.Configuration) error {
-	httpTimeout := time.Duration(cfg.CurrencyConverter.FetchTimeoutMilliseconds) * time.Millisecond
 	fetchingInterval := time.Duration(cfg.CurrencyConverter.FetchIntervalSeconds) * time.Second
-	staleRatesThreshold := time.Duration(cfg.CurrencyConverter.StaleRatesSeconds) * time.Second
-	currencyConverter := currency.NewRateConverter(&http.Client{}, httpTimeout, cfg.CurrencyConverter.FetchURL, staleRatesThreshold)
+	currencyConverter := currency.NewRateConverterWithSources(newCurrencyRatesSources(cfg.CurrencyConverter), cfg.CurrencyConverter.MaxRateChangePercent)
 
 	currencyConverterTickerTask := task.NewTickerTask(fetchingInterval, currencyConverter)
 	currencyConverterTickerTask.Start()
@@ -81,7 +102,7 @@ func serve(cfg *config.Configuration) error {
 	}
 
 	corsRouter := router.SupportCORS(r)
-	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(currencyConverter, fetchingInterval), r.MetricsEngine); err != nil {
+	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(cfg, currencyConverter, fetchingInterval, r.AdminHandlers), r.MetricsEngine); err != nil {
 		logger.Fatalf("prebid-server returned an error: %v", err)
 	}
 
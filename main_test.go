@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/stretchr/testify/assert"
@@ -65,3 +66,27 @@ func TestViperEnv(t *testing.T) {
 	assert.Equal(t, 60, v.Get("host_cookie.ttl_days"), "Config With Underscores")
 	assert.ElementsMatch(t, []string{"1.1.1.1/24", "2.2.2.2/24"}, v.Get("request_validation.ipv4_private_networks"), "Arrays")
 }
+
+func TestNewCurrencyRatesSources(t *testing.T) {
+	defaultSources := newCurrencyRatesSources(config.CurrencyConverter{FetchURL: "https://currency.prebid.org", StaleRatesSeconds: 60})
+	if assert.Len(t, defaultSources, 1) {
+		assert.Equal(t, "https://currency.prebid.org", defaultSources[0].Name())
+		assert.Equal(t, time.Minute, defaultSources[0].StaleRatesThreshold())
+	}
+
+	sources := newCurrencyRatesSources(config.CurrencyConverter{
+		FetchURL: "https://currency.prebid.org",
+		Sources: []config.CurrencyRatesSource{
+			{Type: config.CurrencyRatesSourceURL, URL: "https://rates.example.com", StaleRatesSeconds: 3600},
+			{Type: config.CurrencyRatesSourceFile, Path: "/etc/pbs/rates.json", StaleRatesSeconds: 86400},
+			{Type: config.CurrencyRatesSourceStatic, Rates: map[string]map[string]float64{"USD": {"EUR": 0.9}}},
+		},
+	})
+	if assert.Len(t, sources, 3) {
+		assert.Equal(t, "https://rates.example.com", sources[0].Name())
+		assert.Equal(t, time.Hour, sources[0].StaleRatesThreshold())
+		assert.Equal(t, "/etc/pbs/rates.json", sources[1].Name())
+		assert.Equal(t, 24*time.Hour, sources[1].StaleRatesThreshold())
+		assert.Equal(t, "static", sources[2].Name())
+	}
+}
@@ -432,6 +432,13 @@ func (me *MultiMetricsEngine) RecordCollatedVastMissingMetadata(adapterName open
 	}
 }
 
+// RecordBidBelowFloor across all engines
+func (me *MultiMetricsEngine) RecordBidBelowFloor(adapter openrtb_ext.BidderName, account string, outcome metrics.FloorsOutcome, cpm float64) {
+	for _, thisME := range *me {
+		thisME.RecordBidBelowFloor(adapter, account, outcome, cpm)
+	}
+}
+
 // NilMetricsEngine implements the MetricsEngine interface where no metrics are actually captured. This is
 // used if no metric backend is configured and also for tests.
 type NilMetricsEngine struct{}
@@ -638,3 +645,7 @@ func (me *NilMetricsEngine) RecordCollatedVastVersionMismatch(adapterName openrt
 
 func (me *NilMetricsEngine) RecordCollatedVastMissingMetadata(adapterName openrtb_ext.BidderName) {
 }
+
+// RecordBidBelowFloor as a noop
+func (me *NilMetricsEngine) RecordBidBelowFloor(adapter openrtb_ext.BidderName, account string, outcome metrics.FloorsOutcome, cpm float64) {
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -119,6 +120,10 @@ type AdapterMetrics struct {
 
 	BidValidationSecureMarkupErrorMeter metrics.Meter
 	BidValidationSecureMarkupWarnMeter  metrics.Meter
+
+	// BidsBelowFloorMeters count the bids below their floor and RevenueBelowFloorCounters sum their price in micro CPM
+	BidsBelowFloorMeters      map[FloorsOutcome]metrics.Meter
+	RevenueBelowFloorCounters map[FloorsOutcome]metrics.Counter
 }
 
 type MarkupDeliveryMetrics struct {
@@ -456,6 +461,12 @@ func makeBlankAdapterMetrics(disabledMetrics config.DisabledMetrics) *AdapterMet
 	for _, err := range AdapterErrors() {
 		newAdapter.ErrorMeters[err] = blankMeter
 	}
+	newAdapter.BidsBelowFloorMeters = make(map[FloorsOutcome]metrics.Meter)
+	newAdapter.RevenueBelowFloorCounters = make(map[FloorsOutcome]metrics.Counter)
+	for _, outcome := range FloorsOutcomes() {
+		newAdapter.BidsBelowFloorMeters[outcome] = blankMeter
+		newAdapter.RevenueBelowFloorCounters[outcome] = metrics.NilCounter{}
+	}
 	return newAdapter
 }
 
@@ -540,6 +551,11 @@ func registerAdapterMetrics(registry metrics.Registry, adapterOrAccount string,
 
 	am.BidValidationSecureMarkupErrorMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.response.validation.secure.err", adapterOrAccount, exchange), registry)
 	am.BidValidationSecureMarkupWarnMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.response.validation.secure.warn", adapterOrAccount, exchange), registry)
+
+	for _, outcome := range FloorsOutcomes() {
+		am.BidsBelowFloorMeters[outcome] = metrics.GetOrRegisterMeter(fmt.Sprintf("%s.%s.floors.below_floor.%s", adapterOrAccount, exchange, outcome), registry)
+		am.RevenueBelowFloorCounters[outcome] = metrics.GetOrRegisterCounter(fmt.Sprintf("%s.%s.floors.below_floor.%s.revenue_micros", adapterOrAccount, exchange, outcome), registry)
+	}
 }
 
 func registerModuleMetrics(registry metrics.Registry, module string, stages []string, mm map[string]*ModuleMetrics) {
@@ -848,6 +864,28 @@ func (m *Metrics) RecordCollatedVastVersionMismatch(adapterName openrtb_ext.Bidd
 func (m *Metrics) RecordCollatedVastMissingMetadata(adapterName openrtb_ext.BidderName) {
 }
 
+// RecordBidBelowFloor implements a part of the MetricsEngine interface. Records a bid below its floor and its price
+// in micro CPM by adapter and by account and adapter.
+func (me *Metrics) RecordBidBelowFloor(adapter openrtb_ext.BidderName, pubID string, outcome FloorsOutcome, cpm float64) {
+	adapterStr := string(adapter)
+	lowercaseAdapter := strings.ToLower(adapterStr)
+	am, ok := me.AdapterMetrics[lowercaseAdapter]
+	if !ok {
+		logger.Errorf("Trying to run adapter floors metrics on %s: adapter metrics not found", adapterStr)
+		return
+	}
+	revenueMicros := int64(math.Round(cpm * 1e6))
+	am.BidsBelowFloorMeters[outcome].Mark(1)
+	am.RevenueBelowFloorCounters[outcome].Inc(revenueMicros)
+
+	if pubID != PublisherUnknown {
+		if aam, ok := me.getAccountMetrics(pubID).adapterMetrics[lowercaseAdapter]; ok {
+			aam.BidsBelowFloorMeters[outcome].Mark(1)
+			aam.RevenueBelowFloorCounters[outcome].Inc(revenueMicros)
+		}
+	}
+}
+
 func (me *Metrics) RecordDNSTime(dnsLookupTime time.Duration) {
 	me.DNSLookupTimer.Update(dnsLookupTime)
 }
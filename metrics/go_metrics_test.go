@@ -149,6 +149,10 @@ func ensureContainsAdapterMetrics(t *testing.T, registry metrics.Registry, name
 	ensureContains(t, registry, name+".response.validation.secure.err", adapterMetrics.BidValidationSecureMarkupErrorMeter)
 	ensureContains(t, registry, name+".response.validation.secure.warn", adapterMetrics.BidValidationSecureMarkupWarnMeter)
 
+	for _, outcome := range FloorsOutcomes() {
+		ensureContains(t, registry, name+".floors.below_floor."+string(outcome), adapterMetrics.BidsBelowFloorMeters[outcome])
+		ensureContains(t, registry, name+".floors.below_floor."+string(outcome)+".revenue_micros", adapterMetrics.RevenueBelowFloorCounters[outcome])
+	}
 }
 
 func ensureContainsModuleMetrics(t *testing.T, registry metrics.Registry, name string, moduleMetrics *ModuleMetrics) {
@@ -1215,6 +1219,28 @@ func TestRecordAdapterPrice(t *testing.T) {
 	assert.Equal(t, m.getAccountMetrics(pubID).adapterMetrics[lowerCaseAdapterName].PriceHistogram.Max(), int64(1000))
 }
 
+func TestRecordBidBelowFloor(t *testing.T) {
+	registry := metrics.NewRegistry()
+	adapter := "AnyName"
+	lowerCaseAdapterName := "anyname"
+	pubID := "pub1"
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderName(adapter), openrtb_ext.BidderAppnexus}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordBidBelowFloor(openrtb_ext.BidderName(adapter), pubID, FloorsOutcomeRejected, 1.5)
+	m.RecordBidBelowFloor(openrtb_ext.BidderName(adapter), pubID, FloorsOutcomeWouldReject, 0.25)
+	m.RecordBidBelowFloor(openrtb_ext.BidderName(adapter), pubID, FloorsOutcomeWouldReject, 0.5)
+
+	am := m.AdapterMetrics[lowerCaseAdapterName]
+	aam := m.getAccountMetrics(pubID).adapterMetrics[lowerCaseAdapterName]
+	for _, adapterMetrics := range []*AdapterMetrics{am, aam} {
+		assert.Equal(t, int64(1), adapterMetrics.BidsBelowFloorMeters[FloorsOutcomeRejected].Count())
+		assert.Equal(t, int64(1500000), adapterMetrics.RevenueBelowFloorCounters[FloorsOutcomeRejected].Count())
+		assert.Equal(t, int64(2), adapterMetrics.BidsBelowFloorMeters[FloorsOutcomeWouldReject].Count())
+		assert.Equal(t, int64(750000), adapterMetrics.RevenueBelowFloorCounters[FloorsOutcomeWouldReject].Count())
+	}
+	assert.Equal(t, int64(0), m.AdapterMetrics["appnexus"].BidsBelowFloorMeters[FloorsOutcomeRejected].Count())
+}
+
 func TestRecordAdapterTime(t *testing.T) {
 	registry := metrics.NewRegistry()
 	syncerKeys := []string{"foo"}
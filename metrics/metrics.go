@@ -85,6 +85,7 @@ const (
 	RequestDataType  StoredDataType = "request"
 	VideoDataType    StoredDataType = "video"
 	ResponseDataType StoredDataType = "response"
+	FloorsDataType   StoredDataType = "floors"
 )
 
 func StoredDataTypes() []StoredDataType {
@@ -95,6 +96,7 @@ func StoredDataTypes() []StoredDataType {
 		RequestDataType,
 		VideoDataType,
 		ResponseDataType,
+		FloorsDataType,
 	}
 }
 
@@ -519,6 +521,7 @@ const (
 	AuctionAuditErrorProduce    AuctionAuditErrorReason = "produce"
 	AuctionAuditErrorStartup    AuctionAuditErrorReason = "startup"
 	AuctionAuditErrorSend       AuctionAuditErrorReason = "send"
+	AuctionAuditErrorDropped    AuctionAuditErrorReason = "dropped"
 )
 
 // AuctionAuditErrorReasons returns possible auction audit error reasons.
@@ -529,6 +532,23 @@ func AuctionAuditErrorReasons() []AuctionAuditErrorReason {
 		AuctionAuditErrorProduce,
 		AuctionAuditErrorStartup,
 		AuctionAuditErrorSend,
+		AuctionAuditErrorDropped,
+	}
+}
+
+// FloorsOutcome tells whether floors enforcement rejected a bid below its floor.
+type FloorsOutcome string
+
+const (
+	FloorsOutcomeRejected    FloorsOutcome = "rejected"
+	FloorsOutcomeWouldReject FloorsOutcome = "would_reject"
+)
+
+// FloorsOutcomes returns possible outcomes of bids below their floor.
+func FloorsOutcomes() []FloorsOutcome {
+	return []FloorsOutcome{
+		FloorsOutcomeRejected,
+		FloorsOutcomeWouldReject,
 	}
 }
 
@@ -595,4 +615,5 @@ type MetricsEngine interface {
 	RecordAdapterConnectionDialTime(adapterName openrtb_ext.BidderName, dialStartTime time.Duration)
 	RecordCollatedVastVersionMismatch(adapterName openrtb_ext.BidderName)
 	RecordCollatedVastMissingMetadata(adapterName openrtb_ext.BidderName)
+	RecordBidBelowFloor(adapter openrtb_ext.BidderName, account string, outcome FloorsOutcome, cpm float64)
 }
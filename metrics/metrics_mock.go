@@ -266,3 +266,7 @@ func (me *MetricsEngineMock) RecordCollatedVastVersionMismatch(adapterName openr
 func (me *MetricsEngineMock) RecordCollatedVastMissingMetadata(adapterName openrtb_ext.BidderName) {
 	me.Called(adapterName)
 }
+
+func (me *MetricsEngineMock) RecordBidBelowFloor(adapter openrtb_ext.BidderName, account string, outcome FloorsOutcome, cpm float64) {
+	me.Called(adapter, account, outcome, cpm)
+}
@@ -1,6 +1,7 @@
 package prometheusmetrics
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -17,6 +18,8 @@ import (
 type Metrics struct {
 	Registerer prometheus.Registerer
 	Gatherer   *prometheus.Registry
+	// ModulesGatherer exposes collectors registered by hook modules, see NewModulesRegistry.
+	ModulesGatherer prometheus.Gatherer
 
 	// General Metrics
 	tmaxTimeout                  prometheus.Counter
@@ -57,6 +60,8 @@ type Metrics struct {
 	gvlListRequests              prometheus.Counter
 	storedResponsesFetchTimer    *prometheus.HistogramVec
 	storedResponsesErrors        *prometheus.CounterVec
+	storedFloorsFetchTimer       *prometheus.HistogramVec
+	storedFloorsErrors           *prometheus.CounterVec
 	adsCertRequests              *prometheus.CounterVec
 	adsCertSignTimer             prometheus.Histogram
 	bidderServerResponseTimer    prometheus.Histogram
@@ -83,6 +88,8 @@ type Metrics struct {
 	adapterConnectionDialTime             *prometheus.HistogramVec
 	collatedVastVersionMismatch           *prometheus.CounterVec
 	collatedVastMissingMetadata           *prometheus.CounterVec
+	adapterBidsBelowFloor                 *prometheus.CounterVec
+	adapterRevenueBelowFloor              *prometheus.CounterVec
 
 	// Syncer Metrics
 	syncerRequests *prometheus.CounterVec
@@ -96,6 +103,8 @@ type Metrics struct {
 	accountBidResponseValidationSizeWarn  *prometheus.CounterVec
 	accountBidResponseSecureMarkupError   *prometheus.CounterVec
 	accountBidResponseSecureMarkupWarn    *prometheus.CounterVec
+	accountBidsBelowFloor                 *prometheus.CounterVec
+	accountRevenueBelowFloor              *prometheus.CounterVec
 
 	// Module Metrics as a map where the key is the module name
 	moduleDuration        map[string]*prometheus.HistogramVec
@@ -135,6 +144,7 @@ const (
 	isVideoLabel         = "video"
 	markupDeliveryLabel  = "delivery"
 	optOutLabel          = "opt_out"
+	outcomeLabel         = "outcome"
 	overheadTypeLabel    = "overhead_type"
 	privacyBlockedLabel  = "privacy_blocked"
 	errorTypeLabel       = "error_type"
@@ -383,6 +393,17 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of stored video errors by error type",
 		[]string{storedDataErrorLabel})
 
+	metrics.storedFloorsFetchTimer = newHistogramVec(cfg, reg,
+		"stored_floors_fetch_time_seconds",
+		"Seconds to fetch stored floors labeled by fetch type",
+		[]string{storedDataFetchTypeLabel},
+		standardTimeBuckets)
+
+	metrics.storedFloorsErrors = newCounter(cfg, reg,
+		"stored_floors_errors",
+		"Count of stored floors errors by error type",
+		[]string{storedDataErrorLabel})
+
 	metrics.storedResponses = newCounterWithoutLabels(cfg, reg,
 		"stored_responses",
 		"Count of total requests to Prebid Server that have stored responses")
@@ -458,6 +479,16 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of VAST ads discarded during collation due to missing Advertiser or Pricing metadata, labeled by adapter.",
 		[]string{adapterLabel})
 
+	metrics.adapterBidsBelowFloor = newCounter(cfg, reg,
+		"adapter_floors_bids_below_floor",
+		"Count of bids below their floor labeled by adapter and by whether floors enforcement rejected them.",
+		[]string{adapterLabel, outcomeLabel})
+
+	metrics.adapterRevenueBelowFloor = newCounter(cfg, reg,
+		"adapter_floors_revenue_below_floor",
+		"Sum of the CPM of bids below their floor labeled by adapter and by whether floors enforcement rejected them.",
+		[]string{adapterLabel, outcomeLabel})
+
 	metrics.adapterBidResponseValidationSizeError = newCounter(cfg, reg,
 		"adapter_response_validation_size_err",
 		"Count that tracks number of bids removed from bid response that had a creative size greater than maxWidth/maxHeight",
@@ -540,6 +571,16 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count that tracks number of bids removed from bid response that had a invalid bidAdm labeled by account (warn)",
 		[]string{accountLabel, successLabel})
 
+	metrics.accountBidsBelowFloor = newCounter(cfg, reg,
+		"account_floors_bids_below_floor",
+		"Count of bids below their floor labeled by account and by whether floors enforcement rejected them.",
+		[]string{accountLabel, outcomeLabel})
+
+	metrics.accountRevenueBelowFloor = newCounter(cfg, reg,
+		"account_floors_revenue_below_floor",
+		"Sum of the CPM of bids below their floor labeled by account and by whether floors enforcement rejected them.",
+		[]string{accountLabel, outcomeLabel})
+
 	metrics.requestsQueueTimer = newHistogramVec(cfg, reg,
 		"request_queue_time",
 		"Seconds request was waiting in queue",
@@ -584,15 +625,7 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 
 	metrics.Gatherer = reg
 
-	metricsPrefix := ""
-	if len(cfg.Namespace) > 0 {
-		metricsPrefix += fmt.Sprintf("%s_", cfg.Namespace)
-	}
-	if len(cfg.Subsystem) > 0 {
-		metricsPrefix += fmt.Sprintf("%s_", cfg.Subsystem)
-	}
-
-	metrics.Registerer = prometheus.WrapRegistererWithPrefix(metricsPrefix, reg)
+	metrics.Registerer = prometheus.WrapRegistererWithPrefix(metricsPrefix(cfg), reg)
 	metrics.Registerer.MustRegister(promCollector.NewGoCollector())
 
 	preloadLabelValues(&metrics, syncerKeys, moduleStageNames)
@@ -600,6 +633,40 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 	return &metrics
 }
 
+// NewModulesRegistry creates the registry for collectors owned by hook modules. Modules are
+// built before the metrics engine, so they register on the returned registerer, which applies
+// the configured namespace and subsystem, and the registry is later set as ModulesGatherer.
+func NewModulesRegistry(cfg config.PrometheusMetrics) (*prometheus.Registry, prometheus.Registerer) {
+	reg := prometheus.NewRegistry()
+	return reg, prometheus.WrapRegistererWithPrefix(metricsPrefix(cfg), reg)
+}
+
+// RegisterModuleCollector registers c on a modules registerer. A module built more than once
+// against the same registry gets back the collector registered the first time.
+func RegisterModuleCollector[T prometheus.Collector](registerer prometheus.Registerer, c T) (T, error) {
+	if err := registerer.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return c, err
+	}
+	return c, nil
+}
+
+func metricsPrefix(cfg config.PrometheusMetrics) string {
+	prefix := ""
+	if len(cfg.Namespace) > 0 {
+		prefix += fmt.Sprintf("%s_", cfg.Namespace)
+	}
+	if len(cfg.Subsystem) > 0 {
+		prefix += fmt.Sprintf("%s_", cfg.Subsystem)
+	}
+	return prefix
+}
+
 func createModulesMetrics(cfg config.PrometheusMetrics, registry *prometheus.Registry, m *Metrics, moduleStageNames map[string][]string, standardTimeBuckets []float64) {
 	l := len(moduleStageNames)
 	m.moduleDuration = make(map[string]*prometheus.HistogramVec, l)
@@ -835,6 +902,10 @@ func (m *Metrics) RecordStoredDataFetchTime(labels metrics.StoredDataLabels, len
 		m.storedResponsesFetchTimer.With(prometheus.Labels{
 			storedDataFetchTypeLabel: string(labels.DataFetchType),
 		}).Observe(length.Seconds())
+	case metrics.FloorsDataType:
+		m.storedFloorsFetchTimer.With(prometheus.Labels{
+			storedDataFetchTypeLabel: string(labels.DataFetchType),
+		}).Observe(length.Seconds())
 	}
 }
 
@@ -864,6 +935,10 @@ func (m *Metrics) RecordStoredDataError(labels metrics.StoredDataLabels) {
 		m.storedResponsesErrors.With(prometheus.Labels{
 			storedDataErrorLabel: string(labels.Error),
 		}).Inc()
+	case metrics.FloorsDataType:
+		m.storedFloorsErrors.With(prometheus.Labels{
+			storedDataErrorLabel: string(labels.Error),
+		}).Inc()
 	}
 }
 
@@ -1241,3 +1316,21 @@ func (m *Metrics) RecordCollatedVastMissingMetadata(adapterName openrtb_ext.Bidd
 		adapterLabel: strings.ToLower(string(adapterName)),
 	}).Inc()
 }
+
+func (m *Metrics) RecordBidBelowFloor(adapter openrtb_ext.BidderName, account string, outcome metrics.FloorsOutcome, cpm float64) {
+	adapterLabels := prometheus.Labels{
+		adapterLabel: strings.ToLower(string(adapter)),
+		outcomeLabel: string(outcome),
+	}
+	m.adapterBidsBelowFloor.With(adapterLabels).Inc()
+	m.adapterRevenueBelowFloor.With(adapterLabels).Add(cpm)
+
+	if !m.metricsDisabled.AccountAdapterDetails && account != metrics.PublisherUnknown {
+		accountLabels := prometheus.Labels{
+			accountLabel: account,
+			outcomeLabel: string(outcome),
+		}
+		m.accountBidsBelowFloor.With(accountLabels).Inc()
+		m.accountRevenueBelowFloor.With(accountLabels).Add(cpm)
+	}
+}
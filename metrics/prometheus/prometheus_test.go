@@ -282,6 +282,40 @@ func TestBidValidationCreativeSizeMetric(t *testing.T) {
 	}
 }
 
+func TestRecordBidBelowFloorMetric(t *testing.T) {
+	testCases := []struct {
+		description                        string
+		givenAccountAdapterMetricsDisabled bool
+		expectedAccountCount               float64
+		expectedAccountRevenue             float64
+	}{
+		{
+			description:                        "Account Metric isn't disabled, so both metrics should be incremented",
+			givenAccountAdapterMetricsDisabled: false,
+			expectedAccountCount:               2,
+			expectedAccountRevenue:             0.75,
+		},
+		{
+			description:                        "Account Metric is disabled, so only adapter metric should be incremented",
+			givenAccountAdapterMetricsDisabled: true,
+		},
+	}
+	for _, test := range testCases {
+		m := createMetricsForTesting()
+		m.metricsDisabled.AccountAdapterDetails = test.givenAccountAdapterMetricsDisabled
+		m.RecordBidBelowFloor(openrtb_ext.BidderName("AnyName"), "acct-id", metrics.FloorsOutcomeWouldReject, 0.25)
+		m.RecordBidBelowFloor(openrtb_ext.BidderName("AnyName"), "acct-id", metrics.FloorsOutcomeWouldReject, 0.5)
+
+		adapterLabels := prometheus.Labels{adapterLabel: "anyname", outcomeLabel: string(metrics.FloorsOutcomeWouldReject)}
+		accountLabels := prometheus.Labels{accountLabel: "acct-id", outcomeLabel: string(metrics.FloorsOutcomeWouldReject)}
+		assertCounterVecValue(t, test.description, "adapter bids below floor", m.adapterBidsBelowFloor, 2, adapterLabels)
+		assertCounterVecValue(t, test.description, "adapter revenue below floor", m.adapterRevenueBelowFloor, 0.75, adapterLabels)
+		assertCounterVecValue(t, test.description, "account bids below floor", m.accountBidsBelowFloor, test.expectedAccountCount, accountLabels)
+		assertCounterVecValue(t, test.description, "account revenue below floor", m.accountRevenueBelowFloor, test.expectedAccountRevenue, accountLabels)
+		assertCounterVecValue(t, test.description, "adapter bids rejected below floor", m.adapterBidsBelowFloor, 0, prometheus.Labels{adapterLabel: "anyname", outcomeLabel: string(metrics.FloorsOutcomeRejected)})
+	}
+}
+
 func TestBidValidationSecureMarkupMetric(t *testing.T) {
 	testCases := []struct {
 		description                        string
@@ -671,6 +705,11 @@ func TestRecordStoredDataFetchTime(t *testing.T) {
 			dataType:    metrics.ResponseDataType,
 			fetchType:   metrics.FetchDelta,
 		},
+		{
+			description: "Update stored floors histogram with delta label",
+			dataType:    metrics.FloorsDataType,
+			fetchType:   metrics.FetchDelta,
+		},
 	}
 
 	for _, tt := range tests {
@@ -696,6 +735,8 @@ func TestRecordStoredDataFetchTime(t *testing.T) {
 			metricsTimer = m.storedVideoFetchTimer
 		case metrics.ResponseDataType:
 			metricsTimer = m.storedResponsesFetchTimer
+		case metrics.FloorsDataType:
+			metricsTimer = m.storedFloorsFetchTimer
 		}
 
 		result, found := getHistogramFromHistogramVec(
@@ -780,6 +821,12 @@ func TestRecordStoredDataError(t *testing.T) {
 			errorType:   metrics.StoredDataErrorNetwork,
 			metricName:  "stored_response_errors",
 		},
+		{
+			description: "Update stored_floors_errors counter with network label",
+			dataType:    metrics.FloorsDataType,
+			errorType:   metrics.StoredDataErrorNetwork,
+			metricName:  "stored_floors_errors",
+		},
 	}
 
 	for _, tt := range tests {
@@ -803,6 +850,8 @@ func TestRecordStoredDataError(t *testing.T) {
 			metricsCounter = m.storedVideoErrors
 		case metrics.ResponseDataType:
 			metricsCounter = m.storedResponsesErrors
+		case metrics.FloorsDataType:
+			metricsCounter = m.storedFloorsErrors
 		}
 
 		assertCounterVecValue(t, tt.description, tt.metricName, metricsCounter,
@@ -2109,3 +2158,31 @@ func TestRecordAuctionAuditMetric(t *testing.T) {
 			accountLabel: "testaccount",
 		})
 }
+
+func TestNewModulesRegistry(t *testing.T) {
+	registry, registerer := NewModulesRegistry(config.PrometheusMetrics{Namespace: "prebid", Subsystem: "server"})
+
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "modules_foobar_custom", Help: "help"})
+	registerer.MustRegister(counter)
+	counter.Inc()
+
+	metricFamilies, err := registry.Gather()
+	assert.NoError(t, err)
+	if assert.Len(t, metricFamilies, 1) {
+		assert.Equal(t, "prebid_server_modules_foobar_custom", metricFamilies[0].GetName())
+	}
+}
+
+func TestRegisterModuleCollector(t *testing.T) {
+	_, registerer := NewModulesRegistry(config.PrometheusMetrics{})
+
+	first, err := RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{Name: "modules_foobar_calls", Help: "help"}, []string{"stage"}))
+	assert.NoError(t, err)
+
+	second, err := RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{Name: "modules_foobar_calls", Help: "help"}, []string{"stage"}))
+	assert.NoError(t, err)
+	assert.Same(t, first, second, "already registered collector is reused")
+
+	_, err = RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{Name: "modules_foobar_calls", Help: "help"}, []string{"other"}))
+	assert.Error(t, err, "conflicting collector")
+}
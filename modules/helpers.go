@@ -15,49 +15,49 @@ func createModuleStageNamesCollection(modules map[string]interface{}) (map[strin
 	var added bool
 
 	for id, hook := range modules {
-		if _, ok := hook.(hookstage.Entrypoint); ok {
+		if _, ok := hook.(hookstage.Entrypoint); ok && hooks.ProvidesStage(hook, hooks.StageEntrypoint) {
 			added = true
 			stageName := hooks.StageEntrypoint.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.RawAuctionRequest); ok {
+		if _, ok := hook.(hookstage.RawAuctionRequest); ok && hooks.ProvidesStage(hook, hooks.StageRawAuctionRequest) {
 			added = true
 			stageName := hooks.StageRawAuctionRequest.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.ProcessedAuctionRequest); ok {
+		if _, ok := hook.(hookstage.ProcessedAuctionRequest); ok && hooks.ProvidesStage(hook, hooks.StageProcessedAuctionRequest) {
 			added = true
 			stageName := hooks.StageProcessedAuctionRequest.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.BidderRequest); ok {
+		if _, ok := hook.(hookstage.BidderRequest); ok && hooks.ProvidesStage(hook, hooks.StageBidderRequest) {
 			added = true
 			stageName := hooks.StageBidderRequest.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.RawBidderResponse); ok {
+		if _, ok := hook.(hookstage.RawBidderResponse); ok && hooks.ProvidesStage(hook, hooks.StageRawBidderResponse) {
 			added = true
 			stageName := hooks.StageRawBidderResponse.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.AllProcessedBidResponses); ok {
+		if _, ok := hook.(hookstage.AllProcessedBidResponses); ok && hooks.ProvidesStage(hook, hooks.StageAllProcessedBidResponses) {
 			added = true
 			stageName := hooks.StageAllProcessedBidResponses.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.AuctionResponse); ok {
+		if _, ok := hook.(hookstage.AuctionResponse); ok && hooks.ProvidesStage(hook, hooks.StageAuctionResponse) {
 			added = true
 			stageName := hooks.StageAuctionResponse.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
 		}
 
-		if _, ok := hook.(hookstage.Exitpoint); ok {
+		if _, ok := hook.(hookstage.Exitpoint); ok && hooks.ProvidesStage(hook, hooks.StageExitpoint) {
 			added = true
 			stageName := hooks.StageExitpoint.String()
 			moduleStageNameCollector = addModuleStageName(moduleStageNameCollector, id, stageName)
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/prebid/prebid-server/v3/currency"
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // ModuleDeps provides dependencies that custom modules may need for hooks execution.
@@ -12,4 +14,16 @@ type ModuleDeps struct {
 	HTTPClient    *http.Client
 	RateConvertor *currency.RateConverter
 	Geoscope      map[string][]string
+	// MetricsRegisterer is used by modules to register their own Prometheus collectors.
+	// It is nil when Prometheus metrics are disabled.
+	MetricsRegisterer prometheus.Registerer
+	// AccountMetricsDisabled is true when module collectors must not be labeled by account,
+	// following metrics.disabled_metrics.account_modules_metrics.
+	AccountMetricsDisabled bool
+	// RegisterAdminHandler is used by modules to serve a handler on the admin port at path.
+	// It is nil when modules can't serve admin endpoints.
+	RegisterAdminHandler func(path string, handler http.Handler)
+	// Caches is used by modules to create the caches of the responses of the services they call.
+	// Its caches report hits and misses per module and are purged by the /hooks/cache/purge admin endpoint.
+	Caches *hookcache.Registry
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/modules/remote"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 )
 
@@ -44,6 +45,9 @@ type builder struct {
 // The ID chosen for the module's hooks represents a fully qualified module path in the format
 // "vendor.module_name" and should be used to retrieve module hooks from the hooks.HookRepository.
 //
+// Modules which are not compiled into the server can be configured with a "remote" section,
+// see [remote.Config]. Their hooks are provided by an external process.
+//
 // Method returns a hooks.HookRepository and a map of modules to a list of stage names
 // for which module provides hooks or an error occurred during modules initialization.
 func (m *builder) Build(
@@ -84,6 +88,41 @@ func (m *builder) Build(
 		}
 	}
 
+	for vendor, moduleConfigs := range cfg {
+		for moduleName, data := range moduleConfigs {
+			if _, ok := m.builders[vendor][moduleName]; ok {
+				continue
+			}
+
+			values, ok := data.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			remoteConfig, ok := values["remote"]
+			if !ok {
+				continue
+			}
+
+			id := fmt.Sprintf("%s.%s", vendor, moduleName)
+			if isEnabled, _ := values["enabled"].(bool); !isEnabled {
+				logger.Infof("Skip %s module, disabled.", id)
+				continue
+			}
+
+			conf, err := jsonutil.Marshal(remoteConfig)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf(`failed to marshal "%s" module config: %s`, id, err)
+			}
+
+			module, err := remote.Builder(id, conf, deps)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf(`failed to init "%s" module: %s`, id, err)
+			}
+
+			modules[id] = module
+		}
+	}
+
 	collection, err := createModuleStageNamesCollection(modules)
 	if err != nil {
 		return nil, nil, nil, err
@@ -131,3 +131,54 @@ func (h module) HandleAuctionResponseHook(_ context.Context, _ hookstage.ModuleI
 func (h module) Shutdown() error {
 	return nil
 }
+
+func TestModuleBuilderBuildRemote(t *testing.T) {
+	remoteConfig := map[string]interface{}{"transport": "uds", "base_path": "/tmp/partner.sock", "request_path": "hooks", "stages": []interface{}{"raw_auction_request"}}
+
+	testCases := map[string]struct {
+		givenConfig           config.Modules
+		expectedModulesStages map[string][]string
+		expectedHook          bool
+		expectedErr           error
+	}{
+		"Can build remote module which is not compiled": {
+			givenConfig:           config.Modules{"partner": {"remote": map[string]interface{}{"enabled": true, "remote": remoteConfig}}},
+			expectedModulesStages: map[string][]string{"partner_remote": {hooks.StageRawAuctionRequest.String()}},
+			expectedHook:          true,
+		},
+		"Remote module is skipped if it's disabled": {
+			givenConfig:           config.Modules{"partner": {"remote": map[string]interface{}{"enabled": false, "remote": remoteConfig}}},
+			expectedModulesStages: map[string][]string{},
+		},
+		"Remote config of compiled module is ignored": {
+			givenConfig:           config.Modules{"acme": {"foobar": map[string]interface{}{"enabled": true, "remote": remoteConfig}}},
+			expectedModulesStages: map[string][]string{"acme_foobar": {hooks.StageEntrypoint.String(), hooks.StageAuctionResponse.String()}},
+		},
+		"Fails if remote module config is invalid": {
+			givenConfig: config.Modules{"partner": {"remote": map[string]interface{}{"enabled": true, "remote": map[string]interface{}{"transport": "uds"}}}},
+			expectedErr: errors.New(`failed to init "partner.remote" module: base_path is required`),
+		},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			builder := &builder{
+				builders: ModuleBuilders{
+					"acme": {
+						"foobar": func(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
+							return module{}, nil
+						},
+					},
+				},
+			}
+
+			repo, modulesStages, _, err := builder.Build(test.givenConfig, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient})
+			assert.Equal(t, test.expectedErr, err)
+			assert.Equal(t, test.expectedModulesStages, modulesStages)
+			if err == nil {
+				_, found := repo.GetRawAuctionHook("partner.remote")
+				assert.Equal(t, test.expectedHook, found)
+			}
+		})
+	}
+}
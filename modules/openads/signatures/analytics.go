@@ -0,0 +1,67 @@
+package signatures
+
+import (
+	"sort"
+
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+)
+
+// signatures module has only 1 activity: `openads_signatures`, with a result per bidder
+const signActivityName = "openads_signatures"
+
+const (
+	outcomeAnalyticKey          = "outcome"
+	auctionSignatureAnalyticKey = "auction_signature"
+)
+
+// newSignAnalytics wraps results into the module's activity, which is reported
+// as an error when any bidder was left without a signature.
+func newSignAnalytics(results ...hookanalytics.Result) hookanalytics.Analytics {
+	status := hookanalytics.ActivityStatusSuccess
+	for _, result := range results {
+		if result.Status == hookanalytics.ResultStatusError {
+			status = hookanalytics.ActivityStatusError
+		}
+	}
+
+	return hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:    signActivityName,
+				Status:  status,
+				Results: results,
+			},
+		},
+	}
+}
+
+func newSignResult(bidder string, status hookanalytics.ResultStatus, outcome signOutcome) hookanalytics.Result {
+	return hookanalytics.Result{
+		Status: status,
+		Values: map[string]interface{}{outcomeAnalyticKey: string(outcome)},
+		AppliedTo: hookanalytics.AppliedTo{
+			Bidder:  bidder,
+			Request: true,
+		},
+	}
+}
+
+// auctionSignResults returns a result per bidder signed at the auction stage,
+// sorted by bidder.
+func auctionSignResults(outcomes map[string]signOutcome) []hookanalytics.Result {
+	bidders := make([]string, 0, len(outcomes))
+	for bidder := range outcomes {
+		bidders = append(bidders, bidder)
+	}
+	sort.Strings(bidders)
+
+	results := make([]hookanalytics.Result, 0, len(bidders))
+	for _, bidder := range bidders {
+		status := hookanalytics.ResultStatusAllow
+		if outcomes[bidder] != outcomeSigned {
+			status = hookanalytics.ResultStatusError
+		}
+		results = append(results, newSignResult(bidder, status, outcomes[bidder]))
+	}
+	return results
+}
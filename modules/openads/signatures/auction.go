@@ -0,0 +1,195 @@
+package signatures
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const auctionSignaturesKey = "auction_signatures"
+
+// auctionSignatures holds the signatures obtained for all bidders of an auction
+// at the processed auction request stage. Each signature covers that bidder's signing view of the
+// auction request.
+type auctionSignatures struct {
+	byBidder map[string]bidderSignature
+}
+
+type bidderSignature struct {
+	digest [sha256.Size]byte
+	sis    Signature
+}
+
+// lookup returns the signature for bidder if the bidder request still has the
+// signing view that was signed at the auction stage.
+func (a *auctionSignatures) lookup(bidder string, request *openrtb2.BidRequest) (Signature, bool) {
+	entry, ok := a.byBidder[bidder]
+	if !ok {
+		return Signature{}, false
+	}
+
+	view, err := signingView(request, request.Imp)
+	if err != nil || sha256.Sum256(view) != entry.digest {
+		return Signature{}, false
+	}
+	return entry.sis, true
+}
+
+// signingView returns the canonical form of request restricted to imps, with
+// ext.prebid, imp[].ext.prebid and imp[].ext.bidder removed. Splitting the
+// auction request per bidder keeps only the bidder's imps and always rewrites
+// those objects, which carry bidder params and server controls. Every other
+// field, including the rest of ext, is signed, so any other difference, for
+// example from privacy scrubbing, still changes the view. Both signing modes
+// sign this view, so int_sigs always covers the same fields.
+func signingView(request *openrtb2.BidRequest, imps []openrtb2.Imp) ([]byte, error) {
+	view := *request
+
+	var err error
+	if view.Ext, err = withoutKeys(request.Ext, "prebid"); err != nil {
+		return nil, fmt.Errorf("invalid request ext: %w", err)
+	}
+
+	view.Imp = make([]openrtb2.Imp, len(imps))
+	for i := range imps {
+		view.Imp[i] = imps[i]
+		if view.Imp[i].Ext, err = withoutKeys(imps[i].Ext, "prebid", "bidder"); err != nil {
+			return nil, fmt.Errorf("invalid ext of imp %s: %w", imps[i].ID, err)
+		}
+	}
+	return marshalCanonical(&view)
+}
+
+// withoutKeys returns ext without the given top level keys, or nil if no other
+// key is left.
+func withoutKeys(ext json.RawMessage, keys ...string) (json.RawMessage, error) {
+	if len(ext) == 0 {
+		return nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := jsonutil.Unmarshal(ext, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		delete(fields, key)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return jsonutil.Marshal(fields)
+}
+
+// HandleProcessedAuctionHook signs the auction request for every bidder before
+// the request is split when running in auction mode. Failures here never reject the auction; bidders
+// without a usable signature fall back to per-bidder signing.
+func (m Module) HandleProcessedAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ProcessedAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload], error) {
+	result := hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}
+
+	if m.cfg.Mode != SigningModeAuction {
+		return result, nil
+	}
+
+	if payload.Request == nil || payload.Request.BidRequest == nil {
+		return result, hookexecution.NewFailure("payload contains a nil bid request")
+	}
+
+	impsByBidder := auctionImps(payload)
+	if len(impsByBidder) == 0 {
+		return result, nil
+	}
+
+	bidders := make([]string, 0, len(impsByBidder))
+	for bidder := range impsByBidder {
+		bidders = append(bidders, bidder)
+	}
+	sort.Strings(bidders)
+
+	// Bidders that bid on the same imps share a signing view, so they are
+	// signed over the same requestBody in a single call.
+	var requests []signatureRequest
+	requestIndex := make(map[string]int)
+	for _, bidder := range bidders {
+		view, err := signingView(payload.Request.BidRequest, impsByBidder[bidder])
+		if err != nil {
+			return result, hookexecution.NewFailure("failed to marshal bid request: %v", err)
+		}
+
+		if i, ok := requestIndex[string(view)]; ok {
+			requests[i].DemandSources = append(requests[i].DemandSources, bidder)
+			continue
+		}
+		requestIndex[string(view)] = len(requests)
+		requests = append(requests, signatureRequest{RequestBody: view, DemandSources: []string{bidder}})
+	}
+
+	// Each distinct view is a separate call to the service, made concurrently
+	// so the stage takes as long as the slowest call.
+	signaturesByRequest := make([]map[string]Signature, len(requests))
+	outcomesByRequest := make([]map[string]signOutcome, len(requests))
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			signaturesByRequest[i], outcomesByRequest[i], errs[i] = m.sign(ctx, miCtx.AccountID, requests[i])
+		}(i)
+	}
+	wg.Wait()
+
+	outcomes := make(map[string]signOutcome, len(bidders))
+	byBidder := make(map[string]bidderSignature, len(bidders))
+	for i, request := range requests {
+		if errs[i] != nil {
+			result.Warnings = append(result.Warnings, errs[i].Error())
+		}
+		for ds, outcome := range outcomesByRequest[i] {
+			outcomes[ds] = outcome
+		}
+
+		digest := sha256.Sum256(request.RequestBody)
+		for ds, sis := range signaturesByRequest[i] {
+			byBidder[ds] = bidderSignature{digest: digest, sis: sis}
+		}
+	}
+	result.AnalyticsTags = newSignAnalytics(auctionSignResults(outcomes)...)
+
+	if len(byBidder) > 0 {
+		result.ModuleContext = hookstage.ModuleContext{
+			auctionSignaturesKey: &auctionSignatures{byBidder: byBidder},
+		}
+	}
+
+	return result, nil
+}
+
+// auctionImps groups the request imps by the bidders referenced in their
+// ext.prebid.bidder, keeping the request order as the per-bidder split does.
+func auctionImps(payload hookstage.ProcessedAuctionRequestPayload) map[string][]openrtb2.Imp {
+	impsByBidder := make(map[string][]openrtb2.Imp)
+	for _, imp := range payload.Request.GetImp() {
+		impExt, err := imp.GetImpExt()
+		if err != nil {
+			continue
+		}
+		if prebid := impExt.GetPrebid(); prebid != nil {
+			for bidder := range prebid.Bidder {
+				impsByBidder[bidder] = append(impsByBidder[bidder], *imp.Imp)
+			}
+		}
+	}
+	return impsByBidder
+}
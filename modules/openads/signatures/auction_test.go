@@ -0,0 +1,391 @@
+package signatures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFetcher signs every requested demand source with a fixed envelope
+// and records the calls it receives, sorted by their first demand source.
+type recordingFetcher struct {
+	mu       sync.Mutex
+	calls    int
+	requests []signatureRequest
+	omit     map[string]bool
+	err      error
+	// prefix of the returned envelopes, "env-" by default
+	prefix string
+}
+
+func (f *recordingFetcher) Fetch(_ context.Context, body []byte) ([]SignatureWrapper, error) {
+	var request signatureRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	f.requests = append(f.requests, request)
+	sort.Slice(f.requests, func(i, j int) bool {
+		return f.requests[i].DemandSources[0] < f.requests[j].DemandSources[0]
+	})
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	prefix := f.prefix
+	if prefix == "" {
+		prefix = "env-"
+	}
+
+	var signatures []SignatureWrapper
+	for _, ds := range request.DemandSources {
+		if f.omit[ds] {
+			continue
+		}
+		signatures = append(signatures, SignatureWrapper{Name: ds, SIS: Signature{Envelope: prefix + ds, Source: "source"}})
+	}
+	return signatures, nil
+}
+
+func newAuctionModule(fetcher SignatureFetcher, mode SigningMode) Module {
+	return Module{
+		cfg: &Config{
+			Transport:   TransportUDS,
+			BasePath:    "/test.sock",
+			RequestPath: "/test",
+			Mode:        mode,
+			Version:     SchemaVersion,
+		},
+		fetcher: fetcher,
+	}
+}
+
+func auctionRequest() *openrtb2.BidRequest {
+	return &openrtb2.BidRequest{
+		ID: "test-request",
+		Imp: []openrtb2.Imp{
+			{ID: "imp-1", Ext: json.RawMessage(`{"prebid":{"bidder":{"bidderB":{},"bidderA":{}}}}`)},
+			{ID: "imp-2", Ext: json.RawMessage(`{"prebid":{"bidder":{"bidderC":{}}}}`)},
+		},
+	}
+}
+
+func TestHandleProcessedAuctionHook(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             SigningMode
+		fetcher          *recordingFetcher
+		expectCalls      int
+		expectWarnings   []string
+		expectCachedSigs []string
+		expectOutcomes   map[string]signOutcome
+	}{
+		{
+			name:        "bidder mode does nothing",
+			mode:        SigningModeBidder,
+			fetcher:     &recordingFetcher{},
+			expectCalls: 0,
+		},
+		{
+			name:             "auction mode signs all bidders in one call per view",
+			mode:             SigningModeAuction,
+			fetcher:          &recordingFetcher{},
+			expectCalls:      2,
+			expectCachedSigs: []string{"bidderA", "bidderB", "bidderC"},
+			expectOutcomes:   map[string]signOutcome{"bidderA": outcomeSigned, "bidderB": outcomeSigned, "bidderC": outcomeSigned},
+		},
+		{
+			name:             "partial response keeps the signatures it got",
+			mode:             SigningModeAuction,
+			fetcher:          &recordingFetcher{omit: map[string]bool{"bidderB": true}},
+			expectCalls:      2,
+			expectWarnings:   []string{"missing demandSources in sidecar response: [bidderB]"},
+			expectCachedSigs: []string{"bidderA", "bidderC"},
+			expectOutcomes:   map[string]signOutcome{"bidderA": outcomeSigned, "bidderB": outcomeMissing, "bidderC": outcomeSigned},
+		},
+		{
+			name:        "no signatures at all is a warning",
+			mode:        SigningModeAuction,
+			fetcher:     &recordingFetcher{omit: map[string]bool{"bidderA": true, "bidderB": true, "bidderC": true}},
+			expectCalls: 2,
+			expectWarnings: []string{
+				"missing demandSources in sidecar response: [bidderA bidderB]",
+				"missing demandSources in sidecar response: [bidderC]",
+			},
+			expectOutcomes: map[string]signOutcome{"bidderA": outcomeMissing, "bidderB": outcomeMissing, "bidderC": outcomeMissing},
+		},
+		{
+			name:           "fetch error is a warning",
+			mode:           SigningModeAuction,
+			fetcher:        &recordingFetcher{err: errors.New("connection refused")},
+			expectCalls:    2,
+			expectWarnings: []string{"sidecar fetch: connection refused", "sidecar fetch: connection refused"},
+			expectOutcomes: map[string]signOutcome{"bidderA": outcomeSidecarError, "bidderB": outcomeSidecarError, "bidderC": outcomeSidecarError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module := newAuctionModule(tt.fetcher, tt.mode)
+			payload := hookstage.ProcessedAuctionRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{BidRequest: auctionRequest()},
+			}
+
+			result, err := module.HandleProcessedAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+			require.NoError(t, err)
+			assert.False(t, result.Reject)
+			assert.Equal(t, tt.expectCalls, tt.fetcher.calls)
+
+			assert.Equal(t, tt.expectWarnings, result.Warnings)
+
+			if tt.expectOutcomes == nil {
+				assert.Empty(t, result.AnalyticsTags.Activities)
+			} else {
+				assert.Equal(t, newSignAnalytics(auctionSignResults(tt.expectOutcomes)...), result.AnalyticsTags)
+			}
+
+			if tt.expectCalls > 0 {
+				// imp-1 goes to bidderA and bidderB, imp-2 only to bidderC, so
+				// there is one call per distinct signing view
+				require.Len(t, tt.fetcher.requests, 2)
+				assert.Equal(t, []string{"bidderA", "bidderB"}, tt.fetcher.requests[0].DemandSources)
+				assert.Equal(t, []string{"bidderC"}, tt.fetcher.requests[1].DemandSources)
+				assert.JSONEq(t, `{"id":"test-request","imp":[{"id":"imp-1"}]}`, string(tt.fetcher.requests[0].RequestBody))
+				assert.JSONEq(t, `{"id":"test-request","imp":[{"id":"imp-2"}]}`, string(tt.fetcher.requests[1].RequestBody))
+			}
+
+			cached, ok := result.ModuleContext[auctionSignaturesKey].(*auctionSignatures)
+			if len(tt.expectCachedSigs) == 0 {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			for _, bidder := range tt.expectCachedSigs {
+				assert.Contains(t, cached.byBidder, bidder)
+			}
+			assert.Len(t, cached.byBidder, len(tt.expectCachedSigs))
+		})
+	}
+}
+
+func TestHandleProcessedAuctionHook_SingleView(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	module := newAuctionModule(fetcher, SigningModeAuction)
+
+	request := &openrtb2.BidRequest{
+		ID:  "test-request",
+		Imp: []openrtb2.Imp{{ID: "imp-1", Ext: json.RawMessage(`{"prebid":{"bidder":{"bidderA":{},"bidderB":{}}}}`)}},
+	}
+	payload := hookstage.ProcessedAuctionRequestPayload{Request: &openrtb_ext.RequestWrapper{BidRequest: request}}
+
+	_, err := module.HandleProcessedAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+	require.Len(t, fetcher.requests, 1)
+	assert.Equal(t, []string{"bidderA", "bidderB"}, fetcher.requests[0].DemandSources)
+}
+
+func TestHandleBidderRequestHook_AuctionCache(t *testing.T) {
+	auctionFetcher := &recordingFetcher{}
+	auctionResult, err := newAuctionModule(auctionFetcher, SigningModeAuction).HandleProcessedAuctionHook(
+		context.Background(),
+		hookstage.ModuleInvocationContext{},
+		hookstage.ProcessedAuctionRequestPayload{Request: &openrtb_ext.RequestWrapper{BidRequest: auctionRequest()}},
+	)
+	require.NoError(t, err)
+
+	// What the exchange sends bidderA: only its imp, with imp.ext and the
+	// request ext rewritten for that bidder
+	splitRequest := func() *openrtb2.BidRequest {
+		return &openrtb2.BidRequest{
+			ID:  "test-request",
+			Imp: []openrtb2.Imp{{ID: "imp-1", Ext: json.RawMessage(`{"bidder":{}}`)}},
+			Ext: json.RawMessage(`{"prebid":{}}`),
+		}
+	}
+
+	tests := []struct {
+		name             string
+		bidder           string
+		request          *openrtb2.BidRequest
+		expectCalls      int
+		expectedEnvelope string
+	}{
+		{
+			name:             "split request uses cached signature",
+			bidder:           "bidderA",
+			request:          splitRequest(),
+			expectCalls:      0,
+			expectedEnvelope: "env-bidderA",
+		},
+		{
+			name:   "scrubbed request is signed again",
+			bidder: "bidderA",
+			request: func() *openrtb2.BidRequest {
+				r := splitRequest()
+				r.User = &openrtb2.User{BuyerUID: "buyer-1"}
+				return r
+			}(),
+			expectCalls:      1,
+			expectedEnvelope: "fresh-bidderA",
+		},
+		{
+			name:             "request with other imps is signed again",
+			bidder:           "bidderA",
+			request:          auctionRequest(),
+			expectCalls:      1,
+			expectedEnvelope: "fresh-bidderA",
+		},
+		{
+			name:             "bidder missing from cache is signed again",
+			bidder:           "bidderD",
+			request:          splitRequest(),
+			expectCalls:      1,
+			expectedEnvelope: "fresh-bidderD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &recordingFetcher{prefix: "fresh-"}
+			module := newAuctionModule(fetcher, SigningModeAuction)
+
+			payload := hookstage.BidderRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{BidRequest: tt.request},
+				Bidder:  tt.bidder,
+			}
+			miCtx := hookstage.ModuleInvocationContext{ModuleContext: auctionResult.ModuleContext}
+
+			result, err := module.HandleBidderRequestHook(context.Background(), miCtx, payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCalls, fetcher.calls)
+
+			finalPayload := payload
+			for _, mutation := range result.ChangeSet.Mutations() {
+				finalPayload, err = mutation.Apply(finalPayload)
+				require.NoError(t, err)
+			}
+			require.NoError(t, finalPayload.Request.RebuildRequest())
+
+			var extMap map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(finalPayload.Request.BidRequest.Ext, &extMap))
+
+			var openadsExt OpenAdsExt
+			require.NoError(t, json.Unmarshal(extMap["openads"], &openadsExt))
+			require.Len(t, openadsExt.IntSigs, 1)
+			assert.Equal(t, tt.expectedEnvelope, openadsExt.IntSigs[0].Envelope)
+		})
+	}
+}
+
+func TestSigningView(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *openrtb2.BidRequest
+		expectView  string
+		expectError bool
+	}{
+		{
+			name: "bidder controls are removed",
+			request: &openrtb2.BidRequest{
+				ID:  "test-request",
+				Imp: []openrtb2.Imp{{ID: "imp-1", Ext: json.RawMessage(`{"prebid":{"bidder":{"bidderA":{}}},"bidder":{}}`)}},
+				Ext: json.RawMessage(`{"prebid":{"debug":true}}`),
+			},
+			expectView: `{"id":"test-request","imp":[{"id":"imp-1"}]}`,
+		},
+		{
+			name: "other ext fields are signed",
+			request: &openrtb2.BidRequest{
+				ID:  "test-request",
+				Imp: []openrtb2.Imp{{ID: "imp-1", Ext: json.RawMessage(`{"prebid":{},"gpid":"/1/home"}`)}},
+				Ext: json.RawMessage(`{"prebid":{},"schain":{"ver":"1.0","complete":1}}`),
+			},
+			expectView: `{"ext":{"schain":{"complete":1,"ver":"1.0"}},"id":"test-request","imp":[{"ext":{"gpid":"/1/home"},"id":"imp-1"}]}`,
+		},
+		{
+			name: "invalid ext",
+			request: &openrtb2.BidRequest{
+				ID:  "test-request",
+				Ext: json.RawMessage(`{`),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := signingView(tt.request, tt.request.Imp)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectView, string(view))
+		})
+	}
+}
+
+func TestHandleBidderRequestHook_SignsSigningView(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	module := newAuctionModule(fetcher, SigningModeBidder)
+
+	request := &openrtb2.BidRequest{
+		ID:  "test-request",
+		Imp: []openrtb2.Imp{{ID: "imp-1", Ext: json.RawMessage(`{"bidder":{}}`)}},
+		Ext: json.RawMessage(`{"prebid":{},"schain":{"ver":"1.0"}}`),
+	}
+	payload := hookstage.BidderRequestPayload{Request: &openrtb_ext.RequestWrapper{BidRequest: request}, Bidder: "bidderA"}
+
+	_, err := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+
+	// Bidder mode signs the same view auction mode would
+	expected, err := signingView(request, request.Imp)
+	require.NoError(t, err)
+	require.Len(t, fetcher.requests, 1)
+	assert.Equal(t, string(expected), string(fetcher.requests[0].RequestBody))
+}
+
+func TestAuctionFallbackCountsOnce(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := newModuleMetrics(registry, false)
+	require.NoError(t, err)
+
+	fetcher := &recordingFetcher{err: errors.New("connection refused")}
+	module := newAuctionModule(fetcher, SigningModeAuction)
+	module.metrics = metrics
+
+	auctionResult, err := module.HandleProcessedAuctionHook(
+		context.Background(),
+		hookstage.ModuleInvocationContext{AccountID: "acct"},
+		hookstage.ProcessedAuctionRequestPayload{Request: &openrtb_ext.RequestWrapper{BidRequest: auctionRequest()}},
+	)
+	require.NoError(t, err)
+
+	payload := hookstage.BidderRequestPayload{
+		Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "test-request", Imp: []openrtb2.Imp{{ID: "imp-1"}}}},
+		Bidder:  "bidderA",
+	}
+	miCtx := hookstage.ModuleInvocationContext{AccountID: "acct", ModuleContext: auctionResult.ModuleContext}
+	_, err = module.HandleBidderRequestHook(context.Background(), miCtx, payload)
+	require.Error(t, err)
+
+	// Both stages called the service, but the bidder request failed once
+	assert.Equal(t, 3, fetcher.calls)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.sidecarErrors.WithLabelValues("bidderA", "acct")))
+}
@@ -0,0 +1,82 @@
+package signatures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "rfc 8785 section 3.2.2 example",
+			input:    `{"numbers":[333333333.33333329,1E30,4.50,2e-3,0.000000000000000000000000001],"string":"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/","literals":[null,true,false]}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name:     "members sorted by utf-16 code units",
+			input:    `{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7}`,
+			expected: "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"ö\":7,\"€\":1,\"😀\":5,\"\ufb33\":3}",
+		},
+		{
+			name:     "whitespace and nesting",
+			input:    "{ \"b\" : [ 1 , { \"d\": true, \"c\": null } ],\n\t\"a\": \"<&>\" }",
+			expected: `{"a":"<&>","b":[1,{"c":null,"d":true}]}`,
+		},
+		{
+			name:     "number formatting",
+			input:    `[0,-0,1,-1,1.5,100,1e21,1e20,123456789012345680000,0.000001,1e-7,-1.25e-8,5e-324,1.7976931348623157e308]`,
+			expected: `[0,0,1,-1,1.5,100,1e+21,100000000000000000000,123456789012345680000,0.000001,1e-7,-1.25e-8,5e-324,1.7976931348623157e+308]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := canonicalJSON([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+
+			again, err := canonicalJSON(actual)
+			require.NoError(t, err)
+			assert.Equal(t, string(actual), string(again), "canonicalization must be idempotent")
+		})
+	}
+}
+
+func TestCanonicalJSONErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expectErr string
+	}{
+		{name: "invalid", input: `{invalid}`, expectErr: "invalid JSON"},
+		{name: "trailing data", input: `{} {}`, expectErr: "unexpected data after top-level value"},
+		{name: "number out of range", input: `[1e400]`, expectErr: "invalid JSON number 1e400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := canonicalJSON([]byte(tt.input))
+			assert.ErrorContains(t, err, tt.expectErr)
+		})
+	}
+}
+
+func TestMarshalRequestKeepsCanonicalBody(t *testing.T) {
+	body, err := canonicalJSON([]byte(`{"site":{"page":"https://example.com/?a=1&b=<2>"}}`))
+	require.NoError(t, err)
+
+	encoded, err := marshalRequest(signatureRequest{RequestBody: body, DemandSources: []string{"bidder"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), string(body))
+
+	var decoded signatureRequest
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, string(body), string(decoded.RequestBody))
+}
@@ -17,15 +17,56 @@ type TransportType string
 const (
 	TransportUDS TransportType = "uds"
 	TransportTCP TransportType = "tcp"
+	// TransportInProcess signs requests inside the server instead of calling a sidecar
+	TransportInProcess TransportType = "inprocess"
+)
+
+type SigningMode string
+
+const (
+	// SigningModeBidder signs each bidder request separately at the bidder_request stage
+	SigningModeBidder SigningMode = "bidder"
+	// SigningModeAuction signs once for all bidders at the processed_auction_request stage
+	SigningModeAuction SigningMode = "auction"
 )
 
 type Config struct {
-	Enabled         bool          `json:"enabled"`
-	Transport       TransportType `json:"transport"`
-	BasePath        string        `json:"base_path"`
-	RequestPath     string        `json:"request_path"`
-	RejectOnFailure bool          `json:"reject_on_failure"`
-	Version         int           `json:"-"`
+	Enabled         bool                 `json:"enabled"`
+	Transport       TransportType        `json:"transport"`
+	BasePath        string               `json:"base_path"`
+	RequestPath     string               `json:"request_path"`
+	RejectOnFailure bool                 `json:"reject_on_failure"`
+	Mode            SigningMode          `json:"mode"`
+	TimeoutMs       int                  `json:"timeout_ms"`
+	Retry           RetryConfig          `json:"retry"`
+	CircuitBreaker  CircuitBreakerConfig `json:"circuit_breaker"`
+	InProcess       InProcessConfig      `json:"inprocess"`
+	Verification    VerificationConfig   `json:"verification"`
+	Version         int                  `json:"-"`
+}
+
+type RetryConfig struct {
+	MaxRetries int `json:"max_retries"`
+	BackoffMs  int `json:"backoff_ms"`
+}
+
+type CircuitBreakerConfig struct {
+	Enabled          bool `json:"enabled"`
+	FailureThreshold int  `json:"failure_threshold"`
+	OpenDurationMs   int  `json:"open_duration_ms"`
+}
+
+type InProcessConfig struct {
+	PrivateKeyFile string `json:"private_key_file"`
+	KeyID          string `json:"key_id"`
+	Source         string `json:"source"`
+}
+
+type VerificationConfig struct {
+	Enabled           bool      `json:"enabled"`
+	KeyFile           string    `json:"key_file"`
+	KeyFormat         KeyFormat `json:"key_format"`
+	ReloadIntervalSec int       `json:"reload_interval_sec"`
 }
 
 func NewConfig(rawConfig json.RawMessage) (*Config, error) {
@@ -37,16 +78,62 @@ func NewConfig(rawConfig json.RawMessage) (*Config, error) {
 
 	cfg.Version = SchemaVersion
 
-	if cfg.Transport != TransportUDS && cfg.Transport != TransportTCP {
-		return nil, fmt.Errorf("invalid transport: %s (must be 'uds' or 'tcp')", cfg.Transport)
+	switch cfg.Transport {
+	case TransportUDS, TransportTCP:
+		if cfg.BasePath == "" {
+			return nil, fmt.Errorf("base_path is required")
+		}
+
+		if cfg.RequestPath == "" {
+			return nil, fmt.Errorf("request_path is required")
+		}
+	case TransportInProcess:
+		if cfg.InProcess.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("inprocess.private_key_file is required")
+		}
+
+		if cfg.InProcess.Source == "" {
+			return nil, fmt.Errorf("inprocess.source is required")
+		}
+	default:
+		return nil, fmt.Errorf("invalid transport: %s (must be 'uds', 'tcp' or 'inprocess')", cfg.Transport)
+	}
+
+	switch cfg.Mode {
+	case "":
+		cfg.Mode = SigningModeBidder
+	case SigningModeBidder, SigningModeAuction:
+	default:
+		return nil, fmt.Errorf("invalid mode: %s (must be 'bidder' or 'auction')", cfg.Mode)
+	}
+
+	if cfg.TimeoutMs < 0 {
+		return nil, fmt.Errorf("timeout_ms must be non-negative")
+	}
+
+	if cfg.Retry.MaxRetries < 0 || cfg.Retry.BackoffMs < 0 {
+		return nil, fmt.Errorf("retry.max_retries and retry.backoff_ms must be non-negative")
 	}
 
-	if cfg.BasePath == "" {
-		return nil, fmt.Errorf("base_path is required")
+	if cfg.CircuitBreaker.Enabled {
+		if cfg.CircuitBreaker.FailureThreshold <= 0 {
+			return nil, fmt.Errorf("circuit_breaker.failure_threshold must be positive")
+		}
+		if cfg.CircuitBreaker.OpenDurationMs <= 0 {
+			return nil, fmt.Errorf("circuit_breaker.open_duration_ms must be positive")
+		}
 	}
 
-	if cfg.RequestPath == "" {
-		return nil, fmt.Errorf("request_path is required")
+	if cfg.Verification.Enabled {
+		if cfg.Verification.KeyFile == "" {
+			return nil, fmt.Errorf("verification.key_file is required")
+		}
+		if cfg.Verification.KeyFormat != KeyFormatJWKS && cfg.Verification.KeyFormat != KeyFormatPEM {
+			return nil, fmt.Errorf("invalid verification.key_format: %s (must be 'jwks' or 'pem')", cfg.Verification.KeyFormat)
+		}
+		if cfg.Verification.ReloadIntervalSec < 0 {
+			return nil, fmt.Errorf("verification.reload_interval_sec must be non-negative")
+		}
 	}
 
 	cfg.BasePath = strings.TrimRight(cfg.BasePath, "/")
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -25,6 +26,28 @@ type SignatureFetcher interface {
 	Fetch(ctx context.Context, body []byte) ([]SignatureWrapper, error)
 }
 
+// errRetryable is wrapped by fetch errors that another attempt may not repeat:
+// the request could not be sent or read, or the service answered with a 5xx
+// status. Other failures, such as a 4xx status or an invalid response body,
+// would fail the same way again.
+var errRetryable = errors.New("retryable")
+
+type retryableError struct {
+	err error
+}
+
+func retryable(err error) error {
+	return retryableError{err: err}
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() []error {
+	return []error{e.err, errRetryable}
+}
+
 type httpFetcher struct {
 	client *http.Client
 	url    string
@@ -52,6 +75,9 @@ func newFetcher(cfg *Config) (SignatureFetcher, error) {
 		client = &http.Client{}
 		fetchURL = cfg.BasePath + "/" + cfg.RequestPath
 
+	case TransportInProcess:
+		return newInProcessFetcher(cfg.InProcess)
+
 	default:
 		return nil, fmt.Errorf("unsupported transport type: %s", cfg.Transport)
 	}
@@ -72,17 +98,20 @@ func (f *httpFetcher) Fetch(ctx context.Context, body []byte) ([]SignatureWrappe
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, retryable(fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, retryable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, retryable(fmt.Errorf("failed to read response body: %w", err))
 	}
 
 	if len(respBody) == 0 {
@@ -0,0 +1,155 @@
+package signatures
+
+import (
+	"time"
+
+	prometheusmetrics "github.com/prebid/prebid-server/v3/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsPrefix = "modules_openads_signatures_"
+
+// signOutcome is what happened to one demand source of a sign call, or to a
+// bidder request as a whole.
+type signOutcome string
+
+const (
+	outcomeSigned        signOutcome = "signed"
+	outcomeMissing       signOutcome = "missing_demand_source"
+	outcomeUnverified    signOutcome = "unverified"
+	outcomeSidecarError  signOutcome = "sidecar_error"
+	outcomeRejected      signOutcome = "rejected"
+	outcomeInternalError signOutcome = "internal_error"
+)
+
+// moduleMetrics holds the module's Prometheus collectors, which are all nil when
+// Prometheus metrics are disabled.
+type moduleMetrics struct {
+	breakerState       prometheus.Gauge
+	breakerTransitions *prometheus.CounterVec
+
+	signed          *prometheus.CounterVec
+	missing         *prometheus.CounterVec
+	unverified      *prometheus.CounterVec
+	sidecarErrors   *prometheus.CounterVec
+	rejected        *prometheus.CounterVec
+	sidecarDuration *prometheus.HistogramVec
+
+	// accountLabel is false when account level module metrics are disabled,
+	// in which case the collectors are only labeled by bidder
+	accountLabel bool
+}
+
+func newModuleMetrics(registerer prometheus.Registerer, accountMetricsDisabled bool) (*moduleMetrics, error) {
+	m := &moduleMetrics{accountLabel: !accountMetricsDisabled}
+	if registerer == nil {
+		return m, nil
+	}
+
+	labels := []string{"bidder"}
+	labelsHelp := "labeled by bidder"
+	if m.accountLabel {
+		labels = append(labels, "account")
+		labelsHelp = "labeled by bidder and account"
+	}
+
+	var err error
+	m.breakerState, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricsPrefix + "circuit_breaker_state",
+		Help: "State of the signature sidecar circuit breaker: 0 closed, 1 open, 2 half-open.",
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	m.breakerTransitions, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "circuit_breaker_transitions",
+		Help: "Count of signature sidecar circuit breaker transitions labeled by the new state.",
+	}, []string{"state"}))
+	if err != nil {
+		return nil, err
+	}
+
+	counters := []struct {
+		target **prometheus.CounterVec
+		name   string
+		help   string
+	}{
+		{&m.signed, "signed_requests", "Count of bidder requests sent with a signature, "},
+		{&m.missing, "missing_demand_sources", "Count of bidder requests whose demand source was missing from a signature service response, "},
+		{&m.unverified, "unverified_signatures", "Count of bidder requests whose returned signature failed local verification, "},
+		{&m.sidecarErrors, "sidecar_errors", "Count of bidder requests whose signature service call failed, "},
+		{&m.rejected, "rejected_requests", "Count of bidder requests rejected for lack of a signature, "},
+	}
+	for _, c := range counters {
+		*c.target, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + c.name,
+			Help: c.help + labelsHelp + ".",
+		}, labels))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.sidecarDuration, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    metricsPrefix + "sidecar_request_time_seconds",
+		Help:    "Seconds taken by signature service calls, observed for every demand source of a call, " + labelsHelp + ".",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, labels))
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// labelValues returns the label values of bidder for account, dropping the
+// account when it isn't a label.
+func (m *moduleMetrics) labelValues(bidder, account string) []string {
+	if m.accountLabel {
+		return []string{bidder, account}
+	}
+	return []string{bidder}
+}
+
+func (m *moduleMetrics) recordBreakerState(state breakerState) {
+	if m == nil || m.breakerState == nil {
+		return
+	}
+	m.breakerState.Set(float64(state))
+	m.breakerTransitions.WithLabelValues(state.String()).Inc()
+}
+
+// recordOutcome counts outcome for bidder. Outcomes without a counter, such as
+// internal errors, are only reported through analytics tags.
+func (m *moduleMetrics) recordOutcome(bidder, account string, outcome signOutcome) {
+	if m == nil || m.signed == nil {
+		return
+	}
+
+	var counter *prometheus.CounterVec
+	switch outcome {
+	case outcomeSigned:
+		counter = m.signed
+	case outcomeMissing:
+		counter = m.missing
+	case outcomeUnverified:
+		counter = m.unverified
+	case outcomeSidecarError:
+		counter = m.sidecarErrors
+	case outcomeRejected:
+		counter = m.rejected
+	default:
+		return
+	}
+	counter.WithLabelValues(m.labelValues(bidder, account)...).Inc()
+}
+
+func (m *moduleMetrics) recordSidecarDuration(bidders []string, account string, duration time.Duration) {
+	if m == nil || m.sidecarDuration == nil {
+		return
+	}
+	for _, bidder := range bidders {
+		m.sidecarDuration.WithLabelValues(m.labelValues(bidder, account)...).Observe(duration.Seconds())
+	}
+}
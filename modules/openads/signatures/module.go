@@ -1,9 +1,13 @@
 package signatures
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
 	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
@@ -19,36 +23,86 @@ type OpenAdsExt struct {
 	IntSigs []Signature `json:"int_sigs"`
 }
 
+// signatureRequest is the body sent to the signing service. RequestBody is
+// always in canonical form, so it is verified as is.
 type signatureRequest struct {
-	RequestBody   interface{} `json:"requestBody"`
-	DemandSources []string    `json:"demandSources"`
+	RequestBody   json.RawMessage `json:"requestBody"`
+	DemandSources []string        `json:"demandSources"`
 }
 
-func Builder(rawConfig json.RawMessage, _ moduledeps.ModuleDeps) (interface{}, error) {
+// marshalRequest encodes a request to the signing service without HTML
+// escaping, so the canonical requestBody arrives byte-for-byte.
+func marshalRequest(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func Builder(rawConfig json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, error) {
 	cfg, err := NewConfig(rawConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	metrics, err := newModuleMetrics(deps.MetricsRegisterer, deps.AccountMetricsDisabled)
+	if err != nil {
+		return nil, err
+	}
+
 	fetcher, err := newFetcher(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return Module{
+	// Timeouts, retries and the breaker only make sense for a remote sidecar
+	if cfg.Transport != TransportInProcess {
+		fetcher = newResilientFetcher(fetcher, cfg, metrics)
+	}
+
+	module := Module{
 		cfg:     cfg,
 		fetcher: fetcher,
-	}, nil
+		metrics: metrics,
+	}
+
+	if cfg.Verification.Enabled {
+		keys, err := newVerifier(cfg.Verification)
+		if err != nil {
+			return nil, err
+		}
+		go keys.Run()
+
+		module.verifier = keys
+		module.keys = keys
+	}
+
+	return module, nil
 }
 
 type Module struct {
-	cfg     *Config
-	fetcher SignatureFetcher
+	cfg      *Config
+	fetcher  SignatureFetcher
+	verifier SignatureVerifier
+	metrics  *moduleMetrics
+	// keys is the verifier's key set, kept to stop its reload loop on shutdown
+	keys *keyVerifier
+}
+
+// Shutdown stops reloading the verification key file.
+func (m Module) Shutdown() error {
+	if m.keys != nil {
+		m.keys.Shutdown()
+	}
+	return nil
 }
 
 func (m Module) HandleBidderRequestHook(
 	ctx context.Context,
-	_ hookstage.ModuleInvocationContext,
+	miCtx hookstage.ModuleInvocationContext,
 	payload hookstage.BidderRequestPayload,
 ) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
 	result := hookstage.HookResult[hookstage.BidderRequestPayload]{}
@@ -57,29 +111,68 @@ func (m Module) HandleBidderRequestHook(
 		return result, hookexecution.NewFailure("payload contains a nil bid request")
 	}
 
-	request := signatureRequest{
-		RequestBody:   payload.Request.BidRequest,
-		DemandSources: []string{payload.Bidder},
+	// Requests signed at the auction level can only be reused if the bidder
+	// request still has the signed view, otherwise sign again for this bidder
+	if cached, ok := miCtx.ModuleContext[auctionSignaturesKey].(*auctionSignatures); ok {
+		if sis, found := cached.lookup(payload.Bidder, payload.Request.BidRequest); found {
+			signedResult := newSignResult(payload.Bidder, hookanalytics.ResultStatusModify, outcomeSigned)
+			signedResult.Values[auctionSignatureAnalyticKey] = true
+			result.AnalyticsTags = newSignAnalytics(signedResult)
+			m.metrics.recordOutcome(payload.Bidder, miCtx.AccountID, outcomeSigned)
+			return m.setOpenAdsExt([]Signature{sis}, result, nil)
+		}
 	}
 
-	requestBody, err := json.Marshal(request)
+	// The signatures are computed over the canonical signing view of the
+	// request, the same view auction mode signs. It is also what the service
+	// receives and what verification checks against.
+	bidRequestBody, err := signingView(payload.Request.BidRequest, payload.Request.BidRequest.Imp)
 	if err != nil {
-		if m.cfg.RejectOnFailure {
-			result.Reject = true
-			result.NbrCode = NbrCodeServiceUnavailable
-			return result, hookexecution.NewFailure("failed to marshal bid request: %v", err)
+		return m.fail(result, miCtx.AccountID, payload.Bidder, outcomeInternalError, hookexecution.NewFailure("failed to marshal bid request: %v", err))
+	}
+
+	signaturesByName, outcomes, err := m.sign(ctx, miCtx.AccountID, signatureRequest{
+		RequestBody:   bidRequestBody,
+		DemandSources: []string{payload.Bidder},
+	})
+	if err != nil {
+		m.metrics.recordOutcome(payload.Bidder, miCtx.AccountID, outcomes[payload.Bidder])
+		return m.fail(result, miCtx.AccountID, payload.Bidder, outcomes[payload.Bidder], hookexecution.NewFailure("%v", err))
+	}
+
+	result.AnalyticsTags = newSignAnalytics(newSignResult(payload.Bidder, hookanalytics.ResultStatusModify, outcomeSigned))
+	m.metrics.recordOutcome(payload.Bidder, miCtx.AccountID, outcomeSigned)
+	return m.setOpenAdsExt([]Signature{signaturesByName[payload.Bidder]}, result, nil)
+}
+
+// sign requests signatures for the demand sources of request. It returns the
+// verified signatures it received and the outcome for every demand source,
+// along with an error naming the demand sources that are missing or
+// unverifiable. Outcomes are counted by the bidder request hook only, once
+// per bidder request, so a bidder that falls back from auction mode isn't
+// counted twice.
+func (m Module) sign(ctx context.Context, account string, request signatureRequest) (map[string]Signature, map[string]signOutcome, error) {
+	demandSources := request.DemandSources
+
+	outcomes := make(map[string]signOutcome, len(demandSources))
+	failAll := func(outcome signOutcome) {
+		for _, ds := range demandSources {
+			outcomes[ds] = outcome
 		}
-		return m.setOpenAdsExt([]Signature{}, result, hookexecution.NewFailure("failed to marshal bid request: %v", err))
 	}
 
+	requestBody, err := marshalRequest(request)
+	if err != nil {
+		failAll(outcomeInternalError)
+		return nil, outcomes, fmt.Errorf("failed to marshal bid request: %v", err)
+	}
+
+	start := time.Now()
 	signatures, err := m.fetcher.Fetch(ctx, requestBody)
+	m.metrics.recordSidecarDuration(demandSources, account, time.Since(start))
 	if err != nil {
-		if m.cfg.RejectOnFailure {
-			result.Reject = true
-			result.NbrCode = NbrCodeServiceUnavailable
-			return result, hookexecution.NewFailure("sidecar fetch: %v", err)
-		}
-		return m.setOpenAdsExt([]Signature{}, result, hookexecution.NewFailure("sidecar fetch: %v", err))
+		failAll(outcomeSidecarError)
+		return nil, outcomes, fmt.Errorf("sidecar fetch: %v", err)
 	}
 
 	signaturesByName := make(map[string]Signature)
@@ -88,27 +181,57 @@ func (m Module) HandleBidderRequestHook(
 	}
 
 	// Filter to only requested demandSources and collect their sis objects
-	intSigs := make([]Signature, 0, len(request.DemandSources))
+	verified := make(map[string]Signature)
 	var missingDemandSources []string
-	for _, ds := range request.DemandSources {
-		if sis, found := signaturesByName[ds]; found {
-			intSigs = append(intSigs, sis)
-		} else {
+	var unverifiedDemandSources []string
+	for _, ds := range demandSources {
+		sis, found := signaturesByName[ds]
+		if !found {
 			missingDemandSources = append(missingDemandSources, ds)
+			outcomes[ds] = outcomeMissing
+			continue
 		}
+		if m.verifier != nil {
+			if err := m.verifier.VerifyCanonical(request.RequestBody, sis); err != nil {
+				unverifiedDemandSources = append(unverifiedDemandSources, ds)
+				outcomes[ds] = outcomeUnverified
+				continue
+			}
+		}
+		verified[ds] = sis
+		outcomes[ds] = outcomeSigned
 	}
 
 	// If any requested demandSource is missing, treat as failure
 	if len(missingDemandSources) > 0 {
-		if m.cfg.RejectOnFailure {
-			result.Reject = true
-			result.NbrCode = NbrCodeServiceUnavailable
-			return result, hookexecution.NewFailure("missing demandSources in sidecar response: %v", missingDemandSources)
-		}
-		return m.setOpenAdsExt([]Signature{}, result, hookexecution.NewFailure("missing demandSources in sidecar response: %v", missingDemandSources))
+		return verified, outcomes, fmt.Errorf("missing demandSources in sidecar response: %v", missingDemandSources)
+	}
+
+	// Signatures that don't verify are no better than missing ones
+	if len(unverifiedDemandSources) > 0 {
+		return verified, outcomes, fmt.Errorf("unverifiable signatures in sidecar response: %v", unverifiedDemandSources)
 	}
 
-	return m.setOpenAdsExt(intSigs, result, nil)
+	return verified, outcomes, nil
+}
+
+// fail either rejects the bidder request or continues with empty signatures,
+// depending on RejectOnFailure. outcome is why bidder has no signature.
+func (m Module) fail(
+	result hookstage.HookResult[hookstage.BidderRequestPayload],
+	account, bidder string,
+	outcome signOutcome,
+	hookErr error,
+) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	if m.cfg.RejectOnFailure {
+		result.AnalyticsTags = newSignAnalytics(newSignResult(bidder, hookanalytics.ResultStatusBlock, outcome))
+		m.metrics.recordOutcome(bidder, account, outcomeRejected)
+		result.Reject = true
+		result.NbrCode = NbrCodeServiceUnavailable
+		return result, hookErr
+	}
+	result.AnalyticsTags = newSignAnalytics(newSignResult(bidder, hookanalytics.ResultStatusError, outcome))
+	return m.setOpenAdsExt([]Signature{}, result, hookErr)
 }
 
 func (m Module) setOpenAdsExt(
@@ -13,9 +13,12 @@ import (
 	"testing"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -121,6 +124,114 @@ func TestBuilder(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid transport",
 		},
+		{
+			name: "valid auction mode config",
+			config: `{
+				"transport": "uds",
+				"base_path": "/var/run/test.sock",
+				"request_path": "/test/path",
+				"mode": "auction"
+			}`,
+			expectError: false,
+		},
+		{
+			name: "invalid mode",
+			config: `{
+				"transport": "uds",
+				"base_path": "/var/run/test.sock",
+				"request_path": "/test/path",
+				"mode": "batch"
+			}`,
+			expectError: true,
+			errorMsg:    "invalid mode",
+		},
+		{
+			name: "valid config with timeout, retries and circuit breaker",
+			config: `{
+				"transport": "tcp",
+				"base_path": "localhost:8080",
+				"request_path": "/test/path",
+				"timeout_ms": 20,
+				"retry": {"max_retries": 1, "backoff_ms": 5},
+				"circuit_breaker": {"enabled": true, "failure_threshold": 5, "open_duration_ms": 10000}
+			}`,
+			expectError: false,
+		},
+		{
+			name:        "negative timeout",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "/p", "timeout_ms": -1}`,
+			expectError: true,
+			errorMsg:    "timeout_ms must be non-negative",
+		},
+		{
+			name:        "negative retries",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "/p", "retry": {"max_retries": -1}}`,
+			expectError: true,
+			errorMsg:    "must be non-negative",
+		},
+		{
+			name:        "circuit breaker without failure_threshold",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "/p", "circuit_breaker": {"enabled": true, "open_duration_ms": 1000}}`,
+			expectError: true,
+			errorMsg:    "circuit_breaker.failure_threshold must be positive",
+		},
+		{
+			name:        "circuit breaker without open_duration_ms",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "/p", "circuit_breaker": {"enabled": true, "failure_threshold": 3}}`,
+			expectError: true,
+			errorMsg:    "circuit_breaker.open_duration_ms must be positive",
+		},
+		{
+			name:        "inprocess without private_key_file",
+			config:      `{"transport": "inprocess", "inprocess": {"source": "example.com"}}`,
+			expectError: true,
+			errorMsg:    "inprocess.private_key_file is required",
+		},
+		{
+			name:        "inprocess without source",
+			config:      `{"transport": "inprocess", "inprocess": {"private_key_file": "/etc/key.pem"}}`,
+			expectError: true,
+			errorMsg:    "inprocess.source is required",
+		},
+		{
+			name:        "inprocess with unreadable private_key_file",
+			config:      `{"transport": "inprocess", "inprocess": {"private_key_file": "/nonexistent/key.pem", "source": "example.com"}}`,
+			expectError: true,
+			errorMsg:    "failed to read private key file",
+		},
+		{
+			name: "verification without key_file",
+			config: `{
+				"transport": "uds",
+				"base_path": "/var/run/test.sock",
+				"request_path": "/test/path",
+				"verification": {"enabled": true, "key_format": "jwks"}
+			}`,
+			expectError: true,
+			errorMsg:    "verification.key_file is required",
+		},
+		{
+			name: "verification with invalid key_format",
+			config: `{
+				"transport": "uds",
+				"base_path": "/var/run/test.sock",
+				"request_path": "/test/path",
+				"verification": {"enabled": true, "key_file": "/etc/keys", "key_format": "der"}
+			}`,
+			expectError: true,
+			errorMsg:    "invalid verification.key_format",
+		},
+		{
+			name: "verification with unreadable key_file",
+			config: `{
+				"transport": "uds",
+				"base_path": "/var/run/test.sock",
+				"request_path": "/test/path",
+				"verification": {"enabled": true, "key_file": "/nonexistent/keys.jwks", "key_format": "jwks"}
+			}`,
+			expectError: true,
+			errorMsg:    "failed to read key file",
+		},
 		{
 			name:        "invalid JSON",
 			config:      `{invalid}`,
@@ -518,6 +629,11 @@ func TestHandleBidderRequestHook_FailureRejectMode(t *testing.T) {
 			fetchErr:  errors.New("unexpected status code: 500"),
 			expectErr: "sidecar fetch: unexpected status code: 500",
 		},
+		{
+			name:      "circuit breaker open with rejection",
+			fetchErr:  errCircuitOpen,
+			expectErr: "sidecar fetch: circuit breaker open",
+		},
 	}
 
 	for _, tt := range tests {
@@ -745,6 +861,113 @@ func TestHandleBidderRequestHook_InvalidJSONResponse(t *testing.T) {
 	}
 }
 
+type rejectingVerifier struct{}
+
+func (rejectingVerifier) Verify([]byte, Signature) error {
+	return errors.New("signature mismatch")
+}
+
+func (rejectingVerifier) VerifyCanonical([]byte, Signature) error {
+	return errors.New("signature mismatch")
+}
+
+func TestHandleBidderRequestHook_Outcomes(t *testing.T) {
+	tests := []struct {
+		name            string
+		fetcher         SignatureFetcher
+		verifier        SignatureVerifier
+		rejectOnFailure bool
+		expectResult    hookanalytics.Result
+		expectCounts    map[string]float64
+	}{
+		{
+			name:    "signed",
+			fetcher: &mockFetcher{response: []SignatureWrapper{{Name: "testbidder", SIS: Signature{Envelope: "envelope"}}}},
+			expectResult: hookanalytics.Result{
+				Status:    hookanalytics.ResultStatusModify,
+				Values:    map[string]interface{}{"outcome": "signed"},
+				AppliedTo: hookanalytics.AppliedTo{Bidder: "testbidder", Request: true},
+			},
+			expectCounts: map[string]float64{"signed_requests": 1},
+		},
+		{
+			name:    "missing demand source",
+			fetcher: &mockFetcher{response: []SignatureWrapper{}},
+			expectResult: hookanalytics.Result{
+				Status:    hookanalytics.ResultStatusError,
+				Values:    map[string]interface{}{"outcome": "missing_demand_source"},
+				AppliedTo: hookanalytics.AppliedTo{Bidder: "testbidder", Request: true},
+			},
+			expectCounts: map[string]float64{"missing_demand_sources": 1},
+		},
+		{
+			name:     "unverified signature",
+			fetcher:  &mockFetcher{response: []SignatureWrapper{{Name: "testbidder", SIS: Signature{Envelope: "envelope"}}}},
+			verifier: rejectingVerifier{},
+			expectResult: hookanalytics.Result{
+				Status:    hookanalytics.ResultStatusError,
+				Values:    map[string]interface{}{"outcome": "unverified"},
+				AppliedTo: hookanalytics.AppliedTo{Bidder: "testbidder", Request: true},
+			},
+			expectCounts: map[string]float64{"unverified_signatures": 1},
+		},
+		{
+			name:            "sidecar error with rejection",
+			fetcher:         &mockFetcher{err: errors.New("connection refused")},
+			rejectOnFailure: true,
+			expectResult: hookanalytics.Result{
+				Status:    hookanalytics.ResultStatusBlock,
+				Values:    map[string]interface{}{"outcome": "sidecar_error"},
+				AppliedTo: hookanalytics.AppliedTo{Bidder: "testbidder", Request: true},
+			},
+			expectCounts: map[string]float64{"sidecar_errors": 1, "rejected_requests": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			metrics, err := newModuleMetrics(registry, false)
+			require.NoError(t, err)
+
+			module := Module{
+				cfg:      &Config{RejectOnFailure: tt.rejectOnFailure, Version: SchemaVersion},
+				fetcher:  tt.fetcher,
+				verifier: tt.verifier,
+				metrics:  metrics,
+			}
+
+			payload := hookstage.BidderRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "test-request"}},
+				Bidder:  "testbidder",
+			}
+			result, _ := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{AccountID: "acct"}, payload)
+
+			require.Len(t, result.AnalyticsTags.Activities, 1)
+			activity := result.AnalyticsTags.Activities[0]
+			assert.Equal(t, "openads_signatures", activity.Name)
+			assert.Equal(t, []hookanalytics.Result{tt.expectResult}, activity.Results)
+			if tt.expectResult.Status == hookanalytics.ResultStatusError {
+				assert.Equal(t, hookanalytics.ActivityStatusError, activity.Status)
+			} else {
+				assert.Equal(t, hookanalytics.ActivityStatusSuccess, activity.Status)
+			}
+
+			counters := map[string]*prometheus.CounterVec{
+				"signed_requests":        metrics.signed,
+				"missing_demand_sources": metrics.missing,
+				"unverified_signatures":  metrics.unverified,
+				"sidecar_errors":         metrics.sidecarErrors,
+				"rejected_requests":      metrics.rejected,
+			}
+			for name, counter := range counters {
+				assert.Equal(t, tt.expectCounts[name], testutil.ToFloat64(counter.WithLabelValues("testbidder", "acct")), name)
+			}
+			assert.Equal(t, 1, testutil.CollectAndCount(metrics.sidecarDuration), "sidecar latency is observed")
+		})
+	}
+}
+
 func TestTCPIntegration(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "POST", r.Method)
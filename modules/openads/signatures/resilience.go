@@ -0,0 +1,239 @@
+package signatures
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var errCircuitOpen = errors.New("circuit breaker open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker trips open after a number of consecutive failures. Once the
+// open duration has passed it lets a single probe through (half-open); the
+// probe's outcome closes or re-opens the breaker.
+//
+// Every state change starts a new generation. allow hands out the current
+// generation and record ignores outcomes from an earlier one, so calls that
+// were in flight when the state changed can neither extend the open period nor
+// close the breaker without a probe.
+type circuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+	onStateChange    func(breakerState)
+
+	mu               sync.Mutex
+	state            breakerState
+	generation       uint64
+	consecutiveFails int
+	openedAt         time.Time
+	probeInFlight    bool
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig, onStateChange func(breakerState)) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: cfg.FailureThreshold,
+		openDuration:     time.Duration(cfg.OpenDurationMs) * time.Millisecond,
+		now:              time.Now,
+		onStateChange:    onStateChange,
+	}
+}
+
+// allow reports whether a call may proceed, along with the generation its
+// outcome must be recorded against.
+func (b *circuitBreaker) allow() (uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.openDuration {
+			return 0, false
+		}
+		b.setState(breakerHalfOpen)
+		b.probeInFlight = true
+		return b.generation, true
+	case breakerHalfOpen:
+		if b.probeInFlight {
+			return 0, false
+		}
+		b.probeInFlight = true
+		return b.generation, true
+	default:
+		return b.generation, true
+	}
+}
+
+// record counts the outcome of a call admitted in generation.
+func (b *circuitBreaker) record(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	if success {
+		b.consecutiveFails = 0
+		b.probeInFlight = false
+		b.setState(breakerClosed)
+		return
+	}
+
+	b.consecutiveFails++
+	if b.state == breakerHalfOpen || b.consecutiveFails >= b.failureThreshold {
+		b.probeInFlight = false
+		b.openedAt = b.now()
+		b.setState(breakerOpen)
+	}
+}
+
+// abandon releases a call admitted in generation without counting it, for
+// calls cut short by the caller rather than by the service.
+func (b *circuitBreaker) abandon(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation == b.generation {
+		b.probeInFlight = false
+	}
+}
+
+// setState must be called with the lock held.
+func (b *circuitBreaker) setState(state breakerState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	b.generation++
+	b.consecutiveFails = 0
+	if b.onStateChange != nil {
+		b.onStateChange(state)
+	}
+}
+
+// resilientFetcher wraps a SignatureFetcher with a per-call timeout, bounded
+// retries with jittered backoff and an optional circuit breaker. Only failures
+// marked errRetryable and per-call timeouts are retried.
+type resilientFetcher struct {
+	next       SignatureFetcher
+	timeout    time.Duration
+	maxRetries int
+	backoff    time.Duration
+	breaker    *circuitBreaker
+	sleep      func(context.Context, time.Duration) error
+}
+
+func newResilientFetcher(next SignatureFetcher, cfg *Config, metrics *moduleMetrics) SignatureFetcher {
+	f := &resilientFetcher{
+		next:       next,
+		timeout:    time.Duration(cfg.TimeoutMs) * time.Millisecond,
+		maxRetries: cfg.Retry.MaxRetries,
+		backoff:    time.Duration(cfg.Retry.BackoffMs) * time.Millisecond,
+		sleep:      sleepContext,
+	}
+
+	if cfg.CircuitBreaker.Enabled {
+		f.breaker = newCircuitBreaker(cfg.CircuitBreaker, metrics.recordBreakerState)
+	}
+
+	return f
+}
+
+func (f *resilientFetcher) Fetch(ctx context.Context, body []byte) ([]SignatureWrapper, error) {
+	var generation uint64
+	if f.breaker != nil {
+		var ok bool
+		if generation, ok = f.breaker.allow(); !ok {
+			return nil, errCircuitOpen
+		}
+	}
+
+	var signatures []SignatureWrapper
+	var err error
+	for attempt := 0; attempt <= f.maxRetries; attempt++ {
+		if attempt > 0 {
+			if sleepErr := f.sleep(ctx, f.backoffFor(attempt)); sleepErr != nil {
+				break
+			}
+		}
+
+		signatures, err = f.fetchOnce(ctx, body)
+		if err == nil || ctx.Err() != nil || !errors.Is(err, errRetryable) {
+			break
+		}
+	}
+
+	if f.breaker != nil {
+		// Running out of the hook's time says nothing about the service
+		if err != nil && ctx.Err() != nil {
+			f.breaker.abandon(generation)
+		} else {
+			f.breaker.record(generation, err == nil)
+		}
+	}
+
+	return signatures, err
+}
+
+func (f *resilientFetcher) fetchOnce(ctx context.Context, body []byte) ([]SignatureWrapper, error) {
+	if f.timeout <= 0 {
+		return f.next.Fetch(ctx, body)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	signatures, err := f.next.Fetch(callCtx, body)
+	if err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+		// The per-call timeout expired, which another attempt may beat
+		return nil, retryable(err)
+	}
+	return signatures, err
+}
+
+// backoffFor returns a random delay in [0, backoff * 2^(attempt-1)] ("full jitter").
+func (f *resilientFetcher) backoffFor(attempt int) time.Duration {
+	if f.backoff <= 0 {
+		return 0
+	}
+	ceiling := f.backoff << (attempt - 1)
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
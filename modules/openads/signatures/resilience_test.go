@@ -0,0 +1,342 @@
+package signatures
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedFetcher returns the queued errors in order, then succeeds.
+type scriptedFetcher struct {
+	errs  []error
+	calls int
+	delay time.Duration
+}
+
+func (f *scriptedFetcher) Fetch(ctx context.Context, _ []byte) ([]SignatureWrapper, error) {
+	f.calls++
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return []SignatureWrapper{{Name: "testbidder"}}, nil
+}
+
+func noSleep(context.Context, time.Duration) error { return nil }
+
+func TestResilientFetcherRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxRetries  int
+		errs        []error
+		expectCalls int
+		expectErr   bool
+	}{
+		{
+			name:        "no retries configured",
+			maxRetries:  0,
+			errs:        []error{retryable(errors.New("connection refused"))},
+			expectCalls: 1,
+			expectErr:   true,
+		},
+		{
+			name:        "succeeds on retry",
+			maxRetries:  2,
+			errs:        []error{retryable(errors.New("connection refused"))},
+			expectCalls: 2,
+		},
+		{
+			name:        "retries exhausted",
+			maxRetries:  2,
+			errs:        []error{retryable(errors.New("a")), retryable(errors.New("b")), retryable(errors.New("c"))},
+			expectCalls: 3,
+			expectErr:   true,
+		},
+		{
+			name:        "non-retryable error",
+			maxRetries:  2,
+			errs:        []error{errors.New("invalid JSON from signature service")},
+			expectCalls: 1,
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedFetcher{errs: tt.errs}
+			fetcher := newResilientFetcher(next, &Config{Retry: RetryConfig{MaxRetries: tt.maxRetries, BackoffMs: 10}}, &moduleMetrics{}).(*resilientFetcher)
+			fetcher.sleep = noSleep
+
+			_, err := fetcher.Fetch(context.Background(), nil)
+			assert.Equal(t, tt.expectErr, err != nil)
+			assert.Equal(t, tt.expectCalls, next.calls)
+		})
+	}
+}
+
+func TestResilientFetcherRetriesByStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		expectCalls int
+		expectErr   string
+	}{
+		{
+			name:        "server error is retried",
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 3,
+			expectErr:   "unexpected status code: 503",
+		},
+		{
+			name:        "client error is not retried",
+			status:      http.StatusBadRequest,
+			expectCalls: 1,
+			expectErr:   "unexpected status code: 400",
+		},
+		{
+			name:        "empty body is not retried",
+			status:      http.StatusOK,
+			expectCalls: 1,
+			expectErr:   "empty response body",
+		},
+		{
+			name:        "invalid JSON is not retried",
+			status:      http.StatusOK,
+			body:        "{invalid",
+			expectCalls: 1,
+			expectErr:   "invalid JSON from signature service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &Config{Transport: TransportTCP, BasePath: server.URL, Retry: RetryConfig{MaxRetries: 2}}
+			next, err := newFetcher(cfg)
+			require.NoError(t, err)
+			fetcher := newResilientFetcher(next, cfg, &moduleMetrics{}).(*resilientFetcher)
+			fetcher.sleep = noSleep
+
+			_, err = fetcher.Fetch(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectErr)
+			assert.Equal(t, tt.expectCalls, int(atomic.LoadInt32(&calls)))
+		})
+	}
+}
+
+func TestResilientFetcherRetriesCallTimeout(t *testing.T) {
+	next := &scriptedFetcher{delay: time.Second}
+	fetcher := newResilientFetcher(next, &Config{TimeoutMs: 10, Retry: RetryConfig{MaxRetries: 1}}, &moduleMetrics{}).(*resilientFetcher)
+	fetcher.sleep = noSleep
+
+	_, err := fetcher.Fetch(context.Background(), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestResilientFetcherTimeout(t *testing.T) {
+	next := &scriptedFetcher{delay: time.Second}
+	fetcher := newResilientFetcher(next, &Config{TimeoutMs: 10}, &moduleMetrics{})
+
+	start := time.Now()
+	_, err := fetcher.Fetch(context.Background(), nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestResilientFetcherStopsRetryingWhenContextDone(t *testing.T) {
+	next := &scriptedFetcher{errs: []error{retryable(errors.New("a")), retryable(errors.New("b"))}}
+	fetcher := newResilientFetcher(next, &Config{Retry: RetryConfig{MaxRetries: 5}}, &moduleMetrics{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := fetcher.Fetch(ctx, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, next.calls)
+}
+
+func TestBackoffFor(t *testing.T) {
+	fetcher := &resilientFetcher{backoff: 10 * time.Millisecond}
+	for attempt := 1; attempt <= 4; attempt++ {
+		ceiling := 10 * time.Millisecond << (attempt - 1)
+		for i := 0; i < 20; i++ {
+			d := fetcher.backoffFor(attempt)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, ceiling)
+		}
+	}
+
+	assert.Zero(t, (&resilientFetcher{}).backoffFor(1))
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	var states []breakerState
+	breaker := newCircuitBreaker(CircuitBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDurationMs: 1000}, func(s breakerState) {
+		states = append(states, s)
+	})
+	breaker.now = func() time.Time { return now }
+
+	allow := func() bool {
+		_, ok := breaker.allow()
+		return ok
+	}
+
+	gen, ok := breaker.allow()
+	require.True(t, ok)
+	breaker.record(gen, false)
+	gen, ok = breaker.allow()
+	assert.True(t, ok, "below threshold")
+	breaker.record(gen, false)
+	assert.False(t, allow(), "tripped open")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, allow(), "still open")
+
+	now = now.Add(time.Second)
+	gen, ok = breaker.allow()
+	assert.True(t, ok, "half-open probe")
+	assert.False(t, allow(), "only one probe at a time")
+	breaker.record(gen, false)
+	assert.False(t, allow(), "failed probe re-opens")
+
+	now = now.Add(2 * time.Second)
+	gen, ok = breaker.allow()
+	assert.True(t, ok, "second probe")
+	breaker.record(gen, true)
+	assert.True(t, allow(), "closed after successful probe")
+	assert.True(t, allow())
+
+	assert.Equal(t, []breakerState{breakerOpen, breakerHalfOpen, breakerOpen, breakerHalfOpen, breakerClosed}, states)
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDurationMs: 1000}, nil)
+	breaker.now = func() time.Time { return now }
+
+	slowFailure, _ := breaker.allow()
+	slowSuccess, _ := breaker.allow()
+	tripping, _ := breaker.allow()
+	breaker.record(tripping, false)
+	require.Equal(t, breakerOpen, breaker.state)
+
+	now = now.Add(900 * time.Millisecond)
+	breaker.record(slowFailure, false)
+	breaker.record(slowSuccess, true)
+	assert.Equal(t, breakerOpen, breaker.state, "late success does not close the breaker")
+
+	now = now.Add(200 * time.Millisecond)
+	probe, ok := breaker.allow()
+	assert.True(t, ok, "late failure does not extend the open period")
+
+	breaker.record(slowSuccess, true)
+	assert.Equal(t, breakerHalfOpen, breaker.state, "only the probe decides")
+	breaker.record(probe, true)
+	assert.Equal(t, breakerClosed, breaker.state)
+}
+
+func TestCircuitBreakerAbandonedProbe(t *testing.T) {
+	now := time.Now()
+	breaker := newCircuitBreaker(CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDurationMs: 1000}, nil)
+	breaker.now = func() time.Time { return now }
+
+	gen, _ := breaker.allow()
+	breaker.record(gen, false)
+	now = now.Add(2 * time.Second)
+
+	probe, ok := breaker.allow()
+	require.True(t, ok)
+	breaker.abandon(probe)
+	assert.Equal(t, breakerHalfOpen, breaker.state)
+
+	_, ok = breaker.allow()
+	assert.True(t, ok, "an abandoned probe frees the slot for another")
+}
+
+func TestResilientFetcherCallerCancellationIsNotAFailure(t *testing.T) {
+	cfg := &Config{CircuitBreaker: CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDurationMs: 60000}}
+	next := &scriptedFetcher{delay: time.Second}
+	fetcher := newResilientFetcher(next, cfg, &moduleMetrics{}).(*resilientFetcher)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := fetcher.Fetch(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, breakerClosed, fetcher.breaker.state)
+}
+
+func TestResilientFetcherCircuitBreaker(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := newModuleMetrics(registry, false)
+	require.NoError(t, err)
+
+	cfg := &Config{CircuitBreaker: CircuitBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDurationMs: 60000}}
+	next := &scriptedFetcher{errs: []error{retryable(errors.New("connection refused"))}}
+	fetcher := newResilientFetcher(next, cfg, metrics)
+
+	_, err = fetcher.Fetch(context.Background(), nil)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, float64(breakerOpen), testutil.ToFloat64(metrics.breakerState))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.breakerTransitions.WithLabelValues("open")))
+
+	_, err = fetcher.Fetch(context.Background(), nil)
+	assert.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, 1, next.calls, "open breaker skips the call")
+}
+
+func TestNewModuleMetricsRegistersOnce(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	first, err := newModuleMetrics(registry, false)
+	require.NoError(t, err)
+	second, err := newModuleMetrics(registry, false)
+	require.NoError(t, err)
+
+	assert.Same(t, first.breakerTransitions, second.breakerTransitions)
+
+	disabled, err := newModuleMetrics(nil, false)
+	require.NoError(t, err)
+	disabled.recordBreakerState(breakerOpen)
+}
+
+func TestNewModuleMetricsAccountLabel(t *testing.T) {
+	withAccount, err := newModuleMetrics(prometheus.NewRegistry(), false)
+	require.NoError(t, err)
+	withAccount.recordOutcome("bidderA", "acct", outcomeSigned)
+	assert.Equal(t, float64(1), testutil.ToFloat64(withAccount.signed.WithLabelValues("bidderA", "acct")))
+
+	withoutAccount, err := newModuleMetrics(prometheus.NewRegistry(), true)
+	require.NoError(t, err)
+	withoutAccount.recordOutcome("bidderA", "acct", outcomeSigned)
+	withoutAccount.recordSidecarDuration([]string{"bidderA"}, "acct", time.Millisecond)
+	assert.Equal(t, float64(1), testutil.ToFloat64(withoutAccount.signed.WithLabelValues("bidderA")))
+	assert.Equal(t, 1, testutil.CollectAndCount(withoutAccount.sidecarDuration))
+}
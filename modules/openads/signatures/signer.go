@@ -0,0 +1,201 @@
+package signatures
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// inProcessFetcher signs bidder requests inside the server with a key loaded
+// from disk, producing the same response shape as the sidecar.
+type inProcessFetcher struct {
+	signer crypto.Signer
+	alg    string
+	kid    string
+	source string
+}
+
+func newInProcessFetcher(cfg InProcessConfig) (SignatureFetcher, error) {
+	data, err := os.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	signer, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	alg, err := algForKey(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inProcessFetcher{
+		signer: signer,
+		alg:    alg,
+		kid:    cfg.KeyID,
+		source: cfg.Source,
+	}, nil
+}
+
+func (f *inProcessFetcher) Fetch(ctx context.Context, body []byte) ([]SignatureWrapper, error) {
+	var request signatureRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, fmt.Errorf("invalid signature request: %w", err)
+	}
+
+	if len(request.RequestBody) == 0 {
+		return nil, errors.New("signature request has no requestBody")
+	}
+
+	payload, err := canonicalJSON(request.RequestBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requestBody: %w", err)
+	}
+
+	// Every demand source gets a signature over the same request body, so the
+	// envelope is computed once and shared.
+	envelope, err := f.sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	signatures := make([]SignatureWrapper, 0, len(request.DemandSources))
+	for _, ds := range request.DemandSources {
+		signatures = append(signatures, SignatureWrapper{
+			Name: ds,
+			SIS: Signature{
+				Envelope: envelope,
+				Source:   f.source,
+			},
+		})
+	}
+
+	return signatures, nil
+}
+
+// sign produces a detached JWS envelope over the canonical payload, the format
+// expected by keyVerifier.Verify.
+func (f *inProcessFetcher) sign(payload []byte) (string, error) {
+	headerJSON, err := json.Marshal(envelopeHeader{Alg: f.alg, Kid: f.kid})
+	if err != nil {
+		return "", err
+	}
+
+	header := base64.RawURLEncoding.EncodeToString(headerJSON)
+	signingInput := []byte(header + "." + base64.RawURLEncoding.EncodeToString(payload))
+
+	sig, err := signWithKey(f.alg, f.signer, signingInput)
+	if err != nil {
+		return "", err
+	}
+
+	return header + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func signWithKey(alg string, signer crypto.Signer, signingInput []byte) ([]byte, error) {
+	switch alg {
+	case algES256, algES384:
+		key, ok := signer.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("key type %T does not match alg %s", signer, alg)
+		}
+		var digest []byte
+		if alg == algES256 {
+			sum := sha256.Sum256(signingInput)
+			digest = sum[:]
+		} else {
+			sum := sha512.Sum384(signingInput)
+			digest = sum[:]
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed-width r||s encoding rather than ASN.1
+		size := (key.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig, nil
+	case algEdDSA:
+		if _, ok := signer.(ed25519.PrivateKey); !ok {
+			return nil, fmt.Errorf("key type %T does not match alg %s", signer, alg)
+		}
+		return signer.Sign(rand.Reader, signingInput, crypto.Hash(0))
+	case algRS256:
+		if _, ok := signer.(*rsa.PrivateKey); !ok {
+			return nil, fmt.Errorf("key type %T does not match alg %s", signer, alg)
+		}
+		sum := sha256.Sum256(signingInput)
+		return signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported alg: %s", alg)
+	}
+}
+
+func algForKey(signer crypto.Signer) (string, error) {
+	switch key := signer.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return algES256, nil
+		case elliptic.P384():
+			return algES384, nil
+		}
+		return "", fmt.Errorf("unsupported curve: %s", key.Curve.Params().Name)
+	case ed25519.PrivateKey:
+		return algEdDSA, nil
+	case *rsa.PrivateKey:
+		return algRS256, nil
+	default:
+		return "", fmt.Errorf("unsupported private key type: %T", signer)
+	}
+}
+
+// parsePrivateKey reads the first PKCS#8, SEC 1 or PKCS#1 private key in a PEM file.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("private key file contains no private key")
+		}
+
+		var key interface{}
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type: %T", key)
+		}
+		return signer, nil
+	}
+}
@@ -0,0 +1,204 @@
+package signatures
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a PKCS#8 private key and its PEM public key to dir.
+func writeKeyPair(t *testing.T, dir string, key crypto.Signer) (string, string) {
+	t.Helper()
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	privPath := writeKeyFile(t, dir, "private.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+	pubPath := writeKeyFile(t, dir, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	return privPath, pubPath
+}
+
+func TestInProcessFetcher(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		key         crypto.Signer
+		expectedAlg string
+	}{
+		{name: "ecdsa p-256", key: ecKey, expectedAlg: algES256},
+		{name: "ecdsa p-384", key: ec384Key, expectedAlg: algES384},
+		{name: "ed25519", key: edKey, expectedAlg: algEdDSA},
+		{name: "rsa", key: rsaKey, expectedAlg: algRS256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privPath, pubPath := writeKeyPair(t, t.TempDir(), tt.key)
+
+			fetcher, err := newInProcessFetcher(InProcessConfig{PrivateKeyFile: privPath, KeyID: "key-1", Source: "example.com"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAlg, fetcher.(*inProcessFetcher).alg)
+
+			bidRequestBody := json.RawMessage(`{"id":"test-request","imp":[{"id":"imp-1"}]}`)
+			body, err := json.Marshal(signatureRequest{
+				RequestBody:   bidRequestBody,
+				DemandSources: []string{"bidder-a", "bidder-b"},
+			})
+			require.NoError(t, err)
+
+			signatures, err := fetcher.Fetch(context.Background(), body)
+			require.NoError(t, err)
+			require.Len(t, signatures, 2)
+			assert.Equal(t, "bidder-a", signatures[0].Name)
+			assert.Equal(t, "bidder-b", signatures[1].Name)
+			assert.Equal(t, "example.com", signatures[0].SIS.Source)
+
+			verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: pubPath, KeyFormat: KeyFormatPEM})
+			require.NoError(t, err)
+			for _, sig := range signatures {
+				assert.NoError(t, verifier.Verify(bidRequestBody, sig.SIS))
+				assert.NoError(t, verifier.Verify([]byte(`{"imp":[{"id":"imp-1"}], "id":"test-request"}`), sig.SIS), "re-serialized request")
+				assert.Error(t, verifier.Verify([]byte(`{"id":"other"}`), sig.SIS))
+			}
+		})
+	}
+}
+
+func TestNewInProcessFetcherErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		path      string
+		expectErr string
+	}{
+		{
+			name:      "missing file",
+			path:      filepath.Join(dir, "missing.pem"),
+			expectErr: "failed to read private key file",
+		},
+		{
+			name:      "no private key",
+			path:      writeKeyFile(t, dir, "empty.pem", []byte("not a key")),
+			expectErr: "private key file contains no private key",
+		},
+		{
+			name:      "corrupt private key",
+			path:      writeKeyFile(t, dir, "corrupt.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("corrupt")})),
+			expectErr: "invalid private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newInProcessFetcher(InProcessConfig{PrivateKeyFile: tt.path, Source: "example.com"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectErr)
+		})
+	}
+}
+
+func TestInProcessFetcherInvalidRequest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPath, _ := writeKeyPair(t, t.TempDir(), key)
+
+	fetcher, err := newInProcessFetcher(InProcessConfig{PrivateKeyFile: privPath, Source: "example.com"})
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), []byte(`{invalid}`))
+	assert.ErrorContains(t, err, "invalid signature request")
+
+	_, err = fetcher.Fetch(context.Background(), []byte(`{"demandSources":["bidder"]}`))
+	assert.ErrorContains(t, err, "signature request has no requestBody")
+
+	_, err = fetcher.Fetch(context.Background(), []byte(`{"requestBody":"not an object",`+"\n"+`"demandSources":["bidder"]}`))
+	assert.NoError(t, err, "any JSON value can be canonicalized")
+}
+
+func TestSignWithKeyMismatch(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = signWithKey(algES256, edKey, []byte("input"))
+	assert.ErrorContains(t, err, "does not match alg ES256")
+
+	_, err = signWithKey(algRS256, ecKey, []byte("input"))
+	assert.ErrorContains(t, err, "does not match alg RS256")
+
+	_, err = signWithKey(algEdDSA, ecKey, []byte("input"))
+	assert.ErrorContains(t, err, "does not match alg EdDSA")
+}
+
+func TestInProcessIntegration(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPath, pubPath := writeKeyPair(t, t.TempDir(), key)
+
+	rawConfig := fmt.Sprintf(`{
+		"transport": "inprocess",
+		"reject_on_failure": true,
+		"inprocess": {"private_key_file": %q, "key_id": "key-1", "source": "example.com"},
+		"verification": {"enabled": true, "key_file": %q, "key_format": "pem"}
+	}`, privPath, pubPath)
+
+	built, err := Builder(json.RawMessage(rawConfig), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+	module := built.(Module)
+
+	payload := hookstage.BidderRequestPayload{
+		Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "test-request-id", Ext: json.RawMessage(`{}`)}},
+		Bidder:  "testbidder",
+	}
+
+	result, err := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+	assert.False(t, result.Reject)
+
+	finalPayload := payload
+	for _, mutation := range result.ChangeSet.Mutations() {
+		finalPayload, err = mutation.Apply(finalPayload)
+		require.NoError(t, err)
+	}
+	require.NoError(t, finalPayload.Request.RebuildRequest())
+
+	var extMap map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(finalPayload.Request.BidRequest.Ext, &extMap))
+
+	var openadsExt OpenAdsExt
+	require.NoError(t, json.Unmarshal(extMap["openads"], &openadsExt))
+	require.Len(t, openadsExt.IntSigs, 1)
+	assert.Equal(t, "example.com", openadsExt.IntSigs[0].Source)
+	assert.NotEmpty(t, openadsExt.IntSigs[0].Envelope)
+
+	assert.NoError(t, module.Shutdown())
+}
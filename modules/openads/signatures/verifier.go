@@ -0,0 +1,434 @@
+package signatures
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v3/logger"
+)
+
+type KeyFormat string
+
+const (
+	KeyFormatJWKS KeyFormat = "jwks"
+	KeyFormatPEM  KeyFormat = "pem"
+)
+
+const (
+	algES256 = "ES256"
+	algES384 = "ES384"
+	algEdDSA = "EdDSA"
+	algRS256 = "RS256"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted from a key file.
+const minRSAKeyBits = 2048
+
+// SignatureVerifier checks that a signature returned by the signing service was
+// computed over the canonical form (RFC 8785) of the request sent to it.
+type SignatureVerifier interface {
+	Verify(payload []byte, sig Signature) error
+	// VerifyCanonical is Verify for a payload that is already in canonical form,
+	// so a request signed for several demand sources is canonicalized only once.
+	VerifyCanonical(canonical []byte, sig Signature) error
+}
+
+// envelopeHeader is the protected header of a detached JWS envelope.
+type envelopeHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type publicKey struct {
+	kid string
+	// alg restricts the key to a single algorithm when the key file names one
+	alg string
+	key crypto.PublicKey
+}
+
+type keyVerifier struct {
+	path           string
+	format         KeyFormat
+	reloadInterval time.Duration
+	done           chan struct{}
+
+	mu      sync.RWMutex
+	keys    []publicKey
+	modTime time.Time
+}
+
+func newVerifier(cfg VerificationConfig) (*keyVerifier, error) {
+	v := &keyVerifier{
+		path:           cfg.KeyFile,
+		format:         cfg.KeyFormat,
+		reloadInterval: time.Duration(cfg.ReloadIntervalSec) * time.Second,
+		done:           make(chan struct{}),
+	}
+
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Verify expects the envelope to be a JWS compact serialization with a detached
+// payload ("<header>..<signature>") where the payload is the canonical request body.
+func (v *keyVerifier) Verify(payload []byte, sig Signature) error {
+	canonical, err := canonicalJSON(payload)
+	if err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return v.VerifyCanonical(canonical, sig)
+}
+
+func (v *keyVerifier) VerifyCanonical(payload []byte, sig Signature) error {
+	parts := strings.Split(sig.Envelope, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return errors.New("envelope is not a detached JWS")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid envelope header encoding: %w", err)
+	}
+
+	var header envelopeHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return fmt.Errorf("invalid envelope header: %w", err)
+	}
+
+	rawSig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("invalid envelope signature encoding: %w", err)
+	}
+
+	signingInput := []byte(parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload))
+
+	candidates := 0
+	for _, k := range v.currentKeys() {
+		if header.Kid != "" && k.kid != "" && k.kid != header.Kid {
+			continue
+		}
+		if k.alg != "" && k.alg != header.Alg {
+			continue
+		}
+		candidates++
+		if verifyWithKey(header.Alg, k.key, signingInput, rawSig) == nil {
+			return nil
+		}
+	}
+
+	if candidates == 0 {
+		return fmt.Errorf("no key found for kid %q", header.Kid)
+	}
+	return errors.New("signature does not match request body")
+}
+
+func (v *keyVerifier) currentKeys() []publicKey {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.keys
+}
+
+// Run checks the key file for changes every reload interval until Shutdown is
+// called. Reloading happens here rather than on the request path, so only one
+// goroutine ever reads the file.
+func (v *keyVerifier) Run() {
+	if v.reloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(v.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			v.reload()
+		case <-v.done:
+			return
+		}
+	}
+}
+
+// Shutdown stops Run.
+func (v *keyVerifier) Shutdown() {
+	close(v.done)
+}
+
+// reload reloads the key file if it changed since the last load. A failed
+// reload keeps the previously loaded keys.
+func (v *keyVerifier) reload() {
+	info, err := os.Stat(v.path)
+	if err != nil {
+		logger.Warnf("openads.signatures: failed to stat key file %s: %v", v.path, err)
+		return
+	}
+
+	v.mu.RLock()
+	unchanged := info.ModTime().Equal(v.modTime)
+	v.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err := v.load(); err != nil {
+		logger.Warnf("openads.signatures: failed to reload key file %s: %v", v.path, err)
+	}
+}
+
+func (v *keyVerifier) load() error {
+	info, err := os.Stat(v.path)
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	data, err := os.ReadFile(v.path)
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var keys []publicKey
+	switch v.format {
+	case KeyFormatJWKS:
+		keys, err = parseJWKS(data)
+	case KeyFormatPEM:
+		keys, err = parsePEMBundle(data)
+	default:
+		err = fmt.Errorf("unsupported key format: %s", v.format)
+	}
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New("key file contains no public keys")
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.modTime = info.ModTime()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func verifyWithKey(alg string, key crypto.PublicKey, signingInput, sig []byte) error {
+	switch alg {
+	case algES256, algES384:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		if pub.Curve != ecdsaCurve(alg) {
+			return errors.New("key curve does not match alg")
+		}
+		var digest []byte
+		if alg == algES256 {
+			sum := sha256.Sum256(signingInput)
+			digest = sum[:]
+		} else {
+			sum := sha512.Sum384(signingInput)
+			digest = sum[:]
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid ECDSA signature length")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case algEdDSA:
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case algRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match alg")
+		}
+		sum := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], sig)
+	default:
+		return fmt.Errorf("unsupported alg: %s", alg)
+	}
+}
+
+// ecdsaCurve returns the only curve alg may be used with (RFC 7518 section 3.4).
+func ecdsaCurve(alg string) elliptic.Curve {
+	if alg == algES384 {
+		return elliptic.P384()
+	}
+	return elliptic.P256()
+}
+
+// checkPublicKey rejects keys too weak to be trusted for verification.
+func checkPublicKey(key crypto.PublicKey) error {
+	if pub, ok := key.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSAKeyBits {
+		return fmt.Errorf("RSA key is %d bits, at least %d required", pub.N.BitLen(), minRSAKeyBits)
+	}
+	return nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func parseJWKS(data []byte) ([]publicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := make([]publicKey, 0, len(set.Keys))
+	for i, k := range set.Keys {
+		// Keys published for encryption can't vouch for a signature
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err == nil {
+			err = checkPublicKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key at index %d: %w", i, err)
+		}
+		keys = append(keys, publicKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+		return pub, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key length")
+		}
+		return ed25519.PublicKey(x), nil
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > math.MaxInt {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// parsePEMBundle reads PUBLIC KEY and CERTIFICATE blocks. A block may carry a
+// "kid" header to be matched against the envelope header.
+func parsePEMBundle(data []byte) ([]publicKey, error) {
+	var keys []publicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var key crypto.PublicKey
+		switch block.Type {
+		case "PUBLIC KEY":
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid PEM public key: %w", err)
+			}
+			key = pub
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid PEM certificate: %w", err)
+			}
+			key = cert.PublicKey
+		default:
+			continue
+		}
+
+		if err := checkPublicKey(key); err != nil {
+			return nil, fmt.Errorf("invalid PEM key: %w", err)
+		}
+		keys = append(keys, publicKey{kid: block.Headers["kid"], key: key})
+	}
+	return keys, nil
+}
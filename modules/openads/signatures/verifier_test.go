@@ -0,0 +1,533 @@
+package signatures
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signDetached(t *testing.T, alg, kid string, key crypto.Signer, payload []byte) string {
+	t.Helper()
+
+	payload, err := canonicalJSON(payload)
+	require.NoError(t, err)
+
+	headerJSON, err := json.Marshal(envelopeHeader{Alg: alg, Kid: kid})
+	require.NoError(t, err)
+	header := base64.RawURLEncoding.EncodeToString(headerJSON)
+	signingInput := []byte(header + "." + base64.RawURLEncoding.EncodeToString(payload))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if alg == algES384 {
+			sum := sha512.Sum384(signingInput)
+			digest = sum[:]
+		} else {
+			sum := sha256.Sum256(signingInput)
+			digest = sum[:]
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		require.NoError(t, err)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, signingInput)
+	default:
+		t.Fatalf("unsupported test key %T", key)
+	}
+
+	return header + ".." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func ecJWKS(t *testing.T, kid string, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	return jwksOf(t, ecJWK(kid, key))
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": key.Curve.Params().Name,
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+	}
+}
+
+func jwksOf(t *testing.T, keys ...map[string]string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	return data
+}
+
+func writeKeyFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestVerifierJWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeKeyFile(t, t.TempDir(), "keys.jwks", ecJWKS(t, "key-1", key))
+	verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatJWKS})
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"test-request"}`)
+
+	tests := []struct {
+		name      string
+		envelope  string
+		payload   []byte
+		expectErr string
+	}{
+		{
+			name:     "valid signature",
+			envelope: signDetached(t, algES256, "key-1", key, payload),
+			payload:  payload,
+		},
+		{
+			name:     "valid signature without kid",
+			envelope: signDetached(t, algES256, "", key, payload),
+			payload:  payload,
+		},
+		{
+			name:     "equivalent serialization of the payload",
+			envelope: signDetached(t, algES256, "key-1", key, payload),
+			payload:  []byte(`{ "id" : "test-request" }`),
+		},
+		{
+			name:      "payload is not JSON",
+			envelope:  signDetached(t, algES256, "key-1", key, payload),
+			payload:   []byte(`not json`),
+			expectErr: "invalid request body",
+		},
+		{
+			name:      "tampered payload",
+			envelope:  signDetached(t, algES256, "key-1", key, payload),
+			payload:   []byte(`{"id":"other-request"}`),
+			expectErr: "signature does not match request body",
+		},
+		{
+			name:      "signed by unknown key",
+			envelope:  signDetached(t, algES256, "key-1", otherKey, payload),
+			payload:   payload,
+			expectErr: "signature does not match request body",
+		},
+		{
+			name:      "unknown kid",
+			envelope:  signDetached(t, algES256, "key-2", key, payload),
+			payload:   payload,
+			expectErr: "no key found for kid",
+		},
+		{
+			name:      "not a detached JWS",
+			envelope:  "stub-signature-value",
+			payload:   payload,
+			expectErr: "envelope is not a detached JWS",
+		},
+		{
+			name:      "invalid header",
+			envelope:  "!!!..c2ln",
+			payload:   payload,
+			expectErr: "invalid envelope header encoding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifier.Verify(tt.payload, Signature{Envelope: tt.envelope, Source: "source"})
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestVerifierPEM(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: map[string]string{"kid": "ed-1"}, Bytes: der})
+
+	path := writeKeyFile(t, t.TempDir(), "keys.pem", bundle)
+	verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatPEM})
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"test-request"}`)
+
+	assert.NoError(t, verifier.Verify(payload, Signature{Envelope: signDetached(t, algEdDSA, "ed-1", priv, payload)}))
+	assert.Error(t, verifier.Verify(payload, Signature{Envelope: signDetached(t, algEdDSA, "ed-2", priv, payload)}))
+	assert.Error(t, verifier.Verify([]byte(`{}`), Signature{Envelope: signDetached(t, algEdDSA, "ed-1", priv, payload)}))
+
+	// The canonical payload is checked byte for byte
+	assert.NoError(t, verifier.VerifyCanonical(payload, Signature{Envelope: signDetached(t, algEdDSA, "ed-1", priv, payload)}))
+	assert.Error(t, verifier.VerifyCanonical([]byte(`{ "id":"test-request"}`), Signature{Envelope: signDetached(t, algEdDSA, "ed-1", priv, payload)}))
+}
+
+func rsaJWK(t *testing.T, key *rsa.PrivateKey) map[string]string {
+	t.Helper()
+
+	return map[string]string{
+		"kty": "RSA",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func rsaPEM(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestNewVerifierErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		cfg       VerificationConfig
+		expectErr string
+	}{
+		{
+			name:      "missing file",
+			cfg:       VerificationConfig{KeyFile: filepath.Join(dir, "missing"), KeyFormat: KeyFormatJWKS},
+			expectErr: "failed to read key file",
+		},
+		{
+			name:      "invalid JWKS",
+			cfg:       VerificationConfig{KeyFile: writeKeyFile(t, dir, "bad.jwks", []byte(`{invalid}`)), KeyFormat: KeyFormatJWKS},
+			expectErr: "invalid JWKS",
+		},
+		{
+			name:      "unsupported key type",
+			cfg:       VerificationConfig{KeyFile: writeKeyFile(t, dir, "oct.jwks", []byte(`{"keys":[{"kty":"oct"}]}`)), KeyFormat: KeyFormatJWKS},
+			expectErr: "unsupported key type",
+		},
+		{
+			name:      "RSA key under 2048 bits in JWKS",
+			cfg:       VerificationConfig{KeyFile: writeKeyFile(t, dir, "small.jwks", jwksOf(t, rsaJWK(t, smallRSAKey))), KeyFormat: KeyFormatJWKS},
+			expectErr: "RSA key is 1024 bits",
+		},
+		{
+			name:      "RSA key under 2048 bits in PEM",
+			cfg:       VerificationConfig{KeyFile: writeKeyFile(t, dir, "small.pem", rsaPEM(t, smallRSAKey)), KeyFormat: KeyFormatPEM},
+			expectErr: "RSA key is 1024 bits",
+		},
+		{
+			name: "RSA exponent does not fit an int",
+			cfg: VerificationConfig{KeyFile: writeKeyFile(t, dir, "exp.jwks", jwksOf(t, map[string]string{
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(smallRSAKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString([]byte{1, 0, 0, 0, 0, 0, 0, 0, 1}),
+			})), KeyFormat: KeyFormatJWKS},
+			expectErr: "RSA exponent is too large",
+		},
+		{
+			name: "only encryption keys",
+			cfg: VerificationConfig{KeyFile: writeKeyFile(t, dir, "enc.jwks", func() []byte {
+				k := rsaJWK(t, smallRSAKey)
+				k["use"] = "enc"
+				return jwksOf(t, k)
+			}()), KeyFormat: KeyFormatJWKS},
+			expectErr: "key file contains no public keys",
+		},
+		{
+			name:      "empty PEM bundle",
+			cfg:       VerificationConfig{KeyFile: writeKeyFile(t, dir, "empty.pem", []byte("no keys here")), KeyFormat: KeyFormatPEM},
+			expectErr: "key file contains no public keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newVerifier(tt.cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectErr)
+		})
+	}
+}
+
+func TestVerifierReload(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeKeyFile(t, t.TempDir(), "keys.jwks", ecJWKS(t, "key-1", oldKey))
+	verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatJWKS, ReloadIntervalSec: 60})
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"test-request"}`)
+	newSig := Signature{Envelope: signDetached(t, algES256, "key-1", newKey, payload)}
+
+	now := time.Now()
+	require.NoError(t, os.WriteFile(path, ecJWKS(t, "key-1", newKey), 0600))
+	require.NoError(t, os.Chtimes(path, now.Add(time.Minute), now.Add(time.Minute)))
+
+	assert.Error(t, verifier.Verify(payload, newSig), "key file not reloaded yet")
+
+	verifier.reload()
+	assert.NoError(t, verifier.Verify(payload, newSig))
+
+	// A broken file keeps the last good key set
+	require.NoError(t, os.WriteFile(path, []byte(`{invalid}`), 0600))
+	require.NoError(t, os.Chtimes(path, now.Add(2*time.Minute), now.Add(2*time.Minute)))
+	verifier.reload()
+	assert.NoError(t, verifier.Verify(payload, newSig))
+}
+
+func TestVerifierRun(t *testing.T) {
+	oldKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeKeyFile(t, t.TempDir(), "keys.jwks", ecJWKS(t, "key-1", oldKey))
+	verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatJWKS, ReloadIntervalSec: 60})
+	require.NoError(t, err)
+	verifier.reloadInterval = 10 * time.Millisecond
+
+	stopped := make(chan struct{})
+	go func() {
+		verifier.Run()
+		close(stopped)
+	}()
+
+	payload := []byte(`{"id":"test-request"}`)
+	newSig := Signature{Envelope: signDetached(t, algES256, "key-1", newKey, payload)}
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(path, ecJWKS(t, "key-1", newKey), 0600))
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	assert.Eventually(t, func() bool {
+		return verifier.Verify(payload, newSig) == nil
+	}, time.Second, 10*time.Millisecond)
+
+	verifier.Shutdown()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after Shutdown")
+	}
+}
+
+func TestVerifierKeyConstraints(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"test-request"}`)
+
+	tests := []struct {
+		name      string
+		jwks      []byte
+		envelope  string
+		expectErr string
+	}{
+		{
+			name:     "ES384 with P-384 key",
+			jwks:     ecJWKS(t, "key-1", p384Key),
+			envelope: signDetached(t, algES384, "key-1", p384Key, payload),
+		},
+		{
+			name:      "ES256 header against P-384 key",
+			jwks:      ecJWKS(t, "key-1", p384Key),
+			envelope:  signDetached(t, algES256, "key-1", p384Key, payload),
+			expectErr: "signature does not match request body",
+		},
+		{
+			name:      "ES384 header against P-256 key",
+			jwks:      ecJWKS(t, "key-1", p256Key),
+			envelope:  signDetached(t, algES384, "key-1", p256Key, payload),
+			expectErr: "signature does not match request body",
+		},
+		{
+			name: "JWK alg does not match header",
+			jwks: func() []byte {
+				k := ecJWK("key-1", p256Key)
+				k["alg"] = algES384
+				return jwksOf(t, k)
+			}(),
+			envelope:  signDetached(t, algES256, "key-1", p256Key, payload),
+			expectErr: "no key found for kid",
+		},
+		{
+			name: "JWK alg matches header",
+			jwks: func() []byte {
+				k := ecJWK("key-1", p256Key)
+				k["alg"] = algES256
+				k["use"] = "sig"
+				return jwksOf(t, k)
+			}(),
+			envelope: signDetached(t, algES256, "key-1", p256Key, payload),
+		},
+		{
+			name: "encryption key is not used",
+			jwks: func() []byte {
+				enc := ecJWK("key-1", p256Key)
+				enc["use"] = "enc"
+				return jwksOf(t, enc, ecJWK("key-2", p384Key))
+			}(),
+			envelope:  signDetached(t, algES256, "key-1", p256Key, payload),
+			expectErr: "no key found for kid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeKeyFile(t, t.TempDir(), "keys.jwks", tt.jwks)
+			verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatJWKS})
+			require.NoError(t, err)
+
+			err = verifier.Verify(payload, Signature{Envelope: tt.envelope})
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHandleBidderRequestHook_Verification(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeKeyFile(t, t.TempDir(), "keys.jwks", ecJWKS(t, "key-1", key))
+	verifier, err := newVerifier(VerificationConfig{Enabled: true, KeyFile: path, KeyFormat: KeyFormatJWKS})
+	require.NoError(t, err)
+
+	bidRequest := &openrtb2.BidRequest{ID: "test-request", Ext: json.RawMessage(`{}`)}
+	signedBody, err := signingView(bidRequest, bidRequest.Imp)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		envelope        string
+		rejectOnFailure bool
+		expectErr       string
+		expectReject    bool
+		expectSigs      int
+	}{
+		{
+			name:       "verified signature",
+			envelope:   signDetached(t, algES256, "key-1", key, signedBody),
+			expectSigs: 1,
+		},
+		{
+			name:       "signature over different body - soft mode",
+			envelope:   signDetached(t, algES256, "key-1", key, []byte(`{"id":"other"}`)),
+			expectErr:  "unverifiable signatures in sidecar response: [testbidder]",
+			expectSigs: 0,
+		},
+		{
+			name:            "garbage envelope - reject mode",
+			envelope:        "garbage",
+			rejectOnFailure: true,
+			expectErr:       "unverifiable signatures in sidecar response: [testbidder]",
+			expectReject:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module := Module{
+				cfg: &Config{
+					Transport:       TransportUDS,
+					BasePath:        "/test.sock",
+					RequestPath:     "/test",
+					RejectOnFailure: tt.rejectOnFailure,
+					Version:         SchemaVersion,
+				},
+				fetcher: &mockFetcher{
+					response: []SignatureWrapper{
+						{Name: "testbidder", SIS: Signature{Envelope: tt.envelope, Source: "source-1"}},
+					},
+				},
+				verifier: verifier,
+			}
+
+			payload := hookstage.BidderRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "test-request", Ext: json.RawMessage(`{}`)}},
+				Bidder:  "testbidder",
+			}
+
+			result, err := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+			}
+			assert.Equal(t, tt.expectReject, result.Reject)
+
+			if tt.expectReject {
+				assert.Equal(t, NbrCodeServiceUnavailable, result.NbrCode)
+				assert.Empty(t, result.ChangeSet.Mutations())
+				return
+			}
+
+			finalPayload := payload
+			for _, mutation := range result.ChangeSet.Mutations() {
+				finalPayload, err = mutation.Apply(finalPayload)
+				require.NoError(t, err)
+			}
+			require.NoError(t, finalPayload.Request.RebuildRequest())
+
+			var extMap map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(finalPayload.Request.BidRequest.Ext, &extMap))
+
+			var openadsExt OpenAdsExt
+			require.NoError(t, json.Unmarshal(extMap["openads"], &openadsExt))
+			assert.Len(t, openadsExt.IntSigs, tt.expectSigs)
+		})
+	}
+}
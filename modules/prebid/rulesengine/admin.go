@@ -0,0 +1,227 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const (
+	adminTreesPath       = "/rulesengine/trees"
+	maxSampleRequestBody = 1 << 20
+)
+
+// adminTreeHandler inspects the rule set trees cached for an account through the admin port: GET with an
+// account query parameter describes the cached rule sets and their compiled trees, and POST with the same
+// parameter and an OpenRTB request body traces the request through every model group of the request stages.
+// Accounts whose trees have not been built yet, because the module has not seen a request for them, are not found.
+type adminTreeHandler struct {
+	cache cacher
+}
+
+type adminCacheEntry struct {
+	Account    string                         `json:"account"`
+	Enabled    bool                           `json:"enabled"`
+	Timestamp  time.Time                      `json:"timestamp"`
+	ConfigHash string                         `json:"configHash"`
+	Stages     map[hooks.Stage][]adminRuleSet `json:"stages"`
+}
+
+type adminRuleSet struct {
+	Name        string            `json:"name"`
+	Version     string            `json:"version,omitempty"`
+	Mode        string            `json:"mode"`
+	ModelGroups []adminModelGroup `json:"modelGroups"`
+}
+
+type adminModelGroup struct {
+	Weight       int           `json:"weight,omitempty"`
+	Version      string        `json:"version,omitempty"`
+	AnalyticsKey string        `json:"analyticsKey,omitempty"`
+	Tree         *adminNode    `json:"tree,omitempty"`
+	Default      []adminResult `json:"default,omitempty"`
+	Trace        *adminTrace   `json:"trace,omitempty"`
+}
+
+type adminNode struct {
+	SchemaFunction string                `json:"schemaFunction,omitempty"`
+	Children       map[string]*adminNode `json:"children,omitempty"`
+	Results        []adminResult         `json:"results,omitempty"`
+}
+
+type adminResult struct {
+	Function string          `json:"function"`
+	Args     json.RawMessage `json:"args,omitempty"`
+}
+
+// adminTrace is the path a sample request took down a tree: the schema function values computed on the
+// way, the rule fired and the result functions that would apply
+type adminTrace struct {
+	SchemaFunctionResults []adminSchemaFunctionResult `json:"schemaFunctionResults"`
+	RuleFired             string                      `json:"ruleFired,omitempty"`
+	Results               []adminResult               `json:"results,omitempty"`
+	Error                 string                      `json:"error,omitempty"`
+}
+
+type adminSchemaFunctionResult struct {
+	Function string `json:"function"`
+	Value    string `json:"value"`
+}
+
+func (h *adminTreeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	account := r.URL.Query().Get("account")
+	if account == "" {
+		http.Error(w, "account is required", http.StatusBadRequest)
+		return
+	}
+
+	co := h.cache.Get(account)
+	if co == nil {
+		http.Error(w, "no rules engine trees cached for account "+account, http.StatusNotFound)
+		return
+	}
+
+	response := adminCacheEntry{
+		Account:    account,
+		Enabled:    co.enabled,
+		Timestamp:  co.timestamp,
+		ConfigHash: co.hashedConfig,
+		Stages:     make(map[hooks.Stage][]adminRuleSet),
+	}
+
+	if r.Method == http.MethodGet {
+		response.Stages[hooks.StageProcessedAuctionRequest] = newAdminRuleSets(co.ruleSetsForProcessedAuctionRequestStage, nil)
+		response.Stages[hooks.StageBidderRequest] = newAdminRuleSets(co.ruleSetsForBidderRequestStage, nil)
+		response.Stages[hooks.StageAllProcessedBidResponses] = newAdminRuleSets(co.ruleSetsForAllProcessedBidResponsesStage, nil)
+	} else {
+		request, err := readSampleRequest(w, r)
+		if err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		// bid stage rule sets need bids so only the request stages can be traced
+		response.Stages[hooks.StageProcessedAuctionRequest] = newAdminRuleSets(co.ruleSetsForProcessedAuctionRequestStage, request)
+		response.Stages[hooks.StageBidderRequest] = newAdminRuleSets(co.ruleSetsForBidderRequestStage, request)
+	}
+
+	body, err := jsonutil.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func readSampleRequest(w http.ResponseWriter, r *http.Request) (*openrtb_ext.RequestWrapper, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSampleRequestBody))
+	if err != nil {
+		return nil, err
+	}
+
+	var bidRequest openrtb2.BidRequest
+	if err := jsonutil.UnmarshalValid(body, &bidRequest); err != nil {
+		return nil, err
+	}
+	return &openrtb_ext.RequestWrapper{BidRequest: &bidRequest}, nil
+}
+
+// newAdminRuleSets describes the rule sets with their compiled trees or, given a payload, with the trace
+// of the payload through the tree of each model group
+func newAdminRuleSets[T1 any, T2 any](ruleSets []cacheRuleSet[T1, T2], payload *T1) []adminRuleSet {
+	adminRuleSets := make([]adminRuleSet, 0, len(ruleSets))
+	for _, ruleSet := range ruleSets {
+		adminRS := adminRuleSet{
+			Name:        ruleSet.name,
+			Version:     ruleSet.version,
+			Mode:        ruleSetMode(ruleSet.shadow),
+			ModelGroups: make([]adminModelGroup, 0, len(ruleSet.modelGroups)),
+		}
+		for _, modelGroup := range ruleSet.modelGroups {
+			adminMG := adminModelGroup{
+				Weight:       modelGroup.weight,
+				Version:      modelGroup.version,
+				AnalyticsKey: modelGroup.analyticsKey,
+			}
+			if payload == nil {
+				adminMG.Tree = newAdminNode(modelGroup.tree.Root)
+				adminMG.Default = newAdminResults(modelGroup.tree.DefaultFunctions)
+			} else {
+				adminMG.Trace = newAdminTrace(&modelGroup.tree, payload)
+			}
+			adminRS.ModelGroups = append(adminRS.ModelGroups, adminMG)
+		}
+		adminRuleSets = append(adminRuleSets, adminRS)
+	}
+	return adminRuleSets
+}
+
+func newAdminNode[T1 any, T2 any](node *rules.Node[T1, T2]) *adminNode {
+	if node == nil {
+		return nil
+	}
+
+	adminN := &adminNode{
+		Results: newAdminResults(node.ResultFunctions),
+	}
+	if node.SchemaFunction != nil {
+		adminN.SchemaFunction = node.SchemaFunction.Name()
+	}
+	if len(node.Children) > 0 {
+		adminN.Children = make(map[string]*adminNode, len(node.Children))
+		for key, child := range node.Children {
+			adminN.Children[key] = newAdminNode(child)
+		}
+	}
+	return adminN
+}
+
+// newAdminResults describes result functions by name along with the args they were configured with
+func newAdminResults[T1 any, T2 any](resultFuncs []rules.ResultFunction[T1, T2]) []adminResult {
+	if len(resultFuncs) == 0 {
+		return nil
+	}
+
+	adminResults := make([]adminResult, 0, len(resultFuncs))
+	for _, resultFunc := range resultFuncs {
+		result := adminResult{Function: resultFunc.Name()}
+		if recording, ok := resultFunc.(*recordingResultFunction[T1, T2]); ok {
+			result.Args = recording.args
+		}
+		adminResults = append(adminResults, result)
+	}
+	return adminResults
+}
+
+func newAdminTrace[T1 any, T2 any](tree *rules.Tree[T1, T2], payload *T1) *adminTrace {
+	meta, resultFuncs, err := tree.Trace(payload)
+
+	trace := &adminTrace{
+		SchemaFunctionResults: make([]adminSchemaFunctionResult, 0, len(meta.SchemaFunctionResults)),
+		RuleFired:             meta.RuleFired,
+		Results:               newAdminResults(resultFuncs),
+	}
+	for _, step := range meta.SchemaFunctionResults {
+		trace.SchemaFunctionResults = append(trace.SchemaFunctionResults, adminSchemaFunctionResult{
+			Function: step.FuncName,
+			Value:    step.FuncResult,
+		})
+	}
+	if err != nil {
+		trace.Error = err.Error()
+	}
+	return trace
+}
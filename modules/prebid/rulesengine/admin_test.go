@@ -0,0 +1,150 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminTreeHandler(t *testing.T) {
+	rawConfig := json.RawMessage(`{
+		"enabled": true,
+		"rulesets": [
+			{
+				"stage": "processed_auction_request",
+				"name": "geo",
+				"version": "2",
+				"mode": "shadow",
+				"modelgroups": [
+					{
+						"weight": 100,
+						"analyticsKey": "geo-key",
+						"version": "1.0",
+						"schema": [{"function": "deviceCountryIn", "args": {"countries": ["USA"]}}, {"function": "channel"}],
+						"rules": [
+							{
+								"conditions": ["true", "web"],
+								"results": [{"function": "excludeBidders", "args": {"bidders": ["bidderA"]}}]
+							}
+						],
+						"default": [{"function": "logATag", "args": {"analyticsValue": "no-match"}}]
+					}
+				]
+			}
+		]
+	}`)
+	validator, err := config.CreateSchemaValidator("config/" + config.RulesEngineSchemaFile)
+	require.NoError(t, err)
+	cfg, err := config.NewConfig(rawConfig, validator)
+	require.NoError(t, err)
+	entry, err := NewCacheEntry(cfg, &rawConfig, nil)
+	require.NoError(t, err)
+
+	cache := NewCache(0)
+	cache.Set("account1", &entry)
+	handler := &adminTreeHandler{cache: cache}
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	t.Run("describe-trees", func(t *testing.T) {
+		recorder := serve(http.MethodGet, adminTreesPath+"?account=account1", "")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		var response adminCacheEntry
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "account1", response.Account)
+		assert.True(t, response.Enabled)
+		assert.Equal(t, entry.hashedConfig, response.ConfigHash)
+		assert.Empty(t, response.Stages["bidder_request"])
+
+		ruleSets := response.Stages["processed_auction_request"]
+		require.Len(t, ruleSets, 1)
+		assert.Equal(t, "geo", ruleSets[0].Name)
+		assert.Equal(t, "2", ruleSets[0].Version)
+		assert.Equal(t, config.RuleSetModeShadow, ruleSets[0].Mode)
+		require.Len(t, ruleSets[0].ModelGroups, 1)
+
+		modelGroup := ruleSets[0].ModelGroups[0]
+		assert.Equal(t, "geo-key", modelGroup.AnalyticsKey)
+		assert.Nil(t, modelGroup.Trace)
+		require.NotNil(t, modelGroup.Tree)
+		assert.Equal(t, "deviceCountryIn", modelGroup.Tree.SchemaFunction)
+		require.Contains(t, modelGroup.Tree.Children, "true")
+		leaf := modelGroup.Tree.Children["true"].Children["web"]
+		require.NotNil(t, leaf)
+		require.Len(t, leaf.Results, 1)
+		assert.Equal(t, ExcludeBiddersName, leaf.Results[0].Function)
+		assert.JSONEq(t, `{"bidders": ["bidderA"]}`, string(leaf.Results[0].Args))
+		require.Len(t, modelGroup.Default, 1)
+		assert.Equal(t, LogATagName, modelGroup.Default[0].Function)
+	})
+
+	t.Run("trace-sample-request", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			request       string
+			expectedTrace adminTrace
+		}{
+			{
+				name:    "rule-fired",
+				request: `{"id": "req1", "device": {"geo": {"country": "USA"}}, "ext": {"prebid": {"channel": {"name": "pbjs"}}}}`,
+				expectedTrace: adminTrace{
+					SchemaFunctionResults: []adminSchemaFunctionResult{
+						{Function: "deviceCountryIn", Value: "true"},
+						{Function: "channel", Value: "web"},
+					},
+					RuleFired: "true|web",
+					Results:   []adminResult{{Function: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"]}`)}},
+				},
+			},
+			{
+				name:    "default",
+				request: `{"id": "req1", "device": {"geo": {"country": "FRA"}}}`,
+				expectedTrace: adminTrace{
+					SchemaFunctionResults: []adminSchemaFunctionResult{
+						{Function: "deviceCountryIn", Value: "false"},
+					},
+					RuleFired: "default",
+					Results:   []adminResult{{Function: LogATagName, Args: json.RawMessage(`{"analyticsValue": "no-match"}`)}},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				recorder := serve(http.MethodPost, adminTreesPath+"?account=account1", tt.request)
+				require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+				var response adminCacheEntry
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				ruleSets := response.Stages["processed_auction_request"]
+				require.Len(t, ruleSets, 1)
+				require.Len(t, ruleSets[0].ModelGroups, 1)
+				assert.Nil(t, ruleSets[0].ModelGroups[0].Tree)
+				require.NotNil(t, ruleSets[0].ModelGroups[0].Trace)
+				assert.Equal(t, tt.expectedTrace, *ruleSets[0].ModelGroups[0].Trace)
+			})
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, adminTreesPath, "").Code, "missing account")
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, adminTreesPath+"?account=account2", "").Code, "account not cached")
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, adminTreesPath+"?account=account1", `{"id":`).Code, "invalid request")
+
+		recorder := serve(http.MethodDelete, adminTreesPath+"?account=account1", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, "GET, POST", recorder.Header().Get("Allow"))
+	})
+}
@@ -0,0 +1,49 @@
+package rulesengine
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/rules"
+)
+
+const analyticsActivityName = "rules-engine"
+
+// newAnalyticsTags wraps the results recorded by the result functions of a hook invocation
+// in a single rules engine activity
+func newAnalyticsTags(results []hookanalytics.Result) hookanalytics.Analytics {
+	return hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:    analyticsActivityName,
+				Status:  hookanalytics.ActivityStatusSuccess,
+				Results: results,
+			},
+		},
+	}
+}
+
+// newAnalyticsResult describes which rule of which model group made a result function run
+func newAnalyticsResult(status hookanalytics.ResultStatus, funcName string, analyticsValue string, meta rules.ResultFunctionMeta) hookanalytics.Result {
+	return hookanalytics.Result{
+		Status: status,
+		Values: map[string]interface{}{
+			"resultFunction": funcName,
+			"analyticsKey":   meta.AnalyticsKey,
+			"analyticsValue": analyticsValue,
+			"modelVersion":   meta.ModelVersion,
+			"ruleFired":      meta.RuleFired,
+		},
+	}
+}
+
+// newShadowAnalyticsResult describes a result function that a shadow rule set would have run,
+// along with the args it would have run with, so shadow results can be compared with live ones
+func newShadowAnalyticsResult(fired firedResult) hookanalytics.Result {
+	result := newAnalyticsResult(hookanalytics.ResultStatusAllow, fired.funcName, fired.analyticsValue, fired.meta)
+	result.Values["mode"] = config.RuleSetModeShadow
+	result.Values["ruleSet"] = fired.ruleSet
+	if len(fired.args) > 0 {
+		result.Values["args"] = fired.args
+	}
+	return result
+}
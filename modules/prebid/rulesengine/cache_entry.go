@@ -16,13 +16,17 @@ import (
 type hash = string
 
 type cacheEntry struct {
-	enabled                                 bool
-	timestamp                               time.Time
-	hashedConfig                            hash
-	ruleSetsForProcessedAuctionRequestStage []cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]
+	enabled                                  bool
+	timestamp                                time.Time
+	hashedConfig                             hash
+	ruleSetsForProcessedAuctionRequestStage  []cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]
+	ruleSetsForBidderRequestStage            []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]
+	ruleSetsForAllProcessedBidResponsesStage []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]
 }
 type cacheRuleSet[T1 any, T2 any] struct {
 	name        string
+	version     string
+	shadow      bool
 	modelGroups []cacheModelGroup[T1, T2]
 }
 type cacheModelGroup[T1 any, T2 any] struct {
@@ -33,8 +37,8 @@ type cacheModelGroup[T1 any, T2 any] struct {
 }
 
 // NewCacheEntry creates a new cache object for the given configuration
-// It builds the tree structures for the rule sets for the processed auction request stage
-// and stores them in the cache object
+// It builds the tree structures for the rule sets for the processed auction request, bidder request
+// and all processed bid responses stages and stores them in the cache object
 func NewCacheEntry(cfg *config.PbRulesEngine, cfgRaw *json.RawMessage, geoscopes map[string][]string) (cacheEntry, error) {
 	if cfg == nil {
 		return cacheEntry{}, errors.New("no rules engine configuration provided")
@@ -59,48 +63,74 @@ func NewCacheEntry(cfg *config.PbRulesEngine, cfgRaw *json.RawMessage, geoscopes
 		newCacheObj.ruleSetsForProcessedAuctionRequestStage = bidderConfigRuleSet
 	}
 
+	requestSchemaFuncFactory := withSetDefinitions(cfg.SetDefinitions, rules.NewRequestSchemaFunction)
+	bidSchemaFuncFactory := withSetDefinitions(cfg.SetDefinitions, rules.NewBidSchemaFunction)
+
 	for _, ruleSet := range cfg.RuleSets {
-		if ruleSet.Stage != hooks.StageProcessedAuctionRequest {
+		switch ruleSet.Stage {
+		case hooks.StageProcessedAuctionRequest:
+			crs, err := createCacheRuleSet(&ruleSet, requestSchemaFuncFactory, NewProcessedAuctionRequestResultFunction)
+			if err != nil {
+				// TODO: log error / metric -->
+				continue
+			}
+			newCacheObj.ruleSetsForProcessedAuctionRequestStage = append(newCacheObj.ruleSetsForProcessedAuctionRequestStage, crs)
+		case hooks.StageBidderRequest:
+			crs, err := createCacheRuleSet(&ruleSet, requestSchemaFuncFactory, NewBidderRequestResultFunction)
+			if err != nil {
+				// TODO: log error / metric -->
+				continue
+			}
+			newCacheObj.ruleSetsForBidderRequestStage = append(newCacheObj.ruleSetsForBidderRequestStage, crs)
+		case hooks.StageAllProcessedBidResponses:
+			crs, err := createCacheRuleSet(&ruleSet, bidSchemaFuncFactory, NewAllProcessedBidResponsesResultFunction)
+			if err != nil {
+				// TODO: log error / metric -->
+				continue
+			}
+			newCacheObj.ruleSetsForAllProcessedBidResponsesStage = append(newCacheObj.ruleSetsForAllProcessedBidResponsesStage, crs)
+		default:
 			// TODO: log error / metric --> stage not supported
-			continue
-		}
-		crs, err := createCacheRuleSet(&ruleSet)
-		if err != nil {
-			// TODO: log error / metric -->
-			continue
 		}
-
-		newCacheObj.ruleSetsForProcessedAuctionRequestStage = append(newCacheObj.ruleSetsForProcessedAuctionRequestStage, crs)
 	}
 
 	return newCacheObj, nil
 }
 
 // createCacheRuleSet creates a new cache rule set for the given configuration
-// It builds the tree structures for the model groups and stores them in the cache rule set
-func createCacheRuleSet(cfg *config.RuleSet) (cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult], error) {
+// It builds the tree structures for the model groups with the schema and result functions
+// of the rule set stage and stores them in the cache rule set. The result functions are wrapped to
+// record each time they fire and, for rule sets in shadow mode, to leave the auction unchanged.
+func createCacheRuleSet[T1 any, T2 any](
+	cfg *config.RuleSet,
+	schemaFuncFactory rules.SchemaFuncFactory[T1],
+	resultFuncFactory rules.ResultFuncFactory[T1, T2],
+) (cacheRuleSet[T1, T2], error) {
 	if cfg == nil {
-		return cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{}, errors.New("no rules engine configuration provided")
+		return cacheRuleSet[T1, T2]{}, errors.New("no rules engine configuration provided")
 	}
 
-	crs := cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+	crs := cacheRuleSet[T1, T2]{
 		name:        cfg.Name,
-		modelGroups: []cacheModelGroup[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{},
+		version:     cfg.Version,
+		shadow:      cfg.Mode == config.RuleSetModeShadow,
+		modelGroups: []cacheModelGroup[T1, T2]{},
 	}
+	resultFuncFactory = withFiredResultRecording(crs.name, crs.shadow, resultFuncFactory)
 
 	for _, modelGroup := range cfg.ModelGroups {
-		tree, err := rules.NewTree[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult](
-			&treeBuilder[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+		tree, err := rules.NewTree[T1, T2](
+			&treeBuilder[T1, T2]{
 				Config:            modelGroup,
-				SchemaFuncFactory: rules.NewRequestSchemaFunction,
-				ResultFuncFactory: NewProcessedAuctionRequestResultFunction,
+				SchemaFuncFactory: schemaFuncFactory,
+				ResultFuncFactory: resultFuncFactory,
 			},
 		)
 		if err != nil {
 			return crs, err
 		}
 
-		cmg := cacheModelGroup[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+		cmg := cacheModelGroup[T1, T2]{
 			weight:       modelGroup.Weight,
 			version:      modelGroup.Version,
 			analyticsKey: modelGroup.AnalyticsKey,
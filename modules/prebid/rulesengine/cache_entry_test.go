@@ -202,6 +202,66 @@ func TestNewCacheEntry(t *testing.T) {
 	}
 }
 
+func TestNewCacheEntryStages(t *testing.T) {
+	cfg := &config.PbRulesEngine{
+		RuleSets: []config.RuleSet{
+			{
+				Stage: hooks.StageProcessedAuctionRequest,
+				ModelGroups: []config.ModelGroup{
+					{Default: []config.Result{{Func: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"]}`)}}},
+				},
+			},
+			{
+				Stage: hooks.StageBidderRequest,
+				ModelGroups: []config.ModelGroup{
+					{Default: []config.Result{{Func: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"]}`)}}},
+				},
+			},
+			{
+				Stage: hooks.StageBidderRequest,
+				ModelGroups: []config.ModelGroup{
+					{Default: []config.Result{{Func: ExcludeBidName}}},
+				},
+			},
+			{
+				Stage: hooks.StageAllProcessedBidResponses,
+				ModelGroups: []config.ModelGroup{
+					{
+						Schema: []config.Schema{{Func: rules.MediaType}},
+						Rules: []config.Rule{
+							{Conditions: []string{"video"}, Results: []config.Result{{Func: ExcludeBidName}}},
+						},
+					},
+				},
+			},
+			{
+				Stage: hooks.StageAllProcessedBidResponses,
+				ModelGroups: []config.ModelGroup{
+					{
+						Schema: []config.Schema{{Func: rules.DeviceCountry}},
+						Rules: []config.Rule{
+							{Conditions: []string{"USA"}, Results: []config.Result{{Func: ExcludeBidName}}},
+						},
+					},
+				},
+			},
+			{
+				Stage: hooks.StageAuctionResponse,
+				ModelGroups: []config.ModelGroup{
+					{Default: []config.Result{{Func: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"]}`)}}},
+				},
+			},
+		},
+	}
+
+	cacheEntry, err := NewCacheEntry(cfg, getValidJsonConfig(), map[string][]string{})
+
+	assert.NoError(t, err)
+	assert.Len(t, cacheEntry.ruleSetsForProcessedAuctionRequestStage, 1)
+	assert.Len(t, cacheEntry.ruleSetsForBidderRequestStage, 1, "bid result functions can't run on bidder requests")
+	assert.Len(t, cacheEntry.ruleSetsForAllProcessedBidResponsesStage, 1, "request schema functions can't run on bids")
+}
+
 func TestCreateCacheRuleSet(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -267,11 +327,14 @@ func TestCreateCacheRuleSet(t *testing.T) {
 						tree: rules.Tree[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
 							Root: &rules.Node[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{},
 							DefaultFunctions: []rules.ResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
-								&ExcludeBidders{
-									Args: config.ResultFuncParams{
-										Bidders:    []string{"bidderA"},
-										SeatNonBid: 111,
+								&recordingResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+									ResultFunction: &ExcludeBidders{
+										Args: config.ResultFuncParams{
+											Bidders:    []string{"bidderA"},
+											SeatNonBid: 111,
+										},
 									},
+									args: json.RawMessage(`{"bidders": ["bidderA"], "seatNonBid": 111}`),
 								},
 							},
 						},
@@ -330,24 +393,73 @@ func TestCreateCacheRuleSet(t *testing.T) {
 						tree: rules.Tree[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
 							Root: &rules.Node[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{},
 							DefaultFunctions: []rules.ResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
-								&ExcludeBidders{
-									Args: config.ResultFuncParams{
-										Bidders:    []string{"bidderFoo"},
-										SeatNonBid: 111,
+								&recordingResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+									ResultFunction: &ExcludeBidders{
+										Args: config.ResultFuncParams{
+											Bidders:    []string{"bidderFoo"},
+											SeatNonBid: 111,
+										},
+									},
+									args: json.RawMessage(`{"bidders": ["bidderFoo"], "seatNonBid": 111}`),
+								},
+							},
+						},
+					},
+					{
+						tree: rules.Tree[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+							Root: &rules.Node[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{},
+							DefaultFunctions: []rules.ResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+								&recordingResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+									ResultFunction: &IncludeBidders{
+										Args: config.ResultFuncParams{
+											Bidders:    []string{"bidderBar"},
+											SeatNonBid: 222,
+										},
 									},
+									args: json.RawMessage(`{"bidders": ["bidderBar"], "seatNonBid": 222}`),
 								},
 							},
 						},
 					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "shadow-mode",
+			in: &config.RuleSet{
+				Name: "shadowRuleSet",
+				Mode: config.RuleSetModeShadow,
+				ModelGroups: []config.ModelGroup{
+					{
+						Default: []config.Result{
+							{
+								Func: ExcludeBiddersName,
+								Args: json.RawMessage(`{"bidders": ["bidderA"], "analyticsValue": "shadow"}`),
+							},
+						},
+					},
+				},
+			},
+			expectedRuleSet: cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+				name:   "shadowRuleSet",
+				shadow: true,
+				modelGroups: []cacheModelGroup[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
 					{
 						tree: rules.Tree[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
 							Root: &rules.Node[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{},
 							DefaultFunctions: []rules.ResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
-								&IncludeBidders{
-									Args: config.ResultFuncParams{
-										Bidders:    []string{"bidderBar"},
-										SeatNonBid: 222,
+								&recordingResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]{
+									ResultFunction: &ExcludeBidders{
+										Args: config.ResultFuncParams{
+											Bidders:        []string{"bidderA"},
+											AnalyticsValue: "shadow",
+										},
 									},
+									ruleSet:        "shadowRuleSet",
+									shadow:         true,
+									analyticsValue: "shadow",
+									args:           json.RawMessage(`{"bidders": ["bidderA"], "analyticsValue": "shadow"}`),
 								},
 							},
 						},
@@ -360,7 +472,7 @@ func TestCreateCacheRuleSet(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			ruleset, err := createCacheRuleSet(tc.in)
+			ruleset, err := createCacheRuleSet(tc.in, rules.NewRequestSchemaFunction, NewProcessedAuctionRequestResultFunction)
 
 			assert.Equal(t, tc.expectedRuleSet, ruleset)
 			assert.Equal(t, tc.expectedErr, err)
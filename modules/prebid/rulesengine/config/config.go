@@ -23,14 +23,34 @@ type PbRulesEngine struct {
 	RuleSets                      []RuleSet      `json:"rulesets,omitempty"`
 }
 
+// SetDefinitions holds named groups of values. A group name listed in the args of an In schema
+// function stands for all the members of the group of that function's kind.
 type SetDefinitions struct {
-	CountryGroups map[string][]string `json:"country_groups,omitempty"`
+	CountryGroups    map[string][]string `json:"country_groups,omitempty"`
+	DeviceTypeGroups map[string][]string `json:"device_type_groups,omitempty"`
+	OsGroups         map[string][]string `json:"os_groups,omitempty"`
+	BrowserGroups    map[string][]string `json:"browser_groups,omitempty"`
+	MediaTypeGroups  map[string][]string `json:"media_type_groups,omitempty"`
+	AdUnitGroups     map[string][]string `json:"ad_unit_groups,omitempty"`
+	DomainGroups     map[string][]string `json:"domain_groups,omitempty"`
+	BundleGroups     map[string][]string `json:"bundle_groups,omitempty"`
+	PublisherGroups  map[string][]string `json:"publisher_groups,omitempty"`
+	DayGroups        map[string][]string `json:"day_groups,omitempty"`
+	BidderGroups     map[string][]string `json:"bidder_groups,omitempty"`
 }
 
+// Rule set modes. A shadow rule set runs its trees like a live one but only reports the result
+// functions its rules would have run, through analytics tags and metrics, leaving the auction unchanged.
+const (
+	RuleSetModeLive   = "live"
+	RuleSetModeShadow = "shadow"
+)
+
 type RuleSet struct {
 	Stage       hooks.Stage  `json:"stage,omitempty"`
 	Name        string       `json:"name,omitempty"`
 	Version     string       `json:"version,omitempty"`
+	Mode        string       `json:"mode,omitempty"`
 	ModelGroups []ModelGroup `json:"modelgroups,omitempty"`
 }
 
@@ -67,6 +87,31 @@ type ResultFuncParams struct {
 	IfSyncedId     bool     `json:"ifsyncedid,omitempty"`
 }
 
+// SetBidFloorParams is a struct that holds parameters for the setBidFloor result function.
+// Either a floor replacing the imp floors or a factor the imp floors are multiplied by must be given.
+type SetBidFloorParams struct {
+	ImpIds      []string `json:"impids,omitempty"`
+	BidFloor    *float64 `json:"bidfloor,omitempty"`
+	BidFloorCur string   `json:"bidfloorcur,omitempty"`
+	Factor      *float64 `json:"factor,omitempty"`
+}
+
+// SetTmaxParams is a struct that holds parameters for the setTmax result function.
+type SetTmaxParams struct {
+	Tmax int64 `json:"tmax,omitempty"`
+}
+
+// SetTargetingParams is a struct that holds the ext.prebid.targeting options the setTargeting
+// result function sets. Options left out keep their request values.
+type SetTargetingParams struct {
+	IncludeWinners     *bool   `json:"includewinners,omitempty"`
+	IncludeBidderKeys  *bool   `json:"includebidderkeys,omitempty"`
+	IncludeFormat      *bool   `json:"includeformat,omitempty"`
+	PreferDeals        *bool   `json:"preferdeals,omitempty"`
+	AlwaysIncludeDeals *bool   `json:"alwaysincludedeals,omitempty"`
+	Prefix             *string `json:"prefix,omitempty"`
+}
+
 func CreateSchemaValidator(jsonSchemaFile string) (*gojsonschema.Schema, error) {
 	jsonSchemaFilePath, err := filepath.Abs(jsonSchemaFile)
 	if err != nil {
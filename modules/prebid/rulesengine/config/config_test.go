@@ -268,7 +268,7 @@ func TestValidateConfig(t *testing.T) {
 				]
 			}
 			`),
-			expectedError: "[rulesets.0.modelgroups.0.schema.0.function: rulesets.0.modelgroups.0.schema.0.function must be one of the following: \"channel\", \"dataCenter\", \"dataCenterIn\", \"deviceCountry\", \"deviceCountryIn\", \"eidAvailable\", \"eidIn\", \"fpdAvailable\", \"gppSidAvailable\", \"gppSidIn\", \"percent\", \"tcfInScope\", \"userFpdAvailable\"] ",
+			expectedError: "[rulesets.0.modelgroups.0.schema.0.function: rulesets.0.modelgroups.0.schema.0.function must be one of the following: \"adomainIn\", \"adUnitCode\", \"adUnitCodeIn\", \"bidder\", \"bidderIn\", \"bidPriceBelow\", \"browser\", \"browserIn\", \"bundle\", \"bundleIn\", \"buyerUidAvailable\", \"buyerUidIn\", \"channel\", \"dataCenter\", \"dataCenterIn\", \"dayOfWeek\", \"dayOfWeekIn\", \"dealIdAvailable\", \"dealIdIn\", \"deviceCountry\", \"deviceCountryIn\", \"deviceOs\", \"deviceOsIn\", \"deviceType\", \"deviceTypeIn\", \"domain\", \"domainIn\", \"eidAvailable\", \"eidIn\", \"fpdAvailable\", \"gppSidAvailable\", \"gppSidIn\", \"hourOfDay\", \"hourOfDayIn\", \"impMediaType\", \"impMediaTypeIn\", \"mediaType\", \"mediaTypeIn\", \"percent\", \"publisherId\", \"publisherIdIn\", \"requestSize\", \"requestSizeIn\", \"tcfInScope\", \"userFpdAvailable\"] ",
 		},
 		{
 			name: "invalid-empty-conditions",
@@ -346,7 +346,82 @@ func TestValidateConfig(t *testing.T) {
 				]
 			}
 			`),
-			expectedError: "[rulesets.0.modelgroups.0.rules.0.results.0.function: rulesets.0.modelgroups.0.rules.0.results.0.function must be one of the following: \"excludeBidders\", \"includeBidders\", \"logATag\"] ",
+			expectedError: "[rulesets.0.modelgroups.0.rules.0.results.0.function: rulesets.0.modelgroups.0.rules.0.results.0.function must be one of the following: \"excludeBid\", \"excludeBidders\", \"flagBid\", \"includeBidders\", \"logATag\", \"removeDeviceGeo\", \"removeUserEids\", \"setBidFloor\", \"setTargeting\", \"setTmax\"] ",
+		},
+		{
+			name: "invalid-result-function-args",
+			config: json.RawMessage(`
+			{
+				"enabled": true,
+				"rulesets": [
+				{
+					"stage": "processed_auction_request",
+					"name": "someName",
+					"modelgroups": [
+					{
+						"schema": [{"function":"channel"}],
+						"rules": [
+						{
+							"conditions": ["cond"],
+							"results": [{"function": "setTmax", "args": {"tmax": 0}}]
+						}
+						]
+					}
+					]
+				}
+				]
+			}
+			`),
+			expectedError: "[rulesets.0.modelgroups.0.rules.0.results.0.args.tmax: Must be greater than or equal to 1] ",
+		},
+		{
+			name: "invalid-default-function-args",
+			config: json.RawMessage(`
+			{
+				"enabled": true,
+				"rulesets": [
+				{
+					"stage": "processed_auction_request",
+					"name": "someName",
+					"modelgroups": [
+					{
+						"schema": [{"function":"channel"}],
+						"default": [{"function": "setBidFloor", "args": {"factor": "2"}}],
+						"rules": [
+						{
+							"conditions": ["cond"],
+							"results": []
+						}
+						]
+					}
+					]
+				}
+				]
+			}
+			`),
+			expectedError: "[rulesets.0.modelgroups.0.default.0.args.factor: Invalid type. Expected: number, given: string] ",
+		},
+		{
+			name: "invalid-ruleset-mode",
+			config: json.RawMessage(`
+			{
+				"enabled": true,
+				"rulesets": [
+				{
+					"stage": "processed_auction_request",
+					"name": "someName",
+					"mode": "dryrun",
+					"modelgroups": [
+					{
+						"schema": [{"function":"channel"}],
+						"rules": []
+					}
+					]
+				}
+				]
+			}
+			`),
+			expectedError: "[rulesets.0.mode: rulesets.0.mode must be one of the following: \"live\", \"shadow\"] ",
 		},
 		{
 			name: "invalid-set-definitions-invalid-property",
@@ -0,0 +1,93 @@
+package rulesengine
+
+import (
+	"fmt"
+
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prebid/prebid-server/v3/util/randomutil"
+)
+
+type AllProcessedBidResponsesHookResult struct {
+	HookResult       hs.HookResult[hs.AllProcessedBidResponsesPayload]
+	ExcludedBids     map[*entities.PbsOrtbBid]struct{}
+	AnalyticsResults []hookanalytics.Result
+
+	firedResults []firedResult
+}
+
+// handleAllProcessedBidResponsesHook runs the rule set trees once for every bid of every bidder.
+// The model group of a rule set is selected once per auction so all bids are evaluated by the same
+// model. Bids excluded by a result function are removed from the payload with a single mutation.
+func handleAllProcessedBidResponsesHook(
+	ruleSets []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult],
+	payload hs.AllProcessedBidResponsesPayload,
+	metrics *moduleMetrics,
+	account string) (hs.HookResult[hs.AllProcessedBidResponsesPayload], error) {
+
+	result := AllProcessedBidResponsesHookResult{
+		HookResult: hs.HookResult[hs.AllProcessedBidResponsesPayload]{
+			ChangeSet: hs.ChangeSet[hs.AllProcessedBidResponsesPayload]{},
+		},
+		ExcludedBids: make(map[*entities.PbsOrtbBid]struct{}),
+	}
+
+	for _, ruleSet := range ruleSets {
+		selectedGroup, err := selectModelGroup(ruleSet.modelGroups, randomutil.RandomNumberGenerator{})
+		if err != nil {
+			result.HookResult.Errors = append(result.HookResult.Errors, fmt.Sprintf("failed to select model group: %s", err))
+			continue
+		}
+		metrics.recordRuleSetRun(account, hooks.StageAllProcessedBidResponses, ruleSet.name, ruleSet.shadow)
+
+		for bidderName, seatBid := range payload.Responses {
+			if seatBid == nil {
+				continue
+			}
+			for _, bid := range seatBid.Bids {
+				if bid == nil {
+					continue
+				}
+				if _, excluded := result.ExcludedBids[bid]; excluded {
+					continue
+				}
+
+				processedBid := rules.ProcessedBid{Bidder: bidderName.String(), Bid: bid}
+				if err = selectedGroup.tree.Run(&processedBid, &result); err != nil {
+					//TODO: classify errors as warnings or errors
+					result.HookResult.Errors = append(result.HookResult.Errors, err.Error())
+				}
+			}
+		}
+	}
+
+	if len(result.ExcludedBids) > 0 {
+		excludedBids := result.ExcludedBids
+		result.HookResult.ChangeSet.AddMutation(func(p hs.AllProcessedBidResponsesPayload) (hs.AllProcessedBidResponsesPayload, error) {
+			for _, seatBid := range p.Responses {
+				if seatBid == nil {
+					continue
+				}
+				bids := make([]*entities.PbsOrtbBid, 0, len(seatBid.Bids))
+				for _, bid := range seatBid.Bids {
+					if _, excluded := excludedBids[bid]; !excluded {
+						bids = append(bids, bid)
+					}
+				}
+				seatBid.Bids = bids
+			}
+			return p, nil
+		}, hs.MutationDelete, "processedbidresponses", "bids")
+	}
+
+	metrics.recordFiredResults(account, hooks.StageAllProcessedBidResponses, result.firedResults)
+
+	if len(result.AnalyticsResults) > 0 {
+		result.HookResult.AnalyticsTags = newAnalyticsTags(result.AnalyticsResults)
+	}
+
+	return result.HookResult, nil
+}
@@ -0,0 +1,140 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAllProcessedBidResponsesHook(t *testing.T) {
+	ruleSet, err := createCacheRuleSet(&config.RuleSet{
+		ModelGroups: []config.ModelGroup{
+			{
+				AnalyticsKey: "bid-quality",
+				Version:      "1.0",
+				Schema:       []config.Schema{{Func: rules.MediaType}, {Func: rules.DealIdAvailable}},
+				Rules: []config.Rule{
+					{
+						Conditions: []string{"video", "false"},
+						Results:    []config.Result{{Func: ExcludeBidName, Args: json.RawMessage(`{"analyticsValue": "no-deal-video"}`)}},
+					},
+					{
+						Conditions: []string{"banner", "*"},
+						Results:    []config.Result{{Func: FlagBidName, Args: json.RawMessage(`{"analyticsValue": "banner"}`)}},
+					},
+				},
+			},
+		},
+	}, rules.NewBidSchemaFunction, NewAllProcessedBidResponsesResultFunction)
+	require.NoError(t, err)
+
+	videoBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "video-bid"}, BidType: openrtb_ext.BidTypeVideo}
+	videoDealBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "video-deal-bid", DealID: "deal1"}, BidType: openrtb_ext.BidTypeVideo}
+	bannerBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "banner-bid"}, BidType: openrtb_ext.BidTypeBanner}
+
+	payload := hs.AllProcessedBidResponsesPayload{
+		Responses: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+			"bidderA": {Bids: []*entities.PbsOrtbBid{videoBid, videoDealBid}},
+			"bidderB": {Bids: []*entities.PbsOrtbBid{bannerBid}},
+			"bidderC": nil,
+		},
+	}
+
+	result, err := handleAllProcessedBidResponsesHook(
+		[]cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{ruleSet}, payload, nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+	mutation := result.ChangeSet.Mutations()[0]
+	assert.Equal(t, hs.MutationDelete, mutation.Type())
+
+	payload, err = mutation.Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []*entities.PbsOrtbBid{videoDealBid}, payload.Responses["bidderA"].Bids)
+	assert.Equal(t, []*entities.PbsOrtbBid{bannerBid}, payload.Responses["bidderB"].Bids)
+
+	require.Len(t, result.AnalyticsTags.Activities, 1)
+	activity := result.AnalyticsTags.Activities[0]
+	assert.Equal(t, analyticsActivityName, activity.Name)
+	assert.ElementsMatch(t, []hookanalytics.Result{
+		{
+			Status: hookanalytics.ResultStatusBlock,
+			Values: map[string]interface{}{
+				"resultFunction": ExcludeBidName,
+				"analyticsKey":   "bid-quality",
+				"analyticsValue": "no-deal-video",
+				"modelVersion":   "1.0",
+				"ruleFired":      "video|false",
+			},
+			AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderA", BidIds: []string{"video-bid"}},
+		},
+		{
+			Status: hookanalytics.ResultStatusAllow,
+			Values: map[string]interface{}{
+				"resultFunction": FlagBidName,
+				"analyticsKey":   "bid-quality",
+				"analyticsValue": "banner",
+				"modelVersion":   "1.0",
+				"ruleFired":      "banner|*",
+			},
+			AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderB", BidIds: []string{"banner-bid"}},
+		},
+	}, activity.Results)
+}
+
+func TestHandleAllProcessedBidResponsesHookNoMatches(t *testing.T) {
+	tests := []struct {
+		name           string
+		ruleSets       []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]
+		expectedResult hs.HookResult[hs.AllProcessedBidResponsesPayload]
+	}{
+		{
+			name:           "empty-rule-sets",
+			ruleSets:       []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{},
+			expectedResult: hs.HookResult[hs.AllProcessedBidResponsesPayload]{},
+		},
+		{
+			name: "failed-to-select-model-group",
+			ruleSets: []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{
+				{modelGroups: []cacheModelGroup[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{}},
+			},
+			expectedResult: hs.HookResult[hs.AllProcessedBidResponsesPayload]{
+				Errors: []string{"failed to select model group: no model groups available"},
+			},
+		},
+		{
+			name: "nil-tree-root",
+			ruleSets: []cacheRuleSet[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{
+				{modelGroups: []cacheModelGroup[rules.ProcessedBid, AllProcessedBidResponsesHookResult]{{weight: 100}}},
+			},
+			expectedResult: hs.HookResult[hs.AllProcessedBidResponsesPayload]{
+				Errors: []string{"tree root is nil"},
+			},
+		},
+	}
+
+	payload := hs.AllProcessedBidResponsesPayload{
+		Responses: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+			"bidderA": {Bids: []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid1"}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handleAllProcessedBidResponsesHook(tt.ruleSets, payload, nil, "")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package rulesengine
+
+import (
+	"fmt"
+
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/randomutil"
+)
+
+type BidderRequestHookResult struct {
+	HookResult       hs.HookResult[hs.BidderRequestPayload]
+	Bidder           string
+	AnalyticsResults []hookanalytics.Result
+
+	firedResults []firedResult
+}
+
+func handleBidderRequestHook(
+	ruleSets []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult],
+	payload hs.BidderRequestPayload,
+	metrics *moduleMetrics,
+	account string) (hs.HookResult[hs.BidderRequestPayload], error) {
+
+	result := BidderRequestHookResult{
+		HookResult: hs.HookResult[hs.BidderRequestPayload]{
+			ChangeSet: hs.ChangeSet[hs.BidderRequestPayload]{},
+		},
+		Bidder: payload.Bidder,
+	}
+
+	for _, ruleSet := range ruleSets {
+		selectedGroup, err := selectModelGroup(ruleSet.modelGroups, randomutil.RandomNumberGenerator{})
+		if err != nil {
+			result.HookResult.Errors = append(result.HookResult.Errors, fmt.Sprintf("failed to select model group: %s", err))
+			continue
+		}
+		metrics.recordRuleSetRun(account, hooks.StageBidderRequest, ruleSet.name, ruleSet.shadow)
+
+		if err = selectedGroup.tree.Run(payload.Request, &result); err != nil {
+			//TODO: classify errors as warnings or errors
+			result.HookResult.Errors = append(result.HookResult.Errors, err.Error())
+		}
+
+		// a rejected bidder request is not sent so later rule sets have nothing left to change
+		if result.HookResult.Reject {
+			break
+		}
+	}
+
+	metrics.recordFiredResults(account, hooks.StageBidderRequest, result.firedResults)
+
+	if len(result.AnalyticsResults) > 0 {
+		result.HookResult.AnalyticsTags = newAnalyticsTags(result.AnalyticsResults)
+	}
+
+	return result.HookResult, nil
+}
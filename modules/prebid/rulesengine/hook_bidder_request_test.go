@@ -0,0 +1,255 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleBidderRequestHook(t *testing.T) {
+	ruleSet, err := createCacheRuleSet(&config.RuleSet{
+		ModelGroups: []config.ModelGroup{
+			{
+				Schema: []config.Schema{{Func: rules.DeviceCountry}},
+				Rules: []config.Rule{
+					{
+						Conditions: []string{"USA"},
+						Results: []config.Result{
+							{Func: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"], "seatNonBid": 301}`)},
+						},
+					},
+				},
+				Default: []config.Result{
+					{Func: IncludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"], "seatNonBid": 302}`)},
+				},
+			},
+		},
+	}, rules.NewRequestSchemaFunction, NewBidderRequestResultFunction)
+	require.NoError(t, err)
+
+	newPayload := func(bidder, country string) hs.BidderRequestPayload {
+		return hs.BidderRequestPayload{
+			Bidder: bidder,
+			Request: &openrtb_ext.RequestWrapper{
+				BidRequest: &openrtb2.BidRequest{
+					Device: &openrtb2.Device{Geo: &openrtb2.Geo{Country: country}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		ruleSets       []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]
+		payload        hs.BidderRequestPayload
+		expectedResult hs.HookResult[hs.BidderRequestPayload]
+	}{
+		{
+			name:           "empty-rule-sets",
+			ruleSets:       []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{},
+			payload:        newPayload("bidderA", "USA"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{},
+		},
+		{
+			name: "failed-to-select-model-group",
+			ruleSets: []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{
+				{modelGroups: []cacheModelGroup[openrtb_ext.RequestWrapper, BidderRequestHookResult]{}},
+			},
+			payload: newPayload("bidderA", "USA"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{
+				Errors: []string{"failed to select model group: no model groups available"},
+			},
+		},
+		{
+			name:     "excluded-bidder-is-rejected",
+			ruleSets: []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{ruleSet},
+			payload:  newPayload("bidderA", "USA"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{
+				Reject:  true,
+				NbrCode: 301,
+			},
+		},
+		{
+			name:           "other-bidder-is-not-excluded",
+			ruleSets:       []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{ruleSet},
+			payload:        newPayload("bidderB", "USA"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{},
+		},
+		{
+			name:           "default-included-bidder-is-not-rejected",
+			ruleSets:       []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{ruleSet},
+			payload:        newPayload("bidderA", "CAN"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{},
+		},
+		{
+			name:     "default-not-included-bidder-is-rejected",
+			ruleSets: []cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{ruleSet},
+			payload:  newPayload("bidderB", "CAN"),
+			expectedResult: hs.HookResult[hs.BidderRequestPayload]{
+				Reject:  true,
+				NbrCode: 302,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := handleBidderRequestHook(tt.ruleSets, tt.payload, nil, "")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestHandleBidderRequestHookAnalyticsTags(t *testing.T) {
+	ruleSet, err := createCacheRuleSet(&config.RuleSet{
+		ModelGroups: []config.ModelGroup{
+			{
+				AnalyticsKey: "geo",
+				Version:      "2.0",
+				Schema:       []config.Schema{{Func: rules.DeviceCountry}},
+				Rules: []config.Rule{
+					{
+						Conditions: []string{"USA"},
+						Results: []config.Result{
+							{Func: RemoveDeviceGeoName, Args: json.RawMessage(`{"bidders": ["bidderA"]}`)},
+							{Func: LogATagName, Args: json.RawMessage(`{"analyticsValue": "geo-removed"}`)},
+						},
+					},
+				},
+			},
+		},
+	}, rules.NewRequestSchemaFunction, NewBidderRequestResultFunction)
+	require.NoError(t, err)
+
+	payload := hs.BidderRequestPayload{
+		Bidder: "bidderA",
+		Request: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{Device: &openrtb2.Device{Geo: &openrtb2.Geo{Country: "USA"}}},
+		},
+	}
+
+	result, err := handleBidderRequestHook(
+		[]cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{ruleSet}, payload, nil, "")
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.False(t, result.Reject)
+	assert.Len(t, result.ChangeSet.Mutations(), 1)
+	assert.Equal(t, hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:   analyticsActivityName,
+				Status: hookanalytics.ActivityStatusSuccess,
+				Results: []hookanalytics.Result{
+					{
+						Status: hookanalytics.ResultStatusAllow,
+						Values: map[string]interface{}{
+							"resultFunction": LogATagName,
+							"analyticsKey":   "geo",
+							"analyticsValue": "geo-removed",
+							"modelVersion":   "2.0",
+							"ruleFired":      "USA",
+						},
+						AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderA", Request: true},
+					},
+				},
+			},
+		},
+	}, result.AnalyticsTags)
+}
+
+func TestHandleBidderRequestHookShadowMode(t *testing.T) {
+	newRuleSet := func(name, mode string) cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult] {
+		ruleSet, err := createCacheRuleSet(&config.RuleSet{
+			Name: name,
+			Mode: mode,
+			ModelGroups: []config.ModelGroup{
+				{
+					AnalyticsKey: "geo",
+					Version:      "3.0",
+					Schema:       []config.Schema{{Func: rules.DeviceCountry}},
+					Rules: []config.Rule{
+						{
+							Conditions: []string{"USA"},
+							Results: []config.Result{
+								{Func: ExcludeBiddersName, Args: json.RawMessage(`{"bidders": ["bidderA"], "seatNonBid": 301, "analyticsValue": "usa"}`)},
+							},
+						},
+					},
+				},
+			},
+		}, rules.NewRequestSchemaFunction, NewBidderRequestResultFunction)
+		require.NoError(t, err)
+		return ruleSet
+	}
+	liveRuleSet := newRuleSet("geo-live", "")
+	shadowRuleSet := newRuleSet("geo-shadow", config.RuleSetModeShadow)
+
+	payload := hs.BidderRequestPayload{
+		Bidder: "bidderA",
+		Request: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{Device: &openrtb2.Device{Geo: &openrtb2.Geo{Country: "USA"}}},
+		},
+	}
+
+	registry := prometheus.NewRegistry()
+	metrics, err := newModuleMetrics(registry, false)
+	require.NoError(t, err)
+
+	result, err := handleBidderRequestHook(
+		[]cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{shadowRuleSet}, payload, metrics, "acct")
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.False(t, result.Reject, "shadow rule sets do not reject bidder requests")
+	assert.Empty(t, result.ChangeSet.Mutations())
+	assert.Equal(t, hookanalytics.Analytics{
+		Activities: []hookanalytics.Activity{
+			{
+				Name:   analyticsActivityName,
+				Status: hookanalytics.ActivityStatusSuccess,
+				Results: []hookanalytics.Result{
+					{
+						Status: hookanalytics.ResultStatusAllow,
+						Values: map[string]interface{}{
+							"resultFunction": ExcludeBiddersName,
+							"analyticsKey":   "geo",
+							"analyticsValue": "usa",
+							"modelVersion":   "3.0",
+							"ruleFired":      "USA",
+							"mode":           config.RuleSetModeShadow,
+							"ruleSet":        "geo-shadow",
+							"args":           json.RawMessage(`{"bidders": ["bidderA"], "seatNonBid": 301, "analyticsValue": "usa"}`),
+						},
+						AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderA", Request: true},
+					},
+				},
+			},
+		},
+	}, result.AnalyticsTags)
+
+	result, err = handleBidderRequestHook(
+		[]cacheRuleSet[openrtb_ext.RequestWrapper, BidderRequestHookResult]{liveRuleSet}, payload, metrics, "acct")
+	require.NoError(t, err)
+	assert.True(t, result.Reject)
+
+	stage := string(hooks.StageBidderRequest)
+	for _, tc := range []struct{ ruleSet, mode string }{
+		{"geo-live", config.RuleSetModeLive},
+		{"geo-shadow", config.RuleSetModeShadow},
+	} {
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ruleSetRuns.WithLabelValues("acct", stage, tc.ruleSet, tc.mode)), tc.ruleSet)
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.firedResults.WithLabelValues("acct", stage, tc.ruleSet, tc.mode, ExcludeBiddersName)), tc.ruleSet)
+	}
+}
@@ -3,6 +3,8 @@ package rulesengine
 import (
 	"fmt"
 
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
 	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/randomutil"
@@ -12,13 +14,18 @@ type RequestWrapper = openrtb_ext.RequestWrapper
 type ModelGroup = cacheModelGroup[RequestWrapper, ProcessedAuctionHookResult]
 
 type ProcessedAuctionHookResult struct {
-	HookResult     hs.HookResult[hs.ProcessedAuctionRequestPayload]
-	AllowedBidders map[string]struct{}
+	HookResult       hs.HookResult[hs.ProcessedAuctionRequestPayload]
+	AllowedBidders   map[string]struct{}
+	AnalyticsResults []hookanalytics.Result
+
+	firedResults []firedResult
 }
 
 func handleProcessedAuctionHook(
 	ruleSets []cacheRuleSet[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult],
-	payload hs.ProcessedAuctionRequestPayload) (hs.HookResult[hs.ProcessedAuctionRequestPayload], error) {
+	payload hs.ProcessedAuctionRequestPayload,
+	metrics *moduleMetrics,
+	account string) (hs.HookResult[hs.ProcessedAuctionRequestPayload], error) {
 
 	result := ProcessedAuctionHookResult{
 		HookResult: hs.HookResult[hs.ProcessedAuctionRequestPayload]{
@@ -33,6 +40,7 @@ func handleProcessedAuctionHook(
 			result.HookResult.Errors = append(result.HookResult.Errors, fmt.Sprintf("failed to select model group: %s", err))
 			continue
 		}
+		metrics.recordRuleSetRun(account, hooks.StageProcessedAuctionRequest, ruleSet.name, ruleSet.shadow)
 
 		if err = selectedGroup.tree.Run(payload.Request, &result); err != nil {
 			//TODO: classify errors as warnings or errors
@@ -44,12 +52,18 @@ func handleProcessedAuctionHook(
 		}
 	}
 
+	metrics.recordFiredResults(account, hooks.StageProcessedAuctionRequest, result.firedResults)
+
+	if len(result.AnalyticsResults) > 0 {
+		result.HookResult.AnalyticsTags = newAnalyticsTags(result.AnalyticsResults)
+	}
+
 	return result.HookResult, nil
 }
 
-func selectModelGroup(modelGroups []ModelGroup, rg randomutil.RandomGenerator) (ModelGroup, error) {
+func selectModelGroup[T1 any, T2 any](modelGroups []cacheModelGroup[T1, T2], rg randomutil.RandomGenerator) (cacheModelGroup[T1, T2], error) {
 	if len(modelGroups) == 0 {
-		return ModelGroup{}, fmt.Errorf("no model groups available")
+		return cacheModelGroup[T1, T2]{}, fmt.Errorf("no model groups available")
 	}
 
 	if len(modelGroups) == 1 {
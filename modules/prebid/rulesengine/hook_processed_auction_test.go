@@ -78,7 +78,7 @@ func TestHandleProcessedAuctionHook(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := handleProcessedAuctionHook(tt.ruleSets, tt.payload)
+			result, err := handleProcessedAuctionHook(tt.ruleSets, tt.payload, nil, "")
 
 			if tt.expectedError {
 				assert.Error(t, err)
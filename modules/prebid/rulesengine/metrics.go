@@ -0,0 +1,84 @@
+package rulesengine
+
+import (
+	"github.com/prebid/prebid-server/v3/hooks"
+	prometheusmetrics "github.com/prebid/prebid-server/v3/metrics/prometheus"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsPrefix = "modules_prebid_rulesengine_"
+
+// moduleMetrics holds the module's Prometheus collectors. Live and shadow rule sets are counted
+// by the same collectors, labeled by mode, so a shadow rule set can be compared with the live one
+// it is meant to replace. The collectors are nil when Prometheus metrics are disabled, and the
+// account label is left out when account level module metrics are disabled.
+type moduleMetrics struct {
+	ruleSetRuns  *prometheus.CounterVec
+	firedResults *prometheus.CounterVec
+	accountLabel bool
+}
+
+func newModuleMetrics(registerer prometheus.Registerer, accountMetricsDisabled bool) (*moduleMetrics, error) {
+	m := &moduleMetrics{accountLabel: !accountMetricsDisabled}
+	if registerer == nil {
+		return m, nil
+	}
+
+	var labels []string
+	labelsHelp := ""
+	if m.accountLabel {
+		labels = append(labels, "account")
+		labelsHelp = "account, "
+	}
+
+	var err error
+	m.ruleSetRuns, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "ruleset_runs",
+		Help: "Count of rule set evaluations labeled by " + labelsHelp + "stage, rule set and mode.",
+	}, append(labels, "stage", "ruleset", "mode")))
+	if err != nil {
+		return nil, err
+	}
+
+	m.firedResults, err = prometheusmetrics.RegisterModuleCollector(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricsPrefix + "fired_result_functions",
+		Help: "Count of result functions run, or that would have run in shadow mode, labeled by " + labelsHelp + "stage, rule set, mode and result function.",
+	}, append(labels, "stage", "ruleset", "mode", "function")))
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *moduleMetrics) recordRuleSetRun(account string, stage hooks.Stage, ruleSet string, shadow bool) {
+	if m == nil || m.ruleSetRuns == nil {
+		return
+	}
+	m.ruleSetRuns.WithLabelValues(m.labelValues(account, string(stage), ruleSet, ruleSetMode(shadow))...).Inc()
+}
+
+func (m *moduleMetrics) recordFiredResults(account string, stage hooks.Stage, firedResults []firedResult) {
+	if m == nil || m.firedResults == nil {
+		return
+	}
+	for _, fired := range firedResults {
+		m.firedResults.WithLabelValues(m.labelValues(account, string(stage), fired.ruleSet, ruleSetMode(fired.shadow), fired.funcName)...).Inc()
+	}
+}
+
+// labelValues prepends account to values when the collectors are labeled by account.
+func (m *moduleMetrics) labelValues(account string, values ...string) []string {
+	if m.accountLabel {
+		return append([]string{account}, values...)
+	}
+	return values
+}
+
+func ruleSetMode(shadow bool) string {
+	if shadow {
+		return config.RuleSetModeShadow
+	}
+	return config.RuleSetModeLive
+}
@@ -0,0 +1,31 @@
+package rulesengine
+
+import (
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleMetricsAccountLabel(t *testing.T) {
+	stage := string(hooks.StageProcessedAuctionRequest)
+	fired := []firedResult{{ruleSet: "geo", funcName: ExcludeBiddersName}}
+
+	withAccount, err := newModuleMetrics(prometheus.NewRegistry(), false)
+	require.NoError(t, err)
+	withAccount.recordRuleSetRun("acct", hooks.StageProcessedAuctionRequest, "geo", false)
+	withAccount.recordFiredResults("acct", hooks.StageProcessedAuctionRequest, fired)
+	assert.Equal(t, float64(1), testutil.ToFloat64(withAccount.ruleSetRuns.WithLabelValues("acct", stage, "geo", config.RuleSetModeLive)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(withAccount.firedResults.WithLabelValues("acct", stage, "geo", config.RuleSetModeLive, ExcludeBiddersName)))
+
+	withoutAccount, err := newModuleMetrics(prometheus.NewRegistry(), true)
+	require.NoError(t, err)
+	withoutAccount.recordRuleSetRun("acct", hooks.StageProcessedAuctionRequest, "geo", false)
+	withoutAccount.recordFiredResults("acct", hooks.StageProcessedAuctionRequest, fired)
+	assert.Equal(t, float64(1), testutil.ToFloat64(withoutAccount.ruleSetRuns.WithLabelValues(stage, "geo", config.RuleSetModeLive)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(withoutAccount.firedResults.WithLabelValues(stage, "geo", config.RuleSetModeLive, ExcludeBiddersName)))
+}
@@ -22,6 +22,11 @@ func Builder(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, erro
 		return nil, err
 	}
 
+	metrics, err := newModuleMetrics(deps.MetricsRegisterer, deps.AccountMetricsDisabled)
+	if err != nil {
+		return nil, err
+	}
+
 	tm := treeManager{
 		done:            make(chan struct{}),
 		requests:        make(chan buildInstruction),
@@ -37,11 +42,16 @@ func Builder(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, erro
 
 	c := NewCache(refreshRate)
 
+	if deps.RegisterAdminHandler != nil {
+		deps.RegisterAdminHandler(adminTreesPath, &adminTreeHandler{cache: c})
+	}
+
 	go tm.Run(c)
 
 	return Module{
 		Cache:       c,
 		TreeManager: &tm,
+		metrics:     metrics,
 	}, nil
 }
 
@@ -49,6 +59,7 @@ func Builder(cfg json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, erro
 type Module struct {
 	Cache       cacher
 	TreeManager *treeManager
+	metrics     *moduleMetrics
 }
 
 // HandleProcessedAuctionHook updates field on openrtb2.BidRequest.
@@ -58,12 +69,52 @@ func (m Module) HandleProcessedAuctionHook(
 	miCtx hs.ModuleInvocationContext,
 	payload hs.ProcessedAuctionRequestPayload,
 ) (hs.HookResult[hs.ProcessedAuctionRequestPayload], error) {
-	result := hs.HookResult[hs.ProcessedAuctionRequestPayload]{}
+	co, skipMessage := m.getCacheEntry(miCtx)
+	if co == nil {
+		return hs.HookResult[hs.ProcessedAuctionRequestPayload]{Message: skipMessage}, nil
+	}
+
+	return handleProcessedAuctionHook(co.ruleSetsForProcessedAuctionRequestStage, payload, m.metrics, miCtx.AccountID)
+}
+
+// HandleBidderRequestHook runs the rule sets of the bidder request stage against the request
+// distilled for a single bidder, possibly rejecting the request to skip the bidder.
+func (m Module) HandleBidderRequestHook(
+	_ context.Context,
+	miCtx hs.ModuleInvocationContext,
+	payload hs.BidderRequestPayload,
+) (hs.HookResult[hs.BidderRequestPayload], error) {
+	co, skipMessage := m.getCacheEntry(miCtx)
+	if co == nil {
+		return hs.HookResult[hs.BidderRequestPayload]{Message: skipMessage}, nil
+	}
+
+	return handleBidderRequestHook(co.ruleSetsForBidderRequestStage, payload, m.metrics, miCtx.AccountID)
+}
+
+// HandleAllProcessedBidResponsesHook runs the rule sets of the all processed bid responses stage
+// against every bid, dropping or flagging bids as the matching rules dictate.
+func (m Module) HandleAllProcessedBidResponsesHook(
+	_ context.Context,
+	miCtx hs.ModuleInvocationContext,
+	payload hs.AllProcessedBidResponsesPayload,
+) (hs.HookResult[hs.AllProcessedBidResponsesPayload], error) {
+	co, skipMessage := m.getCacheEntry(miCtx)
+	if co == nil {
+		return hs.HookResult[hs.AllProcessedBidResponsesPayload]{Message: skipMessage}, nil
+	}
+
+	return handleAllProcessedBidResponsesHook(co.ruleSetsForAllProcessedBidResponsesStage, payload, m.metrics, miCtx.AccountID)
+}
 
+// getCacheEntry returns the enabled cache entry for the account, asking the tree manager to build
+// or rebuild its trees when needed. When there is no entry to run, it returns nil with the message
+// explaining why the hook was skipped.
+func (m Module) getCacheEntry(miCtx hs.ModuleInvocationContext) (*cacheEntry, string) {
 	// AccountConfig will either be an account-specific config or the default account config
 	// AccountConfig only contains the config block for this module
 	if len(miCtx.AccountConfig) == 0 {
-		return result, nil
+		return nil, ""
 	}
 
 	co := m.Cache.Get(miCtx.AccountID)
@@ -77,9 +128,7 @@ func (m Module) HandleProcessedAuctionHook(
 		m.TreeManager.requests <- bi
 
 		// TODO: return with reject or no reject, possible config option
-		return hs.HookResult[hs.ProcessedAuctionRequestPayload]{
-			Message: "skipped, loading rules engine account configuration for future requests",
-		}, nil
+		return nil, "skipped, loading rules engine account configuration for future requests"
 	}
 	// cache hit
 	if rebuildTrees(co, &miCtx.AccountConfig, m.Cache) {
@@ -91,12 +140,10 @@ func (m Module) HandleProcessedAuctionHook(
 	}
 
 	if !co.enabled {
-		return hs.HookResult[hs.ProcessedAuctionRequestPayload]{
-			Message: "skipped, rules engine is disabled for this account",
-		}, nil
+		return nil, "skipped, rules engine is disabled for this account"
 	}
 
-	return handleProcessedAuctionHook(co.ruleSetsForProcessedAuctionRequestStage, payload)
+	return co, ""
 }
 
 // Shutdown signals the module to stop processing and waits for the tree manager to finish
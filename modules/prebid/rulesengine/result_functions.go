@@ -4,19 +4,38 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/rules"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
 )
 
 // ProcessedAuctionResultFunc is a type alias for a result function that runs in the processed auction request stage.
 type ProcessedAuctionResultFunc = rules.ResultFunction[openrtb_ext.RequestWrapper, ProcessedAuctionHookResult]
 
+// BidderRequestResultFunc is a type alias for a result function that runs in the bidder request stage.
+type BidderRequestResultFunc = rules.ResultFunction[openrtb_ext.RequestWrapper, BidderRequestHookResult]
+
+// AllProcessedBidResponsesResultFunc is a type alias for a result function that runs for each bid in the
+// all processed bid responses stage.
+type AllProcessedBidResponsesResultFunc = rules.ResultFunction[rules.ProcessedBid, AllProcessedBidResponsesHookResult]
+
 const (
-	ExcludeBiddersName = "excludeBidders"
-	IncludeBiddersName = "includeBidders"
+	ExcludeBiddersName  = "excludeBidders"
+	IncludeBiddersName  = "includeBidders"
+	ExcludeBidName      = "excludeBid"
+	FlagBidName         = "flagBid"
+	LogATagName         = "logATag"
+	RemoveDeviceGeoName = "removeDeviceGeo"
+	RemoveUserEidsName  = "removeUserEids"
+	SetBidFloorName     = "setBidFloor"
+	SetTargetingName    = "setTargeting"
+	SetTmaxName         = "setTmax"
 )
 
 // NewProcessedAuctionRequestResultFunction is a factory function that creates a new result function based on the provided name and parameters.
@@ -32,6 +51,14 @@ func NewProcessedAuctionRequestResultFunction(name string, params json.RawMessag
 		return NewExcludeBidders(params)
 	case IncludeBiddersName:
 		return NewIncludeBidders(params)
+	case LogATagName:
+		return NewLogATag(params)
+	case SetBidFloorName:
+		return NewSetBidFloor(params)
+	case SetTargetingName:
+		return NewSetTargeting(params)
+	case SetTmaxName:
+		return NewSetTmax(params)
 	default:
 		return nil, fmt.Errorf("result function %s was not created", name)
 	}
@@ -106,3 +133,503 @@ func (ib *IncludeBidders) Call(req *openrtb_ext.RequestWrapper, result *Processe
 func (ib *IncludeBidders) Name() string {
 	return IncludeBiddersName
 }
+
+// NewBidderRequestResultFunction is a factory function that creates a new result function for the bidder request
+// stage based on the provided name and parameters.
+// It returns an error if the function name is not recognized or if there is an issue with the parameters.
+func NewBidderRequestResultFunction(name string, params json.RawMessage) (BidderRequestResultFunc, error) {
+	switch name {
+	case ExcludeBiddersName:
+		return NewBidderRequestExcludeBidders(params)
+	case IncludeBiddersName:
+		return NewBidderRequestIncludeBidders(params)
+	case LogATagName:
+		return NewBidderRequestLogATag(params)
+	case RemoveDeviceGeoName:
+		return NewRemoveDeviceGeo(params)
+	case RemoveUserEidsName:
+		return NewRemoveUserEids(params)
+	default:
+		return nil, fmt.Errorf("result function %s was not created", name)
+	}
+}
+
+// NewBidderRequestExcludeBidders is a factory function that creates a new BidderRequestExcludeBidders result function.
+// The function returns an error if there is an issue with the unmarshalling process or no bidders are specified.
+func NewBidderRequestExcludeBidders(params json.RawMessage) (BidderRequestResultFunc, error) {
+	excludeBiddersParams, err := newBiddersParams(ExcludeBiddersName, params)
+	if err != nil {
+		return nil, err
+	}
+	return &BidderRequestExcludeBidders{Args: excludeBiddersParams}, nil
+}
+
+// BidderRequestExcludeBidders is a struct that holds parameters for excluding bidders in the bidder request stage.
+type BidderRequestExcludeBidders struct {
+	Args config.ResultFuncParams
+}
+
+// Call rejects the bidder request if its bidder is one of the excluded bidders, which skips the call to the bidder.
+func (eb *BidderRequestExcludeBidders) Call(req *openrtb_ext.RequestWrapper, result *BidderRequestHookResult, meta rules.ResultFunctionMeta) error {
+	if containsBidder(eb.Args.Bidders, result.Bidder) {
+		result.HookResult.Reject = true
+		result.HookResult.NbrCode = eb.Args.SeatNonBid
+	}
+	return nil
+}
+
+func (eb *BidderRequestExcludeBidders) Name() string {
+	return ExcludeBiddersName
+}
+
+// NewBidderRequestIncludeBidders is a factory function that creates a new BidderRequestIncludeBidders result function.
+// The function returns an error if there is an issue with the unmarshalling process or no bidders are specified.
+func NewBidderRequestIncludeBidders(params json.RawMessage) (BidderRequestResultFunc, error) {
+	includeBiddersParams, err := newBiddersParams(IncludeBiddersName, params)
+	if err != nil {
+		return nil, err
+	}
+	return &BidderRequestIncludeBidders{Args: includeBiddersParams}, nil
+}
+
+// BidderRequestIncludeBidders is a struct that holds parameters for including bidders in the bidder request stage.
+type BidderRequestIncludeBidders struct {
+	Args config.ResultFuncParams
+}
+
+// Call rejects the bidder request unless its bidder is one of the included bidders.
+func (ib *BidderRequestIncludeBidders) Call(req *openrtb_ext.RequestWrapper, result *BidderRequestHookResult, meta rules.ResultFunctionMeta) error {
+	if !containsBidder(ib.Args.Bidders, result.Bidder) {
+		result.HookResult.Reject = true
+		result.HookResult.NbrCode = ib.Args.SeatNonBid
+	}
+	return nil
+}
+
+func (ib *BidderRequestIncludeBidders) Name() string {
+	return IncludeBiddersName
+}
+
+// NewAllProcessedBidResponsesResultFunction is a factory function that creates a new result function for the
+// all processed bid responses stage based on the provided name and parameters.
+// It returns an error if the function name is not recognized or if there is an issue with the parameters.
+func NewAllProcessedBidResponsesResultFunction(name string, params json.RawMessage) (AllProcessedBidResponsesResultFunc, error) {
+	switch name {
+	case ExcludeBidName:
+		return NewExcludeBid(params)
+	case FlagBidName:
+		return NewFlagBid(params)
+	default:
+		return nil, fmt.Errorf("result function %s was not created", name)
+	}
+}
+
+// NewExcludeBid is a factory function that creates a new ExcludeBid result function.
+// Its parameters are optional, an analytics value may be given to tag the excluded bids with.
+func NewExcludeBid(params json.RawMessage) (AllProcessedBidResponsesResultFunc, error) {
+	var excludeBidParams config.ResultFuncParams
+	if len(params) > 0 {
+		if err := jsonutil.Unmarshal(params, &excludeBidParams); err != nil {
+			return nil, err
+		}
+	}
+	return &ExcludeBid{Args: excludeBidParams}, nil
+}
+
+// ExcludeBid is a struct that holds parameters for dropping the bid the rules were run against.
+type ExcludeBid struct {
+	Args config.ResultFuncParams
+}
+
+// Call marks the bid for removal from the bidder responses and records it in the analytics tags.
+func (eb *ExcludeBid) Call(bid *rules.ProcessedBid, result *AllProcessedBidResponsesHookResult, meta rules.ResultFunctionMeta) error {
+	if bid == nil || bid.Bid == nil {
+		return nil
+	}
+	result.ExcludedBids[bid.Bid] = struct{}{}
+	result.AnalyticsResults = append(result.AnalyticsResults, newBidAnalyticsResult(hookanalytics.ResultStatusBlock, ExcludeBidName, eb.Args.AnalyticsValue, bid, meta))
+	return nil
+}
+
+func (eb *ExcludeBid) Name() string {
+	return ExcludeBidName
+}
+
+// NewFlagBid is a factory function that creates a new FlagBid result function.
+// The function returns an error if there is an issue with the unmarshalling process or no analytics value is specified.
+func NewFlagBid(params json.RawMessage) (AllProcessedBidResponsesResultFunc, error) {
+	var flagBidParams config.ResultFuncParams
+	if err := jsonutil.Unmarshal(params, &flagBidParams); err != nil {
+		return nil, err
+	}
+	if len(flagBidParams.AnalyticsValue) == 0 {
+		return nil, errors.New("flagBid requires an analytics value to be specified")
+	}
+	return &FlagBid{Args: flagBidParams}, nil
+}
+
+// FlagBid is a struct that holds parameters for tagging the bid the rules were run against without removing it.
+type FlagBid struct {
+	Args config.ResultFuncParams
+}
+
+// Call records the bid in the analytics tags leaving the bidder responses untouched.
+func (fb *FlagBid) Call(bid *rules.ProcessedBid, result *AllProcessedBidResponsesHookResult, meta rules.ResultFunctionMeta) error {
+	if bid == nil || bid.Bid == nil {
+		return nil
+	}
+	result.AnalyticsResults = append(result.AnalyticsResults, newBidAnalyticsResult(hookanalytics.ResultStatusAllow, FlagBidName, fb.Args.AnalyticsValue, bid, meta))
+	return nil
+}
+
+func (fb *FlagBid) Name() string {
+	return FlagBidName
+}
+
+// NewSetBidFloor is a factory function that creates a new SetBidFloor result function.
+// The function returns an error if there is an issue with the unmarshalling process or the parameters
+// don't specify exactly one of a non-negative floor or a positive factor.
+func NewSetBidFloor(params json.RawMessage) (ProcessedAuctionResultFunc, error) {
+	var setBidFloorParams config.SetBidFloorParams
+	if err := jsonutil.Unmarshal(params, &setBidFloorParams); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case setBidFloorParams.BidFloor == nil && setBidFloorParams.Factor == nil:
+		return nil, errors.New("setBidFloor requires either a bidfloor or a factor to be specified")
+	case setBidFloorParams.BidFloor != nil && setBidFloorParams.Factor != nil:
+		return nil, errors.New("setBidFloor accepts either a bidfloor or a factor, not both")
+	case setBidFloorParams.BidFloor != nil && *setBidFloorParams.BidFloor < 0:
+		return nil, errors.New("setBidFloor requires a non-negative bidfloor")
+	case setBidFloorParams.Factor != nil && *setBidFloorParams.Factor <= 0:
+		return nil, errors.New("setBidFloor requires a positive factor")
+	case setBidFloorParams.Factor != nil && len(setBidFloorParams.BidFloorCur) > 0:
+		return nil, errors.New("setBidFloor accepts a bidfloorcur only along with a bidfloor")
+	}
+
+	setBidFloor := &SetBidFloor{Args: setBidFloorParams}
+	if len(setBidFloorParams.ImpIds) > 0 {
+		setBidFloor.impIds = make(map[string]struct{}, len(setBidFloorParams.ImpIds))
+		for _, impId := range setBidFloorParams.ImpIds {
+			setBidFloor.impIds[impId] = struct{}{}
+		}
+	}
+	return setBidFloor, nil
+}
+
+// SetBidFloor is a struct that holds parameters for replacing or scaling the floors of the request imps.
+type SetBidFloor struct {
+	Args   config.SetBidFloorParams
+	impIds map[string]struct{}
+}
+
+// Call adds a mutation that updates the floor of every imp, or of the listed imps only when imp IDs are given.
+func (sf *SetBidFloor) Call(req *openrtb_ext.RequestWrapper, result *ProcessedAuctionHookResult, meta rules.ResultFunctionMeta) error {
+	result.HookResult.ChangeSet.AddMutation(func(p hs.ProcessedAuctionRequestPayload) (hs.ProcessedAuctionRequestPayload, error) {
+		if p.Request == nil || p.Request.BidRequest == nil {
+			return p, errors.New("payload contains a nil bid request")
+		}
+		for _, imp := range p.Request.GetImp() {
+			if sf.impIds != nil {
+				if _, found := sf.impIds[imp.ID]; !found {
+					continue
+				}
+			}
+			if sf.Args.BidFloor != nil {
+				imp.BidFloor = *sf.Args.BidFloor
+				if len(sf.Args.BidFloorCur) > 0 {
+					imp.BidFloorCur = sf.Args.BidFloorCur
+				}
+			} else {
+				imp.BidFloor *= *sf.Args.Factor
+			}
+		}
+		return p, nil
+	}, hs.MutationUpdate, "bidrequest", "imp", "bidfloor")
+	return nil
+}
+
+func (sf *SetBidFloor) Name() string {
+	return SetBidFloorName
+}
+
+// NewSetTmax is a factory function that creates a new SetTmax result function.
+// The function returns an error if there is an issue with the unmarshalling process or tmax isn't positive.
+func NewSetTmax(params json.RawMessage) (ProcessedAuctionResultFunc, error) {
+	var setTmaxParams config.SetTmaxParams
+	if err := jsonutil.Unmarshal(params, &setTmaxParams); err != nil {
+		return nil, err
+	}
+	if setTmaxParams.Tmax <= 0 {
+		return nil, errors.New("setTmax requires a positive tmax to be specified")
+	}
+	return &SetTmax{Args: setTmaxParams}, nil
+}
+
+// SetTmax is a struct that holds parameters for shortening the request tmax.
+type SetTmax struct {
+	Args config.SetTmaxParams
+}
+
+// Call adds a mutation that lowers the request tmax to the configured value. A request tmax that is
+// already shorter is left as is so rules can only ever shorten the auction.
+func (st *SetTmax) Call(req *openrtb_ext.RequestWrapper, result *ProcessedAuctionHookResult, meta rules.ResultFunctionMeta) error {
+	result.HookResult.ChangeSet.AddMutation(func(p hs.ProcessedAuctionRequestPayload) (hs.ProcessedAuctionRequestPayload, error) {
+		if p.Request == nil || p.Request.BidRequest == nil {
+			return p, errors.New("payload contains a nil bid request")
+		}
+		if p.Request.TMax <= 0 || p.Request.TMax > st.Args.Tmax {
+			p.Request.TMax = st.Args.Tmax
+		}
+		return p, nil
+	}, hs.MutationUpdate, "bidrequest", "tmax")
+	return nil
+}
+
+func (st *SetTmax) Name() string {
+	return SetTmaxName
+}
+
+// NewSetTargeting is a factory function that creates a new SetTargeting result function.
+// The function returns an error if there is an issue with the unmarshalling process or no option is specified.
+func NewSetTargeting(params json.RawMessage) (ProcessedAuctionResultFunc, error) {
+	var setTargetingParams config.SetTargetingParams
+	if err := jsonutil.Unmarshal(params, &setTargetingParams); err != nil {
+		return nil, err
+	}
+	if setTargetingParams == (config.SetTargetingParams{}) {
+		return nil, errors.New("setTargeting requires at least one targeting option to be specified")
+	}
+	return &SetTargeting{Args: setTargetingParams}, nil
+}
+
+// SetTargeting is a struct that holds the ext.prebid.targeting options to set on the request.
+type SetTargeting struct {
+	Args config.SetTargetingParams
+}
+
+// Call adds a mutation that sets the configured options on ext.prebid.targeting, creating it if needed.
+func (st *SetTargeting) Call(req *openrtb_ext.RequestWrapper, result *ProcessedAuctionHookResult, meta rules.ResultFunctionMeta) error {
+	result.HookResult.ChangeSet.AddMutation(func(p hs.ProcessedAuctionRequestPayload) (hs.ProcessedAuctionRequestPayload, error) {
+		if p.Request == nil || p.Request.BidRequest == nil {
+			return p, errors.New("payload contains a nil bid request")
+		}
+		reqExt, err := p.Request.GetRequestExt()
+		if err != nil {
+			return p, err
+		}
+
+		prebid := reqExt.GetPrebid()
+		if prebid == nil {
+			prebid = &openrtb_ext.ExtRequestPrebid{}
+		}
+		targeting := openrtb_ext.ExtRequestTargeting{}
+		if prebid.Targeting != nil {
+			targeting = *prebid.Targeting
+		}
+
+		if st.Args.IncludeWinners != nil {
+			targeting.IncludeWinners = ptrutil.ToPtr(*st.Args.IncludeWinners)
+		}
+		if st.Args.IncludeBidderKeys != nil {
+			targeting.IncludeBidderKeys = ptrutil.ToPtr(*st.Args.IncludeBidderKeys)
+		}
+		if st.Args.IncludeFormat != nil {
+			targeting.IncludeFormat = *st.Args.IncludeFormat
+		}
+		if st.Args.PreferDeals != nil {
+			targeting.PreferDeals = *st.Args.PreferDeals
+		}
+		if st.Args.AlwaysIncludeDeals != nil {
+			targeting.AlwaysIncludeDeals = *st.Args.AlwaysIncludeDeals
+		}
+		if st.Args.Prefix != nil {
+			targeting.Prefix = *st.Args.Prefix
+		}
+
+		prebid.Targeting = &targeting
+		reqExt.SetPrebid(prebid)
+		return p, nil
+	}, hs.MutationUpdate, "bidrequest", "ext", "prebid", "targeting")
+	return nil
+}
+
+func (st *SetTargeting) Name() string {
+	return SetTargetingName
+}
+
+// NewLogATag is a factory function that creates a new LogATag result function.
+// The function returns an error if there is an issue with the unmarshalling process or no analytics value is specified.
+func NewLogATag(params json.RawMessage) (ProcessedAuctionResultFunc, error) {
+	logATagParams, err := newLogATagParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return &LogATag{Args: logATagParams}, nil
+}
+
+// LogATag is a struct that holds parameters for tagging the auction without changing it.
+type LogATag struct {
+	Args config.ResultFuncParams
+}
+
+// Call records the analytics value in the analytics tags of the processed auction request stage.
+func (lt *LogATag) Call(req *openrtb_ext.RequestWrapper, result *ProcessedAuctionHookResult, meta rules.ResultFunctionMeta) error {
+	analyticsResult := newAnalyticsResult(hookanalytics.ResultStatusAllow, LogATagName, lt.Args.AnalyticsValue, meta)
+	analyticsResult.AppliedTo.Request = true
+	result.AnalyticsResults = append(result.AnalyticsResults, analyticsResult)
+	return nil
+}
+
+func (lt *LogATag) Name() string {
+	return LogATagName
+}
+
+// NewBidderRequestLogATag is a factory function that creates a new BidderRequestLogATag result function.
+// The function returns an error if there is an issue with the unmarshalling process or no analytics value is specified.
+func NewBidderRequestLogATag(params json.RawMessage) (BidderRequestResultFunc, error) {
+	logATagParams, err := newLogATagParams(params)
+	if err != nil {
+		return nil, err
+	}
+	return &BidderRequestLogATag{Args: logATagParams}, nil
+}
+
+// BidderRequestLogATag is a struct that holds parameters for tagging a bidder request without changing it.
+type BidderRequestLogATag struct {
+	Args config.ResultFuncParams
+}
+
+// Call records the analytics value, applied to the bidder of the request, in the analytics tags.
+func (lt *BidderRequestLogATag) Call(req *openrtb_ext.RequestWrapper, result *BidderRequestHookResult, meta rules.ResultFunctionMeta) error {
+	analyticsResult := newAnalyticsResult(hookanalytics.ResultStatusAllow, LogATagName, lt.Args.AnalyticsValue, meta)
+	analyticsResult.AppliedTo.Bidder = result.Bidder
+	analyticsResult.AppliedTo.Request = true
+	result.AnalyticsResults = append(result.AnalyticsResults, analyticsResult)
+	return nil
+}
+
+func (lt *BidderRequestLogATag) Name() string {
+	return LogATagName
+}
+
+// NewRemoveUserEids is a factory function that creates a new RemoveUserEids result function.
+// The function returns an error if there is an issue with the unmarshalling process or no bidders are specified.
+func NewRemoveUserEids(params json.RawMessage) (BidderRequestResultFunc, error) {
+	removeUserEidsParams, err := newBiddersParams(RemoveUserEidsName, params)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveUserEids{Args: removeUserEidsParams}, nil
+}
+
+// RemoveUserEids is a struct that holds the bidders whose requests must not carry user EIDs.
+type RemoveUserEids struct {
+	Args config.ResultFuncParams
+}
+
+// Call adds a mutation that removes user.eids from the request when it is sent to one of the bidders.
+func (ru *RemoveUserEids) Call(req *openrtb_ext.RequestWrapper, result *BidderRequestHookResult, meta rules.ResultFunctionMeta) error {
+	if !containsBidder(ru.Args.Bidders, result.Bidder) {
+		return nil
+	}
+	result.HookResult.ChangeSet.AddMutation(func(p hs.BidderRequestPayload) (hs.BidderRequestPayload, error) {
+		if p.Request == nil || p.Request.BidRequest == nil {
+			return p, errors.New("payload contains a nil bid request")
+		}
+		if p.Request.User == nil || len(p.Request.User.EIDs) == 0 {
+			return p, nil
+		}
+		// the user object may be shared with the requests of other bidders
+		user := *p.Request.User
+		user.EIDs = nil
+		p.Request.User = &user
+		return p, nil
+	}, hs.MutationDelete, "bidrequest", "user", "eids")
+	return nil
+}
+
+func (ru *RemoveUserEids) Name() string {
+	return RemoveUserEidsName
+}
+
+// NewRemoveDeviceGeo is a factory function that creates a new RemoveDeviceGeo result function.
+// The function returns an error if there is an issue with the unmarshalling process or no bidders are specified.
+func NewRemoveDeviceGeo(params json.RawMessage) (BidderRequestResultFunc, error) {
+	removeDeviceGeoParams, err := newBiddersParams(RemoveDeviceGeoName, params)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveDeviceGeo{Args: removeDeviceGeoParams}, nil
+}
+
+// RemoveDeviceGeo is a struct that holds the bidders whose requests must not carry device.geo.
+type RemoveDeviceGeo struct {
+	Args config.ResultFuncParams
+}
+
+// Call adds a mutation that removes device.geo from the request when it is sent to one of the bidders.
+func (rd *RemoveDeviceGeo) Call(req *openrtb_ext.RequestWrapper, result *BidderRequestHookResult, meta rules.ResultFunctionMeta) error {
+	if !containsBidder(rd.Args.Bidders, result.Bidder) {
+		return nil
+	}
+	result.HookResult.ChangeSet.AddMutation(func(p hs.BidderRequestPayload) (hs.BidderRequestPayload, error) {
+		if p.Request == nil || p.Request.BidRequest == nil {
+			return p, errors.New("payload contains a nil bid request")
+		}
+		if p.Request.Device == nil || p.Request.Device.Geo == nil {
+			return p, nil
+		}
+		// the device object may be shared with the requests of other bidders
+		device := *p.Request.Device
+		device.Geo = nil
+		p.Request.Device = &device
+		return p, nil
+	}, hs.MutationDelete, "bidrequest", "device", "geo")
+	return nil
+}
+
+func (rd *RemoveDeviceGeo) Name() string {
+	return RemoveDeviceGeoName
+}
+
+func newLogATagParams(params json.RawMessage) (config.ResultFuncParams, error) {
+	var logATagParams config.ResultFuncParams
+	if err := jsonutil.Unmarshal(params, &logATagParams); err != nil {
+		return logATagParams, err
+	}
+	if len(logATagParams.AnalyticsValue) == 0 {
+		return logATagParams, errors.New("logATag requires an analytics value to be specified")
+	}
+	return logATagParams, nil
+}
+
+func newBiddersParams(funcName string, params json.RawMessage) (config.ResultFuncParams, error) {
+	var biddersParams config.ResultFuncParams
+	if err := jsonutil.Unmarshal(params, &biddersParams); err != nil {
+		return biddersParams, err
+	}
+	if len(biddersParams.Bidders) == 0 {
+		return biddersParams, fmt.Errorf("%s requires at least one bidder to be specified", funcName)
+	}
+	return biddersParams, nil
+}
+
+func containsBidder(bidders []string, bidder string) bool {
+	for _, b := range bidders {
+		if strings.EqualFold(b, bidder) {
+			return true
+		}
+	}
+	return false
+}
+
+func newBidAnalyticsResult(status hookanalytics.ResultStatus, funcName string, analyticsValue string, bid *rules.ProcessedBid, meta rules.ResultFunctionMeta) hookanalytics.Result {
+	result := newAnalyticsResult(status, funcName, analyticsValue, meta)
+	result.AppliedTo.Bidder = bid.Bidder
+	if bid.Bid.Bid != nil {
+		result.AppliedTo.BidIds = []string{bid.Bid.Bid.ID}
+	}
+	return result
+}
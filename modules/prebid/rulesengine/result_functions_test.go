@@ -5,11 +5,15 @@ import (
 	"testing"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
 	hs "github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewProcessedAuctionRequestResultFunction(t *testing.T) {
@@ -63,6 +67,89 @@ func TestNewProcessedAuctionRequestResultFunction(t *testing.T) {
 			funcName:  IncludeBiddersName,
 			params:    json.RawMessage(`invalid-json`),
 			expectErr: true,
+		}, {
+			name:       "valid_setBidFloor_bidfloor",
+			funcName:   SetBidFloorName,
+			params:     json.RawMessage(`{"impids":["imp1"],"bidfloor":1.5,"bidfloorcur":"EUR"}`),
+			expectType: &SetBidFloor{},
+		},
+		{
+			name:       "valid_setBidFloor_factor",
+			funcName:   SetBidFloorName,
+			params:     json.RawMessage(`{"factor":0.8}`),
+			expectType: &SetBidFloor{},
+		},
+		{
+			name:      "setBidFloor_without_bidfloor_or_factor",
+			funcName:  SetBidFloorName,
+			params:    json.RawMessage(`{"impids":["imp1"]}`),
+			expectErr: true,
+		},
+		{
+			name:      "setBidFloor_with_bidfloor_and_factor",
+			funcName:  SetBidFloorName,
+			params:    json.RawMessage(`{"bidfloor":1,"factor":2}`),
+			expectErr: true,
+		},
+		{
+			name:      "setBidFloor_negative_bidfloor",
+			funcName:  SetBidFloorName,
+			params:    json.RawMessage(`{"bidfloor":-1}`),
+			expectErr: true,
+		},
+		{
+			name:      "setBidFloor_zero_factor",
+			funcName:  SetBidFloorName,
+			params:    json.RawMessage(`{"factor":0}`),
+			expectErr: true,
+		},
+		{
+			name:      "setBidFloor_factor_with_currency",
+			funcName:  SetBidFloorName,
+			params:    json.RawMessage(`{"factor":2,"bidfloorcur":"EUR"}`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_setTmax",
+			funcName:   SetTmaxName,
+			params:     json.RawMessage(`{"tmax":300}`),
+			expectType: &SetTmax{},
+		},
+		{
+			name:      "setTmax_without_tmax",
+			funcName:  SetTmaxName,
+			params:    json.RawMessage(`{}`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_setTargeting",
+			funcName:   SetTargetingName,
+			params:     json.RawMessage(`{"includewinners":true}`),
+			expectType: &SetTargeting{},
+		},
+		{
+			name:      "setTargeting_without_options",
+			funcName:  SetTargetingName,
+			params:    json.RawMessage(`{}`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_logATag",
+			funcName:   LogATagName,
+			params:     json.RawMessage(`{"analyticsValue":"tag"}`),
+			expectType: &LogATag{},
+		},
+		{
+			name:      "logATag_without_analytics_value",
+			funcName:  LogATagName,
+			params:    json.RawMessage(`{}`),
+			expectErr: true,
+		},
+		{
+			name:      "bidder_request_function_not_allowed",
+			funcName:  RemoveUserEidsName,
+			params:    json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectErr: true,
 		},
 	}
 
@@ -207,3 +294,446 @@ func mockRequestWrapperWithBidders(t *testing.T, bidders []string) *openrtb_ext.
 
 	return rw
 }
+
+func TestNewBidderRequestResultFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		funcName   string
+		params     json.RawMessage
+		expectType BidderRequestResultFunc
+		expectErr  bool
+	}{
+		{
+			name:       "valid_excludeBidders",
+			funcName:   ExcludeBiddersName,
+			params:     json.RawMessage(`{"bidders":["bidder1"],"seatnonbid":301}`),
+			expectType: &BidderRequestExcludeBidders{},
+		},
+		{
+			name:       "valid_includeBidders",
+			funcName:   IncludeBiddersName,
+			params:     json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectType: &BidderRequestIncludeBidders{},
+		},
+		{
+			name:      "excludeBidders_empty_bidders",
+			funcName:  ExcludeBiddersName,
+			params:    json.RawMessage(`{"bidders":[]}`),
+			expectErr: true,
+		},
+		{
+			name:      "includeBidders_invalid_params",
+			funcName:  IncludeBiddersName,
+			params:    json.RawMessage(`invalid-json`),
+			expectErr: true,
+		},
+		{
+			name:      "bid_function_not_allowed",
+			funcName:  ExcludeBidName,
+			params:    json.RawMessage(`{}`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_removeUserEids",
+			funcName:   RemoveUserEidsName,
+			params:     json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectType: &RemoveUserEids{},
+		},
+		{
+			name:      "removeUserEids_empty_bidders",
+			funcName:  RemoveUserEidsName,
+			params:    json.RawMessage(`{"bidders":[]}`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_removeDeviceGeo",
+			funcName:   RemoveDeviceGeoName,
+			params:     json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectType: &RemoveDeviceGeo{},
+		},
+		{
+			name:      "removeDeviceGeo_invalid_params",
+			funcName:  RemoveDeviceGeoName,
+			params:    json.RawMessage(`invalid-json`),
+			expectErr: true,
+		},
+		{
+			name:       "valid_logATag",
+			funcName:   LogATagName,
+			params:     json.RawMessage(`{"analyticsValue":"tag"}`),
+			expectType: &BidderRequestLogATag{},
+		},
+		{
+			name:      "logATag_without_analytics_value",
+			funcName:  LogATagName,
+			params:    json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectErr: true,
+		},
+		{
+			name:      "request_function_not_allowed",
+			funcName:  SetTmaxName,
+			params:    json.RawMessage(`{"tmax":300}`),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewBidderRequestResultFunction(tt.funcName, tt.params)
+			if tt.expectErr {
+				assert.Error(t, err, "expected error but got nil")
+			} else {
+				assert.NoError(t, err)
+				assert.IsType(t, tt.expectType, v)
+				assert.Equal(t, tt.funcName, v.Name())
+			}
+		})
+	}
+}
+
+func TestNewAllProcessedBidResponsesResultFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		funcName   string
+		params     json.RawMessage
+		expectType AllProcessedBidResponsesResultFunc
+		expectErr  bool
+	}{
+		{
+			name:       "valid_excludeBid",
+			funcName:   ExcludeBidName,
+			params:     json.RawMessage(`{"analyticsvalue":"blocked"}`),
+			expectType: &ExcludeBid{},
+		},
+		{
+			name:       "excludeBid_without_params",
+			funcName:   ExcludeBidName,
+			params:     nil,
+			expectType: &ExcludeBid{},
+		},
+		{
+			name:       "valid_flagBid",
+			funcName:   FlagBidName,
+			params:     json.RawMessage(`{"analyticsvalue":"suspicious"}`),
+			expectType: &FlagBid{},
+		},
+		{
+			name:      "flagBid_without_analytics_value",
+			funcName:  FlagBidName,
+			params:    json.RawMessage(`{}`),
+			expectErr: true,
+		},
+		{
+			name:      "excludeBid_invalid_params",
+			funcName:  ExcludeBidName,
+			params:    json.RawMessage(`invalid-json`),
+			expectErr: true,
+		},
+		{
+			name:      "request_function_not_allowed",
+			funcName:  ExcludeBiddersName,
+			params:    json.RawMessage(`{"bidders":["bidder1"]}`),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewAllProcessedBidResponsesResultFunction(tt.funcName, tt.params)
+			if tt.expectErr {
+				assert.Error(t, err, "expected error but got nil")
+			} else {
+				assert.NoError(t, err)
+				assert.IsType(t, tt.expectType, v)
+				assert.Equal(t, tt.funcName, v.Name())
+			}
+		})
+	}
+}
+
+func TestBidderRequestExcludeBiddersCall(t *testing.T) {
+	eb := &BidderRequestExcludeBidders{Args: config.ResultFuncParams{Bidders: []string{"BidderA"}, SeatNonBid: 301}}
+
+	result := &BidderRequestHookResult{Bidder: "bidderA"}
+	assert.NoError(t, eb.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	assert.True(t, result.HookResult.Reject, "bidder names are case insensitive")
+	assert.Equal(t, 301, result.HookResult.NbrCode)
+
+	result = &BidderRequestHookResult{Bidder: "bidderB"}
+	assert.NoError(t, eb.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	assert.False(t, result.HookResult.Reject)
+}
+
+func TestExcludeBidCall(t *testing.T) {
+	eb := &ExcludeBid{}
+	result := &AllProcessedBidResponsesHookResult{ExcludedBids: make(map[*entities.PbsOrtbBid]struct{})}
+
+	assert.NoError(t, eb.Call(nil, result, rules.ResultFunctionMeta{}))
+	assert.Empty(t, result.ExcludedBids)
+
+	bid := &entities.PbsOrtbBid{}
+	assert.NoError(t, eb.Call(&rules.ProcessedBid{Bidder: "bidderA", Bid: bid}, result, rules.ResultFunctionMeta{RuleFired: "default"}))
+	assert.Contains(t, result.ExcludedBids, bid)
+	assert.Equal(t, []hookanalytics.Result{
+		{
+			Status: hookanalytics.ResultStatusBlock,
+			Values: map[string]interface{}{
+				"resultFunction": ExcludeBidName,
+				"analyticsKey":   "",
+				"analyticsValue": "",
+				"modelVersion":   "",
+				"ruleFired":      "default",
+			},
+			AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderA"},
+		},
+	}, result.AnalyticsResults)
+}
+
+func TestSetBidFloorCall(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         json.RawMessage
+		expectedFloors []float64
+		expectedCurs   []string
+	}{
+		{
+			name:           "set_floor_of_all_imps",
+			params:         json.RawMessage(`{"bidfloor":2,"bidfloorcur":"EUR"}`),
+			expectedFloors: []float64{2, 2},
+			expectedCurs:   []string{"EUR", "EUR"},
+		},
+		{
+			name:           "set_floor_of_listed_imps",
+			params:         json.RawMessage(`{"impids":["imp2"],"bidfloor":2}`),
+			expectedFloors: []float64{1, 2},
+			expectedCurs:   []string{"USD", "USD"},
+		},
+		{
+			name:           "scale_floor_of_all_imps",
+			params:         json.RawMessage(`{"factor":0.5}`),
+			expectedFloors: []float64{0.5, 1.5},
+			expectedCurs:   []string{"USD", "USD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sf, err := NewSetBidFloor(tt.params)
+			require.NoError(t, err)
+
+			result := &ProcessedAuctionHookResult{}
+			require.NoError(t, sf.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+			require.Len(t, result.HookResult.ChangeSet.Mutations(), 1)
+
+			mutation := result.HookResult.ChangeSet.Mutations()[0]
+			assert.Equal(t, hs.MutationUpdate, mutation.Type())
+
+			payload := hs.ProcessedAuctionRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{
+					BidRequest: &openrtb2.BidRequest{
+						Imp: []openrtb2.Imp{
+							{ID: "imp1", BidFloor: 1, BidFloorCur: "USD"},
+							{ID: "imp2", BidFloor: 3, BidFloorCur: "USD"},
+						},
+					},
+				},
+			}
+			payload, err = mutation.Apply(payload)
+			require.NoError(t, err)
+
+			imps := payload.Request.GetImp()
+			require.Len(t, imps, 2)
+			for i, imp := range imps {
+				assert.Equal(t, tt.expectedFloors[i], imp.BidFloor)
+				assert.Equal(t, tt.expectedCurs[i], imp.BidFloorCur)
+			}
+		})
+	}
+}
+
+func TestSetTmaxCall(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestTmax  int64
+		expectedTmax int64
+	}{
+		{
+			name:         "longer_tmax_is_shortened",
+			requestTmax:  1000,
+			expectedTmax: 300,
+		},
+		{
+			name:         "missing_tmax_is_set",
+			requestTmax:  0,
+			expectedTmax: 300,
+		},
+		{
+			name:         "shorter_tmax_is_kept",
+			requestTmax:  200,
+			expectedTmax: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &SetTmax{Args: config.SetTmaxParams{Tmax: 300}}
+
+			result := &ProcessedAuctionHookResult{}
+			require.NoError(t, st.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+			require.Len(t, result.HookResult.ChangeSet.Mutations(), 1)
+
+			payload := hs.ProcessedAuctionRequestPayload{
+				Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{TMax: tt.requestTmax}},
+			}
+			payload, err := result.HookResult.ChangeSet.Mutations()[0].Apply(payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTmax, payload.Request.TMax)
+		})
+	}
+}
+
+func TestSetTargetingCall(t *testing.T) {
+	st, err := NewSetTargeting(json.RawMessage(`{"includewinners":false,"preferdeals":true,"prefix":"pb"}`))
+	require.NoError(t, err)
+
+	result := &ProcessedAuctionHookResult{}
+	require.NoError(t, st.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	require.Len(t, result.HookResult.ChangeSet.Mutations(), 1)
+
+	payload := hs.ProcessedAuctionRequestPayload{
+		Request: &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				Ext: json.RawMessage(`{"prebid":{"targeting":{"includewinners":true,"includeformat":true}}}`),
+			},
+		},
+	}
+	payload, err = result.HookResult.ChangeSet.Mutations()[0].Apply(payload)
+	require.NoError(t, err)
+
+	reqExt, err := payload.Request.GetRequestExt()
+	require.NoError(t, err)
+	assert.Equal(t, &openrtb_ext.ExtRequestTargeting{
+		IncludeWinners: ptrutil.ToPtr(false),
+		IncludeFormat:  true,
+		PreferDeals:    true,
+		Prefix:         "pb",
+	}, reqExt.GetPrebid().Targeting)
+}
+
+func TestLogATagCall(t *testing.T) {
+	lt := &LogATag{Args: config.ResultFuncParams{AnalyticsValue: "tag"}}
+	meta := rules.ResultFunctionMeta{AnalyticsKey: "key", ModelVersion: "1.0", RuleFired: "USA"}
+
+	result := &ProcessedAuctionHookResult{}
+	assert.NoError(t, lt.Call(&openrtb_ext.RequestWrapper{}, result, meta))
+	assert.Empty(t, result.HookResult.ChangeSet.Mutations())
+	assert.Equal(t, []hookanalytics.Result{
+		{
+			Status: hookanalytics.ResultStatusAllow,
+			Values: map[string]interface{}{
+				"resultFunction": LogATagName,
+				"analyticsKey":   "key",
+				"analyticsValue": "tag",
+				"modelVersion":   "1.0",
+				"ruleFired":      "USA",
+			},
+			AppliedTo: hookanalytics.AppliedTo{Request: true},
+		},
+	}, result.AnalyticsResults)
+}
+
+func TestBidderRequestLogATagCall(t *testing.T) {
+	lt := &BidderRequestLogATag{Args: config.ResultFuncParams{AnalyticsValue: "tag"}}
+
+	result := &BidderRequestHookResult{Bidder: "bidderA"}
+	assert.NoError(t, lt.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{RuleFired: "default"}))
+	assert.Empty(t, result.HookResult.ChangeSet.Mutations())
+	assert.False(t, result.HookResult.Reject)
+	assert.Equal(t, []hookanalytics.Result{
+		{
+			Status: hookanalytics.ResultStatusAllow,
+			Values: map[string]interface{}{
+				"resultFunction": LogATagName,
+				"analyticsKey":   "",
+				"analyticsValue": "tag",
+				"modelVersion":   "",
+				"ruleFired":      "default",
+			},
+			AppliedTo: hookanalytics.AppliedTo{Bidder: "bidderA", Request: true},
+		},
+	}, result.AnalyticsResults)
+}
+
+func TestRemoveUserEidsCall(t *testing.T) {
+	ru := &RemoveUserEids{Args: config.ResultFuncParams{Bidders: []string{"BidderA"}}}
+
+	result := &BidderRequestHookResult{Bidder: "bidderB"}
+	assert.NoError(t, ru.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	assert.Empty(t, result.HookResult.ChangeSet.Mutations())
+
+	result = &BidderRequestHookResult{Bidder: "bidderA"}
+	assert.NoError(t, ru.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	require.Len(t, result.HookResult.ChangeSet.Mutations(), 1)
+
+	mutation := result.HookResult.ChangeSet.Mutations()[0]
+	assert.Equal(t, hs.MutationDelete, mutation.Type())
+
+	sharedUser := &openrtb2.User{ID: "user1", EIDs: []openrtb2.EID{{Source: "source.com"}}}
+	payload := hs.BidderRequestPayload{
+		Bidder:  "bidderA",
+		Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: sharedUser}},
+	}
+	payload, err := mutation.Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, &openrtb2.User{ID: "user1"}, payload.Request.User)
+	assert.Len(t, sharedUser.EIDs, 1, "user shared with other bidder requests must not be changed")
+}
+
+func TestRemoveDeviceGeoCall(t *testing.T) {
+	rd := &RemoveDeviceGeo{Args: config.ResultFuncParams{Bidders: []string{"bidderA"}}}
+
+	result := &BidderRequestHookResult{Bidder: "bidderB"}
+	assert.NoError(t, rd.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	assert.Empty(t, result.HookResult.ChangeSet.Mutations())
+
+	result = &BidderRequestHookResult{Bidder: "bidderA"}
+	assert.NoError(t, rd.Call(&openrtb_ext.RequestWrapper{}, result, rules.ResultFunctionMeta{}))
+	require.Len(t, result.HookResult.ChangeSet.Mutations(), 1)
+
+	mutation := result.HookResult.ChangeSet.Mutations()[0]
+	assert.Equal(t, hs.MutationDelete, mutation.Type())
+
+	sharedDevice := &openrtb2.Device{IP: "1.2.3.4", Geo: &openrtb2.Geo{Country: "USA"}}
+	payload := hs.BidderRequestPayload{
+		Bidder:  "bidderA",
+		Request: &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Device: sharedDevice}},
+	}
+	payload, err := mutation.Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, &openrtb2.Device{IP: "1.2.3.4"}, payload.Request.Device)
+	assert.NotNil(t, sharedDevice.Geo, "device shared with other bidder requests must not be changed")
+
+	_, err = mutation.Apply(hs.BidderRequestPayload{Bidder: "bidderA"})
+	assert.EqualError(t, err, "payload contains a nil bid request")
+}
+
+func TestNewResultFunctionsName(t *testing.T) {
+	tests := []struct {
+		expectedName string
+		name         func() string
+	}{
+		{expectedName: SetBidFloorName, name: (&SetBidFloor{}).Name},
+		{expectedName: SetTmaxName, name: (&SetTmax{}).Name},
+		{expectedName: SetTargetingName, name: (&SetTargeting{}).Name},
+		{expectedName: LogATagName, name: (&LogATag{}).Name},
+		{expectedName: LogATagName, name: (&BidderRequestLogATag{}).Name},
+		{expectedName: RemoveUserEidsName, name: (&RemoveUserEids{}).Name},
+		{expectedName: RemoveDeviceGeoName, name: (&RemoveDeviceGeo{}).Name},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedName, func(t *testing.T) {
+			assert.Equal(t, tt.expectedName, tt.name())
+		})
+	}
+}
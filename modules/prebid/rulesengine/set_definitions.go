@@ -0,0 +1,96 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// setDefinitionArg names the args key of an In schema function whose values can refer to
+// set definition groups, along with the groups they can refer to
+type setDefinitionArg struct {
+	key    string
+	groups map[string][]string
+}
+
+// newSetDefinitionArgs maps each In schema function to the set definition groups of its kind.
+// Country groups include the default country groups, as they do for bidder config rule sets.
+func newSetDefinitionArgs(setDefinitions config.SetDefinitions) map[string]setDefinitionArg {
+	return map[string]setDefinitionArg{
+		rules.AdUnitCodeIn:    {key: "codes", groups: setDefinitions.AdUnitGroups},
+		rules.BidderIn:        {key: "bidders", groups: setDefinitions.BidderGroups},
+		rules.BrowserIn:       {key: "browsers", groups: setDefinitions.BrowserGroups},
+		rules.BundleIn:        {key: "bundles", groups: setDefinitions.BundleGroups},
+		rules.BuyerUidIn:      {key: "bidders", groups: setDefinitions.BidderGroups},
+		rules.DayOfWeekIn:     {key: "days", groups: setDefinitions.DayGroups},
+		rules.DeviceCountryIn: {key: "countries", groups: mergeCountryGroups(defaultCountryGroups, setDefinitions.CountryGroups)},
+		rules.DeviceOsIn:      {key: "oses", groups: setDefinitions.OsGroups},
+		rules.DeviceTypeIn:    {key: "types", groups: setDefinitions.DeviceTypeGroups},
+		rules.DomainIn:        {key: "domains", groups: setDefinitions.DomainGroups},
+		rules.ImpMediaTypeIn:  {key: "types", groups: setDefinitions.MediaTypeGroups},
+		rules.MediaTypeIn:     {key: "types", groups: setDefinitions.MediaTypeGroups},
+		rules.PublisherIdIn:   {key: "pubids", groups: setDefinitions.PublisherGroups},
+	}
+}
+
+// withSetDefinitions wraps a schema function factory so the group names listed in the args of In
+// schema functions are replaced with the members of those groups before the schema function is created
+func withSetDefinitions[T any](setDefinitions config.SetDefinitions, factory rules.SchemaFuncFactory[T]) rules.SchemaFuncFactory[T] {
+	setDefinitionArgs := newSetDefinitionArgs(setDefinitions)
+
+	return func(name string, params json.RawMessage) (rules.SchemaFunction[T], error) {
+		if arg, found := setDefinitionArgs[name]; found && len(arg.groups) > 0 {
+			expandedParams, err := expandSetDefinitionGroups(params, arg)
+			if err != nil {
+				return nil, err
+			}
+			params = expandedParams
+		}
+		return factory(name, params)
+	}
+}
+
+// expandSetDefinitionGroups replaces the values of the args key that name a group with the group members
+func expandSetDefinitionGroups(params json.RawMessage, arg setDefinitionArg) (json.RawMessage, error) {
+	if len(params) == 0 {
+		return params, nil
+	}
+
+	var args map[string]json.RawMessage
+	if err := jsonutil.Unmarshal(params, &args); err != nil {
+		return nil, err
+	}
+
+	for key, rawValues := range args {
+		// args keys are case insensitive, as they are when the schema function args are unmarshalled
+		if !strings.EqualFold(key, arg.key) {
+			continue
+		}
+
+		var values []string
+		if err := jsonutil.Unmarshal(rawValues, &values); err != nil {
+			return nil, err
+		}
+
+		expandedValues := make([]string, 0, len(values))
+		for _, value := range values {
+			if members, isGroup := arg.groups[value]; isGroup {
+				expandedValues = append(expandedValues, members...)
+			} else {
+				expandedValues = append(expandedValues, value)
+			}
+		}
+
+		expandedRawValues, err := jsonutil.Marshal(expandedValues)
+		if err != nil {
+			return nil, err
+		}
+		args[key] = expandedRawValues
+		return jsonutil.Marshal(args)
+	}
+
+	return params, nil
+}
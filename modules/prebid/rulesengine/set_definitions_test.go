@@ -0,0 +1,166 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSetDefinitionGroups(t *testing.T) {
+	arg := setDefinitionArg{
+		key: "domains",
+		groups: map[string][]string{
+			"news":   {"a.com", "b.com"},
+			"sports": {"c.com"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		params         json.RawMessage
+		expectedParams json.RawMessage
+		expectedErr    bool
+	}{
+		{
+			name:           "nil-params",
+			params:         nil,
+			expectedParams: nil,
+		},
+		{
+			name:           "groups-and-values",
+			params:         json.RawMessage(`{"domains": ["news", "d.com", "sports"]}`),
+			expectedParams: json.RawMessage(`{"domains": ["a.com", "b.com", "d.com", "c.com"]}`),
+		},
+		{
+			name:           "args-key-is-case-insensitive",
+			params:         json.RawMessage(`{"Domains": ["sports"], "other": 1}`),
+			expectedParams: json.RawMessage(`{"Domains": ["c.com"], "other": 1}`),
+		},
+		{
+			name:           "no-args-key",
+			params:         json.RawMessage(`{"other": ["news"]}`),
+			expectedParams: json.RawMessage(`{"other": ["news"]}`),
+		},
+		{
+			name:        "invalid-args-key-values",
+			params:      json.RawMessage(`{"domains": "news"}`),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid-params",
+			params:      json.RawMessage(`[`),
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := expandSetDefinitionGroups(tt.params, arg)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedParams == nil {
+				assert.Nil(t, params)
+			} else {
+				assert.JSONEq(t, string(tt.expectedParams), string(params))
+			}
+		})
+	}
+}
+
+func TestWithSetDefinitions(t *testing.T) {
+	setDefinitions := config.SetDefinitions{
+		CountryGroups: map[string][]string{"NORTH_AMERICA": {"USA", "CAN"}},
+		DomainGroups:  map[string][]string{"news": {"news.example.com"}},
+		BidderGroups:  map[string][]string{"premium": {"bidderA", "bidderB"}},
+	}
+	requestFactory := withSetDefinitions(setDefinitions, rules.NewRequestSchemaFunction)
+	bidFactory := withSetDefinitions(setDefinitions, rules.NewBidSchemaFunction)
+
+	newRequest := func(country, domain string) *openrtb_ext.RequestWrapper {
+		return &openrtb_ext.RequestWrapper{
+			BidRequest: &openrtb2.BidRequest{
+				Site:   &openrtb2.Site{Domain: domain},
+				Device: &openrtb2.Device{Geo: &openrtb2.Geo{Country: country}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		funcName       string
+		params         json.RawMessage
+		request        *openrtb_ext.RequestWrapper
+		expectedResult string
+	}{
+		{
+			name:           "account-country-group",
+			funcName:       rules.DeviceCountryIn,
+			params:         json.RawMessage(`{"countries": ["NORTH_AMERICA"]}`),
+			request:        newRequest("CAN", ""),
+			expectedResult: "true",
+		},
+		{
+			name:           "default-country-group",
+			funcName:       rules.DeviceCountryIn,
+			params:         json.RawMessage(`{"countries": ["EEA"]}`),
+			request:        newRequest("FRA", ""),
+			expectedResult: "true",
+		},
+		{
+			name:           "country-not-in-group",
+			funcName:       rules.DeviceCountryIn,
+			params:         json.RawMessage(`{"countries": ["EEA", "JPN"]}`),
+			request:        newRequest("USA", ""),
+			expectedResult: "false",
+		},
+		{
+			name:           "domain-group",
+			funcName:       rules.DomainIn,
+			params:         json.RawMessage(`{"domains": ["news"]}`),
+			request:        newRequest("", "news.example.com"),
+			expectedResult: "true",
+		},
+		{
+			name:           "function-without-groups",
+			funcName:       rules.BundleIn,
+			params:         json.RawMessage(`{"bundles": ["news"]}`),
+			request:        newRequest("", ""),
+			expectedResult: "false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaFunc, err := requestFactory(tt.funcName, tt.params)
+			require.NoError(t, err)
+
+			result, err := schemaFunc.Call(tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+
+	t.Run("bidder-group", func(t *testing.T) {
+		schemaFunc, err := bidFactory(rules.BidderIn, json.RawMessage(`{"bidders": ["premium"]}`))
+		require.NoError(t, err)
+
+		result, err := schemaFunc.Call(&rules.ProcessedBid{Bidder: "bidderB", Bid: &entities.PbsOrtbBid{}})
+		assert.NoError(t, err)
+		assert.Equal(t, "true", result)
+	})
+
+	t.Run("invalid-args", func(t *testing.T) {
+		_, err := requestFactory(rules.DomainIn, json.RawMessage(`{"domains": "news"}`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package rulesengine
+
+import (
+	"encoding/json"
+
+	"github.com/prebid/prebid-server/v3/modules/prebid/rulesengine/config"
+	"github.com/prebid/prebid-server/v3/rules"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+// firedResult is a result function that a rule set ran, or would have run in shadow mode
+type firedResult struct {
+	ruleSet        string
+	shadow         bool
+	funcName       string
+	analyticsValue string
+	args           json.RawMessage
+	meta           rules.ResultFunctionMeta
+	bid            *rules.ProcessedBid
+}
+
+// firedResultRecorder is implemented by the hook results to collect the result functions fired by
+// the rule sets of a hook invocation so they can be counted the same way in live and shadow mode
+type firedResultRecorder interface {
+	recordFiredResult(fired firedResult)
+}
+
+// recordingResultFunction wraps a result function of a rule set to record each time it fires.
+// In shadow mode the wrapped function is not called so the auction is left unchanged.
+type recordingResultFunction[T1 any, T2 any] struct {
+	rules.ResultFunction[T1, T2]
+	ruleSet        string
+	shadow         bool
+	analyticsValue string
+	args           json.RawMessage
+}
+
+// withFiredResultRecording wraps a result function factory so the result functions it creates
+// record when they fire for the named rule set
+func withFiredResultRecording[T1 any, T2 any](ruleSet string, shadow bool, factory rules.ResultFuncFactory[T1, T2]) rules.ResultFuncFactory[T1, T2] {
+	return func(name string, params json.RawMessage) (rules.ResultFunction[T1, T2], error) {
+		resultFunc, err := factory(name, params)
+		if err != nil {
+			return nil, err
+		}
+
+		return &recordingResultFunction[T1, T2]{
+			ResultFunction: resultFunc,
+			ruleSet:        ruleSet,
+			shadow:         shadow,
+			analyticsValue: getAnalyticsValue(params),
+			args:           params,
+		}, nil
+	}
+}
+
+// Call records the wrapped result function as fired and calls it unless the rule set is in shadow mode
+func (rf *recordingResultFunction[T1, T2]) Call(payload *T1, result *T2, meta rules.ResultFunctionMeta) error {
+	if recorder, ok := any(result).(firedResultRecorder); ok {
+		fired := firedResult{
+			ruleSet:        rf.ruleSet,
+			shadow:         rf.shadow,
+			funcName:       rf.Name(),
+			analyticsValue: rf.analyticsValue,
+			args:           rf.args,
+			meta:           meta,
+		}
+		if bid, ok := any(payload).(*rules.ProcessedBid); ok {
+			fired.bid = bid
+		}
+		recorder.recordFiredResult(fired)
+	}
+
+	if rf.shadow {
+		return nil
+	}
+	return rf.ResultFunction.Call(payload, result, meta)
+}
+
+// getAnalyticsValue returns the analytics value of the result function args, if any
+func getAnalyticsValue(params json.RawMessage) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var args config.ResultFuncParams
+	if err := jsonutil.Unmarshal(params, &args); err != nil {
+		return ""
+	}
+	return args.AnalyticsValue
+}
+
+func (r *ProcessedAuctionHookResult) recordFiredResult(fired firedResult) {
+	r.firedResults = append(r.firedResults, fired)
+	if fired.shadow {
+		analyticsResult := newShadowAnalyticsResult(fired)
+		analyticsResult.AppliedTo.Request = true
+		r.AnalyticsResults = append(r.AnalyticsResults, analyticsResult)
+	}
+}
+
+func (r *BidderRequestHookResult) recordFiredResult(fired firedResult) {
+	r.firedResults = append(r.firedResults, fired)
+	if fired.shadow {
+		analyticsResult := newShadowAnalyticsResult(fired)
+		analyticsResult.AppliedTo.Bidder = r.Bidder
+		analyticsResult.AppliedTo.Request = true
+		r.AnalyticsResults = append(r.AnalyticsResults, analyticsResult)
+	}
+}
+
+func (r *AllProcessedBidResponsesHookResult) recordFiredResult(fired firedResult) {
+	r.firedResults = append(r.firedResults, fired)
+	if fired.shadow {
+		analyticsResult := newShadowAnalyticsResult(fired)
+		if fired.bid != nil {
+			analyticsResult.AppliedTo.Bidder = fired.bid.Bidder
+			if fired.bid.Bid != nil && fired.bid.Bid.Bid != nil {
+				analyticsResult.AppliedTo.BidIds = []string{fired.bid.Bid.Bid.ID}
+			}
+		}
+		r.AnalyticsResults = append(r.AnalyticsResults, analyticsResult)
+	}
+}
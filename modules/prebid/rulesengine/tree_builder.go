@@ -33,6 +33,8 @@ func (tb *treeBuilder[T1, T2]) Build(tree *rules.Tree[T1, T2]) error {
 		return err
 	}
 	tree.DefaultFunctions = defaultFunctions
+	tree.AnalyticsKey = tb.Config.AnalyticsKey
+	tree.ModelVersion = tb.Config.Version
 
 	for _, rule := range tb.Config.Rules {
 		for ci, condition := range rule.Conditions {
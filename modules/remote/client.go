@@ -0,0 +1,94 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+type hookClient interface {
+	Call(ctx context.Context, request Request) (Response, error)
+}
+
+type httpClient struct {
+	client *http.Client
+	url    string
+}
+
+func newClient(cfg *Config) (hookClient, error) {
+	var client *http.Client
+	var callURL string
+
+	switch cfg.Transport {
+	case TransportUDS:
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", cfg.BasePath)
+				},
+				MaxIdleConns:        30,
+				MaxIdleConnsPerHost: 30,
+				IdleConnTimeout:     60 * time.Second,
+			},
+		}
+		callURL = "http://unix/" + cfg.RequestPath
+
+	case TransportTCP:
+		client = &http.Client{}
+		callURL = cfg.BasePath + "/" + cfg.RequestPath
+
+	default:
+		return nil, fmt.Errorf("unsupported transport type: %s", cfg.Transport)
+	}
+
+	return &httpClient{
+		client: client,
+		url:    callURL,
+	}, nil
+}
+
+func (c *httpClient) Call(ctx context.Context, request Request) (Response, error) {
+	var response Response
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return response, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return response, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return response, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return response, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return response, fmt.Errorf("invalid JSON from remote module: %w", err)
+	}
+
+	if response.Version != SchemaVersion {
+		return response, fmt.Errorf("unsupported schema version %d (expected %d)", response.Version, SchemaVersion)
+	}
+
+	return response, nil
+}
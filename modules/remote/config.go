@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+type TransportType string
+
+const (
+	TransportUDS TransportType = "uds"
+	TransportTCP TransportType = "tcp"
+)
+
+// Config is the "remote" section of the config of a module which is not compiled into the server.
+type Config struct {
+	Transport   TransportType `json:"transport"`
+	BasePath    string        `json:"base_path"`
+	RequestPath string        `json:"request_path"`
+	// Stages lists the stages the remote process provides hooks for
+	Stages []hooks.Stage `json:"stages"`
+}
+
+func NewConfig(rawConfig json.RawMessage) (*Config, error) {
+	cfg := &Config{}
+
+	if err := jsonutil.UnmarshalValid(rawConfig, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	switch cfg.Transport {
+	case TransportUDS, TransportTCP:
+	default:
+		return nil, fmt.Errorf("invalid transport: %s (must be 'uds' or 'tcp')", cfg.Transport)
+	}
+
+	if cfg.BasePath == "" {
+		return nil, fmt.Errorf("base_path is required")
+	}
+
+	if cfg.RequestPath == "" {
+		return nil, fmt.Errorf("request_path is required")
+	}
+
+	if len(cfg.Stages) == 0 {
+		return nil, fmt.Errorf("stages is required")
+	}
+
+	for _, stage := range cfg.Stages {
+		if !slices.Contains(hooks.Stages, stage) {
+			return nil, fmt.Errorf("stage %q is not a known stage", stage)
+		}
+	}
+
+	return cfg, nil
+}
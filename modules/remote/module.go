@@ -0,0 +1,219 @@
+// Package remote provides hook modules which are not compiled into the server. A remote module forwards
+// the payload of the stages it is configured for to an external process over HTTP on a Unix domain socket
+// or TCP, and turns the response of the process into the hook result, with mutations sent back as a changeset.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+)
+
+// Builder returns the remote module registered under id, configured by the "remote" section of its config.
+func Builder(id string, rawConfig json.RawMessage, _ moduledeps.ModuleDeps) (interface{}, error) {
+	cfg, err := NewConfig(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return Module{
+		id:     id,
+		stages: cfg.Stages,
+		client: client,
+	}, nil
+}
+
+// Module implements every hook interface, but only provides the hooks of its configured stages.
+type Module struct {
+	id     string
+	stages []hooks.Stage
+	client hookClient
+}
+
+func (m Module) ProvidesStage(stage hooks.Stage) bool {
+	return slices.Contains(m.stages, stage)
+}
+
+func (m Module) HandleEntrypointHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.EntrypointPayload,
+) (hookstage.HookResult[hookstage.EntrypointPayload], error) {
+	wirePayload := entrypointPayload{Body: validJSON(payload.Body)}
+	if payload.Request != nil {
+		wirePayload.Method = payload.Request.Method
+		wirePayload.Path = payload.Request.URL.Path
+		wirePayload.Query = payload.Request.URL.RawQuery
+		wirePayload.Headers = payload.Request.Header.Clone()
+		wirePayload.Headers.Del("Cookie")
+		wirePayload.Headers.Del("Authorization")
+	}
+
+	return handle(ctx, m, miCtx, hooks.StageEntrypoint, wirePayload, addEntrypointMutation)
+}
+
+func (m Module) HandleRawAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.RawAuctionRequestPayload], error) {
+	wirePayload := rawAuctionRequestPayload{Body: validJSON(payload)}
+
+	return handle(ctx, m, miCtx, hooks.StageRawAuctionRequest, wirePayload, addRawAuctionRequestMutation)
+}
+
+func (m Module) HandleProcessedAuctionHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ProcessedAuctionRequestPayload,
+) (hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload], error) {
+	if payload.Request == nil || payload.Request.BidRequest == nil {
+		return hookstage.HookResult[hookstage.ProcessedAuctionRequestPayload]{}, hookexecution.NewFailure("payload contains a nil bid request")
+	}
+	wirePayload := bidRequestPayload{Request: payload.Request.BidRequest}
+
+	return handle(ctx, m, miCtx, hooks.StageProcessedAuctionRequest, wirePayload, addProcessedAuctionRequestMutation)
+}
+
+func (m Module) HandleBidderRequestHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.BidderRequestPayload,
+) (hookstage.HookResult[hookstage.BidderRequestPayload], error) {
+	if payload.Request == nil || payload.Request.BidRequest == nil {
+		return hookstage.HookResult[hookstage.BidderRequestPayload]{}, hookexecution.NewFailure("payload contains a nil bid request")
+	}
+	wirePayload := bidRequestPayload{Bidder: payload.Bidder, Request: payload.Request.BidRequest}
+
+	return handle(ctx, m, miCtx, hooks.StageBidderRequest, wirePayload, addBidderRequestMutation)
+}
+
+func (m Module) HandleRawBidderResponseHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.RawBidderResponsePayload,
+) (hookstage.HookResult[hookstage.RawBidderResponsePayload], error) {
+	if payload.BidderResponse == nil {
+		return hookstage.HookResult[hookstage.RawBidderResponsePayload]{}, hookexecution.NewFailure("payload contains a nil bidder response")
+	}
+	wirePayload := rawBidderResponsePayload{
+		Bidder:   payload.Bidder,
+		Currency: payload.BidderResponse.Currency,
+		Bids:     newTypedBids(payload.BidderResponse.Bids),
+	}
+
+	return handle(ctx, m, miCtx, hooks.StageRawBidderResponse, wirePayload, addRawBidderResponseMutation)
+}
+
+func (m Module) HandleAllProcessedBidResponsesHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.AllProcessedBidResponsesPayload,
+) (hookstage.HookResult[hookstage.AllProcessedBidResponsesPayload], error) {
+	wirePayload := allProcessedBidResponsesPayload{Responses: newSeatBids(payload.Responses)}
+
+	return handle(ctx, m, miCtx, hooks.StageAllProcessedBidResponses, wirePayload, addAllProcessedBidResponsesMutation)
+}
+
+func (m Module) HandleAuctionResponseHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.AuctionResponsePayload,
+) (hookstage.HookResult[hookstage.AuctionResponsePayload], error) {
+	if payload.BidResponse == nil {
+		return hookstage.HookResult[hookstage.AuctionResponsePayload]{}, hookexecution.NewFailure("payload contains a nil bid response")
+	}
+	wirePayload := bidResponsePayload{Response: payload.BidResponse}
+
+	return handle(ctx, m, miCtx, hooks.StageAuctionResponse, wirePayload, addAuctionResponseMutation)
+}
+
+func (m Module) HandleExitpointHook(
+	ctx context.Context,
+	miCtx hookstage.ModuleInvocationContext,
+	payload hookstage.ExitpointPayload,
+) (hookstage.HookResult[hookstage.ExitpointPayload], error) {
+	wirePayload := exitpointPayload{Response: payload.Response}
+
+	return handle(ctx, m, miCtx, hooks.StageExitpoint, wirePayload, addExitpointMutation)
+}
+
+// handle sends the payload of the stage to the remote process and converts its response into the hook result.
+// Mutations which can't be added to the changeset are reported as warnings, the others are applied by the
+// executor like the mutations of any other module. A failed call is a failure of the hook.
+func handle[T any](
+	ctx context.Context,
+	m Module,
+	miCtx hookstage.ModuleInvocationContext,
+	stage hooks.Stage,
+	payload interface{},
+	addMutation func(*hookstage.ChangeSet[T], Mutation) error,
+) (hookstage.HookResult[T], error) {
+	result := hookstage.HookResult[T]{}
+
+	response, err := m.client.Call(ctx, Request{
+		Version:            SchemaVersion,
+		Stage:              stage,
+		Module:             m.id,
+		HookImplCode:       miCtx.HookImplCode,
+		Endpoint:           miCtx.Endpoint,
+		AccountID:          miCtx.AccountID,
+		AccountConfig:      miCtx.AccountConfig,
+		ModuleContext:      miCtx.ModuleContext,
+		DependencyContexts: miCtx.DependencyContexts,
+		Payload:            payload,
+	})
+	if err != nil {
+		return result, hookexecution.NewFailure("remote module %s: %v", m.id, err)
+	}
+
+	result.Reject = response.Reject
+	result.NbrCode = response.NbrCode
+	result.Message = response.Message
+	result.Errors = response.Errors
+	result.Warnings = response.Warnings
+	result.DebugMessages = response.DebugMessages
+	result.AnalyticsTags = response.AnalyticsTags
+	result.ModuleContext = response.ModuleContext
+
+	for _, mutation := range response.ChangeSet {
+		err := checkMutation(mutation)
+		if err == nil {
+			err = addMutation(&result.ChangeSet, mutation)
+		}
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ignored %s mutation of %q: %v", mutation.Type, mutation.Key, err))
+		}
+	}
+
+	return result, nil
+}
+
+func checkMutation(mutation Mutation) error {
+	if mutation.Type != mutationTypeUpdate {
+		return fmt.Errorf("unsupported mutation type")
+	}
+	if len(mutation.Value) == 0 {
+		return fmt.Errorf("value is required")
+	}
+	return nil
+}
+
+// validJSON returns the body as raw JSON, or nil when it isn't valid JSON
+func validJSON(body []byte) json.RawMessage {
+	if !json.Valid(body) {
+		return nil
+	}
+	return body
+}
@@ -0,0 +1,371 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/modules/moduledeps"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name:   "valid UDS config",
+			config: `{"transport": "uds", "base_path": "/var/run/partner.sock", "request_path": "hooks", "stages": ["entrypoint", "exitpoint"]}`,
+		},
+		{
+			name:   "valid TCP config",
+			config: `{"transport": "tcp", "base_path": "http://localhost:8080", "request_path": "hooks", "stages": ["bidder_request"]}`,
+		},
+		{
+			name:        "invalid transport",
+			config:      `{"transport": "grpc", "base_path": "localhost:8080", "request_path": "hooks", "stages": ["bidder_request"]}`,
+			expectedErr: "invalid transport: grpc (must be 'uds' or 'tcp')",
+		},
+		{
+			name:        "missing base_path",
+			config:      `{"transport": "tcp", "request_path": "hooks", "stages": ["bidder_request"]}`,
+			expectedErr: "base_path is required",
+		},
+		{
+			name:        "missing request_path",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "stages": ["bidder_request"]}`,
+			expectedErr: "request_path is required",
+		},
+		{
+			name:        "missing stages",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "hooks"}`,
+			expectedErr: "stages is required",
+		},
+		{
+			name:        "unknown stage",
+			config:      `{"transport": "tcp", "base_path": "localhost:8080", "request_path": "hooks", "stages": ["bidder_requests"]}`,
+			expectedErr: `stage "bidder_requests" is not a known stage`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			module, err := Builder("partner.remote", json.RawMessage(test.config), moduledeps.ModuleDeps{})
+			if len(test.expectedErr) > 0 {
+				assert.EqualError(t, err, test.expectedErr)
+				assert.Nil(t, module)
+			} else {
+				assert.NoError(t, err)
+				assert.IsType(t, Module{}, module)
+			}
+		})
+	}
+}
+
+func TestModuleProvidesConfiguredStages(t *testing.T) {
+	module, err := Builder("partner.remote", json.RawMessage(`{"transport": "tcp", "base_path": "localhost:8080", "request_path": "hooks", "stages": ["raw_auction_request", "exitpoint"]}`), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+
+	repo, err := hooks.NewHookRepository(map[string]interface{}{"partner.remote": module})
+	require.NoError(t, err)
+
+	_, found := repo.GetRawAuctionHook("partner.remote")
+	assert.True(t, found)
+	_, found = repo.GetExitpointHook("partner.remote")
+	assert.True(t, found)
+	_, found = repo.GetEntrypointHook("partner.remote")
+	assert.False(t, found)
+	_, found = repo.GetBidderRequestHook("partner.remote")
+	assert.False(t, found)
+}
+
+// newTestModule returns a module calling the handler over TCP
+func newTestModule(t *testing.T, handler http.HandlerFunc) Module {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := newClient(&Config{Transport: TransportTCP, BasePath: server.URL, RequestPath: "hooks"})
+	require.NoError(t, err)
+
+	return Module{id: "partner.remote", stages: hooks.Stages, client: client}
+}
+
+// respond decodes the request sent to the remote process and answers with the response
+func respond(t *testing.T, requests chan<- Request, response string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hooks", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var request Request
+		if assert.NoError(t, json.NewDecoder(r.Body).Decode(&request)) && requests != nil {
+			requests <- request
+		}
+		w.Write([]byte(response))
+	}
+}
+
+func TestHandleEntrypointHook(t *testing.T) {
+	requests := make(chan Request, 1)
+	module := newTestModule(t, respond(t, requests, `{
+		"version": 1,
+		"message": "checked",
+		"warnings": ["slow"],
+		"analytics_tags": {"activities": [{"name": "check", "status": "success"}]},
+		"module_context": {"seen": true},
+		"changeset": [{"type": "update", "key": "body", "value": {"id": "new"}}]
+	}`))
+
+	httpReq := httptest.NewRequest(http.MethodPost, "/openrtb2/auction?debug=1", nil)
+	httpReq.Header.Set("Cookie", "uids=secret")
+	httpReq.Header.Set("Authorization", "Bearer secret")
+	httpReq.Header.Set("User-Agent", "test-agent")
+
+	result, err := module.HandleEntrypointHook(
+		context.Background(),
+		hookstage.ModuleInvocationContext{
+			AccountID:     "account",
+			Endpoint:      "/openrtb2/auction",
+			HookImplCode:  "partner-entrypoint",
+			ModuleContext: hookstage.ModuleContext{"count": 1},
+		},
+		hookstage.EntrypointPayload{Request: httpReq, Body: []byte(`{"id": "old"}`)},
+	)
+	require.NoError(t, err)
+
+	request := <-requests
+	assert.Equal(t, SchemaVersion, request.Version)
+	assert.Equal(t, hooks.StageEntrypoint, request.Stage)
+	assert.Equal(t, "partner.remote", request.Module)
+	assert.Equal(t, "partner-entrypoint", request.HookImplCode)
+	assert.Equal(t, "/openrtb2/auction", request.Endpoint)
+	assert.Equal(t, "account", request.AccountID)
+	assert.Equal(t, hookstage.ModuleContext{"count": float64(1)}, request.ModuleContext)
+	assert.JSONEq(t, `{
+		"method": "POST",
+		"path": "/openrtb2/auction",
+		"query": "debug=1",
+		"headers": {"User-Agent": ["test-agent"]},
+		"body": {"id": "old"}
+	}`, mustMarshal(t, request.Payload))
+
+	assert.False(t, result.Reject)
+	assert.Equal(t, "checked", result.Message)
+	assert.Equal(t, []string{"slow"}, result.Warnings)
+	assert.Equal(t, hookanalytics.Analytics{Activities: []hookanalytics.Activity{{Name: "check", Status: hookanalytics.ActivityStatusSuccess}}}, result.AnalyticsTags)
+	assert.Equal(t, hookstage.ModuleContext{"seen": true}, result.ModuleContext)
+
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+	mutation := result.ChangeSet.Mutations()[0]
+	assert.Equal(t, hookstage.MutationUpdate, mutation.Type())
+	assert.Equal(t, []string{"body"}, mutation.Key())
+	payload, err := mutation.Apply(hookstage.EntrypointPayload{Body: []byte(`{"id": "old"}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "new"}`, string(payload.Body))
+}
+
+func TestHandleRawAuctionHookReject(t *testing.T) {
+	module := newTestModule(t, respond(t, nil, `{"version": 1, "reject": true, "nbr_code": 123}`))
+
+	result, err := module.HandleRawAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.RawAuctionRequestPayload(`{"id": "req"}`))
+
+	require.NoError(t, err)
+	assert.True(t, result.Reject)
+	assert.Equal(t, 123, result.NbrCode)
+}
+
+func TestHandleBidderRequestHookPatchesRequest(t *testing.T) {
+	requests := make(chan Request, 1)
+	module := newTestModule(t, respond(t, requests, `{
+		"version": 1,
+		"changeset": [
+			{"type": "update", "key": "bidrequest", "value": {"tmax": 200, "site": null, "ext": {"partner": {"tier": 1}}}},
+			{"type": "update", "key": "bids", "value": []},
+			{"type": "delete", "key": "bidrequest", "value": {}}
+		]
+	}`))
+
+	request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+		ID:   "req",
+		Imp:  []openrtb2.Imp{{ID: "imp"}},
+		Site: &openrtb2.Site{ID: "site"},
+		Ext:  json.RawMessage(`{"prebid": {"debug": true}}`),
+	}}
+	payload := hookstage.BidderRequestPayload{Request: request, Bidder: "appnexus"}
+
+	result, err := module.HandleBidderRequestHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+
+	sent := <-requests
+	assert.Equal(t, hooks.StageBidderRequest, sent.Stage)
+	assert.JSONEq(t, `{"bidder": "appnexus", "request": {"id": "req", "imp": [{"id": "imp"}], "site": {"id": "site"}, "ext": {"prebid": {"debug": true}}}}`, mustMarshal(t, sent.Payload))
+
+	assert.Equal(t, []string{
+		`ignored update mutation of "bids": unsupported key "bids"`,
+		`ignored delete mutation of "bidrequest": unsupported mutation type`,
+	}, result.Warnings)
+
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+	_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, "req", request.ID)
+	assert.Equal(t, int64(200), request.TMax)
+	assert.Nil(t, request.Site)
+	assert.JSONEq(t, `{"prebid": {"debug": true}, "partner": {"tier": 1}}`, string(request.Ext))
+}
+
+func TestHandleRawBidderResponseHookUpdatesBids(t *testing.T) {
+	module := newTestModule(t, respond(t, nil, `{
+		"version": 1,
+		"changeset": [{"type": "update", "key": "bids", "value": [{"bid": {"id": "kept", "price": 1.5}, "bid_type": "banner"}]}]
+	}`))
+
+	response := &adapters.BidderResponse{Currency: "USD", Bids: []*adapters.TypedBid{
+		{Bid: &openrtb2.Bid{ID: "kept", Price: 1}, BidType: openrtb_ext.BidTypeBanner},
+		{Bid: &openrtb2.Bid{ID: "dropped", Price: 2}, BidType: openrtb_ext.BidTypeVideo},
+	}}
+	payload := hookstage.RawBidderResponsePayload{BidderResponse: response, Bidder: "appnexus"}
+
+	result, err := module.HandleRawBidderResponseHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+
+	_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, []*adapters.TypedBid{{Bid: &openrtb2.Bid{ID: "kept", Price: 1.5}, BidType: openrtb_ext.BidTypeBanner}}, response.Bids)
+}
+
+func TestHandleAuctionResponseHookPatchesResponse(t *testing.T) {
+	module := newTestModule(t, respond(t, nil, `{
+		"version": 1,
+		"changeset": [{"type": "update", "key": "bidresponse", "value": {"cur": "EUR"}}]
+	}`))
+
+	response := &openrtb2.BidResponse{ID: "resp", Cur: "USD"}
+	payload := hookstage.AuctionResponsePayload{BidResponse: response}
+
+	result, err := module.HandleAuctionResponseHook(context.Background(), hookstage.ModuleInvocationContext{}, payload)
+	require.NoError(t, err)
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+
+	_, err = result.ChangeSet.Mutations()[0].Apply(payload)
+	require.NoError(t, err)
+	assert.Equal(t, &openrtb2.BidResponse{ID: "resp", Cur: "EUR"}, response)
+}
+
+func TestHandleExitpointHookReplacesResponse(t *testing.T) {
+	module := newTestModule(t, respond(t, nil, `{
+		"version": 1,
+		"changeset": [{"type": "update", "key": "response", "value": {"replaced": true}}]
+	}`))
+
+	result, err := module.HandleExitpointHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.ExitpointPayload{Response: map[string]string{"id": "resp"}})
+	require.NoError(t, err)
+	require.Len(t, result.ChangeSet.Mutations(), 1)
+
+	payload, err := result.ChangeSet.Mutations()[0].Apply(hookstage.ExitpointPayload{})
+	require.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"replaced": true}`), payload.Response)
+}
+
+func TestHandleHookFailures(t *testing.T) {
+	testCases := []struct {
+		name        string
+		handler     http.HandlerFunc
+		expectedErr string
+	}{
+		{
+			name: "unexpected status code",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedErr: "unexpected status code: 500",
+		},
+		{
+			name: "invalid JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`not json`))
+			},
+			expectedErr: "invalid JSON from remote module",
+		},
+		{
+			name: "unsupported schema version",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"version": 2}`))
+			},
+			expectedErr: "unsupported schema version 2 (expected 1)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			module := newTestModule(t, test.handler)
+
+			_, err := module.HandleRawAuctionHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.RawAuctionRequestPayload(`{}`))
+
+			var failure hookexecution.FailureError
+			require.ErrorAs(t, err, &failure)
+			assert.True(t, strings.HasPrefix(failure.Message, "remote module partner.remote: "), failure.Message)
+			assert.Contains(t, failure.Message, test.expectedErr)
+		})
+	}
+}
+
+func TestHandleHookTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	module := newTestModule(t, func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	})
+	t.Cleanup(func() { close(unblock) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := module.HandleRawAuctionHook(ctx, hookstage.ModuleInvocationContext{}, hookstage.RawAuctionRequestPayload(`{}`))
+
+	var failure hookexecution.FailureError
+	require.ErrorAs(t, err, &failure)
+	assert.Contains(t, failure.Message, "context deadline exceeded")
+}
+
+func TestUDSTransport(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "remote.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	requests := make(chan Request, 1)
+	server := &http.Server{Handler: respond(t, requests, `{"version": 1}`)}
+	go server.Serve(listener)
+	defer server.Close()
+
+	module, err := Builder("partner.remote", json.RawMessage(`{"transport": "uds", "base_path": "`+socketPath+`", "request_path": "hooks", "stages": ["exitpoint"]}`), moduledeps.ModuleDeps{})
+	require.NoError(t, err)
+
+	result, err := module.(Module).HandleExitpointHook(context.Background(), hookstage.ModuleInvocationContext{}, hookstage.ExitpointPayload{Response: "ok"})
+	require.NoError(t, err)
+	assert.Empty(t, result.ChangeSet.Mutations())
+
+	request := <-requests
+	assert.Equal(t, hooks.StageExitpoint, request.Stage)
+	assert.Equal(t, map[string]interface{}{"response": "ok"}, request.Payload)
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,223 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/adapters"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	jsonpatch "gopkg.in/evanphx/json-patch.v5"
+)
+
+func errUnsupportedKey(key string) error {
+	return fmt.Errorf("unsupported key %q", key)
+}
+
+func addEntrypointMutation(changeSet *hookstage.ChangeSet[hookstage.EntrypointPayload], mutation Mutation) error {
+	if mutation.Key != keyBody {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	body := []byte(mutation.Value)
+	changeSet.AddMutation(func(p hookstage.EntrypointPayload) (hookstage.EntrypointPayload, error) {
+		p.Body = body
+		return p, nil
+	}, hookstage.MutationUpdate, keyBody)
+	return nil
+}
+
+func addRawAuctionRequestMutation(changeSet *hookstage.ChangeSet[hookstage.RawAuctionRequestPayload], mutation Mutation) error {
+	if mutation.Key != keyBody {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	body := hookstage.RawAuctionRequestPayload(mutation.Value)
+	changeSet.AddMutation(func(_ hookstage.RawAuctionRequestPayload) (hookstage.RawAuctionRequestPayload, error) {
+		return body, nil
+	}, hookstage.MutationUpdate, keyBody)
+	return nil
+}
+
+// The processed auction and bidder request payloads are not returned to the exchange,
+// so their mutations patch the request wrapper in place.
+
+func addProcessedAuctionRequestMutation(changeSet *hookstage.ChangeSet[hookstage.ProcessedAuctionRequestPayload], mutation Mutation) error {
+	if mutation.Key != keyBidRequest {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	patch := mutation.Value
+	changeSet.AddMutation(func(p hookstage.ProcessedAuctionRequestPayload) (hookstage.ProcessedAuctionRequestPayload, error) {
+		return p, patchBidRequest(p.Request, patch)
+	}, hookstage.MutationUpdate, keyBidRequest)
+	return nil
+}
+
+func addBidderRequestMutation(changeSet *hookstage.ChangeSet[hookstage.BidderRequestPayload], mutation Mutation) error {
+	if mutation.Key != keyBidRequest {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	patch := mutation.Value
+	changeSet.AddMutation(func(p hookstage.BidderRequestPayload) (hookstage.BidderRequestPayload, error) {
+		return p, patchBidRequest(p.Request, patch)
+	}, hookstage.MutationUpdate, keyBidRequest)
+	return nil
+}
+
+func addRawBidderResponseMutation(changeSet *hookstage.ChangeSet[hookstage.RawBidderResponsePayload], mutation Mutation) error {
+	if mutation.Key != keyBids {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	var bids []typedBid
+	if err := jsonutil.Unmarshal(mutation.Value, &bids); err != nil {
+		return fmt.Errorf("invalid bids: %v", err)
+	}
+
+	adapterBids := make([]*adapters.TypedBid, 0, len(bids))
+	for _, bid := range bids {
+		if bid.Bid == nil {
+			return errors.New("invalid bids: bid is required")
+		}
+		adapterBids = append(adapterBids, &adapters.TypedBid{
+			Bid:          bid.Bid,
+			BidMeta:      bid.BidMeta,
+			BidType:      bid.BidType,
+			DealPriority: bid.DealPriority,
+			Seat:         bid.Seat,
+		})
+	}
+
+	changeSet.RawBidderResponse().Bids().UpdateBids(adapterBids)
+	return nil
+}
+
+func addAllProcessedBidResponsesMutation(_ *hookstage.ChangeSet[hookstage.AllProcessedBidResponsesPayload], _ Mutation) error {
+	return errors.New("the all_processed_bid_responses stage does not support mutations")
+}
+
+func addAuctionResponseMutation(changeSet *hookstage.ChangeSet[hookstage.AuctionResponsePayload], mutation Mutation) error {
+	if mutation.Key != keyBidResponse {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	patch := mutation.Value
+	changeSet.AddMutation(func(p hookstage.AuctionResponsePayload) (hookstage.AuctionResponsePayload, error) {
+		if p.BidResponse == nil {
+			return p, errors.New("payload contains a nil bid response")
+		}
+
+		response, err := mergePatch(p.BidResponse, patch)
+		if err != nil {
+			return p, err
+		}
+
+		var patched openrtb2.BidResponse
+		if err := jsonutil.Unmarshal(response, &patched); err != nil {
+			return p, fmt.Errorf("invalid patched bid response: %v", err)
+		}
+		*p.BidResponse = patched
+		return p, nil
+	}, hookstage.MutationUpdate, keyBidResponse)
+	return nil
+}
+
+func addExitpointMutation(changeSet *hookstage.ChangeSet[hookstage.ExitpointPayload], mutation Mutation) error {
+	if mutation.Key != keyResponse {
+		return errUnsupportedKey(mutation.Key)
+	}
+
+	response := mutation.Value
+	changeSet.AddMutation(func(p hookstage.ExitpointPayload) (hookstage.ExitpointPayload, error) {
+		p.Response = response
+		return p, nil
+	}, hookstage.MutationUpdate, keyResponse)
+	return nil
+}
+
+// patchBidRequest applies the JSON merge patch to the bid request of the wrapper
+func patchBidRequest(request *openrtb_ext.RequestWrapper, patch json.RawMessage) error {
+	if request == nil || request.BidRequest == nil {
+		return errors.New("payload contains a nil bid request")
+	}
+
+	if err := request.RebuildRequest(); err != nil {
+		return fmt.Errorf("failed to rebuild bid request: %v", err)
+	}
+
+	bidRequest, err := mergePatch(request.BidRequest, patch)
+	if err != nil {
+		return err
+	}
+
+	var patched openrtb2.BidRequest
+	if err := jsonutil.Unmarshal(bidRequest, &patched); err != nil {
+		return fmt.Errorf("invalid patched bid request: %v", err)
+	}
+	*request = openrtb_ext.RequestWrapper{BidRequest: &patched}
+	return nil
+}
+
+func mergePatch(v interface{}, patch json.RawMessage) ([]byte, error) {
+	original, err := jsonutil.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %v", err)
+	}
+
+	patched, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply merge patch: %v", err)
+	}
+	return patched, nil
+}
+
+func newTypedBids(bids []*adapters.TypedBid) []typedBid {
+	wireBids := make([]typedBid, 0, len(bids))
+	for _, bid := range bids {
+		if bid == nil {
+			continue
+		}
+		wireBids = append(wireBids, typedBid{
+			Bid:          bid.Bid,
+			BidMeta:      bid.BidMeta,
+			BidType:      bid.BidType,
+			DealPriority: bid.DealPriority,
+			Seat:         bid.Seat,
+		})
+	}
+	return wireBids
+}
+
+func newSeatBids(responses map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid) map[openrtb_ext.BidderName]seatBid {
+	seatBids := make(map[openrtb_ext.BidderName]seatBid, len(responses))
+	for bidder, response := range responses {
+		if response == nil {
+			continue
+		}
+
+		wireSeatBid := seatBid{
+			Seat:     response.Seat,
+			Currency: response.Currency,
+			Bids:     make([]typedBid, 0, len(response.Bids)),
+		}
+		for _, bid := range response.Bids {
+			if bid == nil {
+				continue
+			}
+			wireSeatBid.Bids = append(wireSeatBid.Bids, typedBid{
+				Bid:          bid.Bid,
+				BidMeta:      bid.BidMeta,
+				BidType:      bid.BidType,
+				DealPriority: bid.DealPriority,
+			})
+		}
+		seatBids[bidder] = wireSeatBid
+	}
+	return seatBids
+}
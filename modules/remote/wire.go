@@ -0,0 +1,118 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookstage"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+)
+
+// SchemaVersion is the version of the wire schema sent with every request.
+// The remote process must answer with the same version.
+const SchemaVersion = 1
+
+// Request is sent to the remote process for every hook invocation.
+type Request struct {
+	Version            int                                `json:"version"`
+	Stage              hooks.Stage                        `json:"stage"`
+	Module             string                             `json:"module"`
+	HookImplCode       string                             `json:"hook_impl_code"`
+	Endpoint           string                             `json:"endpoint"`
+	AccountID          string                             `json:"account_id,omitempty"`
+	AccountConfig      json.RawMessage                    `json:"account_config,omitempty"`
+	ModuleContext      hookstage.ModuleContext            `json:"module_context,omitempty"`
+	DependencyContexts map[string]hookstage.ModuleContext `json:"dependency_contexts,omitempty"`
+	Payload            interface{}                        `json:"payload"`
+}
+
+// Response is the answer of the remote process, mirroring hookstage.HookResult.
+type Response struct {
+	Version       int                     `json:"version"`
+	Reject        bool                    `json:"reject"`
+	NbrCode       int                     `json:"nbr_code"`
+	Message       string                  `json:"message"`
+	Errors        []string                `json:"errors"`
+	Warnings      []string                `json:"warnings"`
+	DebugMessages []string                `json:"debug_messages"`
+	AnalyticsTags hookanalytics.Analytics `json:"analytics_tags"`
+	ModuleContext hookstage.ModuleContext `json:"module_context"`
+	ChangeSet     []Mutation              `json:"changeset"`
+}
+
+// Mutation replaces the payload value at Key by Value. The keys and values supported by each stage are:
+//   - entrypoint, raw_auction_request: "body", the new request body
+//   - processed_auction_request, bidder_request: "bidrequest", a JSON merge patch (RFC 7386) of the bid request
+//   - raw_bidder_response: "bids", the new list of bids
+//   - auction_response: "bidresponse", a JSON merge patch of the bid response
+//   - exitpoint: "response", the new response
+type Mutation struct {
+	Type  string          `json:"type"`
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+const (
+	mutationTypeUpdate = "update"
+
+	keyBody        = "body"
+	keyBidRequest  = "bidrequest"
+	keyBids        = "bids"
+	keyBidResponse = "bidresponse"
+	keyResponse    = "response"
+)
+
+// Stage payloads. Bodies which are not valid JSON are left out.
+
+type entrypointPayload struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	// Headers excludes the Cookie and Authorization headers
+	Headers http.Header     `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+type rawAuctionRequestPayload struct {
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+type bidRequestPayload struct {
+	Bidder  string               `json:"bidder,omitempty"`
+	Request *openrtb2.BidRequest `json:"request"`
+}
+
+type rawBidderResponsePayload struct {
+	Bidder   string     `json:"bidder"`
+	Currency string     `json:"currency"`
+	Bids     []typedBid `json:"bids"`
+}
+
+type typedBid struct {
+	Bid          *openrtb2.Bid                 `json:"bid"`
+	BidMeta      *openrtb_ext.ExtBidPrebidMeta `json:"bid_meta,omitempty"`
+	BidType      openrtb_ext.BidType           `json:"bid_type"`
+	DealPriority int                           `json:"deal_priority,omitempty"`
+	Seat         openrtb_ext.BidderName        `json:"seat,omitempty"`
+}
+
+type allProcessedBidResponsesPayload struct {
+	Responses map[openrtb_ext.BidderName]seatBid `json:"responses"`
+}
+
+type seatBid struct {
+	Seat     string     `json:"seat,omitempty"`
+	Currency string     `json:"currency"`
+	Bids     []typedBid `json:"bids"`
+}
+
+type bidResponsePayload struct {
+	Response *openrtb2.BidResponse `json:"response"`
+}
+
+type exitpointPayload struct {
+	Response interface{} `json:"response"`
+}
@@ -17,12 +17,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/coocood/freecache"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v3/hooks/hookanalytics"
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
-	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
 	"github.com/prebid/prebid-server/v3/util/iterutil"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
@@ -79,7 +78,7 @@ func Builder(config json.RawMessage, deps moduledeps.ModuleDeps) (interface{}, e
 			Timeout:   time.Duration(cfg.Timeout) * time.Millisecond,
 			Transport: deps.HTTPClient.Transport,
 		},
-		cache: freecache.NewCache(cfg.CacheSize),
+		cache: deps.Caches.NewCache(moduleCode, cfg.CacheSize, time.Duration(cfg.CacheTTL)*time.Second),
 		sha256Pool: &sync.Pool{
 			New: func() any {
 				return sha256.New()
@@ -99,6 +98,9 @@ var scope3MacroKeyPlusSeparator = scope3MacroKey + scope3MacroSeparator
 
 const DefaultScope3RTDURL = "https://rtdp.scope3.com/prebid/prebid"
 
+// moduleCode labels the metrics of the segment cache and selects it on the /hooks/cache/purge admin endpoint
+const moduleCode = "scope3.rtd"
+
 var (
 	// Declare hooks
 	_ hookstage.Entrypoint        = (*Module)(nil)
@@ -183,7 +185,7 @@ type Scope3Segment struct {
 type Module struct {
 	cfg        Config
 	httpClient *http.Client
-	cache      *freecache.Cache
+	cache      *hookcache.Cache
 	// sha256Pool provides a pool of reusable SHA-256 hash instances for performance
 	sha256Pool *sync.Pool
 }
@@ -371,10 +373,10 @@ func (m *Module) HandleAuctionResponseHook(
 // fetchScope3Segments calls the Scope3 API and extracts segments
 func (m *Module) fetchScope3Segments(ctx context.Context, bidRequest *openrtb2.BidRequest) ([]string, error) {
 	// Create cache key based on relevant user identifiers and site context
-	cacheKey := []byte(m.createCacheKey(bidRequest))
+	cacheKey := m.createCacheKey(bidRequest)
 
 	// Check cache first
-	if segments, err := m.cache.Get(cacheKey); err == nil {
+	if segments, ok := m.cache.Get(cacheKey); ok {
 		return strings.Split(string(segments), ","), nil
 	}
 
@@ -445,10 +447,7 @@ func (m *Module) fetchScope3Segments(ctx context.Context, bidRequest *openrtb2.B
 	}
 
 	// Cache the result
-	err = m.cache.Set(cacheKey, []byte(strings.Join(segments, ",")), m.cfg.CacheTTL)
-	if err != nil {
-		logger.Infof("could not set segments in cache: %v", err)
-	}
+	m.cache.Set(cacheKey, []byte(strings.Join(segments, ",")))
 
 	return segments, nil
 }
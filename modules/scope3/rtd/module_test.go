@@ -12,9 +12,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/coocood/freecache"
 	"github.com/prebid/openrtb/v20/adcom1"
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
 	"github.com/prebid/prebid-server/v3/hooks/hookstage"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
@@ -63,6 +63,19 @@ func TestBuilder(t *testing.T) {
 	assert.NotNil(t, m.cache)
 }
 
+func TestBuilderRegistersCache(t *testing.T) {
+	config := json.RawMessage(`{"enabled": true, "auth_key": "test-key"}`)
+	caches, err := hookcache.NewRegistry(nil)
+	require.NoError(t, err)
+
+	module, err := Builder(config, moduledeps.ModuleDeps{HTTPClient: http.DefaultClient, Caches: caches})
+	require.NoError(t, err)
+
+	module.(*Module).cache.Set("key", []byte("segment"))
+	assert.Equal(t, []string{"scope3.rtd"}, caches.Modules())
+	assert.Equal(t, map[string]int{"scope3.rtd": 1}, caches.Purge(""))
+}
+
 func TestBuilderInvalidConfig(t *testing.T) {
 	config := json.RawMessage(`invalid json`)
 	deps := moduledeps.ModuleDeps{HTTPClient: http.DefaultClient}
@@ -1401,7 +1414,7 @@ func TestFetchScope3Segments_MaskingFailure(t *testing.T) {
 		httpClient: &http.Client{
 			Timeout: 1 * time.Second,
 		},
-		cache: freecache.NewCache(10),
+		cache: hookcache.New(10, time.Minute),
 		sha256Pool: &sync.Pool{
 			New: func() any {
 				return sha256.New()
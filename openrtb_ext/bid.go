@@ -35,6 +35,9 @@ type ExtBidPrebidFloors struct {
 	FloorRuleValue float64 `json:"floorRuleValue,omitempty"`
 	FloorValue     float64 `json:"floorValue,omitempty"`
 	FloorCurrency  string  `json:"floorCurrency,omitempty"`
+	// WouldReject flags a bid below its floor which floors enforcement did not reject, because the enforce rate
+	// skipped it or enforcepbs is false
+	WouldReject bool `json:"wouldReject,omitempty"`
 }
 
 // ExtBidPrebidCache defines the contract for  bidresponse.seatbid.bid[i].ext.prebid.cache
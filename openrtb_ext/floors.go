@@ -35,6 +35,7 @@ type PriceFloorRules struct {
 	Skipped            *bool                  `json:"skipped,omitempty"`
 	FloorProvider      string                 `json:"floorprovider,omitempty"`
 	FetchStatus        string                 `json:"fetchstatus,omitempty"`
+	FetchVersion       string                 `json:"fetchversion,omitempty"`
 	PriceFloorLocation string                 `json:"location,omitempty"`
 }
 
@@ -201,7 +201,29 @@ type ExtRequestPrebidCacheVAST struct {
 
 // ExtRequestPrebidBidAdjustments defines the contract for bidrequest.ext.prebid.bidadjustments
 type ExtRequestPrebidBidAdjustments struct {
-	MediaType MediaType `mapstructure:"mediatype" json:"mediatype,omitempty"`
+	MediaType MediaType        `mapstructure:"mediatype" json:"mediatype,omitempty"`
+	Rules     []AdjustmentRule `mapstructure:"rules" json:"rules,omitempty"`
+}
+
+// AdjustmentRule defines the contract for bidrequest.ext.prebid.bidadjustments.rules[i]
+// Empty dimensions match any value. Rules are only active within their time window, if one is set
+type AdjustmentRule struct {
+	MediaType   string           `mapstructure:"mediatype" json:"mediatype,omitempty"`
+	Bidder      BidderName       `mapstructure:"bidder" json:"bidder,omitempty"`
+	DealID      string           `mapstructure:"dealid" json:"dealid,omitempty"`
+	Country     string           `mapstructure:"country" json:"country,omitempty"`
+	DeviceType  int              `mapstructure:"devicetype" json:"devicetype,omitempty"`
+	Domain      string           `mapstructure:"domain" json:"domain,omitempty"`
+	Bundle      string           `mapstructure:"bundle" json:"bundle,omitempty"`
+	Hours       *AdjustmentHours `mapstructure:"hours" json:"hours,omitempty"`
+	Adjustments []Adjustment     `mapstructure:"adjustments" json:"adjustments,omitempty"`
+}
+
+// AdjustmentHours defines a UTC time of day window, starting at the Start hour inclusive and ending at the End hour exclusive
+// A window with Start greater than End wraps around midnight
+type AdjustmentHours struct {
+	Start int `mapstructure:"start" json:"start"`
+	End   int `mapstructure:"end" json:"end"`
 }
 
 // AdjustmentsByDealID maps a dealID to a slice of bid adjustments
@@ -61,6 +61,22 @@ type ExtResponsePrebid struct {
 	Cache            *ExtResponsePrebidCache `json:"cache,omitempty"`
 	// SeatNonBid holds the array of Bids which are either rejected, no bids inside bidresponse.ext.prebid.seatnonbid
 	SeatNonBid []SeatNonBid `json:"seatnonbid,omitempty"`
+	// BidAdjustments holds the bid adjustment rules applied to bids, only returned in debug mode
+	BidAdjustments []AppliedBidAdjustment `json:"bidadjustments,omitempty"`
+}
+
+// AppliedBidAdjustment defines the contract for bidresponse.ext.prebid.bidadjustments[i]
+// OriginalPrice is the bid price before the matched rule's adjustments were applied
+type AppliedBidAdjustment struct {
+	Bidder           BidderName   `json:"bidder"`
+	ImpID            string       `json:"impid"`
+	BidID            string       `json:"bidid"`
+	Rule             string       `json:"rule"`
+	Adjustments      []Adjustment `json:"adjustments"`
+	OriginalPrice    float64      `json:"origbidcpm"`
+	OriginalCurrency string       `json:"origbidcur"`
+	AdjustedPrice    float64      `json:"bidcpm"`
+	AdjustedCurrency string       `json:"bidcur"`
 }
 
 // FledgeResponse defines the contract for bidresponse.ext.fledge
@@ -129,8 +145,9 @@ type NonBidObject struct {
 	MType   openrtb2.MarkupType     `json:"mtype,omitempty"`
 
 	// Custom Fields
-	OriginalBidCPM float64 `json:"origbidcpm,omitempty"`
-	OriginalBidCur string  `json:"origbidcur,omitempty"`
+	OriginalBidCPM float64             `json:"origbidcpm,omitempty"`
+	OriginalBidCur string              `json:"origbidcur,omitempty"`
+	Floors         *ExtBidPrebidFloors `json:"floors,omitempty"`
 }
 
 // ExtResponseNonBidPrebid represents bidresponse.ext.prebid.seatnonbid[].nonbid[].ext
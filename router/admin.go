@@ -5,12 +5,14 @@ import (
 	"net/http/pprof"
 	"time"
 
+	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/currency"
 	"github.com/prebid/prebid-server/v3/endpoints"
+	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/version"
 )
 
-func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration) *http.ServeMux {
+func Admin(cfg *config.Configuration, rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration, handlers map[string]http.Handler) *http.ServeMux {
 	// Add endpoints to the admin server
 	// Making sure to add pprof routes
 	mux := http.NewServeMux()
@@ -23,5 +25,16 @@ func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval
 	// Register prebid-server defined admin handlers
 	mux.HandleFunc("/currency/rates", endpoints.NewCurrencyRatesEndpoint(rateConverter, rateConverterFetchingInterval))
 	mux.HandleFunc("/version", endpoints.NewVersionEndpoint(version.Ver, version.Rev))
+	if cfg.Admin.ConfigToken != "" {
+		document, err := cfg.EffectiveDocument()
+		if err != nil {
+			logger.Fatalf("Failed to serialize the effective configuration: %v", err)
+		}
+		mux.HandleFunc("/config", endpoints.NewEffectiveConfigEndpoint(document, cfg.Admin.ConfigToken))
+	}
+	// Register handlers of modules served on the admin port
+	for path, handler := range handlers {
+		mux.Handle(path, handler)
+	}
 	return mux
 }
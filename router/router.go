@@ -25,10 +25,13 @@ import (
 	"github.com/prebid/prebid-server/v3/floors"
 	"github.com/prebid/prebid-server/v3/gdpr"
 	"github.com/prebid/prebid-server/v3/hooks"
+	"github.com/prebid/prebid-server/v3/hooks/hookcache"
+	"github.com/prebid/prebid-server/v3/hooks/hookexecution"
 	"github.com/prebid/prebid-server/v3/logger"
 	"github.com/prebid/prebid-server/v3/macros"
 	"github.com/prebid/prebid-server/v3/metrics"
 	metricsConf "github.com/prebid/prebid-server/v3/metrics/config"
+	prometheusmetrics "github.com/prebid/prebid-server/v3/metrics/prometheus"
 	"github.com/prebid/prebid-server/v3/modules"
 	"github.com/prebid/prebid-server/v3/modules/moduledeps"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
@@ -46,6 +49,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/julienschmidt/httprouter"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/cors"
 )
 
@@ -117,6 +121,8 @@ type Router struct {
 	*httprouter.Router
 	MetricsEngine   *metricsConf.DetailedMetricsEngine
 	ParamsValidator openrtb_ext.BidderParamValidator
+	// AdminHandlers are served on the admin port, by path
+	AdminHandlers map[string]http.Handler
 
 	shutdowns []func()
 }
@@ -210,16 +216,48 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 
 	normalizedGeoscopes := getNormalizedGeoscopes(cfg.BidderInfos)
 	moduleDeps := moduledeps.ModuleDeps{HTTPClient: generalHttpClient, RateConvertor: rateConvertor, Geoscope: normalizedGeoscopes}
+	moduleAdminHandlers := make(map[string]http.Handler)
+	moduleDeps.RegisterAdminHandler = func(path string, handler http.Handler) {
+		moduleAdminHandlers[path] = handler
+	}
+	var modulesMetricsRegistry *prometheus.Registry
+	if cfg.Metrics.Prometheus.Port != 0 {
+		modulesMetricsRegistry, moduleDeps.MetricsRegisterer = prometheusmetrics.NewModulesRegistry(cfg.Metrics.Prometheus)
+		moduleDeps.AccountMetricsDisabled = cfg.Metrics.Disabled.AccountModulesMetrics
+	}
+	moduleDeps.Caches, err = hookcache.NewRegistry(moduleDeps.MetricsRegisterer)
+	if err != nil {
+		logger.Fatalf("Failed to init hook module caches: %v", err)
+	}
 	repo, moduleStageNames, shutdownModules, err := modules.NewBuilder().Build(cfg.Hooks.Modules, moduleDeps)
 	if err != nil {
 		logger.Fatalf("Failed to init hook modules: %v", err)
 	}
+	hookEndpoints := []string{hookexecution.EndpointAuction, hookexecution.EndpointAmp}
+	if cfg.Hooks.Enabled {
+		errs := validateExecutionPlans(cfg, repo, hookEndpoints)
+		if len(errs) > 0 && cfg.Hooks.FailOnInvalidExecutionPlans {
+			return nil, errortypes.NewAggregateError("Invalid hook execution plans", errs)
+		}
+		for _, err := range errs {
+			logger.Warnf("Invalid hook execution plan: %v", err)
+		}
+	}
 
 	// Metrics engine
 	r.MetricsEngine = metricsConf.NewMetricsEngine(cfg, openrtb_ext.CoreBidderNames(), syncerKeys, moduleStageNames)
-	shutdown, fetcher, ampFetcher, accounts, categoriesFetcher, videoFetcher, storedRespFetcher := storedRequestsConf.NewStoredRequests(cfg, r.MetricsEngine, generalHttpClient, r.Router)
+	if r.MetricsEngine.PrometheusMetrics != nil && modulesMetricsRegistry != nil {
+		r.MetricsEngine.PrometheusMetrics.ModulesGatherer = modulesMetricsRegistry
+	}
+	shutdown, fetcher, ampFetcher, accounts, categoriesFetcher, videoFetcher, storedRespFetcher, storedFloorsFetcher := storedRequestsConf.NewStoredRequests(cfg, r.MetricsEngine, generalHttpClient, r.Router)
 
 	analyticsRunner := analyticsBuild.New(&cfg.Analytics, r.MetricsEngine)
+	r.AdminHandlers = analyticsBuild.AdminHandlers(analyticsRunner)
+	for path, handler := range moduleAdminHandlers {
+		r.AdminHandlers[path] = handler
+	}
+	r.AdminHandlers["/hooks/execution_plan"] = endpoints.NewHookExecutionPlanEndpoint(cfg, repo, accounts, hookEndpoints, r.MetricsEngine)
+	r.AdminHandlers["/hooks/cache/purge"] = endpoints.NewHookCachePurgeEndpoint(moduleDeps.Caches)
 
 	// register the analytics runner for shutdown
 	r.shutdowns = append(r.shutdowns, shutdown, analyticsRunner.Shutdown, shutdownModules.Shutdown)
@@ -252,7 +290,15 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 	}
 
 	requestValidator := ortb.NewRequestValidator(activeBidders, disabledBidders, paramsValidator)
-	priceFloorFetcher := floors.NewPriceFloorFetcher(cfg.PriceFloors, floorFechterHttpClient, r.MetricsEngine)
+	var priceFloorFetcher floors.FloorFetcher
+	if urlFloorFetcher := floors.NewPriceFloorFetcher(cfg.PriceFloors, floorFechterHttpClient, r.MetricsEngine); urlFloorFetcher != nil {
+		priceFloorFetcher = floors.NewStoredFloorFetcher(storedFloorsFetcher, urlFloorFetcher)
+		if cfg.PriceFloors.Learning.Enabled {
+			floorsLearner := floors.NewLearner(cfg.PriceFloors.Learning, priceFloorFetcher)
+			r.AdminHandlers["/floors/learning"] = endpoints.NewFloorsLearningEndpoint(floorsLearner)
+			priceFloorFetcher = floorsLearner
+		}
+	}
 
 	tmaxAdjustments := exchange.ProcessTMaxAdjustments(cfg.TmaxAdjustments)
 	planBuilder := hooks.NewExecutionPlanBuilder(cfg.Hooks, repo)
@@ -289,7 +335,11 @@ func New(cfg *config.Configuration, rateConvertor *currency.RateConverter) (r *R
 	r.GET("/status", endpoints.NewStatusEndpoint(cfg.StatusResponse))
 	r.GET("/", serveIndex)
 	r.Handler("GET", "/version", endpoints.NewVersionEndpoint(version.Ver, version.Rev))
-	r.Handler("GET", "/attestation", endpoints.NewAttestationEndpoint())
+	configDigest, err := cfg.Digest()
+	if err != nil {
+		return nil, err
+	}
+	r.Handler("GET", "/attestation", endpoints.NewAttestationEndpoint(newAttestationRuntime(configDigest, activeBidders, moduleStageNames), cfg.Attestation.InstanceKeyFile))
 	r.ServeFiles("/static/*filepath", http.Dir("static"))
 
 	// vtrack endpoint
@@ -382,6 +432,27 @@ func SupportCORS(handler http.Handler) http.Handler {
 	return c.Handler(handler)
 }
 
+// validateExecutionPlans checks the host-level and default account-level hook execution plans against the
+// hook modules in repo so that misconfigured hooks are reported at startup instead of being skipped per request
+func validateExecutionPlans(cfg *config.Configuration, repo hooks.HookRepository, hookEndpoints []string) []error {
+	plans := []struct {
+		name string
+		plan config.HookExecutionPlan
+	}{
+		{"hooks.host_execution_plan", cfg.Hooks.HostExecutionPlan},
+		{"hooks.default_account_execution_plan", cfg.Hooks.DefaultAccountExecutionPlan},
+		{"account_defaults.hooks.execution_plan", cfg.AccountDefaults.Hooks.ExecutionPlan},
+	}
+
+	var errs []error
+	for _, p := range plans {
+		for _, err := range hooks.ValidateExecutionPlan(p.plan, repo, hookEndpoints) {
+			errs = append(errs, fmt.Errorf("%s: %v", p.name, err))
+		}
+	}
+	return errs
+}
+
 func readDefaultRequest(defReqConfig config.DefReqConfig) []byte {
 	switch defReqConfig.Type {
 	case "file":
@@ -425,3 +496,19 @@ func getNormalizedGeoscopes(bidderInfos config.BidderInfos) map[string][]string
 	}
 	return geoscopes
 }
+
+// newAttestationRuntime lists what the /attestation endpoint reports about the running instance.
+func newAttestationRuntime(configDigest string, activeBidders map[string]openrtb_ext.BidderName, moduleStageNames map[string][]string) endpoints.AttestationRuntime {
+	runtime := endpoints.AttestationRuntime{
+		Bidders:      make([]string, 0, len(activeBidders)),
+		Modules:      make([]string, 0, len(moduleStageNames)),
+		ConfigDigest: configDigest,
+	}
+	for name := range activeBidders {
+		runtime.Bidders = append(runtime.Bidders, name)
+	}
+	for module := range moduleStageNames {
+		runtime.Modules = append(runtime.Modules, module)
+	}
+	return runtime
+}
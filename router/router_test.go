@@ -9,10 +9,12 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/prebid/prebid-server/v3/config"
+	"github.com/prebid/prebid-server/v3/hooks"
 	"github.com/prebid/prebid-server/v3/openrtb_ext"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const adapterDirectory = "../adapters"
@@ -317,3 +319,31 @@ func TestGetNormalizedGeoscopes(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAttestationRuntime(t *testing.T) {
+	activeBidders := map[string]openrtb_ext.BidderName{"appnexus": "appnexus", "rubicon": "rubicon"}
+	moduleStageNames := map[string][]string{"openads.signatures": {"bidder_request"}}
+
+	runtime := newAttestationRuntime("config-digest", activeBidders, moduleStageNames)
+
+	assert.ElementsMatch(t, []string{"appnexus", "rubicon"}, runtime.Bidders)
+	assert.Equal(t, []string{"openads.signatures"}, runtime.Modules)
+	assert.Equal(t, "config-digest", runtime.ConfigDigest)
+}
+
+func TestValidateExecutionPlans(t *testing.T) {
+	repo, err := hooks.NewHookRepository(map[string]interface{}{})
+	require.NoError(t, err)
+
+	cfg := &config.Configuration{}
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(`{"endpoints": {"/openrtb2/auction": {"stages": {
+		"entrypoint": {"groups": [{"hook_sequence": [{"module_code": "vendor.missing", "hook_impl_code": "foo"}]}]}
+	}}}}`), &cfg.AccountDefaults.Hooks.ExecutionPlan))
+	require.NoError(t, jsonutil.UnmarshalValid([]byte(`{"endpoints": {"/openrtb2/video": {}}}`), &cfg.Hooks.HostExecutionPlan))
+
+	errs := validateExecutionPlans(cfg, repo, []string{"/openrtb2/auction", "/openrtb2/amp"})
+
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], `hooks.host_execution_plan: endpoint "/openrtb2/video" is not one of /openrtb2/auction, /openrtb2/amp`)
+	assert.EqualError(t, errs[1], `account_defaults.hooks.execution_plan: endpoint "/openrtb2/auction": stage "entrypoint": group 0: hook 0 (foo): module "vendor.missing" is not registered or not enabled`)
+}
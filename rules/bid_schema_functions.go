@@ -0,0 +1,312 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+)
+
+const (
+	AdomainIn       = "adomainIn"
+	Bidder          = "bidder"
+	BidderIn        = "bidderIn"
+	BidPriceBelow   = "bidPriceBelow"
+	DealIdAvailable = "dealIdAvailable"
+	DealIdIn        = "dealIdIn"
+	MediaType       = "mediaType"
+	MediaTypeIn     = "mediaTypeIn"
+)
+
+// ProcessedBid is the payload bid schema functions operate on: a single bid, priced in the
+// auction currency, along with the name of the bidder that made it
+type ProcessedBid struct {
+	Bidder string
+	Bid    *entities.PbsOrtbBid
+}
+
+// NewBidSchemaFunction returns the specified schema function that operates on a processed bid payload along with
+// any schema function args validation errors that occurred during instantiation
+func NewBidSchemaFunction(name string, params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	switch name {
+	case AdomainIn:
+		return NewAdomainIn(params)
+	case Bidder:
+		return NewBidder(params)
+	case BidderIn:
+		return NewBidderIn(params)
+	case BidPriceBelow:
+		return NewBidPriceBelow(params)
+	case DealIdAvailable:
+		return NewDealIdAvailable(params)
+	case DealIdIn:
+		return NewDealIdIn(params)
+	case MediaType:
+		return NewMediaType(params)
+	case MediaTypeIn:
+		return NewMediaTypeIn(params)
+	default:
+		return nil, fmt.Errorf("Schema function %s was not created", name)
+	}
+}
+
+// ------------bidder-----------------------
+type bidder struct{}
+
+func NewBidder(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	if err := checkNilArgs(params, Bidder); err != nil {
+		return nil, err
+	}
+	return &bidder{}, nil
+}
+
+func (b *bidder) Call(payload *ProcessedBid) (string, error) {
+	if payload == nil {
+		return "", nil
+	}
+	return payload.Bidder, nil
+}
+
+func (b *bidder) Name() string {
+	return Bidder
+}
+
+// ------------bidderIn---------------------
+type bidderIn struct {
+	Bidders   []string `json:"bidders"`
+	BidderDir map[string]struct{}
+}
+
+func NewBidderIn(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	schemaFunc := &bidderIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Bidders) == 0 {
+		return nil, errors.New("Empty bidders argument in bidderIn schema function")
+	}
+
+	schemaFunc.BidderDir = make(map[string]struct{})
+	for i := range schemaFunc.Bidders {
+		schemaFunc.BidderDir[strings.ToLower(schemaFunc.Bidders[i])] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (bi *bidderIn) Call(payload *ProcessedBid) (string, error) {
+	if payload == nil || len(payload.Bidder) == 0 {
+		return "false", nil
+	}
+
+	_, found := bi.BidderDir[strings.ToLower(payload.Bidder)]
+	return fmt.Sprintf("%t", found), nil
+}
+
+func (bi *bidderIn) Name() string {
+	return BidderIn
+}
+
+// ------------adomainIn--------------------
+type adomainIn struct {
+	Domains   []string `json:"domains"`
+	DomainDir map[string]struct{}
+}
+
+func NewAdomainIn(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	schemaFunc := &adomainIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Domains) == 0 {
+		return nil, errors.New("Empty domains argument in adomainIn schema function")
+	}
+
+	schemaFunc.DomainDir = make(map[string]struct{})
+	for i := range schemaFunc.Domains {
+		schemaFunc.DomainDir[strings.ToLower(schemaFunc.Domains[i])] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (ai *adomainIn) Call(payload *ProcessedBid) (string, error) {
+	bid := getOrtbBid(payload)
+	if bid == nil {
+		return "false", nil
+	}
+
+	for i := range bid.ADomain {
+		if _, found := ai.DomainDir[strings.ToLower(bid.ADomain[i])]; found {
+			return "true", nil
+		}
+	}
+	return "false", nil
+}
+
+func (ai *adomainIn) Name() string {
+	return AdomainIn
+}
+
+// ------------bidPriceBelow----------------
+type bidPriceBelow struct {
+	Price *float64 `json:"price"`
+}
+
+func NewBidPriceBelow(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	schemaFunc := &bidPriceBelow{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if schemaFunc.Price == nil {
+		return nil, errors.New("Missing price argument for bidPriceBelow schema function")
+	}
+	if *schemaFunc.Price < 0 {
+		return nil, errors.New("Negative price argument in bidPriceBelow schema function")
+	}
+
+	return schemaFunc, nil
+}
+
+func (bpb *bidPriceBelow) Call(payload *ProcessedBid) (string, error) {
+	bid := getOrtbBid(payload)
+	if bid == nil {
+		return "false", nil
+	}
+	return fmt.Sprintf("%t", bid.Price < *bpb.Price), nil
+}
+
+func (bpb *bidPriceBelow) Name() string {
+	return BidPriceBelow
+}
+
+// ------------dealIdAvailable--------------
+type dealIdAvailable struct{}
+
+func NewDealIdAvailable(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	if err := checkNilArgs(params, DealIdAvailable); err != nil {
+		return nil, err
+	}
+	return &dealIdAvailable{}, nil
+}
+
+func (da *dealIdAvailable) Call(payload *ProcessedBid) (string, error) {
+	if bid := getOrtbBid(payload); bid != nil && len(bid.DealID) > 0 {
+		return "true", nil
+	}
+	return "false", nil
+}
+
+func (da *dealIdAvailable) Name() string {
+	return DealIdAvailable
+}
+
+// ------------dealIdIn---------------------
+type dealIdIn struct {
+	DealIds []string `json:"dealids"`
+	DealDir map[string]struct{}
+}
+
+func NewDealIdIn(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	schemaFunc := &dealIdIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.DealIds) == 0 {
+		return nil, errors.New("Empty dealids argument in dealIdIn schema function")
+	}
+
+	schemaFunc.DealDir = make(map[string]struct{})
+	for i := range schemaFunc.DealIds {
+		schemaFunc.DealDir[schemaFunc.DealIds[i]] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (di *dealIdIn) Call(payload *ProcessedBid) (string, error) {
+	bid := getOrtbBid(payload)
+	if bid == nil || len(bid.DealID) == 0 {
+		return "false", nil
+	}
+
+	_, found := di.DealDir[bid.DealID]
+	return fmt.Sprintf("%t", found), nil
+}
+
+func (di *dealIdIn) Name() string {
+	return DealIdIn
+}
+
+// ------------mediaType--------------------
+type mediaType struct{}
+
+func NewMediaType(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	if err := checkNilArgs(params, MediaType); err != nil {
+		return nil, err
+	}
+	return &mediaType{}, nil
+}
+
+func (mt *mediaType) Call(payload *ProcessedBid) (string, error) {
+	if payload == nil || payload.Bid == nil {
+		return "", nil
+	}
+	return string(payload.Bid.BidType), nil
+}
+
+func (mt *mediaType) Name() string {
+	return MediaType
+}
+
+// ------------mediaTypeIn------------------
+type mediaTypeIn struct {
+	MediaTypes   []string `json:"types"`
+	MediaTypeDir map[string]struct{}
+}
+
+func NewMediaTypeIn(params json.RawMessage) (SchemaFunction[ProcessedBid], error) {
+	schemaFunc := &mediaTypeIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.MediaTypes) == 0 {
+		return nil, errors.New("Empty types argument in mediaTypeIn schema function")
+	}
+
+	schemaFunc.MediaTypeDir = make(map[string]struct{})
+	for i := range schemaFunc.MediaTypes {
+		schemaFunc.MediaTypeDir[schemaFunc.MediaTypes[i]] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (mti *mediaTypeIn) Call(payload *ProcessedBid) (string, error) {
+	if payload == nil || payload.Bid == nil || len(payload.Bid.BidType) == 0 {
+		return "false", nil
+	}
+
+	_, found := mti.MediaTypeDir[string(payload.Bid.BidType)]
+	return fmt.Sprintf("%t", found), nil
+}
+
+func (mti *mediaTypeIn) Name() string {
+	return MediaTypeIn
+}
+
+func getOrtbBid(payload *ProcessedBid) *openrtb2.Bid {
+	if payload != nil && payload.Bid != nil && payload.Bid.Bid != nil {
+		return payload.Bid.Bid
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/exchange/entities"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBidSchemaFunction(t *testing.T) {
+	testCases := []struct {
+		inFunctionName     string
+		inParams           json.RawMessage
+		expectedSchemaFunc SchemaFunction[ProcessedBid]
+		expectedError      error
+	}{
+		{
+			inFunctionName: AdomainIn,
+			inParams:       json.RawMessage(`{"domains": ["Advertiser.com"]}`),
+			expectedSchemaFunc: &adomainIn{
+				Domains:   []string{"Advertiser.com"},
+				DomainDir: map[string]struct{}{"advertiser.com": {}},
+			},
+		},
+		{
+			inFunctionName: AdomainIn,
+			inParams:       json.RawMessage(`{"domains": []}`),
+			expectedError:  errors.New("Empty domains argument in adomainIn schema function"),
+		},
+		{
+			inFunctionName:     Bidder,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &bidder{},
+		},
+		{
+			inFunctionName: BidderIn,
+			inParams:       json.RawMessage(`{"bidders": ["AppNexus"]}`),
+			expectedSchemaFunc: &bidderIn{
+				Bidders:   []string{"AppNexus"},
+				BidderDir: map[string]struct{}{"appnexus": {}},
+			},
+		},
+		{
+			inFunctionName: BidderIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty bidders argument in bidderIn schema function"),
+		},
+		{
+			inFunctionName:     BidPriceBelow,
+			inParams:           json.RawMessage(`{"price": 0.5}`),
+			expectedSchemaFunc: &bidPriceBelow{Price: ptrutil.ToPtr(0.5)},
+		},
+		{
+			inFunctionName: BidPriceBelow,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Missing price argument for bidPriceBelow schema function"),
+		},
+		{
+			inFunctionName: BidPriceBelow,
+			inParams:       json.RawMessage(`{"price": -1}`),
+			expectedError:  errors.New("Negative price argument in bidPriceBelow schema function"),
+		},
+		{
+			inFunctionName:     DealIdAvailable,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &dealIdAvailable{},
+		},
+		{
+			inFunctionName: DealIdAvailable,
+			inParams:       json.RawMessage(`{"dealids": ["deal1"]}`),
+			expectedError:  errors.New("dealIdAvailable expects 0 arguments"),
+		},
+		{
+			inFunctionName: DealIdIn,
+			inParams:       json.RawMessage(`{"dealids": ["deal1"]}`),
+			expectedSchemaFunc: &dealIdIn{
+				DealIds: []string{"deal1"},
+				DealDir: map[string]struct{}{"deal1": {}},
+			},
+		},
+		{
+			inFunctionName: DealIdIn,
+			inParams:       json.RawMessage(`{"dealids": null}`),
+			expectedError:  errors.New("Empty dealids argument in dealIdIn schema function"),
+		},
+		{
+			inFunctionName:     MediaType,
+			inParams:           nil,
+			expectedSchemaFunc: &mediaType{},
+		},
+		{
+			inFunctionName: MediaTypeIn,
+			inParams:       json.RawMessage(`{"types": ["video"]}`),
+			expectedSchemaFunc: &mediaTypeIn{
+				MediaTypes:   []string{"video"},
+				MediaTypeDir: map[string]struct{}{"video": {}},
+			},
+		},
+		{
+			inFunctionName: MediaTypeIn,
+			inParams:       json.RawMessage(`{"types": []}`),
+			expectedError:  errors.New("Empty types argument in mediaTypeIn schema function"),
+		},
+		{
+			inFunctionName: DeviceCountry,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Schema function deviceCountry was not created"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inFunctionName, func(t *testing.T) {
+			schemaFunc, err := NewBidSchemaFunction(tc.inFunctionName, tc.inParams)
+
+			assert.Equal(t, tc.expectedSchemaFunc, schemaFunc)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestBidSchemaFunctionsCall(t *testing.T) {
+	bid := &ProcessedBid{
+		Bidder: "appnexus",
+		Bid: &entities.PbsOrtbBid{
+			Bid: &openrtb2.Bid{
+				ID:      "bid1",
+				Price:   1.25,
+				ADomain: []string{"other.com", "Advertiser.com"},
+				DealID:  "deal1",
+			},
+			BidType: openrtb_ext.BidTypeVideo,
+		},
+	}
+	bidWithoutDeal := &ProcessedBid{
+		Bidder: "rubicon",
+		Bid: &entities.PbsOrtbBid{
+			Bid:     &openrtb2.Bid{ID: "bid2", Price: 0.1},
+			BidType: openrtb_ext.BidTypeBanner,
+		},
+	}
+	emptyBid := &ProcessedBid{Bidder: "appnexus", Bid: &entities.PbsOrtbBid{}}
+
+	testCases := []struct {
+		desc           string
+		inFunctionName string
+		inParams       json.RawMessage
+		inBid          *ProcessedBid
+		expectedResult string
+	}{
+		{
+			desc:           "adomainIn match is case insensitive",
+			inFunctionName: AdomainIn,
+			inParams:       json.RawMessage(`{"domains": ["advertiser.com"]}`),
+			inBid:          bid,
+			expectedResult: "true",
+		},
+		{
+			desc:           "adomainIn no match",
+			inFunctionName: AdomainIn,
+			inParams:       json.RawMessage(`{"domains": ["advertiser.com"]}`),
+			inBid:          bidWithoutDeal,
+			expectedResult: "false",
+		},
+		{
+			desc:           "adomainIn nil ortb bid",
+			inFunctionName: AdomainIn,
+			inParams:       json.RawMessage(`{"domains": ["advertiser.com"]}`),
+			inBid:          emptyBid,
+			expectedResult: "false",
+		},
+		{
+			desc:           "bidder",
+			inFunctionName: Bidder,
+			inBid:          bid,
+			expectedResult: "appnexus",
+		},
+		{
+			desc:           "bidder nil payload",
+			inFunctionName: Bidder,
+			inBid:          nil,
+			expectedResult: "",
+		},
+		{
+			desc:           "bidderIn match",
+			inFunctionName: BidderIn,
+			inParams:       json.RawMessage(`{"bidders": ["AppNexus"]}`),
+			inBid:          bid,
+			expectedResult: "true",
+		},
+		{
+			desc:           "bidderIn no match",
+			inFunctionName: BidderIn,
+			inParams:       json.RawMessage(`{"bidders": ["appnexus"]}`),
+			inBid:          bidWithoutDeal,
+			expectedResult: "false",
+		},
+		{
+			desc:           "bidPriceBelow price above threshold",
+			inFunctionName: BidPriceBelow,
+			inParams:       json.RawMessage(`{"price": 1.25}`),
+			inBid:          bid,
+			expectedResult: "false",
+		},
+		{
+			desc:           "bidPriceBelow price below threshold",
+			inFunctionName: BidPriceBelow,
+			inParams:       json.RawMessage(`{"price": 1.25}`),
+			inBid:          bidWithoutDeal,
+			expectedResult: "true",
+		},
+		{
+			desc:           "bidPriceBelow nil ortb bid",
+			inFunctionName: BidPriceBelow,
+			inParams:       json.RawMessage(`{"price": 1.25}`),
+			inBid:          emptyBid,
+			expectedResult: "false",
+		},
+		{
+			desc:           "dealIdAvailable with deal",
+			inFunctionName: DealIdAvailable,
+			inBid:          bid,
+			expectedResult: "true",
+		},
+		{
+			desc:           "dealIdAvailable without deal",
+			inFunctionName: DealIdAvailable,
+			inBid:          bidWithoutDeal,
+			expectedResult: "false",
+		},
+		{
+			desc:           "dealIdIn match",
+			inFunctionName: DealIdIn,
+			inParams:       json.RawMessage(`{"dealids": ["deal1", "deal2"]}`),
+			inBid:          bid,
+			expectedResult: "true",
+		},
+		{
+			desc:           "dealIdIn without deal",
+			inFunctionName: DealIdIn,
+			inParams:       json.RawMessage(`{"dealids": ["deal1", "deal2"]}`),
+			inBid:          bidWithoutDeal,
+			expectedResult: "false",
+		},
+		{
+			desc:           "mediaType",
+			inFunctionName: MediaType,
+			inBid:          bid,
+			expectedResult: "video",
+		},
+		{
+			desc:           "mediaType without bid type",
+			inFunctionName: MediaType,
+			inBid:          emptyBid,
+			expectedResult: "",
+		},
+		{
+			desc:           "mediaTypeIn match",
+			inFunctionName: MediaTypeIn,
+			inParams:       json.RawMessage(`{"types": ["video", "audio"]}`),
+			inBid:          bid,
+			expectedResult: "true",
+		},
+		{
+			desc:           "mediaTypeIn no match",
+			inFunctionName: MediaTypeIn,
+			inParams:       json.RawMessage(`{"types": ["video", "audio"]}`),
+			inBid:          bidWithoutDeal,
+			expectedResult: "false",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			schemaFunc, err := NewBidSchemaFunction(tc.inFunctionName, tc.inParams)
+			assert.NoError(t, err)
+
+			result, err := schemaFunc.Call(tc.inBid)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestBidSchemaFunctionsName(t *testing.T) {
+	testCases := []struct {
+		expectedSchemaFuncName string
+		inSchemaFunc           SchemaFunction[ProcessedBid]
+	}{
+		{expectedSchemaFuncName: AdomainIn, inSchemaFunc: &adomainIn{}},
+		{expectedSchemaFuncName: Bidder, inSchemaFunc: &bidder{}},
+		{expectedSchemaFuncName: BidderIn, inSchemaFunc: &bidderIn{}},
+		{expectedSchemaFuncName: BidPriceBelow, inSchemaFunc: &bidPriceBelow{}},
+		{expectedSchemaFuncName: DealIdAvailable, inSchemaFunc: &dealIdAvailable{}},
+		{expectedSchemaFuncName: DealIdIn, inSchemaFunc: &dealIdIn{}},
+		{expectedSchemaFuncName: MediaType, inSchemaFunc: &mediaType{}},
+		{expectedSchemaFuncName: MediaTypeIn, inSchemaFunc: &mediaTypeIn{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expectedSchemaFuncName, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSchemaFuncName, tc.inSchemaFunc.Name())
+		})
+	}
+}
@@ -0,0 +1,964 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	// embeds the IANA timezone database so timezone args load on hosts without one
+	_ "time/tzdata"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/jsonutil"
+	"github.com/prebid/prebid-server/v3/util/timeutil"
+)
+
+const (
+	AdUnitCode        = "adUnitCode"
+	AdUnitCodeIn      = "adUnitCodeIn"
+	Browser           = "browser"
+	BrowserIn         = "browserIn"
+	Bundle            = "bundle"
+	BundleIn          = "bundleIn"
+	BuyerUidAvailable = "buyerUidAvailable"
+	BuyerUidIn        = "buyerUidIn"
+	DayOfWeek         = "dayOfWeek"
+	DayOfWeekIn       = "dayOfWeekIn"
+	DeviceOs          = "deviceOs"
+	DeviceOsIn        = "deviceOsIn"
+	DeviceType        = "deviceType"
+	DeviceTypeIn      = "deviceTypeIn"
+	Domain            = "domain"
+	DomainIn          = "domainIn"
+	HourOfDay         = "hourOfDay"
+	HourOfDayIn       = "hourOfDayIn"
+	ImpMediaType      = "impMediaType"
+	ImpMediaTypeIn    = "impMediaTypeIn"
+	PublisherId       = "publisherId"
+	PublisherIdIn     = "publisherIdIn"
+	RequestSize       = "requestSize"
+	RequestSizeIn     = "requestSizeIn"
+)
+
+// defaultRequestSizeBuckets are the imp count upper bounds requestSize uses when no buckets are configured
+var defaultRequestSizeBuckets = []int{1, 3, 5, 10}
+
+// ------------deviceType-------------------
+type deviceType struct{}
+
+func NewDeviceType(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, DeviceType); err != nil {
+		return nil, err
+	}
+	return &deviceType{}, nil
+}
+
+func (dt *deviceType) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getDeviceType(getDevice(wrapper)), nil
+}
+
+func (dt *deviceType) Name() string {
+	return DeviceType
+}
+
+// ------------deviceTypeIn-----------------
+type deviceTypeIn struct {
+	DeviceTypes   []string `json:"types"`
+	DeviceTypeDir map[string]struct{}
+}
+
+func NewDeviceTypeIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &deviceTypeIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.DeviceTypes) == 0 {
+		return nil, errors.New("Empty types argument in deviceTypeIn schema function")
+	}
+	schemaFunc.DeviceTypeDir = newLowerCaseDir(schemaFunc.DeviceTypes)
+
+	return schemaFunc, nil
+}
+
+func (dti *deviceTypeIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(dti.DeviceTypeDir, getDeviceType(getDevice(wrapper))), nil
+}
+
+func (dti *deviceTypeIn) Name() string {
+	return DeviceTypeIn
+}
+
+// ------------deviceOs---------------------
+type deviceOs struct{}
+
+func NewDeviceOs(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, DeviceOs); err != nil {
+		return nil, err
+	}
+	return &deviceOs{}, nil
+}
+
+func (do *deviceOs) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getDeviceOS(getDevice(wrapper)), nil
+}
+
+func (do *deviceOs) Name() string {
+	return DeviceOs
+}
+
+// ------------deviceOsIn-------------------
+type deviceOsIn struct {
+	OSes  []string `json:"oses"`
+	OSDir map[string]struct{}
+}
+
+func NewDeviceOsIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &deviceOsIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.OSes) == 0 {
+		return nil, errors.New("Empty oses argument in deviceOsIn schema function")
+	}
+
+	schemaFunc.OSDir = make(map[string]struct{})
+	for i := range schemaFunc.OSes {
+		schemaFunc.OSDir[normalizeOS(schemaFunc.OSes[i])] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (doi *deviceOsIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(doi.OSDir, getDeviceOS(getDevice(wrapper))), nil
+}
+
+func (doi *deviceOsIn) Name() string {
+	return DeviceOsIn
+}
+
+// ------------browser----------------------
+type browser struct{}
+
+func NewBrowser(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, Browser); err != nil {
+		return nil, err
+	}
+	return &browser{}, nil
+}
+
+func (b *browser) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getBrowser(getDevice(wrapper)), nil
+}
+
+func (b *browser) Name() string {
+	return Browser
+}
+
+// ------------browserIn--------------------
+type browserIn struct {
+	Browsers   []string `json:"browsers"`
+	BrowserDir map[string]struct{}
+}
+
+func NewBrowserIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &browserIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Browsers) == 0 {
+		return nil, errors.New("Empty browsers argument in browserIn schema function")
+	}
+	schemaFunc.BrowserDir = newLowerCaseDir(schemaFunc.Browsers)
+
+	return schemaFunc, nil
+}
+
+func (bi *browserIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(bi.BrowserDir, getBrowser(getDevice(wrapper))), nil
+}
+
+func (bi *browserIn) Name() string {
+	return BrowserIn
+}
+
+// ------------impMediaType-----------------
+// impMediaType returns the media types requested by the imps, sorted and comma separated
+type impMediaType struct{}
+
+func NewImpMediaType(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, ImpMediaType); err != nil {
+		return nil, err
+	}
+	return &impMediaType{}, nil
+}
+
+func (imt *impMediaType) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return strings.Join(getImpMediaTypes(wrapper), ","), nil
+}
+
+func (imt *impMediaType) Name() string {
+	return ImpMediaType
+}
+
+// ------------impMediaTypeIn---------------
+type impMediaTypeIn struct {
+	MediaTypes   []string `json:"types"`
+	MediaTypeDir map[string]struct{}
+}
+
+func NewImpMediaTypeIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &impMediaTypeIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.MediaTypes) == 0 {
+		return nil, errors.New("Empty types argument in impMediaTypeIn schema function")
+	}
+	schemaFunc.MediaTypeDir = newLowerCaseDir(schemaFunc.MediaTypes)
+
+	return schemaFunc, nil
+}
+
+func (imti *impMediaTypeIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	for _, mediaType := range getImpMediaTypes(wrapper) {
+		if _, found := imti.MediaTypeDir[mediaType]; found {
+			return "true", nil
+		}
+	}
+	return "false", nil
+}
+
+func (imti *impMediaTypeIn) Name() string {
+	return ImpMediaTypeIn
+}
+
+// ------------adUnitCode-------------------
+// adUnitCode returns the ad unit code of the first imp, taken from imp.ext.gpid, imp.tagid or
+// imp.ext.data.pbadslot in that order of preference
+type adUnitCode struct{}
+
+func NewAdUnitCode(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, AdUnitCode); err != nil {
+		return nil, err
+	}
+	return &adUnitCode{}, nil
+}
+
+func (auc *adUnitCode) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return "", nil
+	}
+	imps := wrapper.GetImp()
+	if len(imps) == 0 {
+		return "", nil
+	}
+
+	codes, err := getAdUnitCodes(imps[0])
+	if err != nil || len(codes) == 0 {
+		return "", err
+	}
+	return codes[0], nil
+}
+
+func (auc *adUnitCode) Name() string {
+	return AdUnitCode
+}
+
+// ------------adUnitCodeIn-----------------
+// adUnitCodeIn is true when any imp has an ad unit code, tag ID or ad slot in the configured codes
+type adUnitCodeIn struct {
+	Codes   []string `json:"codes"`
+	CodeDir map[string]struct{}
+}
+
+func NewAdUnitCodeIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &adUnitCodeIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Codes) == 0 {
+		return nil, errors.New("Empty codes argument in adUnitCodeIn schema function")
+	}
+
+	schemaFunc.CodeDir = make(map[string]struct{})
+	for i := range schemaFunc.Codes {
+		schemaFunc.CodeDir[schemaFunc.Codes[i]] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (auci *adUnitCodeIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return "false", nil
+	}
+
+	for _, imp := range wrapper.GetImp() {
+		codes, err := getAdUnitCodes(imp)
+		if err != nil {
+			return "false", err
+		}
+		for _, code := range codes {
+			if _, found := auci.CodeDir[code]; found {
+				return "true", nil
+			}
+		}
+	}
+	return "false", nil
+}
+
+func (auci *adUnitCodeIn) Name() string {
+	return AdUnitCodeIn
+}
+
+// ------------domain-----------------------
+type domain struct{}
+
+func NewDomain(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, Domain); err != nil {
+		return nil, err
+	}
+	return &domain{}, nil
+}
+
+func (d *domain) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getDomain(wrapper), nil
+}
+
+func (d *domain) Name() string {
+	return Domain
+}
+
+// ------------domainIn---------------------
+type domainIn struct {
+	Domains   []string `json:"domains"`
+	DomainDir map[string]struct{}
+}
+
+func NewDomainIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &domainIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Domains) == 0 {
+		return nil, errors.New("Empty domains argument in domainIn schema function")
+	}
+	schemaFunc.DomainDir = newLowerCaseDir(schemaFunc.Domains)
+
+	return schemaFunc, nil
+}
+
+func (di *domainIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(di.DomainDir, strings.ToLower(getDomain(wrapper))), nil
+}
+
+func (di *domainIn) Name() string {
+	return DomainIn
+}
+
+// ------------bundle-----------------------
+type bundle struct{}
+
+func NewBundle(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, Bundle); err != nil {
+		return nil, err
+	}
+	return &bundle{}, nil
+}
+
+func (b *bundle) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getBundle(wrapper), nil
+}
+
+func (b *bundle) Name() string {
+	return Bundle
+}
+
+// ------------bundleIn---------------------
+type bundleIn struct {
+	Bundles   []string `json:"bundles"`
+	BundleDir map[string]struct{}
+}
+
+func NewBundleIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &bundleIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Bundles) == 0 {
+		return nil, errors.New("Empty bundles argument in bundleIn schema function")
+	}
+
+	schemaFunc.BundleDir = make(map[string]struct{})
+	for i := range schemaFunc.Bundles {
+		schemaFunc.BundleDir[schemaFunc.Bundles[i]] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (bi *bundleIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(bi.BundleDir, getBundle(wrapper)), nil
+}
+
+func (bi *bundleIn) Name() string {
+	return BundleIn
+}
+
+// ------------publisherId------------------
+type publisherId struct{}
+
+func NewPublisherId(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, PublisherId); err != nil {
+		return nil, err
+	}
+	return &publisherId{}, nil
+}
+
+func (p *publisherId) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getPublisherId(wrapper), nil
+}
+
+func (p *publisherId) Name() string {
+	return PublisherId
+}
+
+// ------------publisherIdIn----------------
+type publisherIdIn struct {
+	PublisherIds []string `json:"pubids"`
+	PublisherDir map[string]struct{}
+}
+
+func NewPublisherIdIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &publisherIdIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.PublisherIds) == 0 {
+		return nil, errors.New("Empty pubids argument in publisherIdIn schema function")
+	}
+
+	schemaFunc.PublisherDir = make(map[string]struct{})
+	for i := range schemaFunc.PublisherIds {
+		schemaFunc.PublisherDir[schemaFunc.PublisherIds[i]] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (pi *publisherIdIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(pi.PublisherDir, getPublisherId(wrapper)), nil
+}
+
+func (pi *publisherIdIn) Name() string {
+	return PublisherIdIn
+}
+
+// ------------hourOfDay--------------------
+// hourOfDay returns the hour, from 0 to 23, in the configured IANA timezone which defaults to UTC
+type hourOfDay struct {
+	Timezone string `json:"timezone"`
+	location *time.Location
+	clock    timeutil.Time
+}
+
+func NewHourOfDay(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &hourOfDay{clock: &timeutil.RealTime{}}
+	if err := unmarshalOptionalArgs(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	location, err := loadTimezone(schemaFunc.Timezone, HourOfDay)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.location = location
+
+	return schemaFunc, nil
+}
+
+func (h *hourOfDay) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return strconv.Itoa(h.clock.Now().In(h.location).Hour()), nil
+}
+
+func (h *hourOfDay) Name() string {
+	return HourOfDay
+}
+
+// ------------hourOfDayIn------------------
+type hourOfDayIn struct {
+	Hours    []int  `json:"hours"`
+	Timezone string `json:"timezone"`
+	HourDir  map[int]struct{}
+	location *time.Location
+	clock    timeutil.Time
+}
+
+func NewHourOfDayIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &hourOfDayIn{clock: &timeutil.RealTime{}}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Hours) == 0 {
+		return nil, errors.New("Empty hours argument in hourOfDayIn schema function")
+	}
+
+	schemaFunc.HourDir = make(map[int]struct{})
+	for _, hour := range schemaFunc.Hours {
+		if hour < 0 || hour > 23 {
+			return nil, fmt.Errorf("Invalid hour %d in hourOfDayIn schema function", hour)
+		}
+		schemaFunc.HourDir[hour] = struct{}{}
+	}
+
+	location, err := loadTimezone(schemaFunc.Timezone, HourOfDayIn)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.location = location
+
+	return schemaFunc, nil
+}
+
+func (hi *hourOfDayIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	_, found := hi.HourDir[hi.clock.Now().In(hi.location).Hour()]
+	return fmt.Sprintf("%t", found), nil
+}
+
+func (hi *hourOfDayIn) Name() string {
+	return HourOfDayIn
+}
+
+// ------------dayOfWeek--------------------
+// dayOfWeek returns the lower case English name of the day in the configured IANA timezone which defaults to UTC
+type dayOfWeek struct {
+	Timezone string `json:"timezone"`
+	location *time.Location
+	clock    timeutil.Time
+}
+
+func NewDayOfWeek(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &dayOfWeek{clock: &timeutil.RealTime{}}
+	if err := unmarshalOptionalArgs(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	location, err := loadTimezone(schemaFunc.Timezone, DayOfWeek)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.location = location
+
+	return schemaFunc, nil
+}
+
+func (d *dayOfWeek) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return strings.ToLower(d.clock.Now().In(d.location).Weekday().String()), nil
+}
+
+func (d *dayOfWeek) Name() string {
+	return DayOfWeek
+}
+
+// ------------dayOfWeekIn------------------
+type dayOfWeekIn struct {
+	Days     []string `json:"days"`
+	Timezone string   `json:"timezone"`
+	DayDir   map[time.Weekday]struct{}
+	location *time.Location
+	clock    timeutil.Time
+}
+
+func NewDayOfWeekIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &dayOfWeekIn{clock: &timeutil.RealTime{}}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Days) == 0 {
+		return nil, errors.New("Empty days argument in dayOfWeekIn schema function")
+	}
+
+	schemaFunc.DayDir = make(map[time.Weekday]struct{})
+	for _, day := range schemaFunc.Days {
+		weekday, found := parseWeekday(day)
+		if !found {
+			return nil, fmt.Errorf("Invalid day %s in dayOfWeekIn schema function", day)
+		}
+		schemaFunc.DayDir[weekday] = struct{}{}
+	}
+
+	location, err := loadTimezone(schemaFunc.Timezone, DayOfWeekIn)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.location = location
+
+	return schemaFunc, nil
+}
+
+func (di *dayOfWeekIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	_, found := di.DayDir[di.clock.Now().In(di.location).Weekday()]
+	return fmt.Sprintf("%t", found), nil
+}
+
+func (di *dayOfWeekIn) Name() string {
+	return DayOfWeekIn
+}
+
+// ------------buyerUidAvailable------------
+// buyerUidAvailable is true when the request carries user.buyeruid or any user.ext.prebid.buyeruids entry
+type buyerUidAvailable struct{}
+
+func NewBuyerUidAvailable(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	if err := checkNilArgs(params, BuyerUidAvailable); err != nil {
+		return nil, err
+	}
+	return &buyerUidAvailable{}, nil
+}
+
+func (bua *buyerUidAvailable) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	if wrapper == nil || wrapper.BidRequest == nil || wrapper.User == nil {
+		return "false", nil
+	}
+	if len(wrapper.User.BuyerUID) > 0 {
+		return "true", nil
+	}
+
+	buyerUIDs, err := getBuyerUIDs(wrapper)
+	if err != nil {
+		return "false", err
+	}
+	for _, uid := range buyerUIDs {
+		if len(uid) > 0 {
+			return "true", nil
+		}
+	}
+	return "false", nil
+}
+
+func (bua *buyerUidAvailable) Name() string {
+	return BuyerUidAvailable
+}
+
+// ------------buyerUidIn-------------------
+// buyerUidIn is true when user.ext.prebid.buyeruids has a buyer UID for any of the configured bidders
+type buyerUidIn struct {
+	Bidders   []string `json:"bidders"`
+	BidderDir map[string]struct{}
+}
+
+func NewBuyerUidIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &buyerUidIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	if len(schemaFunc.Bidders) == 0 {
+		return nil, errors.New("Empty bidders argument in buyerUidIn schema function")
+	}
+	schemaFunc.BidderDir = newLowerCaseDir(schemaFunc.Bidders)
+
+	return schemaFunc, nil
+}
+
+func (bui *buyerUidIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	if wrapper == nil || wrapper.BidRequest == nil || wrapper.User == nil {
+		return "false", nil
+	}
+
+	buyerUIDs, err := getBuyerUIDs(wrapper)
+	if err != nil {
+		return "false", err
+	}
+	for bidder, uid := range buyerUIDs {
+		if _, found := bui.BidderDir[strings.ToLower(bidder)]; found && len(uid) > 0 {
+			return "true", nil
+		}
+	}
+	return "false", nil
+}
+
+func (bui *buyerUidIn) Name() string {
+	return BuyerUidIn
+}
+
+// ------------requestSize------------------
+// requestSize returns the bucket the number of imps falls in. Buckets are given as ascending upper
+// bounds and labeled by the imp counts they cover, e.g. buckets [1, 3] are labeled "1", "2-3" and "4+"
+type requestSize struct {
+	Buckets []int `json:"buckets"`
+}
+
+func NewRequestSize(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &requestSize{}
+	if err := unmarshalOptionalArgs(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	buckets, err := checkRequestSizeBuckets(schemaFunc.Buckets, RequestSize)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.Buckets = buckets
+
+	return schemaFunc, nil
+}
+
+func (rs *requestSize) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return getRequestSizeBucket(rs.Buckets, getImpCount(wrapper)), nil
+}
+
+func (rs *requestSize) Name() string {
+	return RequestSize
+}
+
+// ------------requestSizeIn----------------
+type requestSizeIn struct {
+	Buckets []int    `json:"buckets"`
+	Sizes   []string `json:"sizes"`
+	SizeDir map[string]struct{}
+}
+
+func NewRequestSizeIn(params json.RawMessage) (SchemaFunction[openrtb_ext.RequestWrapper], error) {
+	schemaFunc := &requestSizeIn{}
+	if err := jsonutil.Unmarshal(params, schemaFunc); err != nil {
+		return nil, err
+	}
+
+	buckets, err := checkRequestSizeBuckets(schemaFunc.Buckets, RequestSizeIn)
+	if err != nil {
+		return nil, err
+	}
+	schemaFunc.Buckets = buckets
+
+	if len(schemaFunc.Sizes) == 0 {
+		return nil, errors.New("Empty sizes argument in requestSizeIn schema function")
+	}
+
+	labels := getRequestSizeBucketLabels(buckets)
+	schemaFunc.SizeDir = make(map[string]struct{})
+	for _, size := range schemaFunc.Sizes {
+		if !slices.Contains(labels, size) {
+			return nil, fmt.Errorf("Size %s in requestSizeIn schema function is not one of the bucket labels %s", size, strings.Join(labels, ", "))
+		}
+		schemaFunc.SizeDir[size] = struct{}{}
+	}
+
+	return schemaFunc, nil
+}
+
+func (rsi *requestSizeIn) Call(wrapper *openrtb_ext.RequestWrapper) (string, error) {
+	return dirContains(rsi.SizeDir, getRequestSizeBucket(rsi.Buckets, getImpCount(wrapper))), nil
+}
+
+func (rsi *requestSizeIn) Name() string {
+	return RequestSizeIn
+}
+
+func getDevice(wrapper *openrtb_ext.RequestWrapper) *openrtb2.Device {
+	if wrapper != nil && wrapper.BidRequest != nil {
+		return wrapper.Device
+	}
+	return nil
+}
+
+func getDomain(wrapper *openrtb_ext.RequestWrapper) string {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return ""
+	}
+	switch {
+	case wrapper.Site != nil:
+		return wrapper.Site.Domain
+	case wrapper.App != nil:
+		return wrapper.App.Domain
+	case wrapper.DOOH != nil:
+		return wrapper.DOOH.Domain
+	}
+	return ""
+}
+
+func getBundle(wrapper *openrtb_ext.RequestWrapper) string {
+	if wrapper != nil && wrapper.BidRequest != nil && wrapper.App != nil {
+		return wrapper.App.Bundle
+	}
+	return ""
+}
+
+func getPublisherId(wrapper *openrtb_ext.RequestWrapper) string {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return ""
+	}
+
+	var publisher *openrtb2.Publisher
+	switch {
+	case wrapper.Site != nil:
+		publisher = wrapper.Site.Publisher
+	case wrapper.App != nil:
+		publisher = wrapper.App.Publisher
+	case wrapper.DOOH != nil:
+		publisher = wrapper.DOOH.Publisher
+	}
+	if publisher == nil {
+		return ""
+	}
+	return publisher.ID
+}
+
+func getBuyerUIDs(wrapper *openrtb_ext.RequestWrapper) (map[string]string, error) {
+	userExt, err := wrapper.GetUserExt()
+	if err != nil {
+		return nil, err
+	}
+	if prebid := userExt.GetPrebid(); prebid != nil {
+		return prebid.BuyerUIDs, nil
+	}
+	return nil, nil
+}
+
+// getImpMediaTypes returns the sorted media types requested by any of the imps
+func getImpMediaTypes(wrapper *openrtb_ext.RequestWrapper) []string {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return nil
+	}
+
+	var mediaTypes []string
+	addMediaType := func(present bool, mediaType openrtb_ext.BidType) {
+		if present && !slices.Contains(mediaTypes, string(mediaType)) {
+			mediaTypes = append(mediaTypes, string(mediaType))
+		}
+	}
+	for _, imp := range wrapper.GetImp() {
+		addMediaType(imp.Banner != nil, openrtb_ext.BidTypeBanner)
+		addMediaType(imp.Video != nil, openrtb_ext.BidTypeVideo)
+		addMediaType(imp.Audio != nil, openrtb_ext.BidTypeAudio)
+		addMediaType(imp.Native != nil, openrtb_ext.BidTypeNative)
+	}
+	slices.Sort(mediaTypes)
+	return mediaTypes
+}
+
+// getAdUnitCodes returns the non empty imp.ext.gpid, imp.tagid and imp.ext.data.pbadslot of the imp
+func getAdUnitCodes(imp *openrtb_ext.ImpWrapper) ([]string, error) {
+	impExt, err := imp.GetImpExt()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, 3)
+	if gpid := impExt.GetGpId(); len(gpid) > 0 {
+		codes = append(codes, gpid)
+	}
+	if len(imp.TagID) > 0 {
+		codes = append(codes, imp.TagID)
+	}
+	if data := impExt.GetData(); data != nil && len(data.PbAdslot) > 0 {
+		codes = append(codes, data.PbAdslot)
+	}
+	return codes, nil
+}
+
+func getImpCount(wrapper *openrtb_ext.RequestWrapper) int {
+	if wrapper == nil || wrapper.BidRequest == nil {
+		return 0
+	}
+	return len(wrapper.GetImp())
+}
+
+func checkRequestSizeBuckets(buckets []int, funcName string) ([]int, error) {
+	if len(buckets) == 0 {
+		return defaultRequestSizeBuckets, nil
+	}
+	for i, bucket := range buckets {
+		if bucket < 1 || (i > 0 && bucket <= buckets[i-1]) {
+			return nil, fmt.Errorf("Buckets argument in %s schema function must hold ascending positive imp counts", funcName)
+		}
+	}
+	return buckets, nil
+}
+
+func getRequestSizeBucket(buckets []int, impCount int) string {
+	lower := 1
+	for _, upper := range buckets {
+		if impCount <= upper {
+			return getRequestSizeBucketLabel(lower, upper)
+		}
+		lower = upper + 1
+	}
+	return fmt.Sprintf("%d+", lower)
+}
+
+func getRequestSizeBucketLabels(buckets []int) []string {
+	labels := make([]string, 0, len(buckets)+1)
+	lower := 1
+	for _, upper := range buckets {
+		labels = append(labels, getRequestSizeBucketLabel(lower, upper))
+		lower = upper + 1
+	}
+	return append(labels, fmt.Sprintf("%d+", lower))
+}
+
+func getRequestSizeBucketLabel(lower, upper int) string {
+	if lower == upper {
+		return strconv.Itoa(upper)
+	}
+	return fmt.Sprintf("%d-%d", lower, upper)
+}
+
+// unmarshalOptionalArgs unmarshals the args of schema functions that can be used without args
+func unmarshalOptionalArgs(params json.RawMessage, schemaFunc any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	return jsonutil.Unmarshal(params, schemaFunc)
+}
+
+func loadTimezone(timezone string, funcName string) (*time.Location, error) {
+	if len(timezone) == 0 {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid timezone %s in %s schema function", timezone, funcName)
+	}
+	return location, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+func newLowerCaseDir(values []string) map[string]struct{} {
+	dir := make(map[string]struct{}, len(values))
+	for i := range values {
+		dir[strings.ToLower(values[i])] = struct{}{}
+	}
+	return dir
+}
+
+func dirContains(dir map[string]struct{}, value string) string {
+	if len(value) == 0 {
+		return "false"
+	}
+	_, found := dir[value]
+	return fmt.Sprintf("%t", found)
+}
@@ -0,0 +1,617 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/adcom1"
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v3/openrtb_ext"
+	"github.com/prebid/prebid-server/v3/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTime struct {
+	time time.Time
+}
+
+func (ft *fakeTime) Now() time.Time {
+	return ft.time
+}
+
+func TestNewRequestSchemaFunctionSegments(t *testing.T) {
+	testCases := []struct {
+		inFunctionName     string
+		inParams           json.RawMessage
+		expectedSchemaFunc SchemaFunction[openrtb_ext.RequestWrapper]
+		expectedError      error
+	}{
+		{
+			inFunctionName:     DeviceType,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &deviceType{},
+		},
+		{
+			inFunctionName: DeviceTypeIn,
+			inParams:       json.RawMessage(`{"types": ["Mobile", "tablet"]}`),
+			expectedSchemaFunc: &deviceTypeIn{
+				DeviceTypes:   []string{"Mobile", "tablet"},
+				DeviceTypeDir: map[string]struct{}{"mobile": {}, "tablet": {}},
+			},
+		},
+		{
+			inFunctionName: DeviceTypeIn,
+			inParams:       json.RawMessage(`{"types": []}`),
+			expectedError:  errors.New("Empty types argument in deviceTypeIn schema function"),
+		},
+		{
+			inFunctionName:     DeviceOs,
+			inParams:           nil,
+			expectedSchemaFunc: &deviceOs{},
+		},
+		{
+			inFunctionName: DeviceOsIn,
+			inParams:       json.RawMessage(`{"oses": ["iOS", "Mac OS X"]}`),
+			expectedSchemaFunc: &deviceOsIn{
+				OSes:  []string{"iOS", "Mac OS X"},
+				OSDir: map[string]struct{}{"ios": {}, "macos": {}},
+			},
+		},
+		{
+			inFunctionName: DeviceOsIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty oses argument in deviceOsIn schema function"),
+		},
+		{
+			inFunctionName:     Browser,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &browser{},
+		},
+		{
+			inFunctionName: BrowserIn,
+			inParams:       json.RawMessage(`{"browsers": ["Chrome"]}`),
+			expectedSchemaFunc: &browserIn{
+				Browsers:   []string{"Chrome"},
+				BrowserDir: map[string]struct{}{"chrome": {}},
+			},
+		},
+		{
+			inFunctionName: BrowserIn,
+			inParams:       json.RawMessage(`{"browsers": null}`),
+			expectedError:  errors.New("Empty browsers argument in browserIn schema function"),
+		},
+		{
+			inFunctionName:     ImpMediaType,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &impMediaType{},
+		},
+		{
+			inFunctionName: ImpMediaTypeIn,
+			inParams:       json.RawMessage(`{"types": ["video"]}`),
+			expectedSchemaFunc: &impMediaTypeIn{
+				MediaTypes:   []string{"video"},
+				MediaTypeDir: map[string]struct{}{"video": {}},
+			},
+		},
+		{
+			inFunctionName: ImpMediaTypeIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty types argument in impMediaTypeIn schema function"),
+		},
+		{
+			inFunctionName:     AdUnitCode,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &adUnitCode{},
+		},
+		{
+			inFunctionName: AdUnitCodeIn,
+			inParams:       json.RawMessage(`{"codes": ["/1234/home"]}`),
+			expectedSchemaFunc: &adUnitCodeIn{
+				Codes:   []string{"/1234/home"},
+				CodeDir: map[string]struct{}{"/1234/home": {}},
+			},
+		},
+		{
+			inFunctionName: AdUnitCodeIn,
+			inParams:       json.RawMessage(`{"codes": []}`),
+			expectedError:  errors.New("Empty codes argument in adUnitCodeIn schema function"),
+		},
+		{
+			inFunctionName:     Domain,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &domain{},
+		},
+		{
+			inFunctionName: DomainIn,
+			inParams:       json.RawMessage(`{"domains": ["Example.com"]}`),
+			expectedSchemaFunc: &domainIn{
+				Domains:   []string{"Example.com"},
+				DomainDir: map[string]struct{}{"example.com": {}},
+			},
+		},
+		{
+			inFunctionName: DomainIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty domains argument in domainIn schema function"),
+		},
+		{
+			inFunctionName:     Bundle,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &bundle{},
+		},
+		{
+			inFunctionName: BundleIn,
+			inParams:       json.RawMessage(`{"bundles": ["com.example.app"]}`),
+			expectedSchemaFunc: &bundleIn{
+				Bundles:   []string{"com.example.app"},
+				BundleDir: map[string]struct{}{"com.example.app": {}},
+			},
+		},
+		{
+			inFunctionName: BundleIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty bundles argument in bundleIn schema function"),
+		},
+		{
+			inFunctionName:     PublisherId,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &publisherId{},
+		},
+		{
+			inFunctionName: PublisherIdIn,
+			inParams:       json.RawMessage(`{"pubids": ["pub1"]}`),
+			expectedSchemaFunc: &publisherIdIn{
+				PublisherIds: []string{"pub1"},
+				PublisherDir: map[string]struct{}{"pub1": {}},
+			},
+		},
+		{
+			inFunctionName: PublisherIdIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty pubids argument in publisherIdIn schema function"),
+		},
+		{
+			inFunctionName: HourOfDay,
+			inParams:       json.RawMessage(`{"timezone": "Mars/Olympus_Mons"}`),
+			expectedError:  errors.New("Invalid timezone Mars/Olympus_Mons in hourOfDay schema function"),
+		},
+		{
+			inFunctionName: HourOfDayIn,
+			inParams:       json.RawMessage(`{"hours": [9, 24]}`),
+			expectedError:  errors.New("Invalid hour 24 in hourOfDayIn schema function"),
+		},
+		{
+			inFunctionName: HourOfDayIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty hours argument in hourOfDayIn schema function"),
+		},
+		{
+			inFunctionName: DayOfWeek,
+			inParams:       json.RawMessage(`{"timezone": "Nowhere"}`),
+			expectedError:  errors.New("Invalid timezone Nowhere in dayOfWeek schema function"),
+		},
+		{
+			inFunctionName: DayOfWeekIn,
+			inParams:       json.RawMessage(`{"days": ["monday", "someday"]}`),
+			expectedError:  errors.New("Invalid day someday in dayOfWeekIn schema function"),
+		},
+		{
+			inFunctionName: DayOfWeekIn,
+			inParams:       json.RawMessage(`{"days": []}`),
+			expectedError:  errors.New("Empty days argument in dayOfWeekIn schema function"),
+		},
+		{
+			inFunctionName:     BuyerUidAvailable,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &buyerUidAvailable{},
+		},
+		{
+			inFunctionName: BuyerUidAvailable,
+			inParams:       json.RawMessage(`{"bidders": ["appnexus"]}`),
+			expectedError:  errors.New("buyerUidAvailable expects 0 arguments"),
+		},
+		{
+			inFunctionName: BuyerUidIn,
+			inParams:       json.RawMessage(`{"bidders": ["AppNexus"]}`),
+			expectedSchemaFunc: &buyerUidIn{
+				Bidders:   []string{"AppNexus"},
+				BidderDir: map[string]struct{}{"appnexus": {}},
+			},
+		},
+		{
+			inFunctionName: BuyerUidIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty bidders argument in buyerUidIn schema function"),
+		},
+		{
+			inFunctionName:     RequestSize,
+			inParams:           json.RawMessage(`{}`),
+			expectedSchemaFunc: &requestSize{Buckets: []int{1, 3, 5, 10}},
+		},
+		{
+			inFunctionName: RequestSize,
+			inParams:       json.RawMessage(`{"buckets": [2, 2]}`),
+			expectedError:  errors.New("Buckets argument in requestSize schema function must hold ascending positive imp counts"),
+		},
+		{
+			inFunctionName: RequestSizeIn,
+			inParams:       json.RawMessage(`{"buckets": [1, 4], "sizes": ["2-4", "5+"]}`),
+			expectedSchemaFunc: &requestSizeIn{
+				Buckets: []int{1, 4},
+				Sizes:   []string{"2-4", "5+"},
+				SizeDir: map[string]struct{}{"2-4": {}, "5+": {}},
+			},
+		},
+		{
+			inFunctionName: RequestSizeIn,
+			inParams:       json.RawMessage(`{"sizes": ["2-4"]}`),
+			expectedError:  errors.New("Size 2-4 in requestSizeIn schema function is not one of the bucket labels 1, 2-3, 4-5, 6-10, 11+"),
+		},
+		{
+			inFunctionName: RequestSizeIn,
+			inParams:       json.RawMessage(`{"buckets": [0]}`),
+			expectedError:  errors.New("Buckets argument in requestSizeIn schema function must hold ascending positive imp counts"),
+		},
+		{
+			inFunctionName: RequestSizeIn,
+			inParams:       json.RawMessage(`{}`),
+			expectedError:  errors.New("Empty sizes argument in requestSizeIn schema function"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inFunctionName, func(t *testing.T) {
+			schemaFunc, err := NewRequestSchemaFunction(tc.inFunctionName, tc.inParams)
+			assert.Equal(t, tc.expectedSchemaFunc, schemaFunc)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestRequestSegmentSchemaFunctionsCall(t *testing.T) {
+	siteRequest := &openrtb_ext.RequestWrapper{
+		BidRequest: &openrtb2.BidRequest{
+			Site: &openrtb2.Site{
+				Domain:    "News.Example.com",
+				Publisher: &openrtb2.Publisher{ID: "pub1"},
+			},
+			Device: &openrtb2.Device{
+				UA: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			},
+			Imp: []openrtb2.Imp{
+				{ID: "imp1", TagID: "tag1", Banner: &openrtb2.Banner{}, Ext: json.RawMessage(`{"gpid": "/1234/home"}`)},
+				{ID: "imp2", Video: &openrtb2.Video{}, Banner: &openrtb2.Banner{}, Ext: json.RawMessage(`{"data": {"pbadslot": "/1234/footer"}}`)},
+			},
+			User: &openrtb2.User{Ext: json.RawMessage(`{"prebid": {"buyeruids": {"appnexus": "uid1", "rubicon": ""}}}`)},
+		},
+	}
+	appRequest := &openrtb_ext.RequestWrapper{
+		BidRequest: &openrtb2.BidRequest{
+			App: &openrtb2.App{
+				Bundle:    "com.example.app",
+				Domain:    "example.com",
+				Publisher: &openrtb2.Publisher{ID: "pub2"},
+			},
+			Device: &openrtb2.Device{
+				DeviceType: adcom1.DeviceTablet,
+				OS:         "Android",
+			},
+			Imp:  []openrtb2.Imp{{ID: "imp1", Native: &openrtb2.Native{}}},
+			User: &openrtb2.User{BuyerUID: "uid2"},
+		},
+	}
+	emptyRequest := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{}}
+
+	testCases := []struct {
+		desc           string
+		inFunctionName string
+		inParams       json.RawMessage
+		inWrapper      *openrtb_ext.RequestWrapper
+		expectedResult string
+	}{
+		{desc: "deviceType from ua", inFunctionName: DeviceType, inWrapper: siteRequest, expectedResult: DeviceTypeMobile},
+		{desc: "deviceType from devicetype", inFunctionName: DeviceType, inWrapper: appRequest, expectedResult: DeviceTypeTablet},
+		{desc: "deviceType without device", inFunctionName: DeviceType, inWrapper: emptyRequest, expectedResult: ""},
+		{desc: "deviceTypeIn match", inFunctionName: DeviceTypeIn, inParams: json.RawMessage(`{"types": ["Tablet"]}`), inWrapper: appRequest, expectedResult: "true"},
+		{desc: "deviceTypeIn no match", inFunctionName: DeviceTypeIn, inParams: json.RawMessage(`{"types": ["tablet"]}`), inWrapper: siteRequest, expectedResult: "false"},
+		{desc: "deviceTypeIn without device", inFunctionName: DeviceTypeIn, inParams: json.RawMessage(`{"types": ["tablet"]}`), inWrapper: emptyRequest, expectedResult: "false"},
+		{desc: "deviceOs from ua", inFunctionName: DeviceOs, inWrapper: siteRequest, expectedResult: OSIOS},
+		{desc: "deviceOs from os", inFunctionName: DeviceOs, inWrapper: appRequest, expectedResult: OSAndroid},
+		{desc: "deviceOsIn match", inFunctionName: DeviceOsIn, inParams: json.RawMessage(`{"oses": ["iOS"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "deviceOsIn no match", inFunctionName: DeviceOsIn, inParams: json.RawMessage(`{"oses": ["iOS"]}`), inWrapper: appRequest, expectedResult: "false"},
+		{desc: "browser from ua", inFunctionName: Browser, inWrapper: siteRequest, expectedResult: BrowserSafari},
+		{desc: "browser unknown", inFunctionName: Browser, inWrapper: appRequest, expectedResult: ""},
+		{desc: "browserIn match", inFunctionName: BrowserIn, inParams: json.RawMessage(`{"browsers": ["safari", "chrome"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "browserIn unknown browser", inFunctionName: BrowserIn, inParams: json.RawMessage(`{"browsers": ["safari"]}`), inWrapper: appRequest, expectedResult: "false"},
+		{desc: "impMediaType multiple types", inFunctionName: ImpMediaType, inWrapper: siteRequest, expectedResult: "banner,video"},
+		{desc: "impMediaType single type", inFunctionName: ImpMediaType, inWrapper: appRequest, expectedResult: "native"},
+		{desc: "impMediaType without imps", inFunctionName: ImpMediaType, inWrapper: emptyRequest, expectedResult: ""},
+		{desc: "impMediaTypeIn match", inFunctionName: ImpMediaTypeIn, inParams: json.RawMessage(`{"types": ["video"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "impMediaTypeIn no match", inFunctionName: ImpMediaTypeIn, inParams: json.RawMessage(`{"types": ["video"]}`), inWrapper: appRequest, expectedResult: "false"},
+		{desc: "adUnitCode prefers gpid", inFunctionName: AdUnitCode, inWrapper: siteRequest, expectedResult: "/1234/home"},
+		{desc: "adUnitCode without codes", inFunctionName: AdUnitCode, inWrapper: appRequest, expectedResult: ""},
+		{desc: "adUnitCode without imps", inFunctionName: AdUnitCode, inWrapper: emptyRequest, expectedResult: ""},
+		{desc: "adUnitCodeIn tagid match", inFunctionName: AdUnitCodeIn, inParams: json.RawMessage(`{"codes": ["tag1"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "adUnitCodeIn pbadslot of other imp match", inFunctionName: AdUnitCodeIn, inParams: json.RawMessage(`{"codes": ["/1234/footer"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "adUnitCodeIn no match", inFunctionName: AdUnitCodeIn, inParams: json.RawMessage(`{"codes": ["tag1"]}`), inWrapper: appRequest, expectedResult: "false"},
+		{desc: "domain of site", inFunctionName: Domain, inWrapper: siteRequest, expectedResult: "News.Example.com"},
+		{desc: "domain of app", inFunctionName: Domain, inWrapper: appRequest, expectedResult: "example.com"},
+		{desc: "domainIn match is case insensitive", inFunctionName: DomainIn, inParams: json.RawMessage(`{"domains": ["news.example.com"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "domainIn without domain", inFunctionName: DomainIn, inParams: json.RawMessage(`{"domains": ["news.example.com"]}`), inWrapper: emptyRequest, expectedResult: "false"},
+		{desc: "bundle", inFunctionName: Bundle, inWrapper: appRequest, expectedResult: "com.example.app"},
+		{desc: "bundle of site", inFunctionName: Bundle, inWrapper: siteRequest, expectedResult: ""},
+		{desc: "bundleIn match", inFunctionName: BundleIn, inParams: json.RawMessage(`{"bundles": ["com.example.app"]}`), inWrapper: appRequest, expectedResult: "true"},
+		{desc: "bundleIn of site", inFunctionName: BundleIn, inParams: json.RawMessage(`{"bundles": ["com.example.app"]}`), inWrapper: siteRequest, expectedResult: "false"},
+		{desc: "publisherId of site", inFunctionName: PublisherId, inWrapper: siteRequest, expectedResult: "pub1"},
+		{desc: "publisherId of app", inFunctionName: PublisherId, inWrapper: appRequest, expectedResult: "pub2"},
+		{desc: "publisherId without publisher", inFunctionName: PublisherId, inWrapper: emptyRequest, expectedResult: ""},
+		{desc: "publisherIdIn match", inFunctionName: PublisherIdIn, inParams: json.RawMessage(`{"pubids": ["pub2", "pub3"]}`), inWrapper: appRequest, expectedResult: "true"},
+		{desc: "publisherIdIn no match", inFunctionName: PublisherIdIn, inParams: json.RawMessage(`{"pubids": ["pub2", "pub3"]}`), inWrapper: siteRequest, expectedResult: "false"},
+		{desc: "buyerUidAvailable in user ext", inFunctionName: BuyerUidAvailable, inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "buyerUidAvailable in user", inFunctionName: BuyerUidAvailable, inWrapper: appRequest, expectedResult: "true"},
+		{desc: "buyerUidAvailable without user", inFunctionName: BuyerUidAvailable, inWrapper: emptyRequest, expectedResult: "false"},
+		{desc: "buyerUidIn match is case insensitive", inFunctionName: BuyerUidIn, inParams: json.RawMessage(`{"bidders": ["AppNexus"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "buyerUidIn empty buyer uid", inFunctionName: BuyerUidIn, inParams: json.RawMessage(`{"bidders": ["rubicon"]}`), inWrapper: siteRequest, expectedResult: "false"},
+		{desc: "buyerUidIn without user ext", inFunctionName: BuyerUidIn, inParams: json.RawMessage(`{"bidders": ["appnexus"]}`), inWrapper: appRequest, expectedResult: "false"},
+		{desc: "requestSize default buckets", inFunctionName: RequestSize, inWrapper: siteRequest, expectedResult: "2-3"},
+		{desc: "requestSize single imp bucket", inFunctionName: RequestSize, inParams: json.RawMessage(`{"buckets": [1]}`), inWrapper: appRequest, expectedResult: "1"},
+		{desc: "requestSize above last bucket", inFunctionName: RequestSize, inParams: json.RawMessage(`{"buckets": [1]}`), inWrapper: siteRequest, expectedResult: "2+"},
+		{desc: "requestSize without imps", inFunctionName: RequestSize, inWrapper: emptyRequest, expectedResult: "1"},
+		{desc: "requestSizeIn match", inFunctionName: RequestSizeIn, inParams: json.RawMessage(`{"buckets": [1], "sizes": ["2+"]}`), inWrapper: siteRequest, expectedResult: "true"},
+		{desc: "requestSizeIn no match", inFunctionName: RequestSizeIn, inParams: json.RawMessage(`{"buckets": [1], "sizes": ["2+"]}`), inWrapper: appRequest, expectedResult: "false"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			schemaFunc, err := NewRequestSchemaFunction(tc.inFunctionName, tc.inParams)
+			assert.NoError(t, err)
+
+			result, err := schemaFunc.Call(tc.inWrapper)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestTimeSchemaFunctionsCall(t *testing.T) {
+	// Monday 2024-01-01 23:30 UTC is Tuesday 2024-01-02 08:30 in Tokyo
+	clock := &fakeTime{time: time.Date(2024, time.January, 1, 23, 30, 0, 0, time.UTC)}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		inSchemaFunc   SchemaFunction[openrtb_ext.RequestWrapper]
+		expectedResult string
+	}{
+		{
+			desc:           "hourOfDay in UTC",
+			inSchemaFunc:   &hourOfDay{location: time.UTC, clock: clock},
+			expectedResult: "23",
+		},
+		{
+			desc:           "hourOfDay in timezone",
+			inSchemaFunc:   &hourOfDay{location: tokyo, clock: clock},
+			expectedResult: "8",
+		},
+		{
+			desc:           "hourOfDayIn match",
+			inSchemaFunc:   &hourOfDayIn{HourDir: map[int]struct{}{8: {}, 9: {}}, location: tokyo, clock: clock},
+			expectedResult: "true",
+		},
+		{
+			desc:           "hourOfDayIn no match",
+			inSchemaFunc:   &hourOfDayIn{HourDir: map[int]struct{}{8: {}, 9: {}}, location: time.UTC, clock: clock},
+			expectedResult: "false",
+		},
+		{
+			desc:           "dayOfWeek in UTC",
+			inSchemaFunc:   &dayOfWeek{location: time.UTC, clock: clock},
+			expectedResult: "monday",
+		},
+		{
+			desc:           "dayOfWeek in timezone",
+			inSchemaFunc:   &dayOfWeek{location: tokyo, clock: clock},
+			expectedResult: "tuesday",
+		},
+		{
+			desc:           "dayOfWeekIn match",
+			inSchemaFunc:   &dayOfWeekIn{DayDir: map[time.Weekday]struct{}{time.Tuesday: {}}, location: tokyo, clock: clock},
+			expectedResult: "true",
+		},
+		{
+			desc:           "dayOfWeekIn no match",
+			inSchemaFunc:   &dayOfWeekIn{DayDir: map[time.Weekday]struct{}{time.Tuesday: {}}, location: time.UTC, clock: clock},
+			expectedResult: "false",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result, err := tc.inSchemaFunc.Call(nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestNewTimeSchemaFunctions(t *testing.T) {
+	schemaFunc, err := NewDayOfWeekIn(json.RawMessage(`{"days": ["Saturday", "sunday"], "timezone": "America/New_York"}`))
+	assert.NoError(t, err)
+
+	dayOfWeekIn, ok := schemaFunc.(*dayOfWeekIn)
+	assert.True(t, ok)
+	assert.Equal(t, map[time.Weekday]struct{}{time.Saturday: {}, time.Sunday: {}}, dayOfWeekIn.DayDir)
+	assert.Equal(t, "America/New_York", dayOfWeekIn.location.String())
+
+	schemaFunc, err = NewHourOfDay(nil)
+	assert.NoError(t, err)
+
+	hourOfDay, ok := schemaFunc.(*hourOfDay)
+	assert.True(t, ok)
+	assert.Equal(t, time.UTC, hourOfDay.location)
+}
+
+func TestRequestSegmentSchemaFunctionsName(t *testing.T) {
+	testCases := []struct {
+		expectedSchemaFuncName string
+		inSchemaFunc           SchemaFunction[openrtb_ext.RequestWrapper]
+	}{
+		{expectedSchemaFuncName: AdUnitCode, inSchemaFunc: &adUnitCode{}},
+		{expectedSchemaFuncName: AdUnitCodeIn, inSchemaFunc: &adUnitCodeIn{}},
+		{expectedSchemaFuncName: Browser, inSchemaFunc: &browser{}},
+		{expectedSchemaFuncName: BrowserIn, inSchemaFunc: &browserIn{}},
+		{expectedSchemaFuncName: Bundle, inSchemaFunc: &bundle{}},
+		{expectedSchemaFuncName: BundleIn, inSchemaFunc: &bundleIn{}},
+		{expectedSchemaFuncName: BuyerUidAvailable, inSchemaFunc: &buyerUidAvailable{}},
+		{expectedSchemaFuncName: BuyerUidIn, inSchemaFunc: &buyerUidIn{}},
+		{expectedSchemaFuncName: DayOfWeek, inSchemaFunc: &dayOfWeek{}},
+		{expectedSchemaFuncName: DayOfWeekIn, inSchemaFunc: &dayOfWeekIn{}},
+		{expectedSchemaFuncName: DeviceOs, inSchemaFunc: &deviceOs{}},
+		{expectedSchemaFuncName: DeviceOsIn, inSchemaFunc: &deviceOsIn{}},
+		{expectedSchemaFuncName: DeviceType, inSchemaFunc: &deviceType{}},
+		{expectedSchemaFuncName: DeviceTypeIn, inSchemaFunc: &deviceTypeIn{}},
+		{expectedSchemaFuncName: Domain, inSchemaFunc: &domain{}},
+		{expectedSchemaFuncName: DomainIn, inSchemaFunc: &domainIn{}},
+		{expectedSchemaFuncName: HourOfDay, inSchemaFunc: &hourOfDay{}},
+		{expectedSchemaFuncName: HourOfDayIn, inSchemaFunc: &hourOfDayIn{}},
+		{expectedSchemaFuncName: ImpMediaType, inSchemaFunc: &impMediaType{}},
+		{expectedSchemaFuncName: ImpMediaTypeIn, inSchemaFunc: &impMediaTypeIn{}},
+		{expectedSchemaFuncName: PublisherId, inSchemaFunc: &publisherId{}},
+		{expectedSchemaFuncName: PublisherIdIn, inSchemaFunc: &publisherIdIn{}},
+		{expectedSchemaFuncName: RequestSize, inSchemaFunc: &requestSize{}},
+		{expectedSchemaFuncName: RequestSizeIn, inSchemaFunc: &requestSizeIn{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expectedSchemaFuncName, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSchemaFuncName, tc.inSchemaFunc.Name())
+		})
+	}
+}
+
+func TestUserAgentParsing(t *testing.T) {
+	const (
+		chromeWindowsUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		edgeWindowsUA   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0"
+		firefoxMacUA    = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.1; rv:121.0) Gecko/20100101 Firefox/121.0"
+		androidPhoneUA  = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36"
+		androidTabletUA = "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		samsungUA       = "Mozilla/5.0 (Linux; Android 13; SM-S911B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36"
+		fireTVUA        = "Mozilla/5.0 (Linux; Android 9; AFTMM Build/PS7633) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		chromeOSUA      = "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	)
+
+	testCases := []struct {
+		desc               string
+		inDevice           *openrtb2.Device
+		expectedDeviceType string
+		expectedOS         string
+		expectedBrowser    string
+	}{
+		{
+			desc: "nil device",
+		},
+		{
+			desc:               "chrome on windows",
+			inDevice:           &openrtb2.Device{UA: chromeWindowsUA},
+			expectedDeviceType: DeviceTypeDesktop,
+			expectedOS:         OSWindows,
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc:               "edge on windows",
+			inDevice:           &openrtb2.Device{UA: edgeWindowsUA},
+			expectedDeviceType: DeviceTypeDesktop,
+			expectedOS:         OSWindows,
+			expectedBrowser:    BrowserEdge,
+		},
+		{
+			desc:               "firefox on mac",
+			inDevice:           &openrtb2.Device{UA: firefoxMacUA},
+			expectedDeviceType: DeviceTypeDesktop,
+			expectedOS:         OSMacOS,
+			expectedBrowser:    BrowserFirefox,
+		},
+		{
+			desc:               "android phone",
+			inDevice:           &openrtb2.Device{UA: androidPhoneUA},
+			expectedDeviceType: DeviceTypeMobile,
+			expectedOS:         OSAndroid,
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc:               "android tablet",
+			inDevice:           &openrtb2.Device{UA: androidTabletUA},
+			expectedDeviceType: DeviceTypeTablet,
+			expectedOS:         OSAndroid,
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc:               "samsung browser",
+			inDevice:           &openrtb2.Device{UA: samsungUA},
+			expectedDeviceType: DeviceTypeMobile,
+			expectedOS:         OSAndroid,
+			expectedBrowser:    BrowserSamsung,
+		},
+		{
+			desc:               "fire tv",
+			inDevice:           &openrtb2.Device{UA: fireTVUA},
+			expectedDeviceType: DeviceTypeCTV,
+			expectedOS:         OSAndroid,
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc:               "chromebook",
+			inDevice:           &openrtb2.Device{UA: chromeOSUA},
+			expectedDeviceType: DeviceTypeDesktop,
+			expectedOS:         OSChromeOS,
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc: "structured user agent takes precedence over ua",
+			inDevice: &openrtb2.Device{
+				UA: chromeWindowsUA,
+				SUA: &openrtb2.UserAgent{
+					Browsers: []openrtb2.BrandVersion{{Brand: "Not_A Brand"}, {Brand: "Chromium"}, {Brand: "Microsoft Edge"}},
+					Platform: &openrtb2.BrandVersion{Brand: "macOS"},
+					Mobile:   ptrutil.ToPtr[int8](0),
+				},
+			},
+			expectedDeviceType: DeviceTypeDesktop,
+			expectedOS:         OSMacOS,
+			expectedBrowser:    BrowserEdge,
+		},
+		{
+			desc: "structured user agent mobile flag",
+			inDevice: &openrtb2.Device{
+				SUA: &openrtb2.UserAgent{Mobile: ptrutil.ToPtr[int8](1)},
+			},
+			expectedDeviceType: DeviceTypeMobile,
+		},
+		{
+			desc:               "devicetype takes precedence over ua",
+			inDevice:           &openrtb2.Device{UA: chromeWindowsUA, DeviceType: adcom1.DeviceSetTopBox, OS: "tvOS"},
+			expectedDeviceType: DeviceTypeCTV,
+			expectedOS:         "tvos",
+			expectedBrowser:    BrowserChrome,
+		},
+		{
+			desc:               "general mobile devicetype without ua",
+			inDevice:           &openrtb2.Device{DeviceType: adcom1.DeviceMobile},
+			expectedDeviceType: DeviceTypeMobile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDeviceType, getDeviceType(tc.inDevice), "device type")
+			assert.Equal(t, tc.expectedOS, getDeviceOS(tc.inDevice), "os")
+			assert.Equal(t, tc.expectedBrowser, getBrowser(tc.inDevice), "browser")
+		})
+	}
+}
@@ -65,6 +65,54 @@ func NewRequestSchemaFunction(name string, params json.RawMessage) (SchemaFuncti
 		return NewTcfInScope(params)
 	case Percent:
 		return NewPercent(params)
+	case DeviceType:
+		return NewDeviceType(params)
+	case DeviceTypeIn:
+		return NewDeviceTypeIn(params)
+	case DeviceOs:
+		return NewDeviceOs(params)
+	case DeviceOsIn:
+		return NewDeviceOsIn(params)
+	case Browser:
+		return NewBrowser(params)
+	case BrowserIn:
+		return NewBrowserIn(params)
+	case ImpMediaType:
+		return NewImpMediaType(params)
+	case ImpMediaTypeIn:
+		return NewImpMediaTypeIn(params)
+	case AdUnitCode:
+		return NewAdUnitCode(params)
+	case AdUnitCodeIn:
+		return NewAdUnitCodeIn(params)
+	case Domain:
+		return NewDomain(params)
+	case DomainIn:
+		return NewDomainIn(params)
+	case Bundle:
+		return NewBundle(params)
+	case BundleIn:
+		return NewBundleIn(params)
+	case PublisherId:
+		return NewPublisherId(params)
+	case PublisherIdIn:
+		return NewPublisherIdIn(params)
+	case HourOfDay:
+		return NewHourOfDay(params)
+	case HourOfDayIn:
+		return NewHourOfDayIn(params)
+	case DayOfWeek:
+		return NewDayOfWeek(params)
+	case DayOfWeekIn:
+		return NewDayOfWeekIn(params)
+	case BuyerUidAvailable:
+		return NewBuyerUidAvailable(params)
+	case BuyerUidIn:
+		return NewBuyerUidIn(params)
+	case RequestSize:
+		return NewRequestSize(params)
+	case RequestSizeIn:
+		return NewRequestSizeIn(params)
 	default:
 		return nil, fmt.Errorf("Schema function %s was not created", name)
 	}
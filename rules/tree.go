@@ -47,25 +47,42 @@ type Tree[T1 any, T2 any] struct {
 // If the result matches one of the node values on the next level, we move to that node, otherwise we exit.
 // If a leaf node is reached, it's result functions are executed on the provided result payload.
 func (t *Tree[T1, T2]) Run(payload *T1, result *T2) error {
-	var nodeKey string
-	if t.Root == nil {
-		return errors.New("tree root is nil")
+	resFuncMeta, resultFuncs, err := t.Trace(payload)
+	if err != nil {
+		return err
 	}
-	currNode := t.Root
 
+	for _, rf := range resultFuncs {
+		if err := rf.Call(payload, result, resFuncMeta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Trace walks down the tree the same way Run does without executing any result function. It returns the
+// schema function results computed and the rule fired along the way, and the result functions Run would execute.
+func (t *Tree[T1, T2]) Trace(payload *T1) (ResultFunctionMeta, []ResultFunction[T1, T2], error) {
+	var nodeKey string
 	resFuncMeta := ResultFunctionMeta{
 		AnalyticsKey: t.AnalyticsKey,
 		ModelVersion: t.ModelVersion,
 	}
 
+	if t.Root == nil {
+		return resFuncMeta, nil, errors.New("tree root is nil")
+	}
+	currNode := t.Root
+
 	for !currNode.isLeaf() {
 		if currNode.SchemaFunction == nil {
-			return errors.New("schema function is nil")
+			return resFuncMeta, nil, errors.New("schema function is nil")
 		}
 
 		res, err := currNode.SchemaFunction.Call(payload)
 		if err != nil {
-			return err
+			return resFuncMeta, nil, err
 		}
 		resFuncMeta.appendToSchemaFunctionResults(currNode.SchemaFunction.Name(), res)
 
@@ -77,18 +94,10 @@ func (t *Tree[T1, T2]) Run(payload *T1, result *T2) error {
 		resFuncMeta.appendToRuleFired(nodeKey)
 	}
 
-	resultFuncs := t.DefaultFunctions
-	if currNode != nil {
-		resultFuncs = currNode.ResultFunctions
+	if currNode == nil {
+		return resFuncMeta, t.DefaultFunctions, nil
 	}
-
-	for _, rf := range resultFuncs {
-		if err := rf.Call(payload, result, resFuncMeta); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return resFuncMeta, currNode.ResultFunctions, nil
 }
 
 // validate checks if the tree is well-formed which means all leaves are at the same depth.
@@ -567,6 +567,53 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestTrace(t *testing.T) {
+	leafFunction := &leafResultFunction{}
+	defaultFunction := &defaultResultFunction{}
+	tree := &Tree[struct{}, runTestAssertableData]{
+		AnalyticsKey:     "key",
+		ModelVersion:     "1.0",
+		DefaultFunctions: []ResultFunction[struct{}, runTestAssertableData]{defaultFunction},
+		Root: &Node[struct{}, runTestAssertableData]{
+			SchemaFunction: &nodeSchemaFunction{},
+			Children: map[string]*Node[struct{}, runTestAssertableData]{
+				"*": {
+					SchemaFunction: &nodeSchemaFunction{},
+					Children: map[string]*Node[struct{}, runTestAssertableData]{
+						"nodeSchemaResult": {
+							ResultFunctions: []ResultFunction[struct{}, runTestAssertableData]{leafFunction},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	meta, resultFuncs, err := tree.Trace(&struct{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, ResultFunctionMeta{
+		SchemaFunctionResults: []SchemaFunctionStep{
+			{FuncName: "nodeSchemaFuncName", FuncResult: "nodeSchemaResult"},
+			{FuncName: "nodeSchemaFuncName", FuncResult: "nodeSchemaResult"},
+		},
+		AnalyticsKey: "key",
+		RuleFired:    "*|nodeSchemaResult",
+		ModelVersion: "1.0",
+	}, meta)
+	assert.Equal(t, []ResultFunction[struct{}, runTestAssertableData]{leafFunction}, resultFuncs)
+
+	tree.Root.Children["*"].Children = map[string]*Node[struct{}, runTestAssertableData]{"other": {}}
+	meta, resultFuncs, err = tree.Trace(&struct{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, "default", meta.RuleFired)
+	assert.Equal(t, []ResultFunction[struct{}, runTestAssertableData]{defaultFunction}, resultFuncs)
+
+	tree.Root.SchemaFunction = &faultySchemaFunction{}
+	_, resultFuncs, err = tree.Trace(&struct{}{})
+	assert.EqualError(t, err, "faulty schema function error")
+	assert.Nil(t, resultFuncs)
+}
+
 // helper schema functions
 type nodeSchemaFunction struct{}
 
@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/prebid/openrtb/v20/adcom1"
+	"github.com/prebid/openrtb/v20/openrtb2"
+)
+
+// Device types reported by the deviceType schema function
+const (
+	DeviceTypeConnected = "connected"
+	DeviceTypeCTV       = "ctv"
+	DeviceTypeDesktop   = "desktop"
+	DeviceTypeMobile    = "mobile"
+	DeviceTypeOOH       = "ooh"
+	DeviceTypeTablet    = "tablet"
+)
+
+// Operating systems reported by the deviceOs schema function
+const (
+	OSAndroid  = "android"
+	OSChromeOS = "chromeos"
+	OSIOS      = "ios"
+	OSLinux    = "linux"
+	OSMacOS    = "macos"
+	OSWindows  = "windows"
+)
+
+// Browsers reported by the browser schema function
+const (
+	BrowserChrome  = "chrome"
+	BrowserEdge    = "edge"
+	BrowserFirefox = "firefox"
+	BrowserOpera   = "opera"
+	BrowserSafari  = "safari"
+	BrowserSamsung = "samsung"
+)
+
+var ctvUserAgentTokens = []string{"smart-tv", "smarttv", "hbbtv", "googletv", "appletv", "roku", "crkey", "; aft", "bravia", "web0s"}
+var tabletUserAgentTokens = []string{"ipad", "tablet", "kindle", "silk/"}
+var mobileUserAgentTokens = []string{"mobi", "iphone", "ipod", "android", "windows phone"}
+
+// getDeviceType classifies the device using device.devicetype when it is specific enough and
+// falls back to device.sua and device.ua otherwise
+func getDeviceType(device *openrtb2.Device) string {
+	if device == nil {
+		return ""
+	}
+
+	switch device.DeviceType {
+	case adcom1.DevicePC:
+		return DeviceTypeDesktop
+	case adcom1.DevicePhone:
+		return DeviceTypeMobile
+	case adcom1.DeviceTablet:
+		return DeviceTypeTablet
+	case adcom1.DeviceTV, adcom1.DeviceSetTopBox:
+		return DeviceTypeCTV
+	case adcom1.DeviceConnected:
+		return DeviceTypeConnected
+	case adcom1.DeviceOOH:
+		return DeviceTypeOOH
+	}
+
+	ua := strings.ToLower(device.UA)
+	if containsAny(ua, ctvUserAgentTokens) {
+		return DeviceTypeCTV
+	}
+	if containsAny(ua, tabletUserAgentTokens) || (strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")) {
+		return DeviceTypeTablet
+	}
+	if device.SUA != nil && device.SUA.Mobile != nil {
+		if *device.SUA.Mobile == 1 {
+			return DeviceTypeMobile
+		}
+		if device.DeviceType != adcom1.DeviceMobile {
+			return DeviceTypeDesktop
+		}
+	}
+	if containsAny(ua, mobileUserAgentTokens) || device.DeviceType == adcom1.DeviceMobile {
+		return DeviceTypeMobile
+	}
+	if len(ua) > 0 {
+		return DeviceTypeDesktop
+	}
+	return ""
+}
+
+// getDeviceOS returns the operating system from device.sua, device.os or device.ua in that order
+// of preference, normalized so the same system is reported the same way by all three sources
+func getDeviceOS(device *openrtb2.Device) string {
+	if device == nil {
+		return ""
+	}
+	if device.SUA != nil && device.SUA.Platform != nil && len(device.SUA.Platform.Brand) > 0 {
+		return normalizeOS(device.SUA.Platform.Brand)
+	}
+	if len(device.OS) > 0 {
+		return normalizeOS(device.OS)
+	}
+
+	ua := strings.ToLower(device.UA)
+	switch {
+	case len(ua) == 0:
+		return ""
+	case strings.Contains(ua, "windows"):
+		return OSWindows
+	case containsAny(ua, []string{"iphone", "ipad", "ipod"}):
+		return OSIOS
+	case strings.Contains(ua, "android"):
+		return OSAndroid
+	case strings.Contains(ua, "cros "):
+		return OSChromeOS
+	case containsAny(ua, []string{"mac os x", "macintosh"}):
+		return OSMacOS
+	case strings.Contains(ua, "linux"):
+		return OSLinux
+	}
+	return ""
+}
+
+func normalizeOS(os string) string {
+	os = strings.ToLower(strings.TrimSpace(os))
+	switch os {
+	case "mac os", "mac os x", "macos", "osx":
+		return OSMacOS
+	case "chrome os", "chromeos", "chromium os":
+		return OSChromeOS
+	case "ios", "ipados", "iphone os":
+		return OSIOS
+	}
+	return os
+}
+
+// getBrowser returns the browser from device.sua when it names a known browser and from device.ua otherwise
+func getBrowser(device *openrtb2.Device) string {
+	if device == nil {
+		return ""
+	}
+	if device.SUA != nil {
+		for _, browser := range device.SUA.Browsers {
+			if name := browserFromBrand(browser.Brand); len(name) > 0 {
+				return name
+			}
+		}
+	}
+
+	ua := strings.ToLower(device.UA)
+	switch {
+	case containsAny(ua, []string{"edg/", "edge/", "edga/", "edgios/"}):
+		return BrowserEdge
+	case containsAny(ua, []string{"opr/", "opera"}):
+		return BrowserOpera
+	case strings.Contains(ua, "samsungbrowser"):
+		return BrowserSamsung
+	case containsAny(ua, []string{"firefox/", "fxios/"}):
+		return BrowserFirefox
+	case containsAny(ua, []string{"chrome/", "crios/"}):
+		return BrowserChrome
+	case strings.Contains(ua, "safari/"):
+		return BrowserSafari
+	}
+	return ""
+}
+
+// browserFromBrand maps a user agent client hints brand to a browser, skipping the Chromium
+// engine brand and the made up brands browsers add to the list
+func browserFromBrand(brand string) string {
+	brand = strings.ToLower(brand)
+	switch {
+	case strings.Contains(brand, "edge"):
+		return BrowserEdge
+	case strings.Contains(brand, "opera"):
+		return BrowserOpera
+	case strings.Contains(brand, "samsung"):
+		return BrowserSamsung
+	case strings.Contains(brand, "firefox"):
+		return BrowserFirefox
+	case strings.Contains(brand, "safari"):
+		return BrowserSafari
+	case strings.Contains(brand, "chrome"):
+		return BrowserChrome
+	}
+	return ""
+}
+
+func containsAny(s string, tokens []string) bool {
+	for _, token := range tokens {
+		if strings.Contains(s, token) {
+			return true
+		}
+	}
+	return false
+}
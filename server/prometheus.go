@@ -8,6 +8,7 @@ import (
 	"github.com/prebid/prebid-server/v3/config"
 	"github.com/prebid/prebid-server/v3/logger"
 	metricsconfig "github.com/prebid/prebid-server/v3/metrics/config"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -17,9 +18,15 @@ func newPrometheusServer(cfg *config.Configuration, metrics *metricsconfig.Detai
 	if proMetrics == nil {
 		logger.Fatalf("Prometheus metrics configured, but a Prometheus metrics engine was not found. Cannot set up a Prometheus listener.")
 	}
+
+	gatherers := prometheus.Gatherers{proMetrics.Gatherer}
+	if proMetrics.ModulesGatherer != nil {
+		gatherers = append(gatherers, proMetrics.ModulesGatherer)
+	}
+
 	return &http.Server{
 		Addr: cfg.Host + ":" + strconv.Itoa(cfg.Metrics.Prometheus.Port),
-		Handler: promhttp.HandlerFor(proMetrics.Gatherer, promhttp.HandlerOpts{
+		Handler: promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{
 			ErrorLog:            loggerForPrometheus{},
 			MaxRequestsInFlight: 5,
 			Timeout:             cfg.Metrics.Prometheus.Timeout(),
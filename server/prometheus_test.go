@@ -0,0 +1,68 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prebid/prebid-server/v3/config"
+	metricsconfig "github.com/prebid/prebid-server/v3/metrics/config"
+	prometheusmetrics "github.com/prebid/prebid-server/v3/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPrometheusServerServesModuleMetrics(t *testing.T) {
+	engineRegistry := prometheus.NewRegistry()
+	engineCounter := prometheus.NewCounter(prometheus.CounterOpts{Name: "engine_counter", Help: "engine"})
+	engineRegistry.MustRegister(engineCounter)
+	engineCounter.Inc()
+
+	modulesRegistry, modulesRegisterer := prometheusmetrics.NewModulesRegistry(config.PrometheusMetrics{Namespace: "prebid"})
+	moduleCounter := prometheus.NewCounter(prometheus.CounterOpts{Name: "module_counter", Help: "module"})
+	modulesRegisterer.MustRegister(moduleCounter)
+	moduleCounter.Inc()
+
+	testCases := []struct {
+		name            string
+		modulesGatherer prometheus.Gatherer
+		expectModule    bool
+	}{
+		{
+			name:            "module collectors are served next to the engine's",
+			modulesGatherer: modulesRegistry,
+			expectModule:    true,
+		},
+		{
+			name: "no modules registry",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Configuration{}
+			metrics := &metricsconfig.DetailedMetricsEngine{
+				PrometheusMetrics: &prometheusmetrics.Metrics{
+					Gatherer:        engineRegistry,
+					ModulesGatherer: test.modulesGatherer,
+				},
+			}
+
+			server := newPrometheusServer(cfg, metrics)
+			recorder := httptest.NewRecorder()
+			server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			body, err := io.ReadAll(recorder.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), "engine_counter 1")
+			if test.expectModule {
+				assert.Contains(t, string(body), "prebid_module_counter 1")
+			} else {
+				assert.NotContains(t, string(body), "module_counter")
+			}
+		})
+	}
+}
@@ -108,6 +108,25 @@ func (fetcher *dbFetcher) FetchRequests(ctx context.Context, requestIDs []string
 }
 
 func (fetcher *dbFetcher) FetchResponses(ctx context.Context, ids []string) (data map[string]json.RawMessage, errs []error) {
+	return fetcher.fetchByID(ctx, fetcher.responseQueryTemplate, ids)
+}
+
+// FetchFloors fetches the price floors data of an account with the query template of the fetcher,
+// which selects the id, data and type columns of the ids in $ID_LIST.
+func (fetcher *dbFetcher) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	data, errs := fetcher.fetchByID(ctx, fetcher.queryTemplate, []string{accountID})
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	floorsJSON, ok := data[accountID]
+	if !ok {
+		return nil, []error{stored_requests.NotFoundError{ID: accountID, DataType: "Floors"}}
+	}
+	return floorsJSON, nil
+}
+
+// fetchByID runs the query template with the ids as $ID_LIST and returns the data of each id found
+func (fetcher *dbFetcher) fetchByID(ctx context.Context, queryTemplate string, ids []string) (data map[string]json.RawMessage, errs []error) {
 	if len(ids) < 1 {
 		return nil, nil
 	}
@@ -120,7 +139,7 @@ func (fetcher *dbFetcher) FetchResponses(ctx context.Context, ids []string) (dat
 		{Name: "ID_LIST", Value: idInterfaces},
 	}
 
-	rows, err := fetcher.provider.QueryContext(ctx, fetcher.responseQueryTemplate, params...)
+	rows, err := fetcher.provider.QueryContext(ctx, queryTemplate, params...)
 	if err != nil {
 		return nil, []error{err}
 	}
@@ -36,6 +36,10 @@ func (fetcher EmptyFetcher) FetchAccount(ctx context.Context, accountDefaultJSON
 	return nil, []error{stored_requests.NotFoundError{ID: accountID, DataType: "Account"}}
 }
 
+func (fetcher EmptyFetcher) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	return nil, []error{stored_requests.NotFoundError{ID: accountID, DataType: "Floors"}}
+}
+
 func (fetcher EmptyFetcher) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	return "", nil
 }
@@ -70,6 +70,18 @@ func (fetcher *eagerFetcher) FetchAccount(ctx context.Context, accountDefaultsJS
 	return completeJSON, nil
 }
 
+// FetchFloors fetches the price floors data of an account from the "floors" directory
+func (fetcher *eagerFetcher) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	floorsJSON, ok := fetcher.FileSystem.Directories["floors"].Files[accountID]
+	if !ok {
+		return nil, []error{stored_requests.NotFoundError{
+			ID:       accountID,
+			DataType: "Floors",
+		}}
+	}
+	return floorsJSON, nil
+}
+
 func (fetcher *eagerFetcher) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	fileName := primaryAdServer
 
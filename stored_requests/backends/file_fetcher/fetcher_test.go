@@ -99,6 +99,19 @@ func TestAccountFetcher(t *testing.T) {
 
 }
 
+func TestFloorsFetcher(t *testing.T) {
+	fetcher, err := NewFileFetcher("./test")
+	assert.NoError(t, err, "Failed to create test fetcher")
+
+	floors, errs := fetcher.(stored_requests.FloorsFetcher).FetchFloors(context.Background(), "valid")
+	assertErrorCount(t, 0, errs)
+	assert.Contains(t, string(floors), `"version": "1"`)
+
+	_, errs = fetcher.(stored_requests.FloorsFetcher).FetchFloors(context.Background(), "nonexistent")
+	assertErrorCount(t, 1, errs)
+	assert.Equal(t, stored_requests.NotFoundError{ID: "nonexistent", DataType: "Floors"}, errs[0])
+}
+
 func TestInvalidDirectory(t *testing.T) {
 	_, err := NewFileFetcher("./nonexistant-directory")
 	if err == nil {
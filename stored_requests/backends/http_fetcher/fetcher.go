@@ -54,6 +54,20 @@ import (
 //	    "acc2": { ... config data for acc2 ... },
 //	  },
 //	}
+//
+// Floors
+// GET {endpoint}?floors-ids=["acc1"]
+//
+// If UseRfcCompliantBuilder is true (symbols will be URLEncoded)
+// GET {endpoint}?floors-id=acc1
+//
+// The above endpoint should return a payload like:
+//
+//	{
+//	  "floors": {
+//	    "acc1": { ... floors data for acc1 ... },
+//	  },
+//	}
 func NewFetcher(client *http.Client, endpoint string, useRfcCompliantBuilder bool) *HttpFetcher {
 	endpointURL, err := url.Parse(endpoint)
 
@@ -187,6 +201,57 @@ func (fetcher *HttpFetcher) FetchAccount(ctx context.Context, accountDefaultsJSO
 	return completeJSON, nil
 }
 
+// FetchFloors fetches the price floors data of an account and returns it as-is (NOT validated)
+func (fetcher *HttpFetcher) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	u := *fetcher.EndpointURL
+	q := u.Query()
+	if !fetcher.UseRfcCompliantBuilder {
+		q.Set("floors-ids", `["`+accountID+`"]`)
+	} else {
+		q.Add("floors-id", accountID)
+	}
+	u.RawQuery = q.Encode()
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %s via http: build request failed with %v`, accountID, err),
+		}
+	}
+	httpResp, err := ctxhttp.Do(ctx, fetcher.client, httpReq)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %s via http: %v`, accountID, err),
+		}
+	}
+	defer httpResp.Body.Close()
+
+	respBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %s via http: error reading response: %v`, accountID, err),
+		}
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %s via http: unexpected response status %d`, accountID, httpResp.StatusCode),
+		}
+	}
+	var responseData floorsResponseContract
+	if err = jsonutil.UnmarshalValid(respBytes, &responseData); err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %s via http: failed to parse response: %v`, accountID, err),
+		}
+	}
+	floorsJSON, ok := responseData.Floors[accountID]
+	if !ok || floorsJSON == nil {
+		return nil, []error{stored_requests.NotFoundError{
+			ID:       accountID,
+			DataType: "Floors",
+		}}
+	}
+	return floorsJSON, nil
+}
+
 func (fetcher *HttpFetcher) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	if fetcher.Categories == nil {
 		fetcher.Categories = make(map[string]map[string]stored_requests.Category)
@@ -314,3 +379,7 @@ type responseContract struct {
 type accountsResponseContract struct {
 	Accounts map[string]json.RawMessage `json:"accounts"`
 }
+
+type floorsResponseContract struct {
+	Floors map[string]json.RawMessage `json:"floors"`
+}
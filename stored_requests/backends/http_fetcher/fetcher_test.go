@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prebid/prebid-server/v3/stored_requests"
 	"github.com/prebid/prebid-server/v3/util/jsonutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -353,6 +354,53 @@ func TestFetchAccountNoIDProvided(t *testing.T) {
 	assert.Nil(t, account, "Fetching account with empty id should return nil")
 }
 
+func TestFetchFloors(t *testing.T) {
+	for _, useRfcCompliantBuilder := range []bool{false, true} {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			var gotAccIDs []string
+			if !useRfcCompliantBuilder {
+				gotAccIDs = richSplit(query.Get("floors-ids"))
+			} else {
+				gotAccIDs = query["floors-id"]
+			}
+			assertMatches(t, gotAccIDs, []string{"acc-1"})
+			w.Write([]byte(`{"floors":{"acc-1":{"version":"1"}}}`))
+		}
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		fetcher := NewFetcher(server.Client(), server.URL, useRfcCompliantBuilder)
+
+		floors, errs := fetcher.FetchFloors(context.Background(), "acc-1")
+		assert.Empty(t, errs, "Unexpected error fetching known floors")
+		assert.JSONEq(t, `{"version":"1"}`, string(floors))
+
+		server.Close()
+	}
+}
+
+func TestFetchFloorsNoData(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"floors":{}}`))
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+	fetcher := NewFetcher(server.Client(), server.URL, false)
+
+	floors, errs := fetcher.FetchFloors(context.Background(), "acc-1")
+	assert.Len(t, errs, 1, "Fetching unknown floors should have returned an error")
+	assert.Equal(t, stored_requests.NotFoundError{ID: "acc-1", DataType: "Floors"}, errs[0])
+	assert.Nil(t, floors)
+}
+
+func TestFetchFloorsBadJSON(t *testing.T) {
+	fetcher, close := newFetcherBadJSON()
+	defer close()
+
+	floors, errs := fetcher.FetchFloors(context.Background(), "acc-1")
+	assert.Len(t, errs, 1, "Fetching floors with a bad response should have returned an error")
+	assert.Nil(t, floors)
+}
+
 func TestErrResponse(t *testing.T) {
 	fetcher, close := newFetcherBrokenBackend()
 	defer close()
@@ -90,6 +90,8 @@ func CreateStoredRequests(cfg *config.StoredRequests, metricsEngine metrics.Metr
 // 4. A Fetcher which can be used to get Account data
 // 5. A Fetcher which can be used to get Category Mapping data
 // 6. A Fetcher which can be used to get Stored Requests for /openrtb2/video
+// 7. A Fetcher which can be used to get Stored Responses
+// 8. A Fetcher which can be used to get the price floors data of accounts
 //
 // If any errors occur, the program will exit with an error message.
 // It probably means you have a bad config or networking issue.
@@ -102,7 +104,8 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	accountsFetcher stored_requests.AccountFetcher,
 	categoriesFetcher stored_requests.CategoryFetcher,
 	videoFetcher stored_requests.Fetcher,
-	storedRespFetcher stored_requests.Fetcher) {
+	storedRespFetcher stored_requests.Fetcher,
+	floorsFetcher stored_requests.FloorsFetcher) {
 
 	var provider db_provider.DbProvider
 
@@ -112,6 +115,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	fetcher4, shutdown4 := CreateStoredRequests(&cfg.StoredVideo, metricsEngine, client, router, provider)
 	fetcher5, shutdown5 := CreateStoredRequests(&cfg.Accounts, metricsEngine, client, router, provider)
 	fetcher6, shutdown6 := CreateStoredRequests(&cfg.StoredResponses, metricsEngine, client, router, provider)
+	fetcher7, shutdown7 := CreateStoredRequests(&cfg.StoredFloors, metricsEngine, client, router, provider)
 
 	fetcher = fetcher1.(stored_requests.Fetcher)
 	ampFetcher = fetcher2.(stored_requests.Fetcher)
@@ -119,6 +123,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	videoFetcher = fetcher4.(stored_requests.Fetcher)
 	accountsFetcher = fetcher5.(stored_requests.AccountFetcher)
 	storedRespFetcher = fetcher6.(stored_requests.Fetcher)
+	floorsFetcher = fetcher7.(stored_requests.FloorsFetcher)
 
 	shutdown = func() {
 		shutdown1()
@@ -127,6 +132,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 		shutdown4()
 		shutdown5()
 		shutdown6()
+		shutdown7()
 	}
 
 	return
@@ -178,12 +184,15 @@ func newCache(cfg *config.StoredRequests) stored_requests.Cache {
 		Imps:      &nil_cache.NilCache{},
 		Responses: &nil_cache.NilCache{},
 		Accounts:  &nil_cache.NilCache{},
+		Floors:    &nil_cache.NilCache{},
 	}
 	switch {
 	case cfg.InMemoryCache.Type == "none":
 		logger.Warnf("No %s cache configured. The %s Fetcher backend will be used for all data requests", cfg.DataType(), cfg.DataType())
 	case cfg.DataType() == config.AccountDataType:
 		cache.Accounts = memory.NewCache(cfg.InMemoryCache.Size, cfg.InMemoryCache.TTL, "Accounts")
+	case cfg.DataType() == config.FloorsDataType:
+		cache.Floors = memory.NewCache(cfg.InMemoryCache.Size, cfg.InMemoryCache.TTL, "Floors")
 	default:
 		cache.Requests = memory.NewCache(cfg.InMemoryCache.RequestCacheSize, cfg.InMemoryCache.TTL, "Requests")
 		cache.Imps = memory.NewCache(cfg.InMemoryCache.ImpCacheSize, cfg.InMemoryCache.TTL, "Imps")
@@ -169,6 +169,7 @@ func TestNewEmptyCache(t *testing.T) {
 	assert.True(t, isEmptyCacheType(cache.Imps), "The newCache method should return an empty Imp cache")
 	assert.True(t, isEmptyCacheType(cache.Responses), "The newCache method should return an empty Responses cache")
 	assert.True(t, isEmptyCacheType(cache.Accounts), "The newCache method should return an empty Account cache")
+	assert.True(t, isEmptyCacheType(cache.Floors), "The newCache method should return an empty Floors cache")
 }
 
 func TestNewInMemoryCache(t *testing.T) {
@@ -199,6 +200,18 @@ func TestNewInMemoryAccountCache(t *testing.T) {
 	assert.True(t, isEmptyCacheType(cache.Responses), "The newCache method should return an empty Responses cache for Accounts config")
 }
 
+func TestNewInMemoryFloorsCache(t *testing.T) {
+	cache := newCache(typedConfig(config.FloorsDataType, &config.StoredRequests{
+		InMemoryCache: config.InMemoryCache{
+			TTL:  60,
+			Size: 100,
+		},
+	}))
+	assert.True(t, isMemoryCacheType(cache.Floors), "The newCache method should return an in-memory Floors cache for StoredFloors config")
+	assert.True(t, isEmptyCacheType(cache.Accounts), "The newCache method should return an empty Account cache for StoredFloors config")
+	assert.True(t, isEmptyCacheType(cache.Requests), "The newCache method should return an empty Request cache for StoredFloors config")
+}
+
 func TestNewDatabaseEventProducers(t *testing.T) {
 	metricsMock := &metrics.MetricsEngineMock{}
 	metricsMock.Mock.On("RecordStoredDataFetchTime", mock.Anything, mock.Anything).Return()
@@ -27,6 +27,7 @@ var storedDataTypeMetricMap = map[config.DataType]metrics.StoredDataType{
 	config.AMPRequestDataType: metrics.AMPDataType,
 	config.AccountDataType:    metrics.AccountDataType,
 	config.ResponseDataType:   metrics.ResponseDataType,
+	config.FloorsDataType:     metrics.FloorsDataType,
 }
 
 type DatabaseEventProducerConfig struct {
@@ -178,10 +179,12 @@ func (e *DatabaseEventProducer) sendEvents(rows *sql.Rows) (err error) {
 	storedRequestData := make(map[string]json.RawMessage)
 	storedImpData := make(map[string]json.RawMessage)
 	storedRespData := make(map[string]json.RawMessage)
+	storedFloorsData := make(map[string]json.RawMessage)
 
 	var requestInvalidations []string
 	var impInvalidations []string
 	var respInvalidations []string
+	var floorsInvalidations []string
 
 	for rows.Next() {
 		var id string
@@ -212,6 +215,12 @@ func (e *DatabaseEventProducer) sendEvents(rows *sql.Rows) (err error) {
 			} else {
 				storedRespData[id] = data
 			}
+		case "floors":
+			if len(data) == 0 || bytes.Equal(data, bytesNull()) {
+				floorsInvalidations = append(floorsInvalidations, id)
+			} else {
+				storedFloorsData[id] = data
+			}
 		default:
 			logger.Warnf("Stored Data with id=%s has invalid type: %s. This will be ignored.", id, dataType)
 		}
@@ -222,19 +231,21 @@ func (e *DatabaseEventProducer) sendEvents(rows *sql.Rows) (err error) {
 		return rows.Err()
 	}
 
-	if len(storedRequestData) > 0 || len(storedImpData) > 0 || len(storedRespData) > 0 {
+	if len(storedRequestData) > 0 || len(storedImpData) > 0 || len(storedRespData) > 0 || len(storedFloorsData) > 0 {
 		e.saves <- events.Save{
 			Requests:  storedRequestData,
 			Imps:      storedImpData,
 			Responses: storedRespData,
+			Floors:    storedFloorsData,
 		}
 	}
 
-	if (len(requestInvalidations) > 0 || len(impInvalidations) > 0 || len(respInvalidations) > 0) && !e.lastUpdate.IsZero() {
+	if (len(requestInvalidations) > 0 || len(impInvalidations) > 0 || len(respInvalidations) > 0 || len(floorsInvalidations) > 0) && !e.lastUpdate.IsZero() {
 		e.invalidations <- events.Invalidation{
 			Requests:  requestInvalidations,
 			Imps:      impInvalidations,
 			Responses: respInvalidations,
+			Floors:    floorsInvalidations,
 		}
 	}
 
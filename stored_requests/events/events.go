@@ -13,6 +13,7 @@ type Save struct {
 	Imps      map[string]json.RawMessage `json:"imps"`
 	Accounts  map[string]json.RawMessage `json:"accounts"`
 	Responses map[string]json.RawMessage `json:"responses"`
+	Floors    map[string]json.RawMessage `json:"floors"`
 }
 
 // Invalidation represents a bulk invalidation
@@ -21,6 +22,7 @@ type Invalidation struct {
 	Imps      []string `json:"imps"`
 	Accounts  []string `json:"accounts"`
 	Responses []string `json:"responses"`
+	Floors    []string `json:"floors"`
 }
 
 // EventProducer will produce cache update and invalidation events on its channels
@@ -69,6 +71,7 @@ func (e *EventListener) Listen(cache stored_requests.Cache, events EventProducer
 			cache.Imps.Save(context.Background(), save.Imps)
 			cache.Accounts.Save(context.Background(), save.Accounts)
 			cache.Responses.Save(context.Background(), save.Responses)
+			cache.Floors.Save(context.Background(), save.Floors)
 			if e.onSave != nil {
 				e.onSave()
 			}
@@ -77,6 +80,7 @@ func (e *EventListener) Listen(cache stored_requests.Cache, events EventProducer
 			cache.Imps.Invalidate(context.Background(), invalidation.Imps)
 			cache.Accounts.Invalidate(context.Background(), invalidation.Accounts)
 			cache.Responses.Invalidate(context.Background(), invalidation.Responses)
+			cache.Floors.Invalidate(context.Background(), invalidation.Floors)
 			if e.onInvalidate != nil {
 				e.onInvalidate()
 			}
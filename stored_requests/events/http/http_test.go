@@ -34,7 +34,7 @@ func TestStartup(t *testing.T) {
 				{
 					statusCode: httpCore.StatusOK,
 					response:   `{"requests": {"request1": {"value":1}, "request2": {"value":2}}}`,
-					saves:      `{"requests": {"request1": {"value":1}, "request2": {"value":2}}, "imps": null, "responses": null,  "accounts": null}`,
+					saves:      `{"requests": {"request1": {"value":1}, "request2": {"value":2}}, "imps": null, "responses": null,  "accounts": null, "floors": null}`,
 				},
 			},
 		},
@@ -44,7 +44,7 @@ func TestStartup(t *testing.T) {
 				{
 					statusCode: httpCore.StatusOK,
 					response:   `{"imps": {"imp1": {"value":1}}}`,
-					saves:      `{"imps": {"imp1": {"value":1}}, "requests": null, "responses": null, "accounts": null}`,
+					saves:      `{"imps": {"imp1": {"value":1}}, "requests": null, "responses": null, "accounts": null, "floors": null}`,
 				},
 			},
 		},
@@ -54,7 +54,7 @@ func TestStartup(t *testing.T) {
 				{
 					statusCode: httpCore.StatusOK,
 					response:   `{"responses": {"resp1": {"value":1}}}`,
-					saves:      `{"responses": {"resp1": {"value":1}}, "imps": null, "requests": null, "accounts": null}`,
+					saves:      `{"responses": {"resp1": {"value":1}}, "imps": null, "requests": null, "accounts": null, "floors": null}`,
 				},
 			},
 		},
@@ -64,13 +64,13 @@ func TestStartup(t *testing.T) {
 				{
 					statusCode: httpCore.StatusOK,
 					response:   `{"requests": {"request1": {"value":1}, "request2": {"value":2}}, "imps": {"imp1": {"value":3}, "imp2": {"value":4}}, "responses": {"resp1": {"value":5}, "resp2": {"value":6}}}`,
-					saves:      `{"requests": {"request1": {"value":1}, "request2": {"value":2}}, "imps": {"imp1": {"value":3}, "imp2": {"value":4}}, "responses": {"resp1": {"value":5}, "resp2": {"value":6}}, "accounts":null}`,
+					saves:      `{"requests": {"request1": {"value":1}, "request2": {"value":2}}, "imps": {"imp1": {"value":3}, "imp2": {"value":4}}, "responses": {"resp1": {"value":5}, "resp2": {"value":6}}, "accounts":null, "floors": null}`,
 				},
 				{
 					statusCode:    httpCore.StatusOK,
 					response:      `{"requests": {"request1": {"value":7}, "request2": {"deleted":true}}, "imps": {"imp1": {"deleted":true}, "imp2": {"value":8}}, "responses": {"resp1": {"deleted":true}, "resp2": {"value":9}}}`,
-					saves:         `{"requests": {"request1": {"value":7}}, "imps": {"imp2": {"value":8}}, "responses": {"resp2": {"value":9}}, "accounts":null}`,
-					invalidations: `{"requests": ["request2"], "imps": ["imp1"], "responses": ["resp1"], "accounts": []}`,
+					saves:         `{"requests": {"request1": {"value":7}}, "imps": {"imp2": {"value":8}}, "responses": {"resp2": {"value":9}}, "accounts":null, "floors": null}`,
+					invalidations: `{"requests": ["request2"], "imps": ["imp1"], "responses": ["resp1"], "accounts": [], "floors": []}`,
 				},
 			},
 		},
@@ -80,13 +80,29 @@ func TestStartup(t *testing.T) {
 				{
 					statusCode: httpCore.StatusOK,
 					response:   `{"accounts":{"account1":{"value":1}, "account2":{"value":2}}}`,
-					saves:      `{"accounts":{"account1":{"value":1}, "account2":{"value":2}}, "imps": null, "requests": null, "responses": null}`,
+					saves:      `{"accounts":{"account1":{"value":1}, "account2":{"value":2}}, "imps": null, "requests": null, "responses": null, "floors": null}`,
 				},
 				{
 					statusCode:    httpCore.StatusOK,
 					response:      `{"accounts":{"account1":{"value":5}, "account2":{"deleted": true}}}`,
-					saves:         `{"accounts":{"account1":{"value":5}}, "imps": null, "requests": null, "responses": null}`,
-					invalidations: `{"accounts":["account2"], "requests": [], "imps": [], "responses":[]}`,
+					saves:         `{"accounts":{"account1":{"value":5}}, "imps": null, "requests": null, "responses": null, "floors": null}`,
+					invalidations: `{"accounts":["account2"], "requests": [], "imps": [], "responses":[], "floors": []}`,
+				},
+			},
+		},
+		{
+			description: "Load floors then update",
+			tests: []testStep{
+				{
+					statusCode: httpCore.StatusOK,
+					response:   `{"floors":{"account1":{"version":"v1"}, "account2":{"version":"v2"}}}`,
+					saves:      `{"floors":{"account1":{"version":"v1"}, "account2":{"version":"v2"}}, "accounts": null, "imps": null, "requests": null, "responses": null}`,
+				},
+				{
+					statusCode:    httpCore.StatusOK,
+					response:      `{"floors":{"account1":{"version":"v3"}, "account2":{"deleted": true}}}`,
+					saves:         `{"floors":{"account1":{"version":"v3"}}, "accounts": null, "imps": null, "requests": null, "responses": null}`,
+					invalidations: `{"floors":["account2"], "accounts": [], "requests": [], "imps": [], "responses":[]}`,
 				},
 			},
 		},
@@ -36,6 +36,12 @@ type CategoryFetcher interface {
 	FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error)
 }
 
+// FloorsFetcher is implemented by the Fetchers which can fetch the price floors data of accounts.
+type FloorsFetcher interface {
+	// FetchFloors fetches the price floors data stored for the account, or returns a NotFoundError
+	FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error)
+}
+
 // AllFetcher is an interface that encapsulates both the original Fetcher and the CategoryFetcher
 type AllFetcher interface {
 	Fetcher
@@ -68,6 +74,7 @@ type Cache struct {
 	Imps      CacheJSON
 	Responses CacheJSON
 	Accounts  CacheJSON
+	Floors    CacheJSON
 }
 type CacheJSON interface {
 	// Get works much like Fetcher.FetchRequests, with a few exceptions:
@@ -226,6 +233,23 @@ func (f *fetcherWithCache) FetchAccount(ctx context.Context, acccountDefaultJSON
 	return account, errs
 }
 
+func (f *fetcherWithCache) FetchFloors(ctx context.Context, accountID string) (floors json.RawMessage, errs []error) {
+	floorsData := f.cache.Floors.Get(ctx, []string{accountID})
+	if floors, ok := floorsData[accountID]; ok {
+		return floors, errs
+	}
+
+	floorsFetcher, ok := f.fetcher.(FloorsFetcher)
+	if !ok {
+		return nil, []error{NotFoundError{accountID, "Floors"}}
+	}
+	floors, errs = floorsFetcher.FetchFloors(ctx, accountID)
+	if len(errs) == 0 {
+		f.cache.Floors.Save(ctx, map[string]json.RawMessage{accountID: floors})
+	}
+	return floors, errs
+}
+
 func (f *fetcherWithCache) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	return "", nil
 }
@@ -19,7 +19,7 @@ func setupFetcherWithCacheDeps() (*mockCache, *mockCache, *mockCache, *mockFetch
 	respCache := &mockCache{}
 	metricsEngine := &metrics.MetricsEngineMock{}
 	fetcher := &mockFetcher{}
-	afetcherWithCache := WithCache(fetcher, Cache{reqCache, impCache, respCache, &nil_cache.NilCache{}}, metricsEngine)
+	afetcherWithCache := WithCache(fetcher, Cache{reqCache, impCache, respCache, &nil_cache.NilCache{}, &nil_cache.NilCache{}}, metricsEngine)
 
 	return reqCache, impCache, respCache, fetcher, afetcherWithCache, metricsEngine
 }
@@ -226,7 +226,7 @@ func setupAccountFetcherWithCacheDeps() (*mockCache, *mockFetcher, AllFetcher, *
 	accCache := &mockCache{}
 	metricsEngine := &metrics.MetricsEngineMock{}
 	fetcher := &mockFetcher{}
-	afetcherWithCache := WithCache(fetcher, Cache{&nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, accCache}, metricsEngine)
+	afetcherWithCache := WithCache(fetcher, Cache{&nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, accCache, &nil_cache.NilCache{}}, metricsEngine)
 
 	return accCache, fetcher, afetcherWithCache, metricsEngine
 }
@@ -274,6 +274,47 @@ func TestAccountCacheMiss(t *testing.T) {
 	assert.JSONEq(t, `true`, string(account), "FetchAccount should fetch the right account data")
 	assert.Len(t, errs, 0, "FetchAccount shouldn't return any errors")
 }
+
+func TestFloorsCacheHit(t *testing.T) {
+	floorsCache := &mockCache{}
+	fetcher := &mockFetcher{}
+	aFetcherWithCache := WithCache(fetcher, Cache{&nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, floorsCache}, &metrics.MetricsEngineMock{})
+	ctx := context.Background()
+
+	floorsCache.On("Get", ctx, []string{"known"}).Return(
+		map[string]json.RawMessage{
+			"known": json.RawMessage(`{"version": "1"}`),
+		})
+
+	floors, errs := aFetcherWithCache.(FloorsFetcher).FetchFloors(ctx, "known")
+
+	floorsCache.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	assert.JSONEq(t, `{"version": "1"}`, string(floors), "FetchFloors should fetch the right floors data")
+	assert.Len(t, errs, 0, "FetchFloors shouldn't return any errors")
+}
+
+func TestFloorsCacheMiss(t *testing.T) {
+	floorsCache := &mockCache{}
+	fetcher := &mockFetcher{}
+	aFetcherWithCache := WithCache(fetcher, Cache{&nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, &nil_cache.NilCache{}, floorsCache}, &metrics.MetricsEngineMock{})
+	ctx := context.Background()
+	uncachedFloorsData := map[string]json.RawMessage{
+		"uncached": json.RawMessage(`{"version": "1"}`),
+	}
+
+	floorsCache.On("Get", ctx, []string{"uncached"}).Return(map[string]json.RawMessage{})
+	floorsCache.On("Save", ctx, uncachedFloorsData)
+	fetcher.On("FetchFloors", ctx, "uncached").Return(uncachedFloorsData["uncached"], []error{})
+
+	floors, errs := aFetcherWithCache.(FloorsFetcher).FetchFloors(ctx, "uncached")
+
+	floorsCache.AssertExpectations(t)
+	fetcher.AssertExpectations(t)
+	assert.JSONEq(t, `{"version": "1"}`, string(floors), "FetchFloors should fetch the right floors data")
+	assert.Len(t, errs, 0, "FetchFloors shouldn't return any errors")
+}
+
 func TestComposedCache(t *testing.T) {
 	c1 := &mockCache{}
 	c2 := &mockCache{}
@@ -352,6 +393,11 @@ func (a *mockFetcher) FetchAccount(ctx context.Context, defaultAccountsJSON json
 	return args.Get(0).(json.RawMessage), args.Get(1).([]error)
 }
 
+func (f *mockFetcher) FetchFloors(ctx context.Context, accountID string) (json.RawMessage, []error) {
+	args := f.Called(ctx, accountID)
+	return args.Get(0).(json.RawMessage), args.Get(1).([]error)
+}
+
 func (f *mockFetcher) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	return "", nil
 }
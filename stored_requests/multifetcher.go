@@ -55,6 +55,21 @@ func (mf MultiFetcher) FetchAccount(ctx context.Context, accountDefaultJSON json
 	return nil, errs
 }
 
+func (mf MultiFetcher) FetchFloors(ctx context.Context, accountID string) (floors json.RawMessage, errs []error) {
+	for _, f := range mf {
+		if ff, ok := f.(FloorsFetcher); ok {
+			if floors, floorsErrs := ff.FetchFloors(ctx, accountID); len(floorsErrs) == 0 {
+				return floors, nil
+			} else {
+				floorsErrs = dropMissingIDs(floorsErrs)
+				errs = append(errs, floorsErrs...)
+			}
+		}
+	}
+	errs = append(errs, NotFoundError{accountID, "Floors"})
+	return nil, errs
+}
+
 func (mf MultiFetcher) FetchCategories(ctx context.Context, primaryAdServer, publisherId, iabCategory string) (string, error) {
 	for _, f := range mf {
 		if cf, ok := f.(CategoryFetcher); ok {